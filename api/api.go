@@ -10,19 +10,25 @@ import (
 
 	"github.com/ONSdigital/dp-authorisation/auth"
 	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
 	"github.com/ONSdigital/dp-topic-api/apierrors"
 	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/dp-topic-api/preview"
 	"github.com/ONSdigital/dp-topic-api/store"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
 
-// nolint:unused // just for this block
 var (
 	createPermission = auth.Permissions{Create: true}
 	readPermission   = auth.Permissions{Read: true}
 	updatePermission = auth.Permissions{Update: true}
 	deletePermission = auth.Permissions{Delete: true}
+
+	// taxonomyPermission is required to export or import the whole taxonomy, which can create, read, update and
+	// delete every topic
+	taxonomyPermission = auth.Permissions{Create: true, Read: true, Update: true, Delete: true}
 )
 
 // AuthHandler provides authorisation checks on requests
@@ -30,25 +36,76 @@ type AuthHandler interface {
 	Require(required auth.Permissions, handler http.HandlerFunc) http.HandlerFunc
 }
 
+// Notifier provides notification of topic lifecycle events to webhook subscribers
+type Notifier interface {
+	Notify(ctx context.Context, eventType, topicID, state string)
+}
+
+// nopNotifier is used when webhook notifications are disabled
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(_ context.Context, _, _, _ string) {}
+
 // API provides a struct to wrap the api around
 type API struct {
-	Router                 *mux.Router
-	dataStore              store.DataStore
-	enablePrivateEndpoints bool
-	navigationCacheMaxAge  string
-	permissions            AuthHandler
-	topicAPIURL            string
+	Router                   *mux.Router
+	dataStore                store.DataStore
+	adminGroups              []string
+	conceptSchemeCacheMaxAge string
+	conceptSchemeURL         string
+	enablePrivateEndpoints   bool
+	feedCacheMaxAge          string
+	feedMaxEntries           int
+	contentCacheMaxAge       string
+	entityParser             EntityParser
+	navigationCacheMaxAge    string
+	redirectsCacheMaxAge     string
+	rootTopicsCacheMaxAge    string
+	sitemapCacheMaxAge       string
+	sitemapMaxURLs           int
+	subtopicsCacheMaxAge     string
+	topicCacheMaxAge         string
+	notifier                 Notifier
+	permissions              AuthHandler
+	previews                 *preview.Signer
+	requireReview            bool
+	topicAPIURL              string
+	websiteURL               string
+	welshWebsiteURL          string
 }
 
-// Setup function sets up the api and returns an api
-func Setup(ctx context.Context, cfg *config.Config, router *mux.Router, dataStore store.DataStore, permissions AuthHandler, topicAPIURL string) *API {
+// Setup function sets up the api and returns an api. A nil notifier disables webhook notifications, a nil entityParser
+// leaves the ownership of topics unenforced, and nil previews disables the preview of topics on the public API.
+func Setup(ctx context.Context, cfg *config.Config, router *mux.Router, dataStore store.DataStore, permissions AuthHandler, entityParser EntityParser, notifier Notifier, previews *preview.Signer, topicAPIURL string) *API {
+	if notifier == nil {
+		notifier = nopNotifier{}
+	}
+
 	api := &API{
-		Router:                 router,
-		dataStore:              dataStore,
-		enablePrivateEndpoints: cfg.EnablePrivateEndpoints,
-		navigationCacheMaxAge:  fmt.Sprintf("%.0f", cfg.NavigationCacheMaxAge.Seconds()),
-		permissions:            permissions,
-		topicAPIURL:            topicAPIURL,
+		Router:                   router,
+		dataStore:                dataStore,
+		adminGroups:              cfg.TopicAdminGroups,
+		conceptSchemeCacheMaxAge: fmt.Sprintf("%.0f", cfg.ConceptSchemeCacheMaxAge.Seconds()),
+		conceptSchemeURL:         cfg.ConceptSchemeURL,
+		enablePrivateEndpoints:   cfg.EnablePrivateEndpoints,
+		feedCacheMaxAge:          fmt.Sprintf("%.0f", cfg.FeedCacheMaxAge.Seconds()),
+		feedMaxEntries:           cfg.FeedMaxEntries,
+		contentCacheMaxAge:       fmt.Sprintf("%.0f", cfg.ContentCacheMaxAge.Seconds()),
+		entityParser:             entityParser,
+		navigationCacheMaxAge:    fmt.Sprintf("%.0f", cfg.NavigationCacheMaxAge.Seconds()),
+		redirectsCacheMaxAge:     fmt.Sprintf("%.0f", cfg.RedirectsCacheMaxAge.Seconds()),
+		rootTopicsCacheMaxAge:    fmt.Sprintf("%.0f", cfg.RootTopicsCacheMaxAge.Seconds()),
+		sitemapCacheMaxAge:       fmt.Sprintf("%.0f", cfg.SitemapCacheMaxAge.Seconds()),
+		sitemapMaxURLs:           cfg.SitemapMaxURLs,
+		subtopicsCacheMaxAge:     fmt.Sprintf("%.0f", cfg.SubtopicsCacheMaxAge.Seconds()),
+		topicCacheMaxAge:         fmt.Sprintf("%.0f", cfg.TopicCacheMaxAge.Seconds()),
+		notifier:                 notifier,
+		permissions:              permissions,
+		previews:                 previews,
+		requireReview:            cfg.EnableTopicReview,
+		topicAPIURL:              topicAPIURL,
+		websiteURL:               cfg.WebsiteURL,
+		welshWebsiteURL:          cfg.WelshWebsiteURL,
 	}
 
 	if cfg.EnablePrivateEndpoints {
@@ -70,14 +127,56 @@ func Setup(ctx context.Context, cfg *config.Config, router *mux.Router, dataStor
 func (api *API) enablePublicEndpoints() {
 	api.get("/navigation", api.getNavigationHandler)
 	api.get("/topics", api.getRootTopicsPublicHandler)
+	// registered before /topics/{id}, which would otherwise match it
+	api.get("/topics/export", api.getConceptSchemePublicHandler)
 	api.get("/topics/{id}", api.getTopicPublicHandler)
 	api.get("/topics/{id}/content", api.getContentPublicHandler)
 	api.get("/topics/{id}/subtopics", api.getSubtopicsPublicHandler)
+	api.get("/topics/{id}/related", api.getRelatedPublicHandler)
+	api.get("/sitemap.xml", api.getSitemapHandler)
+	api.get("/sitemap-{file:[0-9]+}.xml", api.getSitemapFileHandler)
+	api.get("/feeds/topics.atom", api.getTopicsFeedHandler)
+	api.get("/topics/{id}/feed.atom", api.getTopicFeedHandler)
+	api.get("/redirects", api.getRedirectsHandler)
 }
 
 // enablePrivateTopicEndpoints register the topics endpoints with the appropriate authentication and authorisation
 // checks required when running the topic API in publishing (private) mode.
 func (api *API) enablePrivateTopicEndpoints() {
+	// the taxonomy endpoints are registered before /topics/{id}, which would otherwise match them.
+	// The export is streamed, so it is not conditional.
+	api.Router.HandleFunc(
+		"/topics/export",
+		api.isAuthenticated(
+			api.isAuthorised(taxonomyPermission, api.getTaxonomyExportHandler)),
+	).Methods("GET")
+
+	api.post(
+		"/topics/import",
+		api.isAuthenticated(
+			api.isAuthorised(taxonomyPermission,
+				api.isTopicAdmin(api.postTaxonomyImportHandler))),
+	)
+
+	api.get(
+		"/topics/integrity",
+		api.isAuthenticated(
+			api.isAuthorised(taxonomyPermission, api.getIntegrityHandler)),
+	)
+
+	api.post(
+		"/topics/integrity",
+		api.isAuthenticated(
+			api.isAuthorised(taxonomyPermission,
+				api.isTopicAdmin(api.postIntegrityHandler))),
+	)
+
+	api.post(
+		"/topics/bulk",
+		api.isAuthenticated(
+			api.isAuthorised(updatePermission, api.postTopicsBulkHandler)),
+	)
+
 	api.get(
 		"/topics/{id}",
 		api.isAuthenticated(
@@ -90,6 +189,12 @@ func (api *API) enablePrivateTopicEndpoints() {
 			api.isAuthorised(readPermission, api.getSubtopicsPrivateHandler)),
 	)
 
+	api.get(
+		"/topics/{id}/related",
+		api.isAuthenticated(
+			api.isAuthorised(readPermission, api.getRelatedPrivateHandler)),
+	)
+
 	api.get(
 		"/topics/{id}/content",
 		api.isAuthenticated(
@@ -108,22 +213,112 @@ func (api *API) enablePrivateTopicEndpoints() {
 			api.isAuthorised(readPermission, api.getNavigationHandler)),
 	)
 
+	api.get(
+		"/redirects",
+		api.isAuthenticated(
+			api.isAuthorised(readPermission, api.getRedirectsHandler)),
+	)
+
 	api.put(
 		"/topics/{id}/release-date",
 		api.isAuthenticated(
-			api.isAuthorised(updatePermission, api.putTopicReleaseDatePrivateHandler)),
+			api.isAuthorised(updatePermission,
+				api.isTopicEditor(api.putTopicReleaseDatePrivateHandler))),
 	)
 
 	api.put(
 		"/topics/{id}/state/{state}",
 		api.isAuthenticated(
-			api.isAuthorised(updatePermission, api.putTopicStatePrivateHandler)),
+			api.isAuthorised(updatePermission,
+				api.isTopicEditor(api.putTopicStatePrivateHandler))),
+	)
+
+	if api.previews != nil {
+		api.post(
+			"/topics/{id}/preview-token",
+			api.isAuthenticated(
+				api.isAuthorised(readPermission, api.postPreviewTokenHandler)),
+		)
+	}
+
+	api.put(
+		"/topics/{id}/owners",
+		api.isAuthenticated(
+			api.isAuthorised(updatePermission,
+				api.isTopicAdmin(api.putTopicOwnersHandler))),
 	)
 
 	api.put(
 		"/topics/{id}",
 		api.isAuthenticated(
-			api.isAuthorised(updatePermission, api.putTopicPrivateHandler)),
+			api.isAuthorised(updatePermission,
+				api.isTopicEditor(api.putTopicPrivateHandler))),
+	)
+
+	api.post(
+		"/collections",
+		api.isAuthenticated(
+			api.isAuthorised(createPermission, api.postCollectionHandler)),
+	)
+
+	api.get(
+		"/collections",
+		api.isAuthenticated(
+			api.isAuthorised(readPermission, api.getCollectionsHandler)),
+	)
+
+	api.get(
+		"/collections/{id}",
+		api.isAuthenticated(
+			api.isAuthorised(readPermission, api.getCollectionHandler)),
+	)
+
+	api.put(
+		"/collections/{id}/{change:topics|content}/{topic_id}",
+		api.isAuthenticated(
+			api.isAuthorised(updatePermission, api.putCollectionChangeHandler)),
+	)
+
+	api.delete(
+		"/collections/{id}/{change:topics|content}/{topic_id}",
+		api.isAuthenticated(
+			api.isAuthorised(updatePermission, api.deleteCollectionChangeHandler)),
+	)
+
+	api.put(
+		"/collections/{id}/publish",
+		api.isAuthenticated(
+			api.isAuthorised(updatePermission, api.putCollectionPublishHandler)),
+	)
+
+	api.post(
+		"/webhooks",
+		api.isAuthenticated(
+			api.isAuthorised(createPermission, api.postWebhookHandler)),
+	)
+
+	api.get(
+		"/webhooks",
+		api.isAuthenticated(
+			api.isAuthorised(readPermission, api.getWebhooksHandler)),
+	)
+
+	api.get(
+		"/webhooks/{id}",
+		api.isAuthenticated(
+			api.isAuthorised(readPermission, api.getWebhookHandler)),
+	)
+
+	api.delete(
+		"/webhooks/{id}",
+		api.isAuthenticated(
+			api.isAuthorised(deletePermission, api.deleteWebhookHandler)),
+	)
+
+	api.get(
+		"/webhooks/{id}/deliveries",
+		api.isAuthenticated(
+			api.isAuthorised(readPermission, api.getWebhookDeliveriesHandler)),
 	)
 }
 
@@ -141,9 +336,9 @@ func (api *API) isAuthorised(required auth.Permissions, handler http.HandlerFunc
 	return api.permissions.Require(required, handler)
 }
 
-// get register a GET http.HandlerFunc.
+// get register a GET http.HandlerFunc, supporting conditional requests.
 func (api *API) get(path string, handler http.HandlerFunc) {
-	api.Router.HandleFunc(path, handler).Methods("GET")
+	api.Router.HandleFunc(path, conditional(handler)).Methods("GET")
 }
 
 // get register a PUT http.HandlerFunc.
@@ -151,16 +346,12 @@ func (api *API) put(path string, handler http.HandlerFunc) {
 	api.Router.HandleFunc(path, handler).Methods("PUT")
 }
 
-// get register a POST http.HandlerFunc.
-//
-//nolint:unused // Lint:Method is not currently used but will keep as it's a good reference/completeness for future use.
+// post register a POST http.HandlerFunc.
 func (api *API) post(path string, handler http.HandlerFunc) {
 	api.Router.HandleFunc(path, handler).Methods("POST")
 }
 
-// get register a DELETE http.HandlerFunc.
-//
-//nolint:unused // Lint:Method is not currently used but will keep as it's a good reference/completeness for future use.
+// delete register a DELETE http.HandlerFunc.
 func (api *API) delete(path string, handler http.HandlerFunc) {
 	api.Router.HandleFunc(path, handler).Methods("DELETE")
 }
@@ -211,45 +402,103 @@ func ReadJSONBody(ctx context.Context, body io.ReadCloser, v interface{}, w http
 	return nil
 }
 
-// handleError is a utility function that maps api errors to an http status code and sets the provided responseWriter accordingly
+// handleError is a utility function that maps api errors to an http status code and sets the provided responseWriter accordingly,
+// writing the error as an RFC 7807 problem document
 func handleError(ctx context.Context, w http.ResponseWriter, err error, data log.Data) {
-	var status int
-	if err != nil {
-		switch err {
-		case apierrors.ErrTopicNotFound,
-			apierrors.ErrContentNotFound,
-			apierrors.ErrNotFound:
-			status = http.StatusNotFound
-		case apierrors.ErrUnableToReadMessage,
-			apierrors.ErrUnableToParseJSON:
-			status = http.StatusInternalServerError
-		case apierrors.ErrContentUnrecognisedParameter,
-			apierrors.ErrEmptyRequestBody,
-			apierrors.ErrInvalidReleaseDate,
-			apierrors.ErrTopicInvalidState,
-			apierrors.ErrTopicMissingFields:
-			status = http.StatusBadRequest
-		case apierrors.ErrTopicStateTransitionNotAllowed:
-			status = http.StatusForbidden
-		default:
-			status = http.StatusInternalServerError
-		}
-	}
+	status := errorStatus(err)
 
 	if data == nil {
 		data = log.Data{}
 	}
 
+	problem := models.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if requestID, ok := ctx.Value(dprequest.RequestIdKey).(string); ok {
+		problem.RequestID = requestID
+	}
+
 	switch status {
-	case http.StatusNotFound, http.StatusForbidden, http.StatusBadRequest:
+	case http.StatusNotFound, http.StatusForbidden, http.StatusBadRequest, http.StatusGone:
 		data["response_status"] = status
 		data["user_error"] = err.Error()
 		log.Error(ctx, "request unsuccessful", errors.New("request unsuccessful"), data)
-		http.Error(w, err.Error(), status)
+		problem.Detail = err.Error()
+		problem.Code = apierrors.Code(err)
+
+		var validationErr *apierrors.ValidationError
+		if errors.As(err, &validationErr) {
+			problem.Errors = validationErr.Fields
+		}
 	default:
 		// a stack trace is added for Non User errors
 		data["response_status"] = status
 		log.Error(ctx, "request unsuccessful", err, data)
-		http.Error(w, apierrors.ErrInternalServer.Error(), status)
+		problem.Detail = apierrors.ErrInternalServer.Error()
+		problem.Code = apierrors.CodeInternalServer
+	}
+
+	payload, err := json.Marshal(problem)
+	if err != nil {
+		log.Error(ctx, "failed to marshal error response", err, data)
+		http.Error(w, problem.Detail, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", models.ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	if _, err := w.Write(payload); err != nil {
+		log.Error(ctx, "failed to write error response", err, data)
 	}
 }
+
+// errorStatus returns the HTTP status code for the first error in err's chain that is recognised
+func errorStatus(err error) int {
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch err {
+		case apierrors.ErrTopicNotFound,
+			apierrors.ErrCollectionNotFound,
+			apierrors.ErrContentNotFound,
+			apierrors.ErrNotFound,
+			apierrors.ErrRedirectNotFound,
+			apierrors.ErrWebhookNotFound:
+			return http.StatusNotFound
+		case apierrors.ErrUnableToReadMessage,
+			apierrors.ErrUnableToParseJSON:
+			return http.StatusInternalServerError
+		case apierrors.ErrCollectionEmpty,
+			apierrors.ErrCollectionInvalidFields,
+			apierrors.ErrContentUnrecognisedParameter,
+			apierrors.ErrEmptyRequestBody,
+			apierrors.ErrInvalidConceptSchemeFormat,
+			apierrors.ErrInvalidIncludeArchived,
+			apierrors.ErrInvalidImportStrategy,
+			apierrors.ErrInvalidLimit,
+			apierrors.ErrInvalidPartial,
+			apierrors.ErrInvalidReleaseDate,
+			apierrors.ErrInvalidTaxonomyFormat,
+			apierrors.ErrInvalidTaxonomyVersion,
+			apierrors.ErrInvalidValidateOnly,
+			apierrors.ErrTopicInvalidFields,
+			apierrors.ErrTopicInvalidState,
+			apierrors.ErrTopicMissingFields,
+			apierrors.ErrWebhookInvalidEvent,
+			apierrors.ErrWebhookInvalidURL:
+			return http.StatusBadRequest
+		case apierrors.ErrCollectionPublished,
+			apierrors.ErrInvalidPreviewToken,
+			apierrors.ErrTopicForbidden,
+			apierrors.ErrTopicInCollection,
+			apierrors.ErrTopicSelfReview,
+			apierrors.ErrTopicStateTransitionNotAllowed:
+			return http.StatusForbidden
+		case apierrors.ErrTopicArchived:
+			return http.StatusGone
+		}
+	}
+
+	return http.StatusInternalServerError
+}
@@ -11,7 +11,10 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
 	brokenrecorder "github.com/ONSdigital/dp-topic-api/broken"
+	"github.com/ONSdigital/dp-topic-api/models"
 	"github.com/ONSdigital/log.go/v2/log"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -55,7 +58,7 @@ func TestWriteJSONBody(t *testing.T) {
 				So(w.Code, ShouldEqual, http.StatusInternalServerError)
 				payload, err := io.ReadAll(w.Body)
 				So(err, ShouldBeNil)
-				So(string(payload), ShouldResemble, "internal error\n")
+				So(payload, ShouldResemble, problemPayload(http.StatusInternalServerError, apierrors.ErrInternalServer))
 			})
 		})
 
@@ -79,3 +82,61 @@ func TestWriteJSONBody(t *testing.T) {
 		})
 	})
 }
+
+// problemPayload returns the expected body of the error response for err
+func problemPayload(status int, err error) []byte {
+	payload, _ := json.Marshal(models.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+		Code:   apierrors.Code(err),
+	})
+	return payload
+}
+
+func TestHandleError(t *testing.T) {
+	Convey("Given a request context with a request id", t, func() {
+		ctx := context.WithValue(context.Background(), dprequest.RequestIdKey, "request-1")
+
+		Convey("When a validation error is handled", func() {
+			w := httptest.NewRecorder()
+			handleError(ctx, w, &apierrors.ValidationError{
+				Err:    apierrors.ErrTopicMissingFields,
+				Fields: []apierrors.FieldError{{Field: "title", Message: "must not be empty"}},
+			}, nil)
+
+			Convey("Then a problem document with the code, request id and field errors is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/problem+json")
+
+				var problem models.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem, ShouldResemble, models.Problem{
+					Type:      "about:blank",
+					Title:     "Bad Request",
+					Status:    http.StatusBadRequest,
+					Detail:    apierrors.ErrTopicMissingFields.Error(),
+					Code:      apierrors.CodeTopicMissingFields,
+					RequestID: "request-1",
+					Errors:    []apierrors.FieldError{{Field: "title", Message: "must not be empty"}},
+				})
+			})
+		})
+
+		Convey("When an unrecognised error is handled", func() {
+			w := httptest.NewRecorder()
+			handleError(ctx, w, errors.New("connection refused"), nil)
+
+			Convey("Then the details of the error are not disclosed", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+
+				var problem models.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Detail, ShouldEqual, apierrors.ErrInternalServer.Error())
+				So(problem.Code, ShouldEqual, apierrors.CodeInternalServer)
+				So(problem.RequestID, ShouldEqual, "request-1")
+			})
+		})
+	})
+}
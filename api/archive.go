@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/metrics"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// includeArchived returns whether archived topics are included in the response to a request, as given by its
+// include_archived query parameter
+func includeArchived(req *http.Request) (bool, error) {
+	value := req.URL.Query().Get("include_archived")
+	if value == "" {
+		return false, nil
+	}
+
+	include, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, apierrors.ErrInvalidIncludeArchived
+	}
+	return include, nil
+}
+
+// readArchive returns the archive of the topic with the id, from the optional body of a request archiving it. The
+// successor it gives must be an existing topic that has not been archived itself.
+func (api *API) readArchive(ctx context.Context, req *http.Request, id string) (*models.Archive, error) {
+	var archive models.Archive
+	if err := readOptionalBody(req, &archive); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Validate(id); err != nil {
+		return nil, err
+	}
+	if archive.SuccessorID == "" {
+		return &archive, nil
+	}
+
+	violations := &apierrors.ValidationError{}
+	successor, err := api.dataStore.Backend.GetTopic(ctx, archive.SuccessorID)
+	switch {
+	case errors.Is(err, apierrors.ErrTopicNotFound):
+		violations.Add(apierrors.ErrTopicInvalidFields, "successor_id", "must be an existing topic")
+	case err != nil:
+		return nil, err
+	case successor.Next != nil && successor.Next.IsArchived():
+		violations.Add(apierrors.ErrTopicInvalidFields, "successor_id", "must not be an archived topic")
+	}
+
+	if err := violations.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &archive, nil
+}
+
+// archiveTopic archives the current and next versions of a topic, so that its old URLs are redirected to its
+// successor, if it has one. The current version is replaced at once, outside the publishing workflow of reviews and
+// collections, so archiving is observed as a publication, and its caller notifies it as one.
+func (api *API) archiveTopic(ctx context.Context, topic *models.TopicResponse, successorID string) (err error) {
+	defer func() { metrics.ObservePublish(err) }()
+
+	archive := func(version *models.Topic) *models.Topic {
+		if version == nil {
+			return nil
+		}
+		archived := *version
+		archived.State = models.StateArchived.String()
+		archived.SuccessorID = successorID
+		archived.Review = nil
+		return &archived
+	}
+
+	return api.dataStore.Backend.UpsertTopic(ctx, topic.ID, &models.TopicResponse{
+		ID:      topic.ID,
+		Current: archive(topic.Current),
+		Next:    archive(topic.Next),
+	})
+}
+
+// restoreTopic moves the next version of an archived topic to the state given, forgetting its successor. Its current
+// version stays archived until the topic is published again.
+func (api *API) restoreTopic(ctx context.Context, topic *models.TopicResponse, state string) error {
+	next := *topic.Next
+	next.State = state
+	next.SuccessorID = ""
+
+	return api.dataStore.Backend.UpsertTopic(ctx, topic.ID, &models.TopicResponse{ID: topic.ID, Next: &next})
+}
+
+// writeArchived writes the response to a request for an archived topic, which is a permanent redirect to its
+// successor, or 410 Gone when it has none
+func (api *API) writeArchived(ctx context.Context, w http.ResponseWriter, topic *models.Topic, logdata log.Data) {
+	logdata["successor_id"] = topic.SuccessorID
+	if topic.SuccessorID == "" {
+		handleError(ctx, w, apierrors.ErrTopicArchived, logdata)
+		return
+	}
+
+	successorURL := fmt.Sprintf("%s/topics/%s", api.topicAPIURL, topic.SuccessorID)
+	w.Header().Set("Location", successorURL)
+	w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successorURL))
+	w.WriteHeader(http.StatusMovedPermanently)
+
+	log.Info(ctx, "request redirected to successor of archived topic", logdata)
+}
+
+// archivedTopics returns the ids of the topics whose current version has been archived
+func (api *API) archivedTopics(ctx context.Context) (map[string]bool, error) {
+	ids, err := api.dataStore.Backend.GetArchivedTopicIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	archived := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		archived[id] = true
+	}
+	return archived, nil
+}
+
+// withoutArchived returns the navigation items, and their subtopics, that are not archived topics
+func withoutArchived(items *[]models.TopicNonReferential, archived map[string]bool) *[]models.TopicNonReferential {
+	if items == nil {
+		return nil
+	}
+
+	kept := []models.TopicNonReferential{}
+	for _, item := range *items {
+		if item.Links != nil && item.Links.Self != nil && archived[item.Links.Self.ID] {
+			continue
+		}
+		item.SubtopicItems = withoutArchived(item.SubtopicItems, archived)
+		kept = append(kept, item)
+	}
+	return &kept
+}
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// archivedTopic is a topic that has been archived in favour of the successor given, if any
+func archivedTopic(id, successorID string) *models.TopicResponse {
+	topic := dbTopicWithID(models.StateArchived, id)
+	topic.Current = dbTopicCurrentWithID(models.StateArchived, id)
+	topic.Current.SuccessorID = successorID
+	topic.Next.SuccessorID = successorID
+	return topic
+}
+
+// archiveTaxonomy is a store with the published topics well-being and economy, whose subtopics are well-being and
+// the archived wellbeing, and with the archived topics wellbeing, succeeded by well-being, and retired, succeeded by
+// no topic
+func archiveTaxonomy() *storeMock.MongoDBMock {
+	topics := map[string]*models.TopicResponse{
+		"well-being": dbTopicWithID(models.StatePublished, "well-being"),
+		"wellbeing":  archivedTopic("wellbeing", "well-being"),
+		"retired":    archivedTopic("retired", ""),
+		"economy":    dbTopicWithID(models.StatePublished, "economy"),
+	}
+	topics["well-being"].Current = dbTopicCurrentWithID(models.StatePublished, "well-being")
+	economy := topics["economy"]
+	economy.Current = dbTopicCurrentWithID(models.StatePublished, "economy")
+	economy.Current.SubtopicIds = &[]string{"well-being", "wellbeing"}
+	economy.Next.SubtopicIds = &[]string{"well-being", "wellbeing"}
+
+	return &storeMock.MongoDBMock{
+		GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+			topic, ok := topics[id]
+			if !ok {
+				return nil, apierrors.ErrTopicNotFound
+			}
+			return topic, nil
+		},
+		UpsertTopicFunc: func(ctx context.Context, id string, topic *models.TopicResponse) error { return nil },
+	}
+}
+
+func TestGetArchivedTopicPublicHandler(t *testing.T) {
+	Convey("Given a topic API in web mode, with archived topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = false
+		topicAPI := GetAPIWithMocks(cfg, archiveTaxonomy())
+
+		serve := func(url string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, http.NoBody))
+			return w
+		}
+
+		Convey("When an archived topic with a successor is requested, then it is permanently redirected to its successor", func() {
+			w := serve("http://localhost:25300/topics/wellbeing")
+			So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+			So(w.Header().Get("Location"), ShouldEqual, testTopicAPIURL+"/topics/well-being")
+			So(w.Header().Get("Link"), ShouldEqual, "<"+testTopicAPIURL+"/topics/well-being>; rel=\"successor-version\"")
+		})
+
+		Convey("When an archived topic without a successor is requested, then 410 is returned", func() {
+			w := serve("http://localhost:25300/topics/retired")
+			So(w.Code, ShouldEqual, http.StatusGone)
+			So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusGone, apierrors.ErrTopicArchived))
+		})
+
+		Convey("When an archived topic is requested including archived topics, then it is returned with its successor", func() {
+			w := serve("http://localhost:25300/topics/wellbeing?include_archived=true")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			var topic models.Topic
+			So(json.Unmarshal(w.Body.Bytes(), &topic), ShouldBeNil)
+			So(topic.State, ShouldEqual, models.StateArchived.String())
+			So(topic.SuccessorID, ShouldEqual, "well-being")
+		})
+
+		Convey("When include_archived is not a boolean, then 400 is returned", func() {
+			w := serve("http://localhost:25300/topics/wellbeing?include_archived=maybe")
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusBadRequest, apierrors.ErrInvalidIncludeArchived))
+		})
+
+		Convey("When the subtopics of a topic are requested, then its archived subtopics are left out", func() {
+			w := serve("http://localhost:25300/topics/economy/subtopics")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			var subtopics models.PublicSubtopics
+			So(json.Unmarshal(w.Body.Bytes(), &subtopics), ShouldBeNil)
+			So(subtopics.TotalCount, ShouldEqual, 1)
+			So((*subtopics.PublicItems)[0].ID, ShouldEqual, "well-being")
+
+			Convey("And they are included when asked for", func() {
+				w := serve("http://localhost:25300/topics/economy/subtopics?include_archived=true")
+				So(json.Unmarshal(w.Body.Bytes(), &subtopics), ShouldBeNil)
+				So(subtopics.TotalCount, ShouldEqual, 2)
+			})
+		})
+	})
+}
+
+func TestPutTopicStateArchived(t *testing.T) {
+	Convey("Given a topic API in publishing mode, with archived and published topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		mongoDBMock := archiveTaxonomy()
+		notifier := &notifierStub{}
+		topicAPI := getAPIWithNotifier(cfg, mongoDBMock, notifier)
+
+		serve := func(method, url, body string) *httptest.ResponseRecorder {
+			request, err := createRequestWithAuth(method, url, bytes.NewBufferString(body))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When a published topic is archived in favour of a successor", func() {
+			w := serve(http.MethodPut, "http://localhost:25300/topics/economy/state/archived", `{"successor_id": "well-being"}`)
+
+			Convey("Then both of its versions are archived, naming the successor, and it is notified as published", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpsertTopicCalls(), ShouldHaveLength, 1)
+				archived := mongoDBMock.UpsertTopicCalls()[0].Topic
+				So(archived.Current.State, ShouldEqual, models.StateArchived.String())
+				So(archived.Current.SuccessorID, ShouldEqual, "well-being")
+				So(archived.Next.State, ShouldEqual, models.StateArchived.String())
+				So(archived.Next.SuccessorID, ShouldEqual, "well-being")
+				So(notifier.notifications, ShouldResemble, []notification{
+					{models.WebhookEventTopicStateChanged, "economy", "archived"},
+					{models.WebhookEventTopicPublished, "economy", "archived"},
+				})
+			})
+		})
+
+		Convey("When a published topic is archived without a body, then it is archived without a successor", func() {
+			w := serve(http.MethodPut, "http://localhost:25300/topics/economy/state/archived", "")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(mongoDBMock.UpsertTopicCalls()[0].Topic.Current.SuccessorID, ShouldBeEmpty)
+		})
+
+		Convey("When a topic is archived in favour of a topic that does not exist, then 400 is returned", func() {
+			w := serve(http.MethodPut, "http://localhost:25300/topics/economy/state/archived", `{"successor_id": "unknown"}`)
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(mongoDBMock.UpsertTopicCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When a topic is archived in favour of an archived topic, then 400 is returned", func() {
+			w := serve(http.MethodPut, "http://localhost:25300/topics/economy/state/archived", `{"successor_id": "retired"}`)
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(mongoDBMock.UpsertTopicCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When an archived topic is restored", func() {
+			w := serve(http.MethodPut, "http://localhost:25300/topics/wellbeing/state/created", "")
+
+			Convey("Then its next version is created, forgetting its successor, and its current version stays archived", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpsertTopicCalls(), ShouldHaveLength, 1)
+				restored := mongoDBMock.UpsertTopicCalls()[0].Topic
+				So(restored.Current, ShouldBeNil)
+				So(restored.Next.State, ShouldEqual, models.StateCreated.String())
+				So(restored.Next.SuccessorID, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an archived topic is published, then 403 is returned", func() {
+			w := serve(http.MethodPut, "http://localhost:25300/topics/wellbeing/state/published", "")
+			So(w.Code, ShouldEqual, http.StatusForbidden)
+			So(mongoDBMock.UpsertTopicCalls(), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestCheckUpdateTransitionArchived(t *testing.T) {
+	Convey("Given a published topic, then an update cannot archive it", t, func() {
+		err := checkUpdateTransition(dbTopic(models.StatePublished), &models.TopicUpdate{State: models.StateArchived.String()}, false)
+		So(err, ShouldWrap, apierrors.ErrTopicStateTransitionNotAllowed)
+	})
+
+	Convey("Given an archived topic, then an update cannot restore it", t, func() {
+		err := checkUpdateTransition(archivedTopic("wellbeing", ""), &models.TopicUpdate{State: models.StateCreated.String()}, false)
+		So(err, ShouldWrap, apierrors.ErrTopicStateTransitionNotAllowed)
+	})
+}
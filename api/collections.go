@@ -0,0 +1,439 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/metrics"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+)
+
+// changeContent is the kind of change in the path of a collection change that releases the content of a topic,
+// rather than the topic itself
+const changeContent = "content"
+
+// postCollectionHandler is a handler that creates a collection, to which changes to topics and their content are
+// then added, for Publishing
+func (api *API) postCollectionHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"function":   "postCollectionHandler",
+	}
+
+	collection, err := models.ReadCollection(req.Body)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	if err := collection.Validate(); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["collection_id"] = id.String()
+
+	createdAt := time.Now().UTC()
+	releaseDate := collection.ReleaseDate.UTC()
+	collection = &models.Collection{
+		ID:          id.String(),
+		Name:        collection.Name,
+		ReleaseDate: &releaseDate,
+		State:       models.CollectionStateOpen,
+		Topics:      []string{},
+		Content:     []string{},
+		CreatedAt:   &createdAt,
+		LastUpdated: &createdAt,
+	}
+
+	if err := api.dataStore.Backend.CreateCollection(ctx, collection); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusCreated)
+	if err := WriteJSONBody(ctx, collection, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// getCollectionsHandler is a handler that gets all collections, ordered by their release date, for Publishing
+func (api *API) getCollectionsHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"function":   "getCollectionsHandler",
+	}
+
+	collections, err := api.dataStore.Backend.GetCollections(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	result := models.Collections{
+		TotalCount: len(collections),
+		Items:      collections,
+	}
+	if result.Items == nil {
+		result.Items = []models.Collection{}
+	}
+
+	if err := WriteJSONBody(ctx, result, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// getCollectionHandler is a handler that gets a collection by its id for Publishing
+func (api *API) getCollectionHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	logdata := log.Data{
+		"request_id":    ctx.Value(dprequest.RequestIdKey),
+		"collection_id": id,
+		"function":      "getCollectionHandler",
+	}
+
+	collection, err := api.dataStore.Backend.GetCollection(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	if err := WriteJSONBody(ctx, collection, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// putCollectionChangeHandler is a handler that adds the next version of a topic, or of its content, to an open
+// collection for Publishing. A change can only be in one open collection at a time, so that it is released once.
+func (api *API) putCollectionChangeHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	id, topicID, content := vars["id"], vars["topic_id"], vars["change"] == changeContent
+	logdata := log.Data{
+		"request_id":    ctx.Value(dprequest.RequestIdKey),
+		"collection_id": id,
+		"topic_id":      topicID,
+		"change":        vars["change"],
+		"function":      "putCollectionChangeHandler",
+	}
+
+	collection, err := api.getOpenCollection(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	if content {
+		_, err = api.dataStore.Backend.GetContent(ctx, topicID, queryAllFlags)
+	} else {
+		err = api.dataStore.Backend.CheckTopicExists(ctx, topicID)
+	}
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	// the change is released by publishing the collection, so only a caller who can edit the topic can add it
+	if api.entityParser != nil {
+		if err := api.checkTopicEditor(ctx, api.callerGroups(ctx, req), topicID); err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+	}
+
+	if err := api.checkChangeNotCollected(ctx, collection.ID, topicID, content); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	if collection.AddChange(topicID, content) {
+		if err := api.dataStore.Backend.UpsertCollection(ctx, collection); err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+	}
+
+	if err := WriteJSONBody(ctx, collection, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// deleteCollectionChangeHandler is a handler that removes the next version of a topic, or of its content, from an
+// open collection for Publishing
+func (api *API) deleteCollectionChangeHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	id, topicID, content := vars["id"], vars["topic_id"], vars["change"] == changeContent
+	logdata := log.Data{
+		"request_id":    ctx.Value(dprequest.RequestIdKey),
+		"collection_id": id,
+		"topic_id":      topicID,
+		"change":        vars["change"],
+		"function":      "deleteCollectionChangeHandler",
+	}
+
+	collection, err := api.getOpenCollection(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	if !collection.RemoveChange(topicID, content) {
+		handleError(ctx, w, fmt.Errorf("%w: the %s of topic %s is not in the collection", apierrors.ErrNotFound, vars["change"], topicID), logdata)
+		return
+	}
+
+	if err := api.dataStore.Backend.UpsertCollection(ctx, collection); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	if err := WriteJSONBody(ctx, collection, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// putCollectionPublishHandler is a handler that publishes every change in a collection together, on the release
+// date of the collection, for Publishing. The caller must be able to edit every topic the collection changes, as the
+// owners of the topics may have changed since their changes were added.
+func (api *API) putCollectionPublishHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	logdata := log.Data{
+		"request_id":    ctx.Value(dprequest.RequestIdKey),
+		"collection_id": id,
+		"function":      "putCollectionPublishHandler",
+	}
+
+	collection, err := api.getOpenCollection(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	if api.entityParser != nil {
+		changed := append(slices.Clone(collection.Topics), collection.Content...)
+		if err := api.checkTopicEditor(ctx, api.callerGroups(ctx, req), changed...); err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+	}
+
+	log.Info(ctx, "attempting to publish collection", logdata)
+	published, err := api.publishCollection(ctx, collection)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	for _, topic := range published {
+		api.notifier.Notify(ctx, models.WebhookEventTopicStateChanged, topic.ID, models.StatePublished.String())
+		api.notifier.Notify(ctx, models.WebhookEventTopicPublished, topic.ID, models.StatePublished.String())
+		api.recordPublication(ctx, topic.ID, topic.Current)
+	}
+	api.recordContentPublications(ctx, collection)
+
+	if err := WriteJSONBody(ctx, collection, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// getOpenCollection returns the collection with the id, or an error wrapping apierrors.ErrCollectionPublished if it
+// has already been published, so that it can no longer be changed
+func (api *API) getOpenCollection(ctx context.Context, id string) (*models.Collection, error) {
+	collection, err := api.dataStore.Backend.GetCollection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !collection.IsOpen() {
+		return nil, apierrors.ErrCollectionPublished
+	}
+
+	return collection, nil
+}
+
+// checkChangeNotCollected returns an error wrapping apierrors.ErrTopicInCollection if the next version of the topic,
+// or of its content, is already in an open collection other than the one with the id
+func (api *API) checkChangeNotCollected(ctx context.Context, id, topicID string, content bool) error {
+	collections, err := api.dataStore.Backend.GetCollections(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range collections {
+		if collections[i].ID != id && collections[i].IsOpen() && collections[i].HasChange(topicID, content) {
+			return fmt.Errorf("%w: it is in collection %s", apierrors.ErrTopicInCollection, collections[i].ID)
+		}
+	}
+
+	return nil
+}
+
+// publishCollection publishes the next versions of the topics and content of a collection, and records the collection
+// as published, returning the published topics. It is all or nothing: every change is checked before anything is
+// published, and if publishing a change, or recording the collection, fails, the changes already published are rolled
+// back, restoring the topics and content as they were.
+func (api *API) publishCollection(ctx context.Context, collection *models.Collection) (published []*models.TopicResponse, err error) {
+	defer func() {
+		for range collection.Topics {
+			metrics.ObservePublish(err)
+		}
+	}()
+
+	if collection.IsEmpty() {
+		return nil, apierrors.ErrCollectionEmpty
+	}
+
+	topics, content, err := api.getCollectionChanges(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the paths of the published topics while the old ones are still published, if any of them move
+	moved := false
+	for _, original := range topics {
+		moved = moved || slugChanges(original.Current, original.Next)
+	}
+	paths := api.getPublishedPaths(ctx, moved)
+
+	var restore []func(ctx context.Context) error
+	rollback := func(cause error) error {
+		// the rollback must complete even if the request is cancelled
+		ctx := context.WithoutCancel(ctx)
+		for i := len(restore) - 1; i >= 0; i-- {
+			if err := restore[i](ctx); err != nil {
+				log.Error(ctx, "failed to roll back the publication of a collection", err, log.Data{"collection_id": collection.ID})
+			}
+		}
+		return cause
+	}
+
+	for _, original := range topics {
+		next := *original.Next
+		next.State = models.StatePublished.String()
+		next.ReleaseDate = collection.ReleaseDate
+		topic := syncNextAndCurrentTopic(&models.TopicResponse{ID: original.ID, Next: &next})
+
+		if err := api.dataStore.Backend.UpsertTopic(ctx, original.ID, topic); err != nil {
+			return nil, rollback(fmt.Errorf("failed to publish topic %s: %w", original.ID, err))
+		}
+		restore = append(restore, func(ctx context.Context) error {
+			return api.dataStore.Backend.ReplaceTopic(ctx, original)
+		})
+		published = append(published, topic)
+	}
+
+	for _, original := range content {
+		next := *original.Next
+		next.State = models.StatePublished.String()
+
+		if err := api.dataStore.Backend.UpsertContent(ctx, original.ID, &models.ContentResponse{ID: original.ID, Next: &next, Current: &next}); err != nil {
+			return nil, rollback(fmt.Errorf("failed to publish the content of topic %s: %w", original.ID, err))
+		}
+		restore = append(restore, func(ctx context.Context) error {
+			return api.dataStore.Backend.ReplaceContent(ctx, original)
+		})
+	}
+
+	publishedAt := time.Now().UTC()
+	collection.State = models.CollectionStatePublished
+	collection.PublishedAt = &publishedAt
+	collection.PublishedBy = callerIdentity(ctx)
+	if err := api.dataStore.Backend.UpsertCollection(ctx, collection); err != nil {
+		return nil, rollback(fmt.Errorf("failed to record the publication of the collection: %w", err))
+	}
+
+	// the collection has already been published, so a failure to redirect the old paths of its topics is logged
+	// rather than returned
+	if err := api.recordRedirects(ctx, paths); err != nil {
+		log.Error(ctx, "failed to record redirects of the topics of a collection", err, log.Data{"collection_id": collection.ID})
+	}
+
+	return published, nil
+}
+
+// getCollectionChanges returns the topics and content of a collection as they are before it is published, checking
+// that every one of them has a next version that can be published
+func (api *API) getCollectionChanges(ctx context.Context, collection *models.Collection) ([]*models.TopicResponse, []*models.ContentResponse, error) {
+	topics := make([]*models.TopicResponse, 0, len(collection.Topics))
+	for _, id := range collection.Topics {
+		topic, err := api.dataStore.Backend.GetTopic(ctx, id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("topic %s: %w", id, err)
+		}
+		if topic.Next == nil {
+			return nil, nil, fmt.Errorf("%w: topic %s has no next version to publish", apierrors.ErrTopicNotFound, id)
+		}
+		if err := models.CheckTransition(topic.Next.State, models.StatePublished.String(), api.requireReview); err != nil {
+			return nil, nil, fmt.Errorf("topic %s: %w", id, err)
+		}
+		topics = append(topics, topic)
+	}
+
+	content := make([]*models.ContentResponse, 0, len(collection.Content))
+	for _, id := range collection.Content {
+		stored, err := api.dataStore.Backend.GetContent(ctx, id, queryAllFlags)
+		if err != nil {
+			return nil, nil, fmt.Errorf("content of topic %s: %w", id, err)
+		}
+		if stored.Next == nil {
+			return nil, nil, fmt.Errorf("%w: the content of topic %s has no next version to publish", apierrors.ErrContentNotFound, id)
+		}
+		if err := models.CheckTransition(stored.Next.State, models.StatePublished.String(), false); err != nil {
+			return nil, nil, fmt.Errorf("content of topic %s: %w", id, err)
+		}
+		content = append(content, stored)
+	}
+
+	return topics, content, nil
+}
+
+// recordContentPublications records the publication of the content of the topics of a collection whose topic was not
+// published with it, for the feeds of recently published content
+func (api *API) recordContentPublications(ctx context.Context, collection *models.Collection) {
+	for _, id := range collection.Content {
+		if collection.HasChange(id, false) {
+			// recorded with the publication of the topic
+			continue
+		}
+
+		topic, err := api.dataStore.Backend.GetTopic(ctx, id)
+		if err != nil {
+			log.Error(ctx, "failed to get topic to record publication", err, log.Data{"topic_id": id})
+			continue
+		}
+		if topic.Current != nil {
+			api.recordPublication(ctx, id, topic.Current)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testReleaseDate = time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+// collectionTaxonomy is a store with the topics economy and gdp below the root topic, in the states given, the
+// content of economy, and the collections given. The redirects recorded as topics are published are kept in it.
+func collectionTaxonomy(economy, gdp models.State, collections ...models.Collection) *storeMock.MongoDBMock {
+	redirects := map[string]models.Redirect{}
+	topics := map[string]*models.TopicResponse{
+		topicRoot: {ID: topicRoot, Current: &models.Topic{ID: topicRoot, State: models.StatePublished.String(), SubtopicIds: &[]string{"economy", "gdp"}}},
+		"economy": {ID: "economy", Next: &models.Topic{ID: "economy", Title: "Economy", State: economy.String()}},
+		"gdp": {
+			ID:      "gdp",
+			Current: &models.Topic{ID: "gdp", Title: "GDP", State: models.StatePublished.String()},
+			Next:    &models.Topic{ID: "gdp", Title: "Gross domestic product", State: gdp.String()},
+		},
+	}
+
+	return &storeMock.MongoDBMock{
+		GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+			topic, ok := topics[id]
+			if !ok {
+				return nil, apierrors.ErrTopicNotFound
+			}
+			return topic, nil
+		},
+		CheckTopicExistsFunc: func(ctx context.Context, id string) error {
+			if _, ok := topics[id]; !ok {
+				return apierrors.ErrTopicNotFound
+			}
+			return nil
+		},
+		GetContentFunc: func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+			if id != "economy" {
+				return nil, apierrors.ErrContentNotFound
+			}
+			return &models.ContentResponse{
+				ID:   "economy",
+				Next: &models.Content{State: models.StateCreated.String(), Articles: &[]models.TypeLinkObject{{HRef: "/articles/1", Title: "Article"}}},
+			}, nil
+		},
+		GetCollectionFunc: func(ctx context.Context, id string) (*models.Collection, error) {
+			for i := range collections {
+				if collections[i].ID == id {
+					collection := collections[i]
+					return &collection, nil
+				}
+			}
+			return nil, apierrors.ErrCollectionNotFound
+		},
+		GetCollectionsFunc: func(ctx context.Context) ([]models.Collection, error) {
+			return collections, nil
+		},
+		CreateCollectionFunc: func(ctx context.Context, collection *models.Collection) error { return nil },
+		UpsertCollectionFunc: func(ctx context.Context, collection *models.Collection) error { return nil },
+		GetAllTopicsFunc: func(ctx context.Context) ([]models.TopicResponse, error) {
+			all := make([]models.TopicResponse, 0, len(topics))
+			for _, topic := range topics {
+				all = append(all, *topic)
+			}
+			return all, nil
+		},
+		UpsertTopicFunc: func(ctx context.Context, id string, topic *models.TopicResponse) error {
+			topics[id] = topic
+			return nil
+		},
+		GetRedirectsFunc: func(ctx context.Context) ([]models.Redirect, error) {
+			var result []models.Redirect
+			for _, redirect := range redirects {
+				result = append(result, redirect)
+			}
+			return result, nil
+		},
+		UpsertRedirectFunc: func(ctx context.Context, redirect *models.Redirect) error {
+			redirects[redirect.From] = *redirect
+			return nil
+		},
+		DeleteRedirectFunc: func(ctx context.Context, from string) error {
+			delete(redirects, from)
+			return nil
+		},
+		UpsertContentFunc:     func(ctx context.Context, id string, content *models.ContentResponse) error { return nil },
+		ReplaceTopicFunc:      func(ctx context.Context, topic *models.TopicResponse) error { return nil },
+		ReplaceContentFunc:    func(ctx context.Context, content *models.ContentResponse) error { return nil },
+		GetPublicationsFunc:   func(ctx context.Context, topicID string, limit int) ([]models.Publication, error) { return nil, nil },
+		InsertPublicationFunc: func(ctx context.Context, publication *models.Publication) error { return nil },
+	}
+}
+
+func openCollection(id string, topics, content []string) models.Collection {
+	return models.Collection{ID: id, Name: "March release", ReleaseDate: &testReleaseDate, State: models.CollectionStateOpen, Topics: topics, Content: content}
+}
+
+func serveCollections(topicAPI *API, method, url, body string) *httptest.ResponseRecorder {
+	request, err := createRequestWithAuth(method, url, bytes.NewBufferString(body))
+	So(err, ShouldBeNil)
+	w := httptest.NewRecorder()
+	topicAPI.Router.ServeHTTP(w, request)
+	return w
+}
+
+func TestPostCollectionHandler(t *testing.T) {
+	Convey("Given a topic API in publishing mode", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		mongoDBMock := collectionTaxonomy(models.StateCreated, models.StateCreated)
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		Convey("When a collection is posted with a name and release date", func() {
+			w := serveCollections(topicAPI, http.MethodPost, "http://localhost:25300/collections",
+				`{"name": "March release", "release_date": "2024-03-01T09:30:00Z"}`)
+
+			Convey("Then an open, empty collection is created", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(mongoDBMock.CreateCollectionCalls(), ShouldHaveLength, 1)
+
+				var collection models.Collection
+				So(json.Unmarshal(w.Body.Bytes(), &collection), ShouldBeNil)
+				So(collection.ID, ShouldNotBeEmpty)
+				So(collection.Name, ShouldEqual, "March release")
+				So(*collection.ReleaseDate, ShouldEqual, testReleaseDate)
+				So(collection.State, ShouldEqual, models.CollectionStateOpen)
+				So(collection.Topics, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a collection is posted without a name or release date", func() {
+			w := serveCollections(topicAPI, http.MethodPost, "http://localhost:25300/collections", `{"name": " "}`)
+
+			Convey("Then 400 is returned detailing both fields", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				var problem models.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Code, ShouldEqual, apierrors.CodeCollectionInvalidFields)
+				So(problem.Errors, ShouldHaveLength, 2)
+				So(mongoDBMock.CreateCollectionCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestCollectionChangeHandlers(t *testing.T) {
+	Convey("Given a topic API in publishing mode, with an open collection, and another releasing gdp", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		published := openCollection("published", []string{"economy"}, nil)
+		published.State = models.CollectionStatePublished
+		mongoDBMock := collectionTaxonomy(models.StateCreated, models.StateCreated,
+			openCollection("march", []string{}, []string{}), openCollection("april", []string{"gdp"}, nil), published)
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		Convey("When a topic and its content are added to the collection", func() {
+			serveCollections(topicAPI, http.MethodPut, "http://localhost:25300/collections/march/topics/economy", "")
+			w := serveCollections(topicAPI, http.MethodPut, "http://localhost:25300/collections/march/content/economy", "")
+
+			Convey("Then both changes are stored in the collection", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				calls := mongoDBMock.UpsertCollectionCalls()
+				So(calls, ShouldHaveLength, 2)
+				So(calls[0].Collection.Topics, ShouldResemble, []string{"economy"})
+				So(calls[1].Collection.Content, ShouldResemble, []string{"economy"})
+			})
+		})
+
+		Convey("When a topic in another open collection is added to the collection", func() {
+			w := serveCollections(topicAPI, http.MethodPut, "http://localhost:25300/collections/march/topics/gdp", "")
+
+			Convey("Then 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				var problem models.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Code, ShouldEqual, apierrors.CodeTopicInCollection)
+				So(problem.Detail, ShouldContainSubstring, "april")
+				So(mongoDBMock.UpsertCollectionCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a topic that does not exist is added to the collection", func() {
+			w := serveCollections(topicAPI, http.MethodPut, "http://localhost:25300/collections/march/topics/unknown", "")
+
+			Convey("Then 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When a topic is added to a published collection", func() {
+			w := serveCollections(topicAPI, http.MethodPut, "http://localhost:25300/collections/published/topics/gdp", "")
+
+			Convey("Then 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusForbidden, apierrors.ErrCollectionPublished))
+			})
+		})
+
+		Convey("When a topic is removed from the collection releasing it", func() {
+			w := serveCollections(topicAPI, http.MethodDelete, "http://localhost:25300/collections/april/topics/gdp", "")
+
+			Convey("Then the collection no longer releases it", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpsertCollectionCalls(), ShouldHaveLength, 1)
+				So(mongoDBMock.UpsertCollectionCalls()[0].Collection.Topics, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a topic that is not in the collection is removed from it", func() {
+			w := serveCollections(topicAPI, http.MethodDelete, "http://localhost:25300/collections/march/content/gdp", "")
+
+			Convey("Then 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func TestPutCollectionPublishHandler(t *testing.T) {
+	Convey("Given a topic API in publishing mode, with a collection releasing economy, gdp and the content of economy", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		collection := openCollection("march", []string{"economy", "gdp"}, []string{"economy"})
+
+		Convey("When the collection is published", func() {
+			mongoDBMock := collectionTaxonomy(models.StateCompleted, models.StateCreated, collection)
+			notifier := &notifierStub{}
+			topicAPI := getAPIWithNotifier(cfg, mongoDBMock, notifier)
+			w := serveCollections(topicAPI, http.MethodPut, "http://localhost:25300/collections/march/publish", "")
+
+			Convey("Then every topic and the content are published together, on the release date of the collection", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpsertTopicCalls(), ShouldHaveLength, 2)
+				for _, call := range mongoDBMock.UpsertTopicCalls() {
+					So(call.Topic.Current, ShouldEqual, call.Topic.Next)
+					So(call.Topic.Current.State, ShouldEqual, models.StatePublished.String())
+					So(*call.Topic.Current.ReleaseDate, ShouldEqual, testReleaseDate)
+				}
+				So(mongoDBMock.UpsertContentCalls(), ShouldHaveLength, 1)
+				So(mongoDBMock.UpsertContentCalls()[0].Content.Current.Articles, ShouldNotBeNil)
+				So(mongoDBMock.ReplaceTopicCalls(), ShouldBeEmpty)
+			})
+
+			Convey("Then the collection is recorded as published", func() {
+				So(mongoDBMock.UpsertCollectionCalls(), ShouldHaveLength, 1)
+				published := mongoDBMock.UpsertCollectionCalls()[0].Collection
+				So(published.State, ShouldEqual, models.CollectionStatePublished)
+				So(published.PublishedBy, ShouldEqual, "someone@ons.gov.uk")
+				So(published.PublishedAt, ShouldNotBeNil)
+			})
+
+			Convey("Then the old path of gdp, which moves with its new title, is redirected to its new one", func() {
+				So(mongoDBMock.UpsertRedirectCalls(), ShouldHaveLength, 1)
+				redirect := mongoDBMock.UpsertRedirectCalls()[0].Redirect
+				So(redirect.From, ShouldEqual, "/gdp")
+				So(redirect.To, ShouldEqual, "/grossdomesticproduct")
+				So(redirect.TopicID, ShouldEqual, "gdp")
+			})
+
+			Convey("Then the publication of each topic is notified", func() {
+				So(notifier.notifications, ShouldResemble, []notification{
+					{models.WebhookEventTopicStateChanged, "economy", "published"},
+					{models.WebhookEventTopicPublished, "economy", "published"},
+					{models.WebhookEventTopicStateChanged, "gdp", "published"},
+					{models.WebhookEventTopicPublished, "gdp", "published"},
+				})
+			})
+		})
+
+		Convey("When the collection is published while one of its topics is in review", func() {
+			mongoDBMock := collectionTaxonomy(models.StateCompleted, models.StateInReview, collection)
+			topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+			w := serveCollections(topicAPI, http.MethodPut, "http://localhost:25300/collections/march/publish", "")
+
+			Convey("Then 403 is returned, and nothing is published", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(mongoDBMock.UpsertTopicCalls(), ShouldBeEmpty)
+				So(mongoDBMock.UpsertContentCalls(), ShouldBeEmpty)
+				So(mongoDBMock.UpsertCollectionCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When publishing one of the changes of the collection fails", func() {
+			mongoDBMock := collectionTaxonomy(models.StateCompleted, models.StateCreated, collection)
+			mongoDBMock.UpsertContentFunc = func(ctx context.Context, id string, content *models.ContentResponse) error {
+				return errors.New("connection lost")
+			}
+			topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+			w := serveCollections(topicAPI, http.MethodPut, "http://localhost:25300/collections/march/publish", "")
+
+			Convey("Then 500 is returned, and the topics already published are rolled back, newest first", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(mongoDBMock.UpsertTopicCalls(), ShouldHaveLength, 2)
+				calls := mongoDBMock.ReplaceTopicCalls()
+				So(calls, ShouldHaveLength, 2)
+				So(calls[0].Topic.ID, ShouldEqual, "gdp")
+				So(calls[0].Topic.Current.Title, ShouldEqual, "GDP")
+				So(calls[0].Topic.Next.State, ShouldEqual, models.StateCreated.String())
+				So(calls[1].Topic.ID, ShouldEqual, "economy")
+				So(calls[1].Topic.Current, ShouldBeNil)
+				So(mongoDBMock.UpsertCollectionCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When recording the collection as published fails", func() {
+			mongoDBMock := collectionTaxonomy(models.StateCompleted, models.StateCreated, collection)
+			mongoDBMock.UpsertCollectionFunc = func(ctx context.Context, collection *models.Collection) error {
+				return errors.New("connection lost")
+			}
+			notifier := &notifierStub{}
+			topicAPI := getAPIWithNotifier(cfg, mongoDBMock, notifier)
+			w := serveCollections(topicAPI, http.MethodPut, "http://localhost:25300/collections/march/publish", "")
+
+			Convey("Then 500 is returned, and every change of the collection is rolled back without being notified", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(mongoDBMock.ReplaceContentCalls(), ShouldHaveLength, 1)
+				So(mongoDBMock.ReplaceTopicCalls(), ShouldHaveLength, 2)
+				So(notifier.notifications, ShouldBeEmpty)
+				So(mongoDBMock.InsertPublicationCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an empty collection is published", func() {
+			mongoDBMock := collectionTaxonomy(models.StateCompleted, models.StateCreated, openCollection("empty", nil, nil))
+			topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+			w := serveCollections(topicAPI, http.MethodPut, "http://localhost:25300/collections/empty/publish", "")
+
+			Convey("Then 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusBadRequest, apierrors.ErrCollectionEmpty))
+			})
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConditionalGet(t *testing.T) {
+	Convey("Given a public topic API with a topic last updated at a known time", t, func() {
+		lastUpdated := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = false
+
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+				if id != testTopicID1 {
+					return nil, apierrors.ErrTopicNotFound
+				}
+				topic := dbTopic(models.StatePublished)
+				topic.Current.LastUpdated = &lastUpdated
+				return topic, nil
+			},
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		get := func(url string, headers map[string]string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodGet, url, http.NoBody)
+			for key, value := range headers {
+				request.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When the topic is requested", func() {
+			w := get("http://localhost:25300/topics/"+testTopicID1, nil)
+
+			Convey("Then the response carries a strong ETag, Last-Modified and the configured Cache-Control", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("ETag"), ShouldStartWith, `"`)
+				So(w.Header().Get("ETag"), ShouldEqual, strongETag(w.Body.Bytes()))
+				So(w.Header().Get("Last-Modified"), ShouldEqual, "Fri, 01 Mar 2024 09:30:00 GMT")
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=300")
+			})
+
+			Convey("And the topic is requested again with If-None-Match set to its ETag", func() {
+				etag := w.Header().Get("ETag")
+				w := get("http://localhost:25300/topics/"+testTopicID1, map[string]string{"If-None-Match": `"other", ` + etag})
+
+				Convey("Then the response is a 304 with the validators and no body", func() {
+					So(w.Code, ShouldEqual, http.StatusNotModified)
+					So(w.Body.Len(), ShouldEqual, 0)
+					So(w.Header().Get("ETag"), ShouldEqual, etag)
+					So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=300")
+				})
+			})
+
+			Convey("And the topic is requested again with an If-None-Match that does not match", func() {
+				w := get("http://localhost:25300/topics/"+testTopicID1, map[string]string{
+					"If-None-Match":     `"other"`,
+					"If-Modified-Since": "Fri, 01 Mar 2024 10:00:00 GMT",
+				})
+
+				Convey("Then the topic is returned, ignoring If-Modified-Since", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(w.Body.Len(), ShouldBeGreaterThan, 0)
+				})
+			})
+		})
+
+		Convey("When the topic is requested with If-Modified-Since at its last update", func() {
+			w := get("http://localhost:25300/topics/"+testTopicID1, map[string]string{"If-Modified-Since": "Fri, 01 Mar 2024 09:30:00 GMT"})
+
+			Convey("Then the response is a 304", func() {
+				So(w.Code, ShouldEqual, http.StatusNotModified)
+			})
+		})
+
+		Convey("When the topic is requested with If-Modified-Since before its last update", func() {
+			w := get("http://localhost:25300/topics/"+testTopicID1, map[string]string{"If-Modified-Since": "Fri, 01 Mar 2024 09:29:59 GMT"})
+
+			Convey("Then the topic is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When a topic that does not exist is requested with If-None-Match *", func() {
+			w := get("http://localhost:25300/topics/inexistent", map[string]string{"If-None-Match": "*"})
+
+			Convey("Then the error is returned unchanged, without validators", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+				So(w.Body.String(), ShouldContainSubstring, apierrors.ErrTopicNotFound.Error())
+				So(w.Header().Get("ETag"), ShouldBeEmpty)
+				So(w.Header().Get("Cache-Control"), ShouldBeEmpty)
+			})
+		})
+	})
+}
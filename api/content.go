@@ -28,35 +28,48 @@ func (api *API) getContentPublicHandler(w http.ResponseWriter, req *http.Request
 		return
 	}
 
+	preview, err := api.isPreview(req, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["preview"] = preview
+	dataStore := api.publicStore(preview)
+
 	// check topic from mongoDB by id
-	err := api.dataStore.Backend.CheckTopicExists(ctx, id)
+	err = dataStore.CheckTopicExists(ctx, id)
 	if err != nil {
 		handleError(ctx, w, err, logdata)
 		return
 	}
 
 	// get content from mongoDB by id
-	content, err := api.dataStore.Backend.GetContent(ctx, id, queryTypeFlags)
+	content, err := dataStore.GetContent(ctx, id, queryTypeFlags)
 	if err != nil {
 		// no content found
 		handleError(ctx, w, err, logdata)
 		return
 	}
 
-	// User is not authenticated and hence has only access to current sub document(s)
+	// User is not authenticated and hence has only access to current sub document(s), unless previewing the next ones
+	view := content.Current
+	if preview && content.Next != nil {
+		view = content.Next
+	}
 
-	if content.Current == nil {
+	if view == nil {
 		handleError(ctx, w, apierrors.ErrContentNotFound, logdata)
 		return
 	}
 
-	currentResult := getRequiredItems(queryTypeFlags, content.Current, content.ID)
+	currentResult := getRequiredItems(queryTypeFlags, view, content.ID)
 
 	if currentResult.TotalCount == 0 {
 		handleError(ctx, w, apierrors.ErrContentNotFound, logdata)
 		return
 	}
 
+	setPublicCacheControl(w, preview, api.contentCacheMaxAge)
 	if err := WriteJSONBody(ctx, currentResult, w, logdata); err != nil {
 		// WriteJSONBody has already logged the error
 		return
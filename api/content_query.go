@@ -22,6 +22,10 @@ const (
 	QueryTimeseriesFlag
 )
 
+// queryAllFlags is the flags of every query type
+const queryAllFlags = QuerySpotlightFlag | QueryArticlesFlag | QueryBulletinsFlag | QueryMethodologiesFlag |
+	QueryMethodologyArticlesFlag | QueryStaticDatasetsFlag | QueryTimeseriesFlag
+
 const (
 	spotlightStr           = "spotlight"
 	articlesStr            = "articles"
@@ -54,8 +58,7 @@ func getContentTypeParameter(queryVars url.Values) int {
 	valArray, found := queryVars["type"]
 	if !found {
 		// no type specified, so return flags for all types
-		return QuerySpotlightFlag | QueryArticlesFlag | QueryBulletinsFlag | QueryMethodologiesFlag |
-			QueryMethodologyArticlesFlag | QueryStaticDatasetsFlag | QueryTimeseriesFlag
+		return queryAllFlags
 	}
 
 	// make query type lower case for following comparison to cope with wrong case of letter(s)
@@ -434,7 +434,7 @@ func TestGetContentPublicHandler(t *testing.T) {
 					So(w.Code, ShouldEqual, http.StatusNotFound)
 					payload, err := io.ReadAll(w.Body)
 					So(err, ShouldBeNil)
-					So(payload, ShouldResemble, []byte("content not found\n"))
+					So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrContentNotFound))
 				})
 			})
 
@@ -447,7 +447,7 @@ func TestGetContentPublicHandler(t *testing.T) {
 					So(w.Code, ShouldEqual, http.StatusNotFound)
 					payload, err := io.ReadAll(w.Body)
 					So(err, ShouldBeNil)
-					So(payload, ShouldResemble, []byte("content not found\n"))
+					So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrContentNotFound))
 				})
 			})
 
@@ -459,7 +459,7 @@ func TestGetContentPublicHandler(t *testing.T) {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
 				payload, err := io.ReadAll(w.Body)
 				So(err, ShouldBeNil)
-				So(payload, ShouldResemble, []byte("topic not found\n"))
+				So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrTopicNotFound))
 			})
 
 			Convey("Requesting an nonexistent content ID results in a NotFound response (content read fails)", func() {
@@ -469,7 +469,7 @@ func TestGetContentPublicHandler(t *testing.T) {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
 				payload, err := io.ReadAll(w.Body)
 				So(err, ShouldBeNil)
-				So(payload, ShouldResemble, []byte("content not found\n"))
+				So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrContentNotFound))
 			})
 
 			Convey("When an existing 'published' content is requested with the valid Topic-Id context value for a query type: spotlight", func() {
@@ -596,7 +596,7 @@ func TestGetContentPublicHandler(t *testing.T) {
 					So(w.Code, ShouldEqual, http.StatusBadRequest)
 					payload, err := io.ReadAll(w.Body)
 					So(err, ShouldBeNil)
-					So(payload, ShouldResemble, []byte("content query not recognised\n"))
+					So(payload, ShouldResemble, problemPayload(http.StatusBadRequest, apierrors.ErrContentUnrecognisedParameter))
 				})
 			})
 
@@ -675,7 +675,7 @@ func TestGetContentPublicHandler(t *testing.T) {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
 				payload, err := io.ReadAll(w.Body)
 				So(err, ShouldBeNil)
-				So(payload, ShouldResemble, []byte("content not found\n"))
+				So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrContentNotFound))
 			})
 		})
 	})
@@ -791,7 +791,7 @@ func TestGetContentPrivateHandler(t *testing.T) {
 					So(w.Code, ShouldEqual, http.StatusNotFound)
 					payload, err := io.ReadAll(w.Body)
 					So(err, ShouldBeNil)
-					So(payload, ShouldResemble, []byte("content not found\n"))
+					So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrContentNotFound))
 				})
 			})
 
@@ -805,7 +805,7 @@ func TestGetContentPrivateHandler(t *testing.T) {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
 				payload, err := io.ReadAll(w.Body)
 				So(err, ShouldBeNil)
-				So(payload, ShouldResemble, []byte("topic not found\n"))
+				So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrTopicNotFound))
 			})
 
 			Convey("Requesting an nonexistent content ID results in a NotFound response (content read fails)", func() {
@@ -817,7 +817,7 @@ func TestGetContentPrivateHandler(t *testing.T) {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
 				payload, err := io.ReadAll(w.Body)
 				So(err, ShouldBeNil)
-				So(payload, ShouldResemble, []byte("content not found\n"))
+				So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrContentNotFound))
 			})
 
 			Convey("When an existing 'published' content is requested with the valid Topic-Id context value for a query type: spotlight AND page has not content for next and current", func() {
@@ -829,7 +829,7 @@ func TestGetContentPrivateHandler(t *testing.T) {
 				So(w.Code, ShouldEqual, http.StatusNotFound)
 				payload, err := io.ReadAll(w.Body)
 				So(err, ShouldBeNil)
-				So(payload, ShouldResemble, []byte("content not found\n"))
+				So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrContentNotFound))
 			})
 		})
 	})
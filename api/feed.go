@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// feedAuthor is the author of the feeds
+const feedAuthor = "Office for National Statistics"
+
+// feedCategoryTopic is the category of the entries of feeds for published topics. The entries for content are
+// categorised by the type of the content, e.g. bulletins.
+const feedCategoryTopic = "topic"
+
+// getTopicsFeedHandler is a handler that gets an Atom feed of the recently published topics, and the content they gained
+func (api *API) getTopicsFeedHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"function":   "getTopicsFeedHandler",
+	}
+
+	api.writeFeed(ctx, w, "", "Recently published topics", "/feeds/topics.atom", logdata)
+}
+
+// getTopicFeedHandler is a handler that gets an Atom feed of the recent publications of a topic, and the content it gained
+func (api *API) getTopicFeedHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"topic_id":   id,
+		"function":   "getTopicFeedHandler",
+	}
+
+	if id == topicRoot {
+		handleError(ctx, w, apierrors.ErrTopicNotFound, logdata)
+		return
+	}
+
+	topic, err := api.dataStore.Backend.GetTopic(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	if topic.Current == nil {
+		handleError(ctx, w, apierrors.ErrTopicNotFound, logdata)
+		return
+	}
+
+	api.writeFeed(ctx, w, id, topic.Current.Title, fmt.Sprintf("/topics/%s/feed.atom", id), logdata)
+}
+
+// writeFeed writes the Atom feed of the publications of a topic, or of every topic if id is empty, with the most
+// recently published first. Each publication has an entry for the topic, followed by an entry for each content link
+// it gained.
+func (api *API) writeFeed(ctx context.Context, w http.ResponseWriter, id, title, path string, logdata log.Data) {
+	publications, err := api.dataStore.Backend.GetPublications(ctx, id, api.feedMaxEntries)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	pages, err := api.getSitemapPages(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	uris := make(map[string]string, len(pages))
+	for _, page := range pages {
+		uris[page.id] = page.uri
+	}
+
+	feedURL := api.topicAPIURL + path
+	feed := models.AtomFeed{
+		Namespace: models.AtomNamespace,
+		ID:        feedURL,
+		Title:     title,
+		Author:    models.AtomAuthor{Name: feedAuthor},
+		Links:     []models.AtomLink{{Rel: "self", Type: models.AtomContentType, HRef: feedURL}},
+		Entries:   []models.AtomEntry{},
+	}
+
+	var updated *time.Time
+	for _, publication := range publications {
+		if publication.PublishedAt == nil {
+			continue
+		}
+		if updated == nil {
+			updated = publication.PublishedAt
+		}
+		published := formatLastMod(publication.PublishedAt)
+
+		topicURL := api.topicAPIURL + "/topics/" + publication.TopicID
+		if uri, ok := uris[publication.TopicID]; ok {
+			topicURL = api.websiteURL + uri
+		}
+		feed.Entries = append(feed.Entries, models.AtomEntry{
+			ID:         topicURL + "#" + published,
+			Title:      publication.Title,
+			Updated:    published,
+			Published:  published,
+			Summary:    publication.Description,
+			Links:      []models.AtomLink{{HRef: topicURL}},
+			Categories: []models.AtomCategory{{Term: feedCategoryTopic}},
+		})
+
+		for _, content := range publication.AddedContent {
+			contentURL := content.HRef
+			if strings.HasPrefix(contentURL, "/") {
+				contentURL = api.websiteURL + contentURL
+			}
+			feed.Entries = append(feed.Entries, models.AtomEntry{
+				ID:         contentURL,
+				Title:      content.Title,
+				Updated:    published,
+				Published:  published,
+				Summary:    fmt.Sprintf("Added to %s", publication.Title),
+				Links:      []models.AtomLink{{HRef: contentURL}},
+				Categories: []models.AtomCategory{{Term: content.Type}},
+			})
+		}
+	}
+
+	// each publication can add many entries, so the feed is limited to the entries of the most recent
+	if api.feedMaxEntries > 0 && len(feed.Entries) > api.feedMaxEntries {
+		feed.Entries = feed.Entries[:api.feedMaxEntries]
+	}
+
+	// a feed without entries was last updated when the first topic is published, which is not yet known
+	feed.Updated = formatLastMod(updated)
+	if updated == nil {
+		feed.Updated = time.Unix(0, 0).UTC().Format(time.RFC3339)
+	}
+
+	logdata["total_count"] = len(feed.Entries)
+	setLastModified(w, updated)
+	setCacheControl(w, api.feedCacheMaxAge)
+	if err := writeXMLBody(ctx, feed, models.AtomContentType, w, logdata); err != nil {
+		// writeXMLBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRecordPublication(t *testing.T) {
+	Convey("Given a topic with content, the links of which were partly published before", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+
+		mongoDBMock := sitemapMongoDBMock()
+		mongoDBMock.GetContentFunc = func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+			return &models.ContentResponse{ID: id, Current: &models.Content{
+				State:     models.StatePublished.String(),
+				Bulletins: &[]models.TypeLinkObject{{HRef: "/economy/bulletins/gdp", Title: "GDP"}, {HRef: "/economy/bulletins/cpi", Title: "CPI"}},
+			}}, nil
+		}
+		mongoDBMock.GetPublicationsFunc = func(ctx context.Context, topicID string, limit int) ([]models.Publication, error) {
+			return []models.Publication{{TopicID: topicID, ContentHRefs: []string{"/economy/bulletins/cpi"}}}, nil
+		}
+		mongoDBMock.InsertPublicationFunc = func(ctx context.Context, publication *models.Publication) error { return nil }
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		Convey("When the topic is published", func() {
+			topicAPI.recordPublication(testContext, "1", &models.Topic{Title: "Economy", Description: "The economy"})
+
+			Convey("Then the publication is recorded with only the content that was added", func() {
+				So(mongoDBMock.InsertPublicationCalls(), ShouldHaveLength, 1)
+				publication := mongoDBMock.InsertPublicationCalls()[0].Publication
+				So(publication.TopicID, ShouldEqual, "1")
+				So(publication.Title, ShouldEqual, "Economy")
+				So(publication.PublishedAt, ShouldNotBeNil)
+				So(publication.AddedContent, ShouldResemble, []models.PublishedContent{
+					{Type: "bulletins", Title: "GDP", HRef: "/economy/bulletins/gdp"},
+				})
+				So(publication.ContentHRefs, ShouldResemble, []string{"/economy/bulletins/cpi", "/economy/bulletins/gdp"})
+			})
+		})
+	})
+}
+
+func TestGetFeedHandlers(t *testing.T) {
+	Convey("Given a topic API in web mode with recent publications", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = false
+
+		earlier := time.Date(2022, 10, 10, 8, 30, 0, 0, time.UTC)
+		later := earlier.Add(24 * time.Hour)
+		mongoDBMock := sitemapMongoDBMock()
+		mongoDBMock.GetTopicFunc = func(ctx context.Context, id string) (*models.TopicResponse, error) {
+			switch id {
+			case "2":
+				return &models.TopicResponse{ID: id, Current: &models.Topic{ID: id, Title: "Inflation and price indices"}}, nil
+			case "5":
+				return &models.TopicResponse{ID: id, Next: &models.Topic{ID: id, Title: "Unpublished"}}, nil
+			default:
+				return nil, apierrors.ErrTopicNotFound
+			}
+		}
+		mongoDBMock.GetPublicationsFunc = func(ctx context.Context, topicID string, limit int) ([]models.Publication, error) {
+			return []models.Publication{
+				{TopicID: "2", Title: "Inflation and price indices", PublishedAt: &later, AddedContent: []models.PublishedContent{
+					{Type: "bulletins", Title: "Consumer price inflation", HRef: "/economy/inflationandpriceindices/bulletins/cpi"},
+				}},
+				{TopicID: "2", Title: "Inflation", PublishedAt: &earlier},
+			}, nil
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		get := func(path string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodGet, "http://localhost:25300"+path, http.NoBody)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When the feed of recently published topics is requested", func() {
+			w := get("/feeds/topics.atom")
+
+			Convey("Then it lists the publications and the content they added, most recent first", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, models.AtomContentType)
+				So(w.Header().Get("Last-Modified"), ShouldEqual, "Tue, 11 Oct 2022 08:30:00 GMT")
+				So(mongoDBMock.GetPublicationsCalls()[0].TopicID, ShouldBeEmpty)
+
+				var feed models.AtomFeed
+				So(xml.Unmarshal(w.Body.Bytes(), &feed), ShouldBeNil)
+				So(feed.Updated, ShouldEqual, "2022-10-11T08:30:00Z")
+				So(feed.Entries, ShouldHaveLength, 3)
+
+				So(feed.Entries[0].Title, ShouldEqual, "Inflation and price indices")
+				So(feed.Entries[0].Links[0].HRef, ShouldEqual, "https://www.ons.gov.uk/economy/inflationandpriceindices")
+				So(feed.Entries[0].Categories[0].Term, ShouldEqual, feedCategoryTopic)
+
+				So(feed.Entries[1].ID, ShouldEqual, "https://www.ons.gov.uk/economy/inflationandpriceindices/bulletins/cpi")
+				So(feed.Entries[1].Categories[0].Term, ShouldEqual, "bulletins")
+				So(feed.Entries[1].Published, ShouldEqual, "2022-10-11T08:30:00Z")
+
+				So(feed.Entries[2].Published, ShouldEqual, "2022-10-10T08:30:00Z")
+			})
+		})
+
+		Convey("When the feed of a published topic is requested, only its publications are requested", func() {
+			w := get("/topics/2/feed.atom")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(mongoDBMock.GetPublicationsCalls()[0].TopicID, ShouldEqual, "2")
+
+			var feed models.AtomFeed
+			So(xml.Unmarshal(w.Body.Bytes(), &feed), ShouldBeNil)
+			So(feed.Title, ShouldEqual, "Inflation and price indices")
+		})
+
+		Convey("When the feed of a topic that has not been published is requested, the response is a 404", func() {
+			w := get("/topics/5/feed.atom")
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("When the feed of an unknown topic is requested, the response is a 404", func() {
+			w := get("/topics/6/feed.atom")
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/integrity"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// getIntegrityHandler is a handler that checks the references of the taxonomy, between topics and from topics to their
+// content, responding with every problem found
+func (api *API) getIntegrityHandler(w http.ResponseWriter, req *http.Request) {
+	api.writeIntegrityReport(w, req, false, "getIntegrityHandler")
+}
+
+// postIntegrityHandler is a handler that checks the references of the taxonomy and repairs the problems that can be
+// repaired, responding with every problem found and whether it was fixed
+func (api *API) postIntegrityHandler(w http.ResponseWriter, req *http.Request) {
+	api.writeIntegrityReport(w, req, true, "postIntegrityHandler")
+}
+
+func (api *API) writeIntegrityReport(w http.ResponseWriter, req *http.Request, fix bool, function string) {
+	ctx := req.Context()
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"fix":        fix,
+		"function":   function,
+	}
+
+	report, err := integrity.Run(ctx, api.dataStore.Backend, api.topicAPIURL, fix)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	logdata["problems"] = len(report.Problems)
+	logdata["fixed"] = report.Count(true)
+	if err := WriteJSONBody(ctx, report, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/integrity"
+	"github.com/ONSdigital/dp-topic-api/models"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIntegrityHandlers(t *testing.T) {
+	Convey("Given a topic API in publishing mode, with a topic whose subtopic does not exist", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+
+		root := &models.TopicResponse{
+			ID:      topicRoot,
+			Current: &models.Topic{ID: topicRoot, State: models.StatePublished.String(), SubtopicIds: &[]string{"deleted"}},
+			Next:    &models.Topic{ID: topicRoot, State: models.StatePublished.String(), SubtopicIds: &[]string{"deleted"}},
+		}
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetAllTopicsFunc: func(ctx context.Context) ([]models.TopicResponse, error) {
+				return []models.TopicResponse{*root}, nil
+			},
+			GetAllContentFunc: func(ctx context.Context) ([]models.ContentResponse, error) {
+				return []models.ContentResponse{{ID: topicRoot}}, nil
+			},
+			ReplaceTopicFunc: func(ctx context.Context, topic *models.TopicResponse) error { return nil },
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		serve := func(method string) (*httptest.ResponseRecorder, *integrity.Report) {
+			request, err := createRequestWithAuth(method, "http://localhost:25300/topics/integrity", http.NoBody)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			var report integrity.Report
+			So(json.Unmarshal(w.Body.Bytes(), &report), ShouldBeNil)
+			return w, &report
+		}
+
+		Convey("When the taxonomy is checked, then the dangling subtopic is reported, and nothing is repaired", func() {
+			w, report := serve(http.MethodGet)
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(report.Fix, ShouldBeFalse)
+			So(report.Problems, ShouldHaveLength, 2)
+			So(report.Problems[0].Kind, ShouldEqual, integrity.KindDanglingSubtopic)
+			So(report.Problems[0].Fixed, ShouldBeFalse)
+			So(mongoDBMock.ReplaceTopicCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When the taxonomy is repaired, then the dangling subtopic is removed", func() {
+			w, report := serve(http.MethodPost)
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(report.Fix, ShouldBeTrue)
+			So(report.Count(true), ShouldEqual, 2)
+			So(mongoDBMock.ReplaceTopicCalls(), ShouldHaveLength, 1)
+			So(*mongoDBMock.ReplaceTopicCalls()[0].Topic.Next.SubtopicIds, ShouldBeEmpty)
+		})
+	})
+}
@@ -196,7 +196,22 @@ func (api *API) getNavigationHandler(w http.ResponseWriter, req *http.Request) {
 		nav.Items, nav.Description = getNavItems(english)
 	}
 
-	w.Header().Set("Cache-Control", "public, max-age="+api.navigationCacheMaxAge)
+	include, err := includeArchived(req)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	if !include {
+		// the navigation is still served if the archived topics cannot be found, rather than failing every page
+		archived, err := api.archivedTopics(ctx)
+		if err != nil {
+			log.Error(ctx, "failed to get archived topics for the navigation", err, logdata)
+		} else {
+			nav.Items = withoutArchived(nav.Items, archived)
+		}
+	}
+
+	setCacheControl(w, api.navigationCacheMaxAge)
 	if err := WriteJSONBody(ctx, nav, w, logdata); err != nil {
 		// WriteJSONBody has already logged the error
 		return
@@ -1,11 +1,15 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
 	storetest "github.com/ONSdigital/dp-topic-api/store/mock"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -14,7 +18,11 @@ func TestNavigationGetNavigationHandler(t *testing.T) {
 	Convey("Given a topic API in publishing mode (private endpoints enabled)", t, func() {
 		cfg, err := config.Get()
 		So(err, ShouldBeNil)
-		mockedDataStore := &storetest.StorerMock{}
+		mockedDataStore := &storetest.StorerMock{
+			GetArchivedTopicIDsFunc: func(ctx context.Context) ([]string, error) {
+				return nil, nil
+			},
+		}
 
 		api := GetAPIWithMocks(cfg, mockedDataStore)
 		w := httptest.NewRecorder()
@@ -30,3 +38,54 @@ func TestNavigationGetNavigationHandler(t *testing.T) {
 		So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=1800")
 	})
 }
+
+func TestNavigationArchivedTopics(t *testing.T) {
+	Convey("Given a topic API where the wellbeing topic has been archived", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		mockedDataStore := &storetest.StorerMock{
+			GetArchivedTopicIDsFunc: func(ctx context.Context) ([]string, error) {
+				return []string{"wellbeing"}, nil
+			},
+		}
+		api := GetAPIWithMocks(cfg, mockedDataStore)
+
+		navigationIDs := func(url string) map[string]bool {
+			request, err := createRequestWithAuth("GET", url, nil)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			api.getNavigationHandler(w, request)
+			So(w.Code, ShouldEqual, http.StatusOK)
+
+			var nav models.Navigation
+			So(json.Unmarshal(w.Body.Bytes(), &nav), ShouldBeNil)
+			ids := map[string]bool{}
+			for _, item := range *nav.Items {
+				ids[item.Name] = true
+				if item.SubtopicItems != nil {
+					for _, subtopic := range *item.SubtopicItems {
+						ids[subtopic.Name] = true
+					}
+				}
+			}
+			return ids
+		}
+
+		Convey("When the navigation is requested, then the archived topic is not in it", func() {
+			ids := navigationIDs("http://localhost:25300/navigation")
+			So(ids["wellbeing"], ShouldBeFalse)
+			So(ids["economy"], ShouldBeTrue)
+		})
+
+		Convey("When the navigation is requested including archived topics, then the archived topic is in it", func() {
+			So(navigationIDs("http://localhost:25300/navigation?include_archived=true")["wellbeing"], ShouldBeTrue)
+		})
+
+		Convey("When the archived topics cannot be found, then the whole navigation is still returned", func() {
+			mockedDataStore.GetArchivedTopicIDsFunc = func(ctx context.Context) ([]string, error) {
+				return nil, errors.New("connection lost")
+			}
+			So(navigationIDs("http://localhost:25300/navigation")["wellbeing"], ShouldBeTrue)
+		})
+	})
+}
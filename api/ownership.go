@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// EntityParser parses the JWT of a caller into the entity it identifies, with the groups it is a member of
+type EntityParser interface {
+	Parse(token string) (*permsdk.EntityData, error)
+}
+
+// isTopicEditor wraps a http.HandlerFunc of a request editing the topic with the id of the route in another that checks
+// the caller is a member of one of the groups that own the topic or its ancestors. Topics that neither they nor their
+// ancestors are owned by can be edited by any caller. The check is only made when topic ownership is enforced.
+func (api *API) isTopicEditor(handler http.HandlerFunc) http.HandlerFunc {
+	if api.entityParser == nil {
+		return handler
+	}
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		id := mux.Vars(req)["id"]
+		logdata := log.Data{
+			"request_id": ctx.Value(dprequest.RequestIdKey),
+			"topic_id":   id,
+			"function":   "isTopicEditor",
+		}
+
+		if err := api.checkTopicEditor(ctx, api.callerGroups(ctx, req), id); err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+
+		handler(w, req)
+	}
+}
+
+// isTopicAdmin wraps a http.HandlerFunc in another that checks the caller is a member of one of the admin groups, who
+// can edit every topic and change the groups that own them. The check is only made when topic ownership is enforced.
+func (api *API) isTopicAdmin(handler http.HandlerFunc) http.HandlerFunc {
+	if api.entityParser == nil {
+		return handler
+	}
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		logdata := log.Data{
+			"request_id": ctx.Value(dprequest.RequestIdKey),
+			"function":   "isTopicAdmin",
+		}
+
+		if !intersects(api.callerGroups(ctx, req), api.adminGroups) {
+			err := fmt.Errorf("%w: this request requires membership of one of the groups: %s",
+				apierrors.ErrTopicForbidden, strings.Join(api.adminGroups, ", "))
+			handleError(ctx, w, err, logdata)
+			return
+		}
+
+		handler(w, req)
+	}
+}
+
+// callerGroups returns the groups of the caller, from the JWT in the Authorization header. A caller whose token is not
+// a valid JWT, such as a service token, is in no groups.
+func (api *API) callerGroups(ctx context.Context, req *http.Request) []string {
+	token := strings.TrimPrefix(req.Header.Get(dprequest.AuthHeaderKey), dprequest.BearerPrefix)
+	entity, err := api.entityParser.Parse(token)
+	if err != nil {
+		log.Info(ctx, "caller is in no groups, as its token could not be parsed", log.Data{"error": err.Error()})
+		return nil
+	}
+	return entity.Groups
+}
+
+// checkTopicEditor returns an error wrapping apierrors.ErrTopicForbidden, naming the groups that own the topic, if the
+// groups of the caller do not permit it to edit every one of the topics with the ids given. It returns nil when topic
+// ownership is not enforced.
+func (api *API) checkTopicEditor(ctx context.Context, groups []string, ids ...string) error {
+	if api.entityParser == nil || intersects(groups, api.adminGroups) {
+		return nil
+	}
+
+	topics, err := api.dataStore.Backend.GetAllTopics(ctx)
+	if err != nil {
+		return err
+	}
+	owners := newTopicOwnership(topics)
+
+	for _, id := range ids {
+		required := owners.effectiveOwners(id)
+		if len(required) > 0 && !intersects(groups, required) {
+			return fmt.Errorf("%w: editing topic %s requires membership of one of the groups that own it or its ancestors: %s",
+				apierrors.ErrTopicForbidden, id, strings.Join(required, ", "))
+		}
+	}
+
+	return nil
+}
+
+// topicOwnership holds the groups that own each topic, and the parents of each topic in either version of the taxonomy
+type topicOwnership struct {
+	owners  map[string][]string
+	parents map[string][]string
+}
+
+// newTopicOwnership creates the ownership of the topics given
+func newTopicOwnership(topics []models.TopicResponse) *topicOwnership {
+	o := &topicOwnership{
+		owners:  make(map[string][]string, len(topics)),
+		parents: make(map[string][]string),
+	}
+
+	for i := range topics {
+		topic := &topics[i]
+		o.owners[topic.ID] = topic.Owners
+		for _, version := range []*models.Topic{topic.Current, topic.Next} {
+			if version == nil || version.SubtopicIds == nil {
+				continue
+			}
+			for _, subtopicID := range *version.SubtopicIds {
+				if !slices.Contains(o.parents[subtopicID], topic.ID) {
+					o.parents[subtopicID] = append(o.parents[subtopicID], topic.ID)
+				}
+			}
+		}
+	}
+
+	return o
+}
+
+// effectiveOwners returns the groups that own the topic with the id, or any of its ancestors, sorted
+func (o *topicOwnership) effectiveOwners(id string) []string {
+	groups := make(map[string]bool)
+	visited := make(map[string]bool)
+
+	pending := []string{id}
+	for len(pending) > 0 {
+		id, pending = pending[0], pending[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		for _, group := range o.owners[id] {
+			groups[group] = true
+		}
+		pending = append(pending, o.parents[id]...)
+	}
+
+	owners := make([]string, 0, len(groups))
+	for group := range groups {
+		owners = append(owners, group)
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+// intersects returns whether any of the groups are among those required
+func intersects(groups, required []string) bool {
+	for _, group := range groups {
+		if slices.Contains(required, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// putTopicOwnersHandler replaces the groups that own a topic. An empty list leaves the topic owned by the owners of its
+// ancestors.
+func (api *API) putTopicOwnersHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"topic_id":   id,
+		"function":   "putTopicOwnersHandler",
+	}
+
+	var owners models.TopicOwners
+	if err := ReadJSONBody(ctx, req.Body, &owners, w, logdata); err != nil {
+		// ReadJSONBody has already handled the error
+		return
+	}
+
+	if err := owners.Validate(); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["owners"] = owners.Owners
+
+	if err := api.dataStore.Backend.UpdateOwners(ctx, id, owners.Owners); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
@@ -0,0 +1,399 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/mocks"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/dp-topic-api/store"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+	"github.com/gorilla/mux"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// entityParserStub parses a token into the groups it is mapped to
+type entityParserStub map[string][]string
+
+func (p entityParserStub) Parse(token string) (*permsdk.EntityData, error) {
+	groups, ok := p[token]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	return &permsdk.EntityData{UserID: token, Groups: groups}, nil
+}
+
+func getAPIWithEntityParser(cfg *config.Config, mockedDataStore store.Storer, entityParser EntityParser) *API {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return Setup(testContext, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, mocks.NewAuthHandlerMock(), entityParser, nil, nil, testTopicAPIURL)
+}
+
+// ownedTaxonomy is a taxonomy where census is owned by the census team, and its subtopic population by no one
+func ownedTaxonomy() []models.TopicResponse {
+	return []models.TopicResponse{
+		{ID: "topic_root", Current: &models.Topic{ID: "topic_root", SubtopicIds: &[]string{"census", "economy"}}},
+		{ID: "census", Owners: []string{"census-team"}, Current: &models.Topic{ID: "census", SubtopicIds: &[]string{"population"}}},
+		{ID: "population", Current: &models.Topic{ID: "population"}, Next: &models.Topic{ID: "population"}},
+		{ID: "economy", Current: &models.Topic{ID: "economy"}},
+	}
+}
+
+func TestTopicOwnership(t *testing.T) {
+	Convey("Given a taxonomy where a subtopic is also the subtopic of a topic in the next version", t, func() {
+		topics := ownedTaxonomy()
+		topics[3].Owners = []string{"economy-team"}
+		topics[3].Next = &models.Topic{ID: "economy", SubtopicIds: &[]string{"population"}}
+		ownership := newTopicOwnership(topics)
+
+		Convey("Then the owners of a topic are those of it and all of its ancestors", func() {
+			So(ownership.effectiveOwners("population"), ShouldResemble, []string{"census-team", "economy-team"})
+			So(ownership.effectiveOwners("census"), ShouldResemble, []string{"census-team"})
+			So(ownership.effectiveOwners("topic_root"), ShouldBeEmpty)
+			So(ownership.effectiveOwners("unknown"), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a taxonomy with a cycle", t, func() {
+		ownership := newTopicOwnership([]models.TopicResponse{
+			{ID: "a", Owners: []string{"team-a"}, Current: &models.Topic{SubtopicIds: &[]string{"b"}}},
+			{ID: "b", Current: &models.Topic{SubtopicIds: &[]string{"a"}}},
+		})
+
+		Convey("Then the owners are found without looping", func() {
+			So(ownership.effectiveOwners("b"), ShouldResemble, []string{"team-a"})
+		})
+	})
+}
+
+func TestIsTopicEditor(t *testing.T) {
+	Convey("Given a topic API in publishing mode enforcing the ownership of topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		ownershipCfg := *cfg
+		ownershipCfg.EnablePrivateEndpoints = true
+		ownershipCfg.TopicAdminGroups = []string{"role-admin"}
+
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetAllTopicsFunc:      func(ctx context.Context) ([]models.TopicResponse, error) { return ownedTaxonomy(), nil },
+			UpdateReleaseDateFunc: func(ctx context.Context, id string, releaseDate time.Time) error { return nil },
+		}
+		entityParser := entityParserStub{
+			"census-editor":  {"role-publisher", "census-team"},
+			"economy-editor": {"role-publisher", "economy-team"},
+			"admin":          {"role-admin"},
+		}
+		topicAPI := getAPIWithEntityParser(&ownershipCfg, mongoDBMock, entityParser)
+
+		putReleaseDate := func(id, token string) *httptest.ResponseRecorder {
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/"+id+"/release-date",
+				bytes.NewBufferString(`{"release_date": "2022-10-10T08:30:00Z"}`))
+			So(err, ShouldBeNil)
+			request.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When a member of the group owning an ancestor edits a topic", func() {
+			w := putReleaseDate("population", "census-editor")
+
+			Convey("Then the topic is edited", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpdateReleaseDateCalls(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a caller not in the groups owning a topic edits it", func() {
+			w := putReleaseDate("population", "economy-editor")
+
+			Convey("Then 403 is returned naming the groups that own it", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusForbidden, fmt.Errorf(
+					"%w: editing topic population requires membership of one of the groups that own it or its ancestors: census-team",
+					apierrors.ErrTopicForbidden)))
+				So(mongoDBMock.UpdateReleaseDateCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a caller whose token cannot be parsed edits an owned topic", func() {
+			w := putReleaseDate("census", "service-token")
+
+			Convey("Then 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(mongoDBMock.UpdateReleaseDateCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When any caller edits a topic that neither it nor its ancestors are owned by", func() {
+			w := putReleaseDate("economy", "census-editor")
+
+			Convey("Then the topic is edited", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When an admin edits an owned topic", func() {
+			w := putReleaseDate("census", "admin")
+
+			Convey("Then the topic is edited", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+
+	Convey("Given a topic API in publishing mode not enforcing the ownership of topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		mongoDBMock := &storeMock.MongoDBMock{
+			UpdateReleaseDateFunc: func(ctx context.Context, id string, releaseDate time.Time) error { return nil },
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		Convey("When an owned topic is edited", func() {
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/census/release-date",
+				bytes.NewBufferString(`{"release_date": "2022-10-10T08:30:00Z"}`))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then the topic is edited without its owners being looked up", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.GetAllTopicsCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestPutTopicPrivateHandlerSubtopicOwnership(t *testing.T) {
+	Convey("Given a topic API in publishing mode enforcing the ownership of topics, where economy is owned by the economy team", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		ownershipCfg := *cfg
+		ownershipCfg.EnablePrivateEndpoints = true
+
+		topics := ownedTaxonomy()
+		topics[3].Owners = []string{"economy-team"}
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetAllTopicsFunc: func(ctx context.Context) ([]models.TopicResponse, error) { return topics, nil },
+			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+				return dbTopicWithID(models.StateCreated, id), nil
+			},
+			CheckTopicExistsFunc: func(ctx context.Context, id string) error { return nil },
+			IsSlugInUseFunc:      func(ctx context.Context, id, slug string) (bool, error) { return false, nil },
+			UpdateTopicFunc:      func(context.Context, string, string, *models.TopicUpdate) error { return nil },
+		}
+		topicAPI := getAPIWithEntityParser(&ownershipCfg, mongoDBMock, entityParserStub{"census-editor": {"census-team"}})
+
+		putSubtopics := func(subtopics string) *httptest.ResponseRecorder {
+			payload := `{"title": "Census", "description": "Census", "state": "created", "release_date": "2022-10-10T08:30:00Z", "subtopics_ids": ` + subtopics + `}`
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/census", bytes.NewBufferString(payload))
+			So(err, ShouldBeNil)
+			request.Header.Set("Authorization", "Bearer census-editor")
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When a census editor makes economy a subtopic of census, to gain rights over it", func() {
+			w := putSubtopics(`["population", "economy"]`)
+
+			Convey("Then 403 is returned naming the groups that own economy, and census is not updated", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusForbidden, fmt.Errorf(
+					"%w: editing topic economy requires membership of one of the groups that own it or its ancestors: economy-team",
+					apierrors.ErrTopicForbidden)))
+				So(mongoDBMock.UpdateTopicCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a census editor keeps the subtopics census already has, then census is updated", func() {
+			w := putSubtopics(`["population"]`)
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(mongoDBMock.UpdateTopicCalls(), ShouldHaveLength, 1)
+		})
+	})
+}
+
+func TestPutTopicOwnersHandler(t *testing.T) {
+	Convey("Given a topic API in publishing mode enforcing the ownership of topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		ownershipCfg := *cfg
+		ownershipCfg.EnablePrivateEndpoints = true
+		ownershipCfg.TopicAdminGroups = []string{"role-admin"}
+
+		mongoDBMock := &storeMock.MongoDBMock{
+			UpdateOwnersFunc: func(ctx context.Context, id string, owners []string) error {
+				if id != "census" {
+					return apierrors.ErrTopicNotFound
+				}
+				return nil
+			},
+		}
+		entityParser := entityParserStub{
+			"census-editor": {"census-team"},
+			"admin":         {"role-admin"},
+		}
+		topicAPI := getAPIWithEntityParser(&ownershipCfg, mongoDBMock, entityParser)
+
+		putOwners := func(id, token, body string) *httptest.ResponseRecorder {
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/"+id+"/owners", bytes.NewBufferString(body))
+			So(err, ShouldBeNil)
+			request.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When an admin replaces the owners of a topic", func() {
+			w := putOwners("census", "admin", `{"owners": ["census-team", "population-team"]}`)
+
+			Convey("Then the owners are updated", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpdateOwnersCalls(), ShouldHaveLength, 1)
+				So(mongoDBMock.UpdateOwnersCalls()[0].Owners, ShouldResemble, []string{"census-team", "population-team"})
+			})
+		})
+
+		Convey("When an admin gives invalid owners", func() {
+			w := putOwners("census", "admin", `{"owners": ["census-team", "census-team"]}`)
+
+			Convey("Then 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(mongoDBMock.UpdateOwnersCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an admin replaces the owners of a topic that does not exist", func() {
+			w := putOwners("unknown", "admin", `{"owners": []}`)
+
+			Convey("Then 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When an owner of the topic who is not an admin replaces its owners", func() {
+			w := putOwners("census", "census-editor", `{"owners": []}`)
+
+			Convey("Then 403 is returned naming the admin groups", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusForbidden, fmt.Errorf(
+					"%w: this request requires membership of one of the groups: role-admin", apierrors.ErrTopicForbidden)))
+				So(mongoDBMock.UpdateOwnersCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestPostTopicsBulkHandlerOwnership(t *testing.T) {
+	Convey("Given a topic API in publishing mode enforcing the ownership of topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		ownershipCfg := *cfg
+		ownershipCfg.EnablePrivateEndpoints = true
+
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetAllTopicsFunc: func(ctx context.Context) ([]models.TopicResponse, error) { return ownedTaxonomy(), nil },
+		}
+		topicAPI := getAPIWithEntityParser(&ownershipCfg, mongoDBMock, entityParserStub{"economy-editor": {"economy-team"}})
+
+		Convey("When a bulk update with a row of a topic the caller cannot edit is posted", func() {
+			request, err := createRequestWithAuth(http.MethodPost, "http://localhost:25300/topics/bulk", bytes.NewBufferString(
+				"id,description\neconomy,The economy\npopulation,People\n"))
+			So(err, ShouldBeNil)
+			request.Header.Set("Authorization", "Bearer economy-editor")
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then 403 is returned and no topic is updated", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(mongoDBMock.UpdateTopicCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a bulk update makes a topic the caller cannot edit a subtopic of one it can", func() {
+			request, err := createRequestWithAuth(http.MethodPost, "http://localhost:25300/topics/bulk", bytes.NewBufferString(
+				"id,subtopics_ids\neconomy,population\n"))
+			So(err, ShouldBeNil)
+			request.Header.Set("Authorization", "Bearer economy-editor")
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then 403 is returned naming the groups that own the subtopic, and no topic is updated", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusForbidden, fmt.Errorf(
+					"%w: editing topic population requires membership of one of the groups that own it or its ancestors: census-team",
+					apierrors.ErrTopicForbidden)))
+				So(mongoDBMock.UpdateTopicCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestCollectionOwnership(t *testing.T) {
+	Convey("Given a topic API in publishing mode enforcing the ownership of topics, where gdp is owned by the economy team", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		ownershipCfg := *cfg
+		ownershipCfg.EnablePrivateEndpoints = true
+
+		entityParser := entityParserStub{"census-editor": {"census-team"}, "economy-editor": {"economy-team"}}
+		setup := func(collection models.Collection) (*storeMock.MongoDBMock, *API) {
+			mongoDBMock := collectionTaxonomy(models.StateCompleted, models.StateCreated, collection)
+			mongoDBMock.GetAllTopicsFunc = func(ctx context.Context) ([]models.TopicResponse, error) {
+				return []models.TopicResponse{{ID: "economy"}, {ID: "gdp", Owners: []string{"economy-team"}}}, nil
+			}
+			return mongoDBMock, getAPIWithEntityParser(&ownershipCfg, mongoDBMock, entityParser)
+		}
+		serve := func(topicAPI *API, url, token string) *httptest.ResponseRecorder {
+			request, err := createRequestWithAuth(http.MethodPut, url, http.NoBody)
+			So(err, ShouldBeNil)
+			request.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When a caller not in the groups owning gdp adds it to a collection, then 403 is returned", func() {
+			mongoDBMock, topicAPI := setup(openCollection("march", []string{}, []string{}))
+			w := serve(topicAPI, "http://localhost:25300/collections/march/topics/gdp", "census-editor")
+			So(w.Code, ShouldEqual, http.StatusForbidden)
+			So(mongoDBMock.UpsertCollectionCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When a member of the group owning gdp adds it to a collection, then it is added", func() {
+			mongoDBMock, topicAPI := setup(openCollection("march", []string{}, []string{}))
+			w := serve(topicAPI, "http://localhost:25300/collections/march/topics/gdp", "economy-editor")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(mongoDBMock.UpsertCollectionCalls(), ShouldHaveLength, 1)
+		})
+
+		Convey("When a caller not in the groups owning gdp publishes a collection releasing it, then 403 is returned and nothing is published", func() {
+			mongoDBMock, topicAPI := setup(openCollection("march", []string{"economy", "gdp"}, nil))
+			w := serve(topicAPI, "http://localhost:25300/collections/march/publish", "census-editor")
+			So(w.Code, ShouldEqual, http.StatusForbidden)
+			So(mongoDBMock.UpsertTopicCalls(), ShouldBeEmpty)
+			So(mongoDBMock.UpsertCollectionCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When a member of the group owning gdp publishes a collection releasing it, then it is published", func() {
+			mongoDBMock, topicAPI := setup(openCollection("march", []string{"economy", "gdp"}, nil))
+			w := serve(topicAPI, "http://localhost:25300/collections/march/publish", "economy-editor")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(mongoDBMock.UpsertTopicCalls(), ShouldHaveLength, 2)
+		})
+	})
+}
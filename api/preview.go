@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/dp-topic-api/store"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// previewTokenParam is the query parameter the public endpoints of a topic accept a preview token in
+const previewTokenParam = "preview_token"
+
+// uncachedStorer is implemented by stores that cache the current views of topics and content, returning the store
+// they are read from, which also holds the next views
+type uncachedStorer interface {
+	Uncached() store.Storer
+}
+
+// isPreview returns whether the request presents a preview token for the topic with the id, in which case the next
+// view of the topic is served. It returns an error wrapping apierrors.ErrInvalidPreviewToken if the token is invalid,
+// expired, or cannot be verified as previews are not enabled.
+func (api *API) isPreview(req *http.Request, id string) (bool, error) {
+	token := req.URL.Query().Get(previewTokenParam)
+	if token == "" {
+		return false, nil
+	}
+
+	if api.previews == nil {
+		return false, apierrors.ErrInvalidPreviewToken
+	}
+	if err := api.previews.Verify(token, id, time.Now()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// publicStore returns the store the public endpoints read from, bypassing any cache of current views for previews
+func (api *API) publicStore(preview bool) store.Storer {
+	if preview {
+		if cached, ok := api.dataStore.Backend.(uncachedStorer); ok {
+			return cached.Uncached()
+		}
+	}
+	return api.dataStore.Backend
+}
+
+// publicView returns the view of a topic served by the public endpoints, which is the next view for previews, without
+// its editorial records
+func publicView(topic *models.TopicResponse, preview bool) *models.Topic {
+	if preview && topic.Next != nil {
+		next := *topic.Next
+		next.LastEditedBy = ""
+		next.Review = nil
+		return &next
+	}
+	return topic.Current
+}
+
+// setPublicCacheControl sets the Cache-Control header of a response of the public endpoints, which must not be cached
+// for previews
+func setPublicCacheControl(w http.ResponseWriter, preview bool, maxAge string) {
+	if preview {
+		setNoStore(w)
+		return
+	}
+	setCacheControl(w, maxAge)
+}
+
+// postPreviewTokenHandler is a handler that issues a token giving access to the next view of a topic, with its
+// subtopics and content, on the public API until it expires
+func (api *API) postPreviewTokenHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	id := mux.Vars(req)["id"]
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"topic_id":   id,
+		"function":   "postPreviewTokenHandler",
+	}
+
+	if id == topicRoot {
+		handleError(ctx, w, apierrors.ErrTopicNotFound, logdata)
+		return
+	}
+
+	if err := api.dataStore.Backend.CheckTopicExists(ctx, id); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	token := api.previews.Issue(id, time.Now())
+	logdata["expires_at"] = token.ExpiresAt
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	if err := WriteJSONBody(ctx, token, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/cache"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/mocks"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/dp-topic-api/preview"
+	"github.com/ONSdigital/dp-topic-api/store"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+	"github.com/gorilla/mux"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testPreviewSecret = "a-secret-that-is-at-least-32-characters"
+
+func getAPIWithPreviews(cfg *config.Config, mockedDataStore store.Storer, previews *preview.Signer) *API {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return Setup(testContext, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, mocks.NewAuthHandlerMock(), nil, nil, previews, testTopicAPIURL)
+}
+
+// previewedTaxonomy is a taxonomy where the next version of economy adds the subtopic inflation, and changes its content
+func previewedTaxonomy() *storeMock.MongoDBMock {
+	topics := map[string]*models.TopicResponse{
+		"economy": {
+			ID:      "economy",
+			Current: &models.Topic{ID: "economy", Title: "Economy", SubtopicIds: &[]string{"gdp"}},
+			Next:    &models.Topic{ID: "economy", Title: "The economy", SubtopicIds: &[]string{"gdp", "inflation"}},
+		},
+		"gdp": {
+			ID:      "gdp",
+			Current: &models.Topic{ID: "gdp", Title: "GDP"},
+			Next:    &models.Topic{ID: "gdp", Title: "Gross domestic product"},
+		},
+		"inflation": {
+			ID:   "inflation",
+			Next: &models.Topic{ID: "inflation", Title: "Inflation"},
+		},
+	}
+
+	return &storeMock.MongoDBMock{
+		GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+			topic, ok := topics[id]
+			if !ok {
+				return nil, apierrors.ErrTopicNotFound
+			}
+			return topic, nil
+		},
+		CheckTopicExistsFunc: func(ctx context.Context, id string) error {
+			if _, ok := topics[id]; !ok {
+				return apierrors.ErrTopicNotFound
+			}
+			return nil
+		},
+		GetContentFunc: func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+			return &models.ContentResponse{
+				ID:      id,
+				Current: &models.Content{Articles: &[]models.TypeLinkObject{{HRef: "/articles/1", Title: "Published article"}}},
+				Next:    &models.Content{Articles: &[]models.TypeLinkObject{{HRef: "/articles/2", Title: "Unpublished article"}}},
+			}, nil
+		},
+	}
+}
+
+func TestPreview(t *testing.T) {
+	Convey("Given a topic API in web mode serving previews", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = false
+		signer, err := preview.NewSigner(testPreviewSecret, time.Hour)
+		So(err, ShouldBeNil)
+		topicAPI := getAPIWithPreviews(cfg, previewedTaxonomy(), signer)
+
+		get := func(url string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodGet, url, http.NoBody)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+		token := signer.Issue("economy", time.Now()).Token
+
+		Convey("When a topic is requested with a preview token for it", func() {
+			w := get("http://localhost:25300/topics/economy?preview_token=" + token)
+
+			Convey("Then its next view is returned, and must not be cached", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var topic models.Topic
+				So(json.Unmarshal(w.Body.Bytes(), &topic), ShouldBeNil)
+				So(topic.Title, ShouldEqual, "The economy")
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "private, no-store")
+			})
+		})
+
+		Convey("When a topic is requested without a preview token", func() {
+			w := get("http://localhost:25300/topics/economy")
+
+			Convey("Then its current view is returned, and can be cached", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var topic models.Topic
+				So(json.Unmarshal(w.Body.Bytes(), &topic), ShouldBeNil)
+				So(topic.Title, ShouldEqual, "Economy")
+				So(w.Header().Get("Cache-Control"), ShouldStartWith, "public, max-age=")
+			})
+		})
+
+		Convey("When the subtopics of a topic are requested with a preview token for it", func() {
+			w := get("http://localhost:25300/topics/economy/subtopics?preview_token=" + token)
+
+			Convey("Then the next views of its next subtopics are returned, and must not be cached", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var subtopics models.PublicSubtopics
+				So(json.Unmarshal(w.Body.Bytes(), &subtopics), ShouldBeNil)
+				So(subtopics.TotalCount, ShouldEqual, 2)
+				So((*subtopics.PublicItems)[0].Title, ShouldEqual, "Gross domestic product")
+				So((*subtopics.PublicItems)[1].Title, ShouldEqual, "Inflation")
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "private, no-store")
+			})
+		})
+
+		Convey("When the content of a topic is requested with a preview token for it", func() {
+			w := get("http://localhost:25300/topics/economy/content?preview_token=" + token)
+
+			Convey("Then its next content is returned, and must not be cached", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var content models.ContentResponseAPI
+				So(json.Unmarshal(w.Body.Bytes(), &content), ShouldBeNil)
+				So(content.TotalCount, ShouldEqual, 1)
+				So((*content.Items)[0].Title, ShouldEqual, "Unpublished article")
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "private, no-store")
+			})
+		})
+
+		Convey("When a topic is requested with a preview token for another topic", func() {
+			w := get("http://localhost:25300/topics/gdp?preview_token=" + token)
+
+			Convey("Then 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusForbidden, signer.Verify(token, "gdp", time.Now())))
+			})
+		})
+
+		Convey("When a topic is requested with an expired preview token", func() {
+			expired := signer.Issue("economy", time.Now().Add(-2*time.Hour)).Token
+			w := get("http://localhost:25300/topics/economy?preview_token=" + expired)
+
+			Convey("Then 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+			})
+		})
+	})
+
+	Convey("Given a topic API in web mode caching current views, and serving previews", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = false
+		signer, err := preview.NewSigner(testPreviewSecret, time.Hour)
+		So(err, ShouldBeNil)
+		topicAPI := getAPIWithPreviews(cfg, cache.NewStore(previewedTaxonomy(), time.Minute, 10), signer)
+
+		Convey("When a topic is requested with a preview token for it", func() {
+			request := httptest.NewRequest(http.MethodGet,
+				"http://localhost:25300/topics/economy?preview_token="+signer.Issue("economy", time.Now()).Token, http.NoBody)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then its next view is read past the cache", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var topic models.Topic
+				So(json.Unmarshal(w.Body.Bytes(), &topic), ShouldBeNil)
+				So(topic.Title, ShouldEqual, "The economy")
+			})
+		})
+	})
+
+	Convey("Given a topic API in web mode not serving previews", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = false
+		topicAPI := GetAPIWithMocks(cfg, previewedTaxonomy())
+
+		Convey("When a topic is requested with a preview token", func() {
+			request := httptest.NewRequest(http.MethodGet, "http://localhost:25300/topics/economy?preview_token=token", http.NoBody)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then 403 is returned, as the token cannot be verified", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusForbidden, apierrors.ErrInvalidPreviewToken))
+			})
+		})
+	})
+}
+
+func TestPostPreviewTokenHandler(t *testing.T) {
+	Convey("Given a topic API in publishing mode issuing previews", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		signer, err := preview.NewSigner(testPreviewSecret, time.Hour)
+		So(err, ShouldBeNil)
+		topicAPI := getAPIWithPreviews(cfg, previewedTaxonomy(), signer)
+
+		post := func(id string) *httptest.ResponseRecorder {
+			request, err := createRequestWithAuth(http.MethodPost, "http://localhost:25300/topics/"+id+"/preview-token", http.NoBody)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When a preview token is requested for a topic", func() {
+			w := post("economy")
+
+			Convey("Then a token for the topic is returned, expiring after the ttl", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				var token models.PreviewToken
+				So(json.Unmarshal(w.Body.Bytes(), &token), ShouldBeNil)
+				So(token.TopicID, ShouldEqual, "economy")
+				So(token.ExpiresAt, ShouldHappenWithin, time.Minute, time.Now().Add(time.Hour))
+				So(signer.Verify(token.Token, "economy", time.Now()), ShouldBeNil)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "no-store")
+			})
+		})
+
+		Convey("When a preview token is requested for a topic that does not exist", func() {
+			w := post("unknown")
+
+			Convey("Then 404 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
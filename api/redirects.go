@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// getRedirectsHandler is a handler that gets the redirect of the path given by the path query parameter, or every
+// redirect when there is no path, for the frontend router and CDN to send the old paths of topics to their new ones
+func (api *API) getRedirectsHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"function":   "getRedirectsHandler",
+	}
+
+	if path := req.URL.Query().Get("path"); path != "" {
+		logdata["path"] = path
+
+		redirect, err := api.dataStore.Backend.GetRedirect(ctx, models.NormaliseRedirectPath(path))
+		if err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+
+		setCacheControl(w, api.redirectsCacheMaxAge)
+		if err := WriteJSONBody(ctx, redirect, w, logdata); err != nil {
+			// WriteJSONBody has already logged the error
+			return
+		}
+		log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+		return
+	}
+
+	redirects, err := api.dataStore.Backend.GetRedirects(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	result := models.Redirects{
+		TotalCount: len(redirects),
+		Items:      redirects,
+	}
+	if result.Items == nil {
+		result.Items = []models.Redirect{}
+	}
+
+	setCacheControl(w, api.redirectsCacheMaxAge)
+	if err := WriteJSONBody(ctx, result, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// slugChanges returns whether publishing a topic as the version given changes the slug in the path of its published
+// version, so that the paths of it and the topics below it move
+func slugChanges(published, topic *models.Topic) bool {
+	return published != nil && topic != nil && pathSlug(published) != pathSlug(topic)
+}
+
+// pathSlug returns the slug a topic has in the paths of the published topics
+func pathSlug(topic *models.Topic) string {
+	if topic.Slug != "" {
+		return topic.Slug
+	}
+	return models.GenerateSlug(topic.Title)
+}
+
+// getPublishedPaths returns the paths of the published topics by their ids, to be given to recordRedirects once a
+// publication that moves them has been made. They are only got when moved is true, as there is nothing to redirect
+// otherwise, and a failure to get them is logged, as it only leaves the old paths without redirects.
+func (api *API) getPublishedPaths(ctx context.Context, moved bool) map[string]string {
+	if !moved {
+		return nil
+	}
+	pages, err := api.getSitemapPages(ctx)
+	if err != nil {
+		log.Error(ctx, "failed to get the paths of the published topics to redirect", err)
+		return nil
+	}
+	paths := make(map[string]string, len(pages))
+	for _, page := range pages {
+		paths[page.id] = page.uri
+	}
+	return paths
+}
+
+// recordRedirects records the redirects from the paths the published topics had before a publication, given by
+// getPublishedPaths, to the paths they have after it. Redirects to the old paths are moved on to the new ones, so
+// that there are no chains of redirects, and any redirect from a path that is in use again is removed.
+func (api *API) recordRedirects(ctx context.Context, before map[string]string) error {
+	if len(before) == 0 {
+		return nil
+	}
+	after, err := api.getSitemapPages(ctx)
+	if err != nil {
+		return err
+	}
+
+	moves := make(map[string]string)
+	var pages []sitemapPage
+	for _, page := range after {
+		from, ok := before[page.id]
+		if !ok || from == page.uri {
+			continue
+		}
+		moves[from] = page.uri
+		pages = append(pages, sitemapPage{id: page.id, uri: from})
+	}
+	if len(moves) == 0 {
+		return nil
+	}
+
+	redirects, err := api.dataStore.Backend.GetRedirects(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range redirects {
+		to, ok := moves[redirects[i].To]
+		if !ok {
+			continue
+		}
+		if to == redirects[i].From {
+			err = api.dataStore.Backend.DeleteRedirect(ctx, redirects[i].From)
+		} else {
+			redirects[i].To = to
+			err = api.dataStore.Backend.UpsertRedirect(ctx, &redirects[i])
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	for _, page := range pages {
+		to := moves[page.uri]
+		if err := api.dataStore.Backend.DeleteRedirect(ctx, to); err != nil {
+			return err
+		}
+		redirect := &models.Redirect{From: page.uri, To: to, TopicID: page.id, CreatedAt: &now}
+		if err := api.dataStore.Backend.UpsertRedirect(ctx, redirect); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
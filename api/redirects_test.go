@@ -0,0 +1,287 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// redirectTaxonomy is a store with the published topics economy and gdp below it, at the paths of the slugs given
+// (/economy and /economy/gdp to begin with), and the redirects given
+func redirectTaxonomy(slugs map[string]string, redirects map[string]models.Redirect) *storeMock.MongoDBMock {
+	slugs["economy"], slugs["gdp"] = "economy", "gdp"
+	published := models.StatePublished.String()
+
+	return &storeMock.MongoDBMock{
+		GetAllTopicsFunc: func(ctx context.Context) ([]models.TopicResponse, error) {
+			return []models.TopicResponse{
+				{ID: topicRoot, Current: &models.Topic{ID: topicRoot, State: published, SubtopicIds: &[]string{"economy"}}},
+				{ID: "economy", Current: &models.Topic{ID: "economy", State: published, Slug: slugs["economy"], SubtopicIds: &[]string{"gdp"}}},
+				{ID: "gdp", Current: &models.Topic{ID: "gdp", State: published, Slug: slugs["gdp"]}},
+			}, nil
+		},
+		GetRedirectFunc: func(ctx context.Context, from string) (*models.Redirect, error) {
+			redirect, ok := redirects[from]
+			if !ok {
+				return nil, apierrors.ErrRedirectNotFound
+			}
+			return &redirect, nil
+		},
+		GetRedirectsFunc: func(ctx context.Context) ([]models.Redirect, error) {
+			var result []models.Redirect
+			for _, redirect := range redirects {
+				result = append(result, redirect)
+			}
+			sort.Slice(result, func(i, j int) bool { return result[i].From < result[j].From })
+			return result, nil
+		},
+		UpsertRedirectFunc: func(ctx context.Context, redirect *models.Redirect) error {
+			redirects[redirect.From] = *redirect
+			return nil
+		},
+		DeleteRedirectFunc: func(ctx context.Context, from string) error {
+			delete(redirects, from)
+			return nil
+		},
+	}
+}
+
+// redirectTargets returns the path each redirect is to, by the path it is from
+func redirectTargets(redirects map[string]models.Redirect) map[string]string {
+	targets := map[string]string{}
+	for from, redirect := range redirects {
+		targets[from] = redirect.To
+	}
+	return targets
+}
+
+func TestGetRedirectsHandler(t *testing.T) {
+	Convey("Given a topic API in web mode, with a redirect", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = false
+		redirects := map[string]models.Redirect{
+			"/economy/oldgdp": {From: "/economy/oldgdp", To: "/economy/gdp", TopicID: "gdp"},
+		}
+		topicAPI := GetAPIWithMocks(cfg, redirectTaxonomy(map[string]string{}, redirects))
+
+		serve := func(url string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, http.NoBody))
+			return w
+		}
+
+		Convey("When the redirect of its path is requested, in any case and with a trailing slash", func() {
+			w := serve("http://localhost:25300/redirects?path=/Economy/OldGDP/")
+
+			Convey("Then it is returned, cacheable", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=3600")
+				var redirect models.Redirect
+				So(json.Unmarshal(w.Body.Bytes(), &redirect), ShouldBeNil)
+				So(redirect.To, ShouldEqual, "/economy/gdp")
+				So(redirect.TopicID, ShouldEqual, "gdp")
+			})
+		})
+
+		Convey("When the redirect of a path that has not moved is requested, then 404 is returned", func() {
+			w := serve("http://localhost:25300/redirects?path=/economy/gdp")
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+			So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrRedirectNotFound))
+		})
+
+		Convey("When every redirect is requested, then they are all returned", func() {
+			w := serve("http://localhost:25300/redirects")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			var result models.Redirects
+			So(json.Unmarshal(w.Body.Bytes(), &result), ShouldBeNil)
+			So(result.TotalCount, ShouldEqual, 1)
+			So(result.Items[0].From, ShouldEqual, "/economy/oldgdp")
+		})
+
+		Convey("When every redirect is requested and there are none, then an empty list is returned", func() {
+			delete(redirects, "/economy/oldgdp")
+			w := serve("http://localhost:25300/redirects")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Body.String(), ShouldEqual, `{"total_count":0,"items":[]}`)
+		})
+	})
+}
+
+func TestRecordRedirects(t *testing.T) {
+	Convey("Given the published topics /economy and /economy/gdp", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		slugs := map[string]string{}
+		redirects := map[string]models.Redirect{}
+		topicAPI := GetAPIWithMocks(cfg, redirectTaxonomy(slugs, redirects))
+
+		// publish publishes the slug given for a topic, recording the redirects of the paths it moves
+		publish := func(id, slug string) {
+			paths := topicAPI.getPublishedPaths(testContext, true)
+			slugs[id] = slug
+			So(topicAPI.recordRedirects(testContext, paths), ShouldBeNil)
+		}
+
+		Convey("When the slug of economy changes, then the paths of economy and of gdp below it are redirected", func() {
+			publish("economy", "theeconomy")
+			So(redirectTargets(redirects), ShouldResemble, map[string]string{
+				"/economy":     "/theeconomy",
+				"/economy/gdp": "/theeconomy/gdp",
+			})
+			So(redirects["/economy/gdp"].TopicID, ShouldEqual, "gdp")
+			So(redirects["/economy/gdp"].CreatedAt, ShouldNotBeNil)
+		})
+
+		Convey("When there are redirects to the old paths, then they are moved on to the new ones", func() {
+			redirects["/oldeconomy"] = models.Redirect{From: "/oldeconomy", To: "/economy", TopicID: "economy"}
+			redirects["/oldeconomy/gdp"] = models.Redirect{From: "/oldeconomy/gdp", To: "/economy/gdp", TopicID: "gdp"}
+
+			publish("economy", "theeconomy")
+			So(redirectTargets(redirects), ShouldResemble, map[string]string{
+				"/oldeconomy":     "/theeconomy",
+				"/oldeconomy/gdp": "/theeconomy/gdp",
+				"/economy":        "/theeconomy",
+				"/economy/gdp":    "/theeconomy/gdp",
+			})
+		})
+
+		Convey("When the slug changes back to an old one, then the redirects from the old paths are removed", func() {
+			redirects["/oldeconomy"] = models.Redirect{From: "/oldeconomy", To: "/economy", TopicID: "economy"}
+			redirects["/oldeconomy/gdp"] = models.Redirect{From: "/oldeconomy/gdp", To: "/economy/gdp", TopicID: "gdp"}
+
+			publish("economy", "oldeconomy")
+			So(redirectTargets(redirects), ShouldResemble, map[string]string{
+				"/economy":     "/oldeconomy",
+				"/economy/gdp": "/oldeconomy/gdp",
+			})
+		})
+
+		Convey("When the slug of gdp is unchanged, then no redirect is recorded", func() {
+			publish("gdp", "gdp")
+			So(redirects, ShouldBeEmpty)
+		})
+
+		Convey("When the publication moves no topic, then the paths are not got and no redirect is recorded", func() {
+			So(topicAPI.getPublishedPaths(testContext, false), ShouldBeNil)
+			So(topicAPI.recordRedirects(testContext, nil), ShouldBeNil)
+			So(redirects, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestPublishTopicRecordsRedirects(t *testing.T) {
+	Convey("Given a topic API in publishing mode, with the published topics /economy and /economy/gdp", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		slugs := map[string]string{}
+		redirects := map[string]models.Redirect{}
+		mongoDBMock := redirectTaxonomy(slugs, redirects)
+		mongoDBMock.IsSlugInUseFunc = func(ctx context.Context, id, slug string) (bool, error) { return false, nil }
+		mongoDBMock.GetTopicFunc = func(ctx context.Context, id string) (*models.TopicResponse, error) {
+			topic := dbTopicWithID(models.StateCompleted, id)
+			topic.Current.State, topic.Current.Slug = models.StatePublished.String(), slugs[id]
+			topic.Next.Slug = "grossdomesticproduct"
+			return topic, nil
+		}
+		mongoDBMock.UpdateTopicFunc = func(context.Context, string, string, *models.TopicUpdate) error { return nil }
+		mongoDBMock.UpdateStateFunc = func(ctx context.Context, id, state string) error { return nil }
+		mongoDBMock.UpsertTopicFunc = func(ctx context.Context, id string, topic *models.TopicResponse) error {
+			slugs[id] = topic.Current.Slug
+			return nil
+		}
+		mongoDBMock.GetContentFunc = func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+			return nil, apierrors.ErrContentNotFound
+		}
+		mongoDBMock.GetPublicationsFunc = func(ctx context.Context, topicID string, limit int) ([]models.Publication, error) { return nil, nil }
+		mongoDBMock.InsertPublicationFunc = func(ctx context.Context, publication *models.Publication) error { return nil }
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		serve := func(method, url, payload string) *httptest.ResponseRecorder {
+			request, err := createRequestWithAuth(method, url, bytes.NewBufferString(payload))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When the slug of gdp is updated without being published", func() {
+			payload := `{"title": "GDP", "description": "Gross domestic product", "slug": "grossdomesticproduct", "state": "completed", "release_date": "2022-10-10T08:30:00Z"}`
+			w := serve(http.MethodPut, "http://localhost:25300/topics/gdp", payload)
+
+			Convey("Then the topic is updated, and no redirect is recorded while its old path is still published", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpdateTopicCalls(), ShouldHaveLength, 1)
+				So(redirects, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When gdp is published with its new slug", func() {
+			w := serve(http.MethodPut, "http://localhost:25300/topics/gdp/state/published", "")
+
+			Convey("Then its old path is redirected to its new one", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(redirectTargets(redirects), ShouldResemble, map[string]string{
+					"/economy/gdp": "/economy/grossdomesticproduct",
+				})
+			})
+		})
+	})
+}
+
+func TestImportTaxonomyRecordsRedirects(t *testing.T) {
+	Convey("Given a topic API in publishing mode, with the published topics /economy and /economy/gdp", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		slugs := map[string]string{}
+		redirects := map[string]models.Redirect{}
+		mongoDBMock := redirectTaxonomy(slugs, redirects)
+		mongoDBMock.GetAllContentFunc = func(ctx context.Context) ([]models.ContentResponse, error) { return nil, nil }
+		mongoDBMock.UpsertTopicFunc = func(ctx context.Context, id string, topic *models.TopicResponse) error {
+			slugs[id] = topic.Current.Slug
+			return nil
+		}
+		mongoDBMock.UpsertContentFunc = func(ctx context.Context, id string, content *models.ContentResponse) error { return nil }
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		importTaxonomy := func(query string) *httptest.ResponseRecorder {
+			body := `{"id":"economy","current":{"id":"economy","title":"Economy","slug":"theeconomy","state":"published","subtopics_ids":["gdp"]}}`
+			request, err := createRequestWithAuth(http.MethodPost, "http://localhost:25300/topics/import"+query, bytes.NewBufferString(body))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When a published version of economy with a new slug is imported", func() {
+			w := importTaxonomy("")
+
+			Convey("Then the old paths of economy and of gdp below it are redirected to their new ones", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(redirectTargets(redirects), ShouldResemble, map[string]string{
+					"/economy":     "/theeconomy",
+					"/economy/gdp": "/theeconomy/gdp",
+				})
+			})
+		})
+
+		Convey("When the import is only validated, then no redirect is recorded", func() {
+			w := importTaxonomy("?validate_only=true")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(redirects, ShouldBeEmpty)
+		})
+	})
+}
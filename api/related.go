@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// getRelatedPublicHandler is a handler that gets the topics related to a topic by its id from MongoDB for Web, in
+// their current views or, when previewing, in their next views
+func (api *API) getRelatedPublicHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	id := vars["id"]
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"topic_id":   id,
+		"function":   "getRelatedPublicHandler",
+	}
+
+	if id == topicRoot {
+		handleError(ctx, w, apierrors.ErrTopicNotFound, logdata)
+		return
+	}
+
+	preview, err := api.isPreview(req, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["preview"] = preview
+
+	include, err := includeArchived(req)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["include_archived"] = include
+
+	dataStore := api.publicStore(preview)
+	topic, err := dataStore.GetTopic(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	// User is not authenticated and hence has only access to current sub document(s), unless previewing the next ones
+	view := publicView(topic, preview)
+	if view == nil {
+		handleError(ctx, w, apierrors.ErrContentNotFound, logdata)
+		return
+	}
+
+	related := func(relatedID string) (*models.Topic, error) {
+		topic, err := dataStore.GetTopic(ctx, relatedID)
+		if err != nil {
+			return nil, err
+		}
+		if view := publicView(topic, preview); view != nil {
+			return view, nil
+		}
+		return nil, apierrors.ErrContentNotFound
+	}
+
+	result, lastUpdated := relatedTopics(ctx, view, related, include, logdata)
+
+	setLastModified(w, lastUpdated...)
+	setPublicCacheControl(w, preview, api.subtopicsCacheMaxAge)
+	if err := WriteJSONBody(ctx, result, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// getRelatedPrivateHandler is a handler that gets the topics related to the next version of a topic by its id from
+// MongoDB for Publishing, in their next versions
+func (api *API) getRelatedPrivateHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	id := vars["id"]
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"topic_id":   id,
+		"function":   "getRelatedPrivateHandler",
+	}
+
+	include, err := includeArchived(req)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["include_archived"] = include
+
+	topic, err := api.dataStore.Backend.GetTopic(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	if topic.Next == nil {
+		handleError(ctx, w, apierrors.ErrInternalServer, logdata)
+		return
+	}
+
+	related := func(relatedID string) (*models.Topic, error) {
+		topic, err := api.dataStore.Backend.GetTopic(ctx, relatedID)
+		if err != nil {
+			return nil, err
+		}
+		if topic.Next == nil {
+			return nil, apierrors.ErrInternalServer
+		}
+		return topic.Next, nil
+	}
+
+	result, lastUpdated := relatedTopics(ctx, topic.Next, related, include, logdata)
+
+	setLastModified(w, lastUpdated...)
+	if err := WriteJSONBody(ctx, result, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// relatedTopics returns the topics related to a version of a topic, each got by the related function, with the type of
+// their relation and the last updated times of the version and of them. Related topics that cannot be got are logged
+// and left out, as are those that are archived unless they are included.
+func relatedTopics(ctx context.Context, version *models.Topic, related func(string) (*models.Topic, error), include bool, logdata log.Data) (models.RelatedTopics, []*time.Time) {
+	result := models.RelatedTopics{Items: []models.RelatedTopic{}}
+	lastUpdated := []*time.Time{version.LastUpdated}
+	if version.RelatedTopicIDs == nil {
+		return result, lastUpdated
+	}
+
+	for _, relatedID := range *version.RelatedTopicIDs {
+		topic, err := related(relatedID)
+		if err != nil {
+			logdata["missing related topic for id"] = relatedID
+			log.Error(ctx, "missing related topic for id", err, logdata)
+			continue
+		}
+		if !include && topic.IsArchived() {
+			continue
+		}
+		lastUpdated = append(lastUpdated, topic.LastUpdated)
+
+		result.Items = append(result.Items, models.RelatedTopic{RelationType: version.RelationType(relatedID), Topic: topic})
+		result.TotalCount++
+	}
+
+	return result, lastUpdated
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// relatedTaxonomy is a store with the published topics housing, finances and benefits, and the archived topic
+// wellbeing, where housing is related to finances, to benefits as see-also, and to wellbeing, and nothing is related
+// to finances
+func relatedTaxonomy() *storeMock.MongoDBMock {
+	topics := map[string]*models.TopicResponse{}
+	for _, id := range []string{"housing", "finances", "benefits"} {
+		topics[id] = dbTopicWithID(models.StatePublished, id)
+		topics[id].Current = dbTopicCurrentWithID(models.StatePublished, id)
+	}
+	topics["wellbeing"] = archivedTopic("wellbeing", "")
+
+	housing := topics["housing"]
+	for _, version := range []*models.Topic{housing.Current, housing.Next} {
+		version.RelatedTopicIDs = &[]string{"finances", "benefits", "wellbeing"}
+		version.RelatedTopicTypes = map[string]string{"benefits": models.RelationSeeAlso}
+	}
+
+	return &storeMock.MongoDBMock{
+		GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+			topic, ok := topics[id]
+			if !ok {
+				return nil, apierrors.ErrTopicNotFound
+			}
+			return topic, nil
+		},
+		CheckTopicExistsFunc: func(ctx context.Context, id string) error {
+			if _, ok := topics[id]; !ok {
+				return apierrors.ErrTopicNotFound
+			}
+			return nil
+		},
+		IsSlugInUseFunc: func(ctx context.Context, id, slug string) (bool, error) { return false, nil },
+		UpdateTopicFunc: func(context.Context, string, string, *models.TopicUpdate) error { return nil },
+	}
+}
+
+// relatedIDs returns the id and type of the relation of each related topic
+func relatedIDs(result models.RelatedTopics) [][2]string {
+	ids := [][2]string{}
+	for _, item := range result.Items {
+		ids = append(ids, [2]string{item.Topic.ID, item.RelationType})
+	}
+	return ids
+}
+
+func TestGetRelatedPublicHandler(t *testing.T) {
+	Convey("Given a topic API in web mode, with related topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = false
+		topicAPI := GetAPIWithMocks(cfg, relatedTaxonomy())
+
+		serve := func(url string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, http.NoBody))
+			return w
+		}
+
+		Convey("When the topics related to housing are requested", func() {
+			w := serve("http://localhost:25300/topics/housing/related")
+
+			Convey("Then they are returned in their current versions with the types of their relations, leaving out the archived topic", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age="+topicAPI.subtopicsCacheMaxAge)
+				var result models.RelatedTopics
+				So(json.Unmarshal(w.Body.Bytes(), &result), ShouldBeNil)
+				So(result.TotalCount, ShouldEqual, 2)
+				So(relatedIDs(result), ShouldResemble, [][2]string{
+					{"finances", models.RelationRelated},
+					{"benefits", models.RelationSeeAlso},
+				})
+				So(result.Items[0].Topic.Description, ShouldEqual, "current test description - 1")
+			})
+		})
+
+		Convey("When the topics related to housing are requested including archived topics, then the archived topic is returned", func() {
+			w := serve("http://localhost:25300/topics/housing/related?include_archived=true")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			var result models.RelatedTopics
+			So(json.Unmarshal(w.Body.Bytes(), &result), ShouldBeNil)
+			So(result.TotalCount, ShouldEqual, 3)
+		})
+
+		Convey("When the topics related to a topic without any are requested, then an empty list is returned", func() {
+			w := serve("http://localhost:25300/topics/finances/related")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Body.String(), ShouldEqual, `{"total_count":0,"items":[]}`)
+		})
+
+		Convey("When the topics related to a topic that does not exist are requested, then 404 is returned", func() {
+			w := serve("http://localhost:25300/topics/unknown/related")
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func TestGetRelatedPrivateHandler(t *testing.T) {
+	Convey("Given a topic API in publishing mode, with related topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		topicAPI := GetAPIWithMocks(cfg, relatedTaxonomy())
+
+		Convey("When the topics related to housing are requested, then they are returned in their next versions", func() {
+			request, err := createRequestWithAuth(http.MethodGet, "http://localhost:25300/topics/housing/related", http.NoBody)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			So(w.Code, ShouldEqual, http.StatusOK)
+			var result models.RelatedTopics
+			So(json.Unmarshal(w.Body.Bytes(), &result), ShouldBeNil)
+			So(relatedIDs(result), ShouldResemble, [][2]string{
+				{"finances", models.RelationRelated},
+				{"benefits", models.RelationSeeAlso},
+			})
+			So(result.Items[0].Topic.Description, ShouldEqual, "next test description - 1")
+		})
+	})
+}
+
+func TestPutTopicPrivateHandlerRelatedTopics(t *testing.T) {
+	Convey("Given a topic API in publishing mode, with related topics, and finances being edited", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		mongoDBMock := relatedTaxonomy()
+		finances, err := mongoDBMock.GetTopic(testContext, "finances")
+		So(err, ShouldBeNil)
+		finances.Next.State = models.StateCreated.String()
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		put := func(related string) *httptest.ResponseRecorder {
+			payload := `{"title": "Finances", "description": "Personal and household finances", "state": "created", "release_date": "2022-10-10T08:30:00Z", ` + related + `}`
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/finances", bytes.NewBufferString(payload))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When finances is related to existing topics, then they are updated", func() {
+			w := put(`"related_topic_ids": ["housing"], "related_topic_types": {"housing": "broader-equivalent"}`)
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(mongoDBMock.UpdateTopicCalls(), ShouldHaveLength, 1)
+			update := mongoDBMock.UpdateTopicCalls()[0].Topic
+			So(*update.RelatedTopicIDs, ShouldResemble, []string{"housing"})
+			So(update.RelatedTopicTypes, ShouldResemble, map[string]string{"housing": models.RelationBroaderEquivalent})
+		})
+
+		Convey("When finances is related to a topic that does not exist, then 400 is returned", func() {
+			w := put(`"related_topic_ids": ["housing", "unknown"]`)
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.String(), ShouldContainSubstring, "related_topic_ids[1]")
+			So(mongoDBMock.UpdateTopicCalls(), ShouldBeEmpty)
+		})
+	})
+}
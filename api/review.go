@@ -0,0 +1,149 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// callerIdentity returns the user making a request, or the service making it when it is not made on behalf of a user
+func callerIdentity(ctx context.Context) string {
+	if user := dprequest.User(ctx); user != "" {
+		return user
+	}
+	return dprequest.Caller(ctx)
+}
+
+// reviewTopic returns the review of the next version of a topic once the caller has approved or rejected it, as the
+// target state given. The optional body of the request gives the reason a topic is rejected for. The caller must not
+// be the last editor of the topic, so that every change is seen by a second pair of eyes.
+func reviewTopic(ctx context.Context, req *http.Request, next *models.Topic, target models.State) (*models.Review, error) {
+	var decision models.ReviewDecision
+	if err := readOptionalBody(req, &decision); err != nil {
+		return nil, err
+	}
+
+	if err := decision.Validate(target); err != nil {
+		return nil, err
+	}
+
+	reviewer := callerIdentity(ctx)
+	if editor := next.Editor(); editor != "" && editor == reviewer {
+		return nil, fmt.Errorf("%w: %s last edited the topic, so it must be reviewed by someone else",
+			apierrors.ErrTopicSelfReview, reviewer)
+	}
+
+	review := &models.Review{}
+	if next.Review != nil {
+		*review = *next.Review
+	}
+	reviewedAt := time.Now().UTC()
+	review.ReviewedBy = reviewer
+	review.ReviewedAt = &reviewedAt
+	review.Reason = decision.Reason
+
+	return review, nil
+}
+
+// readOptionalBody decodes the body of a request into v, leaving v unchanged when the body is empty
+func readOptionalBody(req *http.Request, v any) error {
+	payload, err := io.ReadAll(req.Body)
+	if err != nil {
+		return apierrors.ErrUnableToReadMessage
+	}
+	if len(bytes.TrimSpace(payload)) > 0 {
+		if err := json.Unmarshal(payload, v); err != nil {
+			return apierrors.ErrUnableToParseJSON
+		}
+	}
+	return nil
+}
+
+// checkUpdateTransition returns an error wrapping apierrors.ErrTopicStateTransitionNotAllowed if the update of the
+// existing topic cannot move it to the state given. The decisions of reviewers can only be made by reviewing the topic,
+// which records the reviewer, and topics are only archived or restored through their state, which archives every
+// version of the topic. A topic in review cannot be updated until it has been reviewed, as in_review only moves to a
+// decision, and an edit of an approved topic must take it back to created, so that only the version that was approved
+// can be published.
+func checkUpdateTransition(existing *models.TopicResponse, update *models.TopicUpdate, requireReview bool) error {
+	state := update.State
+	target, err := models.ParseState(state)
+	if err != nil {
+		return err
+	}
+	if target.IsReviewDecision() {
+		return fmt.Errorf("%w: a topic can only be %s by reviewing it with PUT /topics/{id}/state/%s",
+			apierrors.ErrTopicStateTransitionNotAllowed, state, state)
+	}
+	if target == models.StateArchived || (existing.Next != nil && existing.Next.IsArchived()) {
+		return fmt.Errorf("%w: a topic can only be archived, or restored, with PUT /topics/{id}/state/{state}",
+			apierrors.ErrTopicStateTransitionNotAllowed)
+	}
+
+	var from string
+	if existing.Next != nil {
+		from = existing.Next.State
+	}
+	if from == models.StateApproved.String() && target != models.StateCreated && update.Changes(existing.Next) {
+		return fmt.Errorf("%w: an approved topic goes back to created when it is edited, to be reviewed again",
+			apierrors.ErrTopicStateTransitionNotAllowed)
+	}
+	return models.CheckTransition(from, state, requireReview)
+}
+
+// getTopicsByState writes the topics whose next version is in the state given, such as the review queue of the topics
+// in review, which is ordered by when they were submitted for review, the longest waiting first
+func (api *API) getTopicsByState(ctx context.Context, state string, logdata log.Data, w http.ResponseWriter) {
+	if _, err := models.ParseState(state); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	topics, err := api.dataStore.Backend.GetAllTopics(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	items := []models.TopicResponse{}
+	var lastUpdated []*time.Time
+	for i := range topics {
+		if topics[i].ID == topicRoot || topics[i].Next == nil || topics[i].Next.State != state {
+			continue
+		}
+		items = append(items, topics[i])
+		lastUpdated = append(lastUpdated, topicLastUpdated(&topics[i])...)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := submittedAt(&items[i]), submittedAt(&items[j])
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return items[i].ID < items[j].ID
+	})
+
+	setLastModified(w, lastUpdated...)
+	if err := WriteJSONBody(ctx, models.PrivateSubtopics{TotalCount: len(items), PrivateItems: &items}, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// submittedAt returns when the next version of a topic was submitted for review, or the zero time if it is not known
+func submittedAt(topic *models.TopicResponse) time.Time {
+	if topic.Next.Review == nil || topic.Next.Review.SubmittedAt == nil {
+		return time.Time{}
+	}
+	return *topic.Next.Review.SubmittedAt
+}
@@ -0,0 +1,362 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// reviewedTopic returns a topic in the state given, whose next version was last edited by the editor given
+func reviewedTopic(state models.State, editor string) *models.TopicResponse {
+	topic := dbTopic(state)
+	topic.Next.LastEditedBy = editor
+	if state == models.StateInReview {
+		submittedAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+		topic.Next.Review = &models.Review{SubmittedBy: editor, SubmittedAt: &submittedAt}
+	}
+	return topic
+}
+
+func TestPutTopicStateReview(t *testing.T) {
+	Convey("Given a topic API in publishing mode", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+
+		var topic *models.TopicResponse
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+				return topic, nil
+			},
+			UpdateReviewFunc: func(ctx context.Context, id, state string, review *models.Review) error { return nil },
+			UpdateStateFunc:  func(ctx context.Context, id, state string) error { return nil },
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		putState := func(state, body string) *httptest.ResponseRecorder {
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/"+testTopicID1+"/state/"+state,
+				bytes.NewBufferString(body))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When a created topic is submitted for review", func() {
+			topic = reviewedTopic(models.StateCreated, "someone@ons.gov.uk")
+			w := putState("in_review", "")
+
+			Convey("Then the submitter is recorded", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpdateReviewCalls(), ShouldHaveLength, 1)
+				call := mongoDBMock.UpdateReviewCalls()[0]
+				So(call.State, ShouldEqual, "in_review")
+				So(call.Review.SubmittedBy, ShouldEqual, "someone@ons.gov.uk")
+				So(call.Review.SubmittedAt, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When a topic in review edited by someone else is approved", func() {
+			topic = reviewedTopic(models.StateInReview, "editor@ons.gov.uk")
+			w := putState("approved", "")
+
+			Convey("Then the reviewer is recorded, keeping the submitter", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpdateReviewCalls(), ShouldHaveLength, 1)
+				call := mongoDBMock.UpdateReviewCalls()[0]
+				So(call.State, ShouldEqual, "approved")
+				So(call.Review.SubmittedBy, ShouldEqual, "editor@ons.gov.uk")
+				So(call.Review.ReviewedBy, ShouldEqual, "someone@ons.gov.uk")
+				So(call.Review.ReviewedAt, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When a topic in review is approved by its last editor", func() {
+			topic = reviewedTopic(models.StateInReview, "someone@ons.gov.uk")
+			w := putState("approved", "")
+
+			Convey("Then 403 is returned, and the topic is not reviewed", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(mongoDBMock.UpdateReviewCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a topic in review is rejected with a reason", func() {
+			topic = reviewedTopic(models.StateInReview, "editor@ons.gov.uk")
+			w := putState("rejected", `{"reason": "The title is misspelt"}`)
+
+			Convey("Then the reason is recorded", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpdateReviewCalls(), ShouldHaveLength, 1)
+				So(mongoDBMock.UpdateReviewCalls()[0].Review.Reason, ShouldEqual, "The title is misspelt")
+			})
+		})
+
+		Convey("When a topic in review is rejected without a reason", func() {
+			topic = reviewedTopic(models.StateInReview, "editor@ons.gov.uk")
+			w := putState("rejected", "")
+
+			Convey("Then 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(mongoDBMock.UpdateReviewCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a topic in review is published before it is approved", func() {
+			topic = reviewedTopic(models.StateInReview, "editor@ons.gov.uk")
+			w := putState("published", "")
+
+			Convey("Then 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusForbidden,
+					models.CheckTransition("in_review", "published", false)))
+			})
+		})
+
+		Convey("When a created topic is approved without being submitted for review", func() {
+			topic = reviewedTopic(models.StateCreated, "editor@ons.gov.uk")
+			w := putState("approved", "")
+
+			Convey("Then 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+			})
+		})
+	})
+
+	Convey("Given a topic API in publishing mode requiring review", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		cfg.EnableTopicReview = true
+		Reset(func() {
+			// the config is shared by every test
+			cfg.EnableTopicReview = false
+		})
+
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+				return dbTopic(models.StateCompleted), nil
+			},
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		Convey("When a completed topic is published without approval", func() {
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/"+testTopicID1+"/state/published", http.NoBody)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then 403 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+			})
+		})
+	})
+}
+
+func TestPutTopicReview(t *testing.T) {
+	Convey("Given a topic API in publishing mode, and a topic in review", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+				return reviewedTopic(models.StateInReview, "editor@ons.gov.uk"), nil
+			},
+			UpdateTopicFunc: func(context.Context, string, string, *models.TopicUpdate) error { return nil },
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		putTopic := func(state string) *httptest.ResponseRecorder {
+			payload := `{"title": "New title", "description": "New description", "keywords": ["keyword"], "state": "` + state +
+				`", "release_date": "2022-10-10T08:30:00Z"}`
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/"+testTopicID1, bytes.NewBufferString(payload))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When the topic is updated to be approved", func() {
+			w := putTopic("approved")
+
+			Convey("Then 403 is returned, as only a review can approve it", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(mongoDBMock.UpdateTopicCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the topic is edited while in review", func() {
+			w := putTopic("in_review")
+
+			Convey("Then 403 is returned, as it must be reviewed first", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(mongoDBMock.UpdateTopicCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a topic API in publishing mode, and a created topic", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+				return dbTopic(models.StateCreated), nil
+			},
+			UpdateTopicFunc: func(context.Context, string, string, *models.TopicUpdate) error { return nil },
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		Convey("When the topic is updated", func() {
+			payload := `{"title": "New title", "description": "New description", "keywords": ["keyword"], "state": "created", "release_date": "2022-10-10T08:30:00Z"}`
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/"+testTopicID1, bytes.NewBufferString(payload))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then the caller is recorded as its last editor", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpdateTopicCalls(), ShouldHaveLength, 1)
+				So(mongoDBMock.UpdateTopicCalls()[0].Topic.LastEditedBy, ShouldEqual, "someone@ons.gov.uk")
+			})
+		})
+	})
+}
+
+func TestPutTopicApproved(t *testing.T) {
+	Convey("Given a topic API in publishing mode, and an approved topic", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+
+		releaseDate := time.Date(2022, 10, 10, 8, 30, 0, 0, time.UTC)
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+				topic := reviewedTopic(models.StateApproved, "editor@ons.gov.uk")
+				topic.Next.ReleaseDate = &releaseDate
+				return topic, nil
+			},
+			UpdateTopicFunc: func(context.Context, string, string, *models.TopicUpdate) error { return nil },
+			UpsertTopicFunc: func(context.Context, string, *models.TopicResponse) error { return nil },
+			GetContentFunc: func(context.Context, string, int) (*models.ContentResponse, error) {
+				return nil, apierrors.ErrContentNotFound
+			},
+			GetPublicationsFunc:   func(context.Context, string, int) ([]models.Publication, error) { return nil, nil },
+			InsertPublicationFunc: func(context.Context, *models.Publication) error { return nil },
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		putTopic := func(description, state string) *httptest.ResponseRecorder {
+			payload := `{"title": "test title - 1", "description": "` + description + `", "keywords": ["keyword 1", "keyword 2", "keyword 3"], "state": "` +
+				state + `", "release_date": "2022-10-10T08:30:00Z"}`
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/"+testTopicID1, bytes.NewBufferString(payload))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When the topic is edited and published in one update", func() {
+			w := putTopic("New description", "published")
+
+			Convey("Then 403 is returned, as the edit must be reviewed before it is published", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(mongoDBMock.UpdateTopicCalls(), ShouldBeEmpty)
+				So(mongoDBMock.UpsertTopicCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the topic is edited and taken back to created, then it is updated", func() {
+			w := putTopic("New description", "created")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(mongoDBMock.UpdateTopicCalls(), ShouldHaveLength, 1)
+		})
+
+		Convey("When the approved version is published unchanged, then it is published", func() {
+			w := putTopic("next test description - 1", "published")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(mongoDBMock.UpsertTopicCalls(), ShouldHaveLength, 1)
+		})
+	})
+}
+
+func TestGetTopicsReviewQueue(t *testing.T) {
+	Convey("Given a topic API in publishing mode, and topics in review submitted at different times", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+
+		submitted := func(id string, at time.Time) models.TopicResponse {
+			topic := dbTopicWithID(models.StateInReview, id)
+			topic.Next.Review = &models.Review{SubmittedBy: "editor@ons.gov.uk", SubmittedAt: &at}
+			return *topic
+		}
+		earlier := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetAllTopicsFunc: func(ctx context.Context) ([]models.TopicResponse, error) {
+				return []models.TopicResponse{
+					submitted("later", earlier.Add(time.Hour)),
+					*dbTopicWithID(models.StateCreated, "created"),
+					submitted("earlier", earlier),
+					*dbTopicWithID(models.StateInReview, topicRoot),
+				}, nil
+			},
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		get := func(url string) *httptest.ResponseRecorder {
+			request, err := createRequestWithAuth(http.MethodGet, url, http.NoBody)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When the review queue is requested", func() {
+			w := get("http://localhost:25300/topics?state=in_review")
+
+			Convey("Then the topics in review are returned, the longest waiting first", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				payload, err := io.ReadAll(w.Body)
+				So(err, ShouldBeNil)
+				var queue models.PrivateSubtopics
+				So(json.Unmarshal(payload, &queue), ShouldBeNil)
+				So(queue.TotalCount, ShouldEqual, 2)
+				So((*queue.PrivateItems)[0].ID, ShouldEqual, "earlier")
+				So((*queue.PrivateItems)[1].ID, ShouldEqual, "later")
+			})
+		})
+
+		Convey("When the topics in a state without any topics are requested", func() {
+			w := get("http://localhost:25300/topics?state=approved")
+
+			Convey("Then an empty list is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `"items":[]`)
+			})
+		})
+
+		Convey("When the topics in an unknown state are requested", func() {
+			w := get("http://localhost:25300/topics?state=deleted")
+
+			Convey("Then 400 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusBadRequest, apierrors.ErrTopicInvalidState))
+			})
+		})
+	})
+}
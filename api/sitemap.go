@@ -0,0 +1,217 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// sitemapPage is the page of a published topic on the website
+type sitemapPage struct {
+	id          string
+	uri         string
+	lastUpdated *time.Time
+}
+
+// getSitemapHandler is a handler that gets the sitemap of the pages of the published topics. Once there are more pages
+// than fit in one file, it gets an index of the files the sitemap is split into instead.
+func (api *API) getSitemapHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"function":   "getSitemapHandler",
+	}
+
+	pages, err := api.getSitemapPages(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["total_count"] = len(pages)
+
+	files := splitSitemap(pages, api.sitemapMaxURLs)
+	if len(files) == 1 {
+		api.writeSitemap(ctx, w, files[0], logdata)
+		return
+	}
+
+	index := models.SitemapIndex{Namespace: models.SitemapNamespace}
+	var lastUpdated []*time.Time
+	for i, file := range files {
+		latest := latestUpdate(file)
+		index.Sitemaps = append(index.Sitemaps, models.SitemapFile{
+			Loc:     fmt.Sprintf("%s/sitemap-%d.xml", api.websiteURL, i+1),
+			LastMod: formatLastMod(latest),
+		})
+		lastUpdated = append(lastUpdated, latest)
+	}
+
+	setLastModified(w, lastUpdated...)
+	setCacheControl(w, api.sitemapCacheMaxAge)
+	if err := writeXMLBody(ctx, index, models.SitemapContentType, w, logdata); err != nil {
+		// writeXMLBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// getSitemapFileHandler is a handler that gets a file, numbered from 1, of a sitemap that is split
+func (api *API) getSitemapFileHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	file := mux.Vars(req)["file"]
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"file":       file,
+		"function":   "getSitemapFileHandler",
+	}
+
+	pages, err := api.getSitemapPages(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	files := splitSitemap(pages, api.sitemapMaxURLs)
+	number, err := strconv.Atoi(file)
+	if err != nil || number < 1 || number > len(files) {
+		handleError(ctx, w, apierrors.ErrNotFound, logdata)
+		return
+	}
+
+	api.writeSitemap(ctx, w, files[number-1], logdata)
+}
+
+// writeSitemap writes a sitemap of the pages, each with links to its English and Welsh versions
+func (api *API) writeSitemap(ctx context.Context, w http.ResponseWriter, pages []sitemapPage, logdata log.Data) {
+	sitemap := models.SitemapURLSet{
+		Namespace:      models.SitemapNamespace,
+		XHTMLNamespace: models.XHTMLNamespace,
+		URLs:           make([]models.SitemapURL, 0, len(pages)),
+	}
+	for _, page := range pages {
+		english := api.websiteURL + page.uri
+		sitemap.URLs = append(sitemap.URLs, models.SitemapURL{
+			Loc:     english,
+			LastMod: formatLastMod(page.lastUpdated),
+			Alternates: []models.SitemapAlternate{
+				{Rel: "alternate", HrefLang: "en", Href: english},
+				{Rel: "alternate", HrefLang: "cy", Href: api.welshWebsiteURL + page.uri},
+			},
+		})
+	}
+
+	setLastModified(w, latestUpdate(pages))
+	setCacheControl(w, api.sitemapCacheMaxAge)
+	if err := writeXMLBody(ctx, sitemap, models.SitemapContentType, w, logdata); err != nil {
+		// writeXMLBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// getSitemapPages returns the pages of the published topics, in the order of the tree of topics. The URI of a page is
+// the path of the slugs of the topics from the root topic, so topics that are not in the tree have no page, and a topic
+// with more than one parent has the page of the first path to it.
+func (api *API) getSitemapPages(ctx context.Context) ([]sitemapPage, error) {
+	topics, err := api.dataStore.Backend.GetAllTopics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	published := make(map[string]*models.Topic, len(topics))
+	for i := range topics {
+		current := topics[i].Current
+		if current != nil && current.State == models.StatePublished.String() {
+			published[topics[i].ID] = current
+		}
+	}
+
+	var pages []sitemapPage
+	visited := map[string]bool{topicRoot: true}
+	var walk func(topic *models.Topic, uri string)
+	walk = func(topic *models.Topic, uri string) {
+		if topic.SubtopicIds == nil {
+			return
+		}
+		for _, id := range *topic.SubtopicIds {
+			subtopic, ok := published[id]
+			if !ok || visited[id] {
+				continue
+			}
+			visited[id] = true
+
+			slug := subtopic.Slug
+			if slug == "" {
+				slug = models.GenerateSlug(subtopic.Title)
+			}
+			page := sitemapPage{id: id, uri: uri + "/" + slug, lastUpdated: subtopic.LastUpdated}
+			pages = append(pages, page)
+			walk(subtopic, page.uri)
+		}
+	}
+	if root, ok := published[topicRoot]; ok {
+		walk(root, "")
+	}
+
+	return pages, nil
+}
+
+// splitSitemap splits the pages into files of at most maxURLs, returning one (possibly empty) file if there are no pages
+func splitSitemap(pages []sitemapPage, maxURLs int) [][]sitemapPage {
+	if maxURLs < 1 || len(pages) <= maxURLs {
+		return [][]sitemapPage{pages}
+	}
+
+	var files [][]sitemapPage
+	for start := 0; start < len(pages); start += maxURLs {
+		end := min(start+maxURLs, len(pages))
+		files = append(files, pages[start:end])
+	}
+	return files
+}
+
+// latestUpdate returns when the most recently updated of the pages was last updated, which is nil if none are known
+func latestUpdate(pages []sitemapPage) *time.Time {
+	var latest *time.Time
+	for _, page := range pages {
+		if page.lastUpdated != nil && (latest == nil || page.lastUpdated.After(*latest)) {
+			latest = page.lastUpdated
+		}
+	}
+	return latest
+}
+
+// formatLastMod formats a time in the W3C datetime format of sitemaps, or returns an empty string if it is nil
+func formatLastMod(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// writeXMLBody encodes the value as XML and writes it, with its declaration, to the response with the content type
+func writeXMLBody(ctx context.Context, v interface{}, contentType string, w http.ResponseWriter, data log.Data) error {
+	b, err := xml.Marshal(v)
+	if err != nil {
+		handleError(ctx, w, apierrors.ErrInternalServer, data)
+		return err
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if _, err := w.Write(append([]byte(xml.Header), b...)); err != nil {
+		// a stack trace is added for Non User errors
+		data["response_status"] = http.StatusInternalServerError
+		log.Error(ctx, "request unsuccessful", err, data)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// sitemapMongoDBMock returns a mongoDB mock holding a tree of topics: a published topic with a published and an
+// unpublished subtopic, and a published topic that is not in the tree
+func sitemapMongoDBMock() *storeMock.MongoDBMock {
+	published := models.StatePublished.String()
+	updated := time.Date(2022, 10, 10, 8, 30, 0, 0, time.UTC)
+	return &storeMock.MongoDBMock{
+		GetAllTopicsFunc: func(ctx context.Context) ([]models.TopicResponse, error) {
+			return []models.TopicResponse{
+				{ID: topicRoot, Current: &models.Topic{ID: topicRoot, State: published, SubtopicIds: &[]string{"1"}}},
+				{ID: "1", Current: &models.Topic{ID: "1", Title: "Economy", Slug: "economy", State: published, SubtopicIds: &[]string{"2", "3"}}},
+				{ID: "2", Current: &models.Topic{ID: "2", Title: "Inflation and price indices", State: published, LastUpdated: &updated}},
+				{ID: "3", Current: &models.Topic{ID: "3", Title: "Trade", State: models.StateCreated.String()}},
+				{ID: "4", Current: &models.Topic{ID: "4", Title: "Orphan", State: published}},
+			}, nil
+		},
+	}
+}
+
+func TestGetSitemapHandler(t *testing.T) {
+	Convey("Given a topic API in web mode with a tree of topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = false
+		topicAPI := GetAPIWithMocks(cfg, sitemapMongoDBMock())
+
+		Convey("When the sitemap is requested", func() {
+			request := httptest.NewRequest(http.MethodGet, "http://localhost:25300/sitemap.xml", http.NoBody)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then it lists the pages of the published topics in the tree, with their Welsh alternates", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, models.SitemapContentType)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age=3600")
+				So(w.Header().Get("Last-Modified"), ShouldEqual, "Mon, 10 Oct 2022 08:30:00 GMT")
+
+				var sitemap models.SitemapURLSet
+				So(xml.Unmarshal(w.Body.Bytes(), &sitemap), ShouldBeNil)
+				So(sitemap.URLs, ShouldHaveLength, 2)
+				So(sitemap.URLs[0].Loc, ShouldEqual, "https://www.ons.gov.uk/economy")
+				So(sitemap.URLs[0].LastMod, ShouldBeEmpty)
+				So(sitemap.URLs[1].Loc, ShouldEqual, "https://www.ons.gov.uk/economy/inflationandpriceindices")
+				So(sitemap.URLs[1].LastMod, ShouldEqual, "2022-10-10T08:30:00Z")
+			})
+
+			Convey("Then each page links to its English and Welsh versions", func() {
+				So(w.Body.String(), ShouldContainSubstring, `<xhtml:link rel="alternate" hreflang="cy" href="https://cy.ons.gov.uk/economy"></xhtml:link>`)
+				So(w.Body.String(), ShouldContainSubstring, `<xhtml:link rel="alternate" hreflang="en" href="https://www.ons.gov.uk/economy"></xhtml:link>`)
+			})
+		})
+	})
+
+	Convey("Given a topic API in web mode with more topics than fit in a sitemap file", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		splitCfg := *cfg
+		splitCfg.EnablePrivateEndpoints = false
+		splitCfg.SitemapMaxURLs = 1
+		topicAPI := GetAPIWithMocks(&splitCfg, sitemapMongoDBMock())
+
+		get := func(path string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodGet, "http://localhost:25300"+path, http.NoBody)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		Convey("When the sitemap is requested, it is an index of the files it is split into", func() {
+			w := get("/sitemap.xml")
+			So(w.Code, ShouldEqual, http.StatusOK)
+
+			var index models.SitemapIndex
+			So(xml.Unmarshal(w.Body.Bytes(), &index), ShouldBeNil)
+			So(index.Sitemaps, ShouldResemble, []models.SitemapFile{
+				{Loc: "https://www.ons.gov.uk/sitemap-1.xml"},
+				{Loc: "https://www.ons.gov.uk/sitemap-2.xml", LastMod: "2022-10-10T08:30:00Z"},
+			})
+		})
+
+		Convey("When a file of the sitemap is requested, it lists the pages in that file", func() {
+			w := get("/sitemap-2.xml")
+			So(w.Code, ShouldEqual, http.StatusOK)
+
+			var sitemap models.SitemapURLSet
+			So(xml.Unmarshal(w.Body.Bytes(), &sitemap), ShouldBeNil)
+			So(sitemap.URLs, ShouldHaveLength, 1)
+			So(sitemap.URLs[0].Loc, ShouldEqual, "https://www.ons.gov.uk/economy/inflationandpriceindices")
+		})
+
+		Convey("When a file beyond the last is requested, the response is a 404", func() {
+			w := get("/sitemap-3.xml")
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,532 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// getTaxonomyExportHandler is a handler that exports every topic, in the format given by the format query parameter.
+// As newline delimited JSON (the default), which is streamed, each topic has its content, with the version of each
+// given by the version query parameter (current, next or both). As SKOS, each topic is a concept, using its current
+// version by default.
+func (api *API) getTaxonomyExportHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	format := req.URL.Query().Get("format")
+	version := req.URL.Query().Get("version")
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"format":     format,
+		"version":    version,
+		"function":   "getTaxonomyExportHandler",
+	}
+
+	switch format {
+	case "":
+		format = models.TaxonomyFormatNDJSON
+	case models.TaxonomyFormatNDJSON, models.TaxonomyFormatSKOSTurtle, models.TaxonomyFormatSKOSJSONLD:
+	default:
+		handleError(ctx, w, apierrors.ErrInvalidTaxonomyFormat, logdata)
+		return
+	}
+
+	switch version {
+	case "":
+		version = models.TaxonomyVersionBoth
+		if format != models.TaxonomyFormatNDJSON {
+			version = models.TaxonomyVersionCurrent
+		}
+	case models.TaxonomyVersionCurrent, models.TaxonomyVersionNext, models.TaxonomyVersionBoth:
+	default:
+		handleError(ctx, w, apierrors.ErrInvalidTaxonomyVersion, logdata)
+		return
+	}
+
+	topics, err := api.dataStore.Backend.GetAllTopics(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	if format != models.TaxonomyFormatNDJSON {
+		api.writeConceptScheme(ctx, w, topics, format, version, "", logdata)
+		return
+	}
+
+	contents, err := api.dataStore.Backend.GetAllContent(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	contentByID := make(map[string]*models.ContentResponse, len(contents))
+	for i := range contents {
+		contentByID[contents[i].ID] = &contents[i]
+	}
+
+	w.Header().Set("Content-Type", models.TaxonomyContentType)
+	w.WriteHeader(http.StatusOK)
+
+	// once the response has started, a failure can only be logged, and leaves the response incomplete
+	encoder := json.NewEncoder(w)
+	for i := range topics {
+		if err := encoder.Encode(models.NewTaxonomyRecord(&topics[i], contentByID[topics[i].ID], version)); err != nil {
+			log.Error(ctx, "failed to write taxonomy record", err, logdata)
+			return
+		}
+	}
+
+	logdata["total_count"] = len(topics)
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// getConceptSchemePublicHandler is a handler that gets the current versions of the topics from MongoDB for Web, as a
+// SKOS concept scheme in Turtle (the default) or JSON-LD
+func (api *API) getConceptSchemePublicHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	format := req.URL.Query().Get("format")
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"format":     format,
+		"function":   "getConceptSchemePublicHandler",
+	}
+
+	switch format {
+	case "":
+		format = models.TaxonomyFormatSKOSTurtle
+	case models.TaxonomyFormatSKOSTurtle, models.TaxonomyFormatSKOSJSONLD:
+	default:
+		handleError(ctx, w, apierrors.ErrInvalidConceptSchemeFormat, logdata)
+		return
+	}
+
+	topics, err := api.publicStore(false).GetAllTopics(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	// archived topics are left out of the concepts, as they are of the other public listings of topics
+	published := make([]models.TopicResponse, 0, len(topics))
+	for i := range topics {
+		if topics[i].Current == nil || !topics[i].Current.IsArchived() {
+			published = append(published, topics[i])
+		}
+	}
+
+	api.writeConceptScheme(ctx, w, published, format, models.TaxonomyVersionCurrent, api.conceptSchemeCacheMaxAge, logdata)
+}
+
+// writeConceptScheme writes the topics as a SKOS concept scheme, in Turtle or JSON-LD, with the max-age of its
+// Cache-Control header given, if any
+func (api *API) writeConceptScheme(ctx context.Context, w http.ResponseWriter, topics []models.TopicResponse, format, version, cacheMaxAge string, logdata log.Data) {
+	scheme := models.NewConceptScheme(api.conceptSchemeURL, topics, topicRoot, version)
+
+	var b bytes.Buffer
+	contentType := models.TurtleContentType
+	write := scheme.WriteTurtle
+	if format == models.TaxonomyFormatSKOSJSONLD {
+		contentType = models.JSONLDContentType
+		write = scheme.WriteJSONLD
+	}
+	if err := write(&b); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if cacheMaxAge != "" {
+		setCacheControl(w, cacheMaxAge)
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(b.Bytes()); err != nil {
+		log.Error(ctx, "failed to write concept scheme", err, logdata)
+		return
+	}
+
+	logdata["total_count"] = len(scheme.Concepts)
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// postTaxonomyImportHandler is a handler that imports topics and their content from newline delimited JSON, in the format
+// exported by getTaxonomyExportHandler, responding with a report of the topics created, updated, skipped and deleted.
+// Nothing is changed if any record is invalid, or if the validate_only query parameter is true.
+func (api *API) postTaxonomyImportHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	query := req.URL.Query()
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"function":   "postTaxonomyImportHandler",
+	}
+
+	strategy := query.Get("strategy")
+	switch strategy {
+	case "":
+		strategy = models.ImportStrategyUpsert
+	case models.ImportStrategyUpsert, models.ImportStrategyReplace:
+	default:
+		handleError(ctx, w, apierrors.ErrInvalidImportStrategy, logdata)
+		return
+	}
+	logdata["strategy"] = strategy
+
+	validateOnly := false
+	if value := query.Get("validate_only"); value != "" {
+		var err error
+		if validateOnly, err = strconv.ParseBool(value); err != nil {
+			handleError(ctx, w, apierrors.ErrInvalidValidateOnly, logdata)
+			return
+		}
+	}
+	logdata["validate_only"] = validateOnly
+
+	defer req.Body.Close()
+	records, err := models.ReadTaxonomy(req.Body)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	report, err := api.importTaxonomy(ctx, records, strategy, validateOnly)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	logdata["created"] = len(report.Created)
+	logdata["updated"] = len(report.Updated)
+	logdata["skipped"] = len(report.Skipped)
+	logdata["deleted"] = len(report.Deleted)
+	if err := WriteJSONBody(ctx, report, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// importTaxonomy merges the records into the stored topics and content, using the strategy to decide what happens to
+// the topics that are not imported, and reports the outcome for each topic. With validateOnly, the report describes
+// what would happen, and nothing is changed.
+func (api *API) importTaxonomy(ctx context.Context, records []models.TaxonomyRecord, strategy string, validateOnly bool) (*models.ImportReport, error) {
+	storedTopics, err := api.dataStore.Backend.GetAllTopics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	topics := make(map[string]*models.TopicResponse, len(storedTopics))
+	for i := range storedTopics {
+		topics[storedTopics[i].ID] = &storedTopics[i]
+	}
+
+	storedContent, err := api.dataStore.Backend.GetAllContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	contents := make(map[string]*models.ContentResponse, len(storedContent))
+	for i := range storedContent {
+		contents[storedContent[i].ID] = &storedContent[i]
+	}
+
+	imported := make(map[string]bool, len(records))
+	for i := range records {
+		imported[records[i].ID] = true
+	}
+
+	if err := checkTaxonomyReferences(records, imported, topics, strategy); err != nil {
+		return nil, err
+	}
+
+	report := &models.ImportReport{
+		ValidateOnly: validateOnly,
+		Strategy:     strategy,
+		Created:      []string{},
+		Updated:      []string{},
+		Skipped:      []string{},
+		Deleted:      []string{},
+	}
+
+	// the import is all or nothing: if a write fails, the writes already made are rolled back, restoring the topics
+	// and content as they were, and the webhooks are only notified once every write has been made
+	var restore []func(ctx context.Context) error
+	rollback := func(cause error) error {
+		// the rollback must complete even if the request is cancelled
+		ctx := context.WithoutCancel(ctx)
+		for i := len(restore) - 1; i >= 0; i-- {
+			if err := restore[i](ctx); err != nil {
+				log.Error(ctx, "failed to roll back the import of the taxonomy", err, log.Data{"strategy": strategy})
+			}
+		}
+		return cause
+	}
+	var notifications []func()
+
+	// get the paths of the published topics while the old ones are still published, if importing a published
+	// version of a topic changes its slug
+	moved := false
+	for i := range records {
+		moved = moved || slugChanges(currentOf(topics[records[i].ID]), records[i].Current)
+	}
+	paths := api.getPublishedPaths(ctx, moved && !validateOnly)
+
+	for i := range records {
+		record := &records[i]
+		existing := topics[record.ID]
+		existingContent := contents[record.ID]
+		topic := api.mergeTopic(existing, record)
+		content := mergeContent(existingContent, record)
+
+		switch {
+		case existing == nil:
+			report.Created = append(report.Created, record.ID)
+			if validateOnly {
+				continue
+			}
+			if err := api.dataStore.Backend.InsertTopic(ctx, topic); err != nil {
+				return nil, rollback(fmt.Errorf("failed to create topic %s: %w", record.ID, err))
+			}
+			restore = append(restore, func(ctx context.Context) error {
+				return api.dataStore.Backend.DeleteTopic(ctx, record.ID)
+			})
+			if err := api.dataStore.Backend.UpsertContent(ctx, record.ID, content); err != nil {
+				return nil, rollback(fmt.Errorf("failed to create content %s: %w", record.ID, err))
+			}
+			notifications = append(notifications, func() {
+				api.notifier.Notify(ctx, models.WebhookEventTopicCreated, record.ID, stateOf(topic))
+			})
+		case sameJSON(existing, topic) && sameJSON(existingContent, content):
+			report.Skipped = append(report.Skipped, record.ID)
+		default:
+			report.Updated = append(report.Updated, record.ID)
+			if validateOnly {
+				continue
+			}
+			if err := api.dataStore.Backend.UpsertTopic(ctx, record.ID, topic); err != nil {
+				return nil, rollback(fmt.Errorf("failed to update topic %s: %w", record.ID, err))
+			}
+			restore = append(restore, func(ctx context.Context) error {
+				return api.dataStore.Backend.ReplaceTopic(ctx, existing)
+			})
+			if err := api.dataStore.Backend.UpsertContent(ctx, record.ID, content); err != nil {
+				return nil, rollback(fmt.Errorf("failed to update content %s: %w", record.ID, err))
+			}
+			restore = append(restore, func(ctx context.Context) error {
+				if existingContent == nil {
+					// the content did not exist before the import, so it is removed rather than left empty
+					return api.dataStore.Backend.DeleteContent(ctx, record.ID)
+				}
+				return api.dataStore.Backend.ReplaceContent(ctx, existingContent)
+			})
+			notifications = append(notifications, func() {
+				api.notifier.Notify(ctx, models.WebhookEventTopicUpdated, record.ID, stateOf(topic))
+			})
+		}
+	}
+
+	if strategy == models.ImportStrategyReplace {
+		for id := range topics {
+			if !imported[id] {
+				report.Deleted = append(report.Deleted, id)
+			}
+		}
+		sort.Strings(report.Deleted)
+
+		for _, id := range report.Deleted {
+			if validateOnly {
+				break
+			}
+			if err := api.dataStore.Backend.DeleteTopic(ctx, id); err != nil {
+				return nil, rollback(fmt.Errorf("failed to delete topic %s: %w", id, err))
+			}
+			deleted, deletedContent := topics[id], contents[id]
+			restore = append(restore, func(ctx context.Context) error {
+				if err := api.dataStore.Backend.InsertTopic(ctx, deleted); err != nil {
+					return err
+				}
+				if deletedContent == nil {
+					return nil
+				}
+				return api.dataStore.Backend.InsertContent(ctx, deletedContent)
+			})
+			notifications = append(notifications, func() {
+				api.notifier.Notify(ctx, models.WebhookEventTopicDeleted, id, "")
+			})
+		}
+	}
+
+	// the taxonomy has already been imported, so a failure to redirect the old paths of its topics is logged rather
+	// than returned
+	if err := api.recordRedirects(ctx, paths); err != nil {
+		log.Error(ctx, "failed to record redirects of the imported topics", err, log.Data{"strategy": strategy})
+	}
+
+	for _, notify := range notifications {
+		notify()
+	}
+
+	return report, nil
+}
+
+// checkTaxonomyReferences checks that the subtopics and related topics of the imported topics will exist once they
+// have been imported, and that the root topic is not replaced away, so that every violation is reported before
+// anything is changed
+func checkTaxonomyReferences(records []models.TaxonomyRecord, imported map[string]bool, stored map[string]*models.TopicResponse, strategy string) error {
+	violations := &apierrors.ValidationError{}
+
+	if strategy == models.ImportStrategyReplace && !imported[topicRoot] {
+		// replacing the taxonomy without its root would delete the root, and with it the navigation and every listing
+		violations.Add(apierrors.ErrTopicInvalidFields, topicRoot, "must be imported when the taxonomy is replaced")
+	}
+
+	for i := range records {
+		for _, version := range []struct {
+			name  string
+			topic *models.Topic
+		}{{"current", records[i].Current}, {"next", records[i].Next}} {
+			if version.topic == nil {
+				continue
+			}
+			for _, list := range []struct {
+				field string
+				ids   *[]string
+			}{{"subtopics_ids", version.topic.SubtopicIds}, {"related_topic_ids", version.topic.RelatedTopicIDs}} {
+				if list.ids == nil {
+					continue
+				}
+				for j, id := range *list.ids {
+					kept := stored[id] != nil && strategy != models.ImportStrategyReplace
+					if !imported[id] && !kept {
+						field := fmt.Sprintf("line %d: %s.%s[%d]", records[i].Line, version.name, list.field, j)
+						violations.Add(apierrors.ErrTopicInvalidFields, field, "must be the id of an imported or existing topic")
+					}
+				}
+			}
+		}
+	}
+
+	return violations.ErrorOrNil()
+}
+
+// mergeTopic returns the topic document that results from importing the record over the existing document, which may be nil.
+// The versions in the record replace those stored, and their links are set to refer to this API. The owners of a topic
+// are not part of the taxonomy, as they are managed by its admins with PUT /topics/{id}/owners, so those stored are kept.
+func (api *API) mergeTopic(existing *models.TopicResponse, record *models.TaxonomyRecord) *models.TopicResponse {
+	topic := &models.TopicResponse{ID: record.ID, Current: record.Current, Next: record.Next}
+	if existing != nil {
+		topic.Owners = existing.Owners
+		// the stored versions are copied, as writing the topic sets their last updated time, which would otherwise
+		// change the existing document that is restored if the import is rolled back
+		if topic.Current == nil && existing.Current != nil {
+			current := *existing.Current
+			topic.Current = &current
+		}
+		if topic.Next == nil && existing.Next != nil {
+			next := *existing.Next
+			topic.Next = &next
+		}
+	}
+
+	for _, versions := range []struct{ imported, stored *models.Topic }{
+		{record.Current, currentOf(existing)},
+		{record.Next, nextOf(existing)},
+	} {
+		version := versions.imported
+		if version == nil {
+			continue
+		}
+		if versions.stored != nil {
+			// the last updated time is not exported, so the stored time is kept for the topic to be compared with
+			// the stored topic, and so skipped when it is unchanged
+			version.LastUpdated = versions.stored.LastUpdated
+		}
+
+		topicURL := fmt.Sprintf("%s/topics/%s", api.topicAPIURL, record.ID)
+		version.Links = &models.TopicLinks{
+			Self:    &models.LinkObject{HRef: topicURL, ID: record.ID},
+			Content: &models.LinkObject{HRef: topicURL + "/content"},
+		}
+		if version.SubtopicIds != nil && len(*version.SubtopicIds) > 0 {
+			version.Links.Subtopics = &models.LinkObject{HRef: topicURL + "/subtopics"}
+		}
+		if version.RelatedTopicIDs != nil && len(*version.RelatedTopicIDs) > 0 {
+			version.Links.Related = &models.LinkObject{HRef: topicURL + "/related"}
+		}
+	}
+
+	return topic
+}
+
+// mergeContent returns the content document that results from importing the record over the existing document, which may be nil.
+// A topic without content is given empty content in the state of the topic.
+func mergeContent(existing *models.ContentResponse, record *models.TaxonomyRecord) *models.ContentResponse {
+	content := &models.ContentResponse{ID: record.ID}
+	if record.Content != nil {
+		content.Current = record.Content.Current
+		content.Next = record.Content.Next
+	}
+	if existing != nil {
+		if content.Current == nil {
+			content.Current = existing.Current
+		}
+		if content.Next == nil {
+			content.Next = existing.Next
+		}
+	}
+
+	if content.Current == nil && record.Current != nil {
+		content.Current = &models.Content{State: record.Current.State}
+	}
+	if content.Next == nil && record.Next != nil {
+		content.Next = &models.Content{State: record.Next.State}
+	}
+
+	return content
+}
+
+// sameJSON reports whether a and b have the same JSON representation, which excludes when topics were last updated
+func sameJSON(a, b interface{}) bool {
+	if reflect.ValueOf(a).IsNil() || reflect.ValueOf(b).IsNil() {
+		return reflect.ValueOf(a).IsNil() == reflect.ValueOf(b).IsNil()
+	}
+
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return string(aJSON) == string(bJSON)
+}
+
+// currentOf returns the current version of a topic document, which may be nil
+func currentOf(topic *models.TopicResponse) *models.Topic {
+	if topic == nil {
+		return nil
+	}
+	return topic.Current
+}
+
+// nextOf returns the next version of a topic document, which may be nil
+func nextOf(topic *models.TopicResponse) *models.Topic {
+	if topic == nil {
+		return nil
+	}
+	return topic.Next
+}
+
+// stateOf returns the state of the next version of a topic, or of its current version if it has no next version
+func stateOf(topic *models.TopicResponse) string {
+	if topic.Next != nil {
+		return topic.Next.State
+	}
+	if topic.Current != nil {
+		return topic.Current.State
+	}
+	return ""
+}
@@ -0,0 +1,381 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// taxonomyMongoDBMock returns a mongoDB mock holding a published topic with subtopics, owned by the economy team, and
+// one of its subtopics
+func taxonomyMongoDBMock() *storeMock.MongoDBMock {
+	subtopicIDs := []string{"2"}
+	links := &models.TopicLinks{
+		Self:      &models.LinkObject{HRef: testTopicAPIURL + "/topics/1", ID: "1"},
+		Content:   &models.LinkObject{HRef: testTopicAPIURL + "/topics/1/content"},
+		Subtopics: &models.LinkObject{HRef: testTopicAPIURL + "/topics/1/subtopics"},
+	}
+	return &storeMock.MongoDBMock{
+		GetAllTopicsFunc: func(ctx context.Context) ([]models.TopicResponse, error) {
+			return []models.TopicResponse{
+				{
+					ID:      "1",
+					Owners:  []string{"economy-team"},
+					Current: &models.Topic{ID: "1", Title: "Economy", WelshTitle: "Yr economi", State: models.StatePublished.String(), SubtopicIds: &subtopicIDs, Links: links},
+					Next:    &models.Topic{ID: "1", Title: "Economy", State: models.StatePublished.String(), SubtopicIds: &subtopicIDs, Links: links},
+				},
+				{
+					ID:      "2",
+					Current: &models.Topic{ID: "2", Title: "Inflation", State: models.StatePublished.String()},
+					Next:    &models.Topic{ID: "2", Title: "Inflation and prices", State: models.StateCompleted.String()},
+				},
+			}, nil
+		},
+		GetAllContentFunc: func(ctx context.Context) ([]models.ContentResponse, error) {
+			return []models.ContentResponse{
+				{ID: "1", Current: &models.Content{State: models.StatePublished.String()}, Next: &models.Content{State: models.StatePublished.String()}},
+			}, nil
+		},
+		InsertTopicFunc:   func(ctx context.Context, topic *models.TopicResponse) error { return nil },
+		UpsertTopicFunc:   func(ctx context.Context, id string, topic *models.TopicResponse) error { return nil },
+		UpsertContentFunc: func(ctx context.Context, id string, content *models.ContentResponse) error { return nil },
+		DeleteTopicFunc:   func(ctx context.Context, id string) error { return nil },
+	}
+}
+
+func TestGetTaxonomyExportHandler(t *testing.T) {
+	Convey("Given a topic API in publishing mode with two topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		topicAPI := GetAPIWithMocks(cfg, taxonomyMongoDBMock())
+
+		Convey("When the taxonomy is exported", func() {
+			request, err := createRequestWithAuth(http.MethodGet, "http://localhost:25300/topics/export", http.NoBody)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then each topic and its content is on a line of newline delimited JSON", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, models.TaxonomyContentType)
+
+				lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+				So(lines, ShouldHaveLength, 2)
+
+				var record models.TaxonomyRecord
+				So(json.Unmarshal([]byte(lines[0]), &record), ShouldBeNil)
+				So(record.ID, ShouldEqual, "1")
+				So(record.Current.Title, ShouldEqual, "Economy")
+				So(record.Next, ShouldNotBeNil)
+				So(record.Content.Current.State, ShouldEqual, models.StatePublished.String())
+			})
+		})
+
+		Convey("When only the next versions are exported", func() {
+			request, err := createRequestWithAuth(http.MethodGet, "http://localhost:25300/topics/export?version=next", http.NoBody)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then the current versions are left out", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				records, err := models.ReadTaxonomy(w.Body)
+				So(err, ShouldBeNil)
+				So(records[1].Current, ShouldBeNil)
+				So(records[1].Next.Title, ShouldEqual, "Inflation and prices")
+				So(records[1].Content, ShouldBeNil)
+			})
+		})
+
+		Convey("When an unknown version is requested, the response is a 400", func() {
+			request, err := createRequestWithAuth(http.MethodGet, "http://localhost:25300/topics/export?version=latest", http.NoBody)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusBadRequest, apierrors.ErrInvalidTaxonomyVersion))
+		})
+
+		Convey("When the taxonomy is exported as SKOS in JSON-LD", func() {
+			request, err := createRequestWithAuth(http.MethodGet, "http://localhost:25300/topics/export?format=skos-jsonld", http.NoBody)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then each topic is a concept, using its current version", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, models.JSONLDContentType)
+
+				var document models.JSONLDDocument
+				So(json.Unmarshal(w.Body.Bytes(), &document), ShouldBeNil)
+				So(document.Graph, ShouldHaveLength, 3)
+				So(document.Graph[1].ID, ShouldEqual, cfg.ConceptSchemeURL+"/1")
+				So(document.Graph[1].PrefLabel, ShouldResemble, map[string]string{"en": "Economy", "cy": "Yr economi"})
+				So(document.Graph[1].Narrower, ShouldResemble, []string{cfg.ConceptSchemeURL + "/2"})
+				So(document.Graph[2].PrefLabel, ShouldResemble, map[string]string{"en": "Inflation"})
+				So(document.Graph[2].Broader, ShouldResemble, []string{cfg.ConceptSchemeURL + "/1"})
+			})
+		})
+
+		Convey("When the taxonomy is exported as SKOS in Turtle", func() {
+			request, err := createRequestWithAuth(http.MethodGet, "http://localhost:25300/topics/export?format=skos-turtle&version=next", http.NoBody)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then each topic is a concept, using the version requested", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, models.TurtleContentType)
+				So(w.Body.String(), ShouldContainSubstring, "<"+cfg.ConceptSchemeURL+"/2>\n    a skos:Concept")
+				So(w.Body.String(), ShouldContainSubstring, `skos:prefLabel "Inflation and prices"@en ;`)
+			})
+		})
+
+		Convey("When an unknown format is requested, the response is a 400", func() {
+			request, err := createRequestWithAuth(http.MethodGet, "http://localhost:25300/topics/export?format=rdf-xml", http.NoBody)
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusBadRequest, apierrors.ErrInvalidTaxonomyFormat))
+		})
+	})
+}
+
+func TestGetConceptSchemePublicHandler(t *testing.T) {
+	Convey("Given a topic API in web mode with two topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = false
+		topicAPI := GetAPIWithMocks(cfg, taxonomyMongoDBMock())
+
+		serve := func(url string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, http.NoBody))
+			return w
+		}
+
+		Convey("When the concept scheme is requested without authentication", func() {
+			w := serve("http://localhost:25300/topics/export")
+
+			Convey("Then it is returned in Turtle from the current versions, with the Welsh titles as Welsh labels", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, models.TurtleContentType)
+				So(w.Header().Get("Cache-Control"), ShouldEqual, "public, max-age="+topicAPI.conceptSchemeCacheMaxAge)
+				So(w.Body.String(), ShouldContainSubstring, `skos:prefLabel "Economy"@en, "Yr economi"@cy ;`)
+				So(w.Body.String(), ShouldContainSubstring, `skos:prefLabel "Inflation"@en ;`)
+				So(w.Body.String(), ShouldNotContainSubstring, "Inflation and prices")
+			})
+		})
+
+		Convey("When the concept scheme is requested in JSON-LD, then it is returned", func() {
+			w := serve("http://localhost:25300/topics/export?format=skos-jsonld")
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(w.Header().Get("Content-Type"), ShouldEqual, models.JSONLDContentType)
+		})
+
+		Convey("When the concept scheme is requested once the subtopic has been archived", func() {
+			mongoDBMock := taxonomyMongoDBMock()
+			getAllTopics := mongoDBMock.GetAllTopicsFunc
+			mongoDBMock.GetAllTopicsFunc = func(ctx context.Context) ([]models.TopicResponse, error) {
+				topics, err := getAllTopics(ctx)
+				topics[1].Current.State = models.StateArchived.String()
+				return topics, err
+			}
+			topicAPI = GetAPIWithMocks(cfg, mongoDBMock)
+			w := serve("http://localhost:25300/topics/export")
+
+			Convey("Then the archived topic is left out, as a concept and as a narrower concept", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `skos:prefLabel "Economy"@en, "Yr economi"@cy .`)
+				So(w.Body.String(), ShouldNotContainSubstring, "Inflation")
+				So(w.Body.String(), ShouldNotContainSubstring, "narrower")
+			})
+		})
+
+		Convey("When the taxonomy is requested as newline delimited JSON, the response is a 400", func() {
+			w := serve("http://localhost:25300/topics/export?format=ndjson")
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusBadRequest, apierrors.ErrInvalidConceptSchemeFormat))
+
+			var problem models.Problem
+			So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+			So(problem.Code, ShouldEqual, apierrors.CodeInvalidConceptSchemeFormat)
+		})
+	})
+}
+
+func TestPostTaxonomyImportHandler(t *testing.T) {
+	Convey("Given a topic API in publishing mode with two topics", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		mongoDBMock := taxonomyMongoDBMock()
+		notifier := &notifierStub{}
+		topicAPI := getAPIWithNotifier(cfg, mongoDBMock, notifier)
+
+		// the first topic is unchanged, the second has a new next version, and the third is new
+		body := `{"id":"1","current":{"id":"1","title":"Economy","welsh_title":"Yr economi","state":"published","subtopics_ids":["2"]},"next":{"id":"1","title":"Economy","state":"published","subtopics_ids":["2"]},"content":{"current":{"state":"published"},"next":{"state":"published"}}}
+{"id":"2","next":{"title":"Prices","state":"completed"}}
+{"id":"3","current":{"title":"Trade","state":"published"}}
+`
+		importTaxonomy := func(query string) (*httptest.ResponseRecorder, models.ImportReport) {
+			request, err := createRequestWithAuth(http.MethodPost, "http://localhost:25300/topics/import"+query, bytes.NewBufferString(body))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			var report models.ImportReport
+			if w.Code == http.StatusOK {
+				So(json.Unmarshal(w.Body.Bytes(), &report), ShouldBeNil)
+			}
+			return w, report
+		}
+
+		Convey("When the taxonomy is imported", func() {
+			w, report := importTaxonomy("")
+
+			Convey("Then unchanged topics are skipped, changed topics are updated and new topics are created", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(report.Strategy, ShouldEqual, models.ImportStrategyUpsert)
+				So(report.Skipped, ShouldResemble, []string{"1"})
+				So(report.Updated, ShouldResemble, []string{"2"})
+				So(report.Created, ShouldResemble, []string{"3"})
+				So(report.Deleted, ShouldBeEmpty)
+			})
+
+			Convey("Then the version that was not imported is kept", func() {
+				So(mongoDBMock.UpsertTopicCalls(), ShouldHaveLength, 1)
+				updated := mongoDBMock.UpsertTopicCalls()[0].Topic
+				So(updated.Current.Title, ShouldEqual, "Inflation")
+				So(updated.Next.Title, ShouldEqual, "Prices")
+				So(updated.Next.Links.Self.HRef, ShouldEqual, testTopicAPIURL+"/topics/2")
+			})
+
+			Convey("Then the new topic is created with empty content", func() {
+				So(mongoDBMock.InsertTopicCalls(), ShouldHaveLength, 1)
+				So(mongoDBMock.InsertTopicCalls()[0].Topic.Current.ID, ShouldEqual, "3")
+				So(mongoDBMock.UpsertContentCalls(), ShouldHaveLength, 2)
+				So(mongoDBMock.UpsertContentCalls()[1].Content.Current.State, ShouldEqual, models.StatePublished.String())
+			})
+
+			Convey("Then subscribers are notified of the changes", func() {
+				So(notifier.notifications, ShouldResemble, []notification{
+					{models.WebhookEventTopicUpdated, "2", models.StateCompleted.String()},
+					{models.WebhookEventTopicCreated, "3", models.StatePublished.String()},
+				})
+			})
+		})
+
+		Convey("When the taxonomy is validated with the replace strategy", func() {
+			body += `{"id":"topic_root","current":{"state":"published","subtopics_ids":["1"]}}`
+			w, report := importTaxonomy("?strategy=replace&validate_only=true")
+
+			Convey("Then the report describes the changes, but nothing is changed", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(report.ValidateOnly, ShouldBeTrue)
+				So(report.Created, ShouldResemble, []string{"3", "topic_root"})
+				So(report.Deleted, ShouldBeEmpty)
+				So(mongoDBMock.InsertTopicCalls(), ShouldBeEmpty)
+				So(mongoDBMock.UpsertTopicCalls(), ShouldBeEmpty)
+				So(mongoDBMock.UpsertContentCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When only some of the topics are imported with the replace strategy", func() {
+			body = `{"id":"topic_root","current":{"state":"published","subtopics_ids":["3"]}}
+{"id":"3","current":{"title":"Trade","state":"published"}}`
+			w, report := importTaxonomy("?strategy=replace")
+
+			Convey("Then the topics that were not imported are deleted", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(report.Deleted, ShouldResemble, []string{"1", "2"})
+				So(mongoDBMock.DeleteTopicCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When the taxonomy is replaced without the root topic", func() {
+			body = `{"id":"3","current":{"title":"Trade","state":"published"}}`
+			w, _ := importTaxonomy("?strategy=replace")
+
+			Convey("Then the response is a 400 detailing the root topic, and nothing is deleted", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var problem models.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Errors, ShouldResemble, []apierrors.FieldError{
+					{Field: "topic_root", Message: "must be imported when the taxonomy is replaced"},
+				})
+				So(mongoDBMock.DeleteTopicCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a write fails part way through the import", func() {
+			mongoDBMock.UpsertContentFunc = func(ctx context.Context, id string, content *models.ContentResponse) error {
+				if id == "3" {
+					return errors.New("connection lost")
+				}
+				return nil
+			}
+			// as the stores do, writing a topic sets the last updated time of its versions
+			mongoDBMock.UpsertTopicFunc = func(ctx context.Context, id string, topic *models.TopicResponse) error {
+				now := time.Now()
+				topic.Current.LastUpdated, topic.Next.LastUpdated = &now, &now
+				return nil
+			}
+			mongoDBMock.ReplaceTopicFunc = func(ctx context.Context, topic *models.TopicResponse) error { return nil }
+			mongoDBMock.DeleteContentFunc = func(ctx context.Context, id string) error { return nil }
+			w, _ := importTaxonomy("")
+
+			Convey("Then the writes already made are rolled back, and no subscriber is notified", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(mongoDBMock.ReplaceTopicCalls(), ShouldHaveLength, 1)
+				So(mongoDBMock.ReplaceTopicCalls()[0].Topic.Next.Title, ShouldEqual, "Inflation and prices")
+				So(mongoDBMock.ReplaceTopicCalls()[0].Topic.Current.LastUpdated, ShouldBeNil)
+				So(mongoDBMock.DeleteContentCalls(), ShouldHaveLength, 1)
+				So(mongoDBMock.DeleteContentCalls()[0].ID, ShouldEqual, "2")
+				So(mongoDBMock.DeleteTopicCalls(), ShouldHaveLength, 1)
+				So(mongoDBMock.DeleteTopicCalls()[0].ID, ShouldEqual, "3")
+				So(notifier.notifications, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an imported topic refers to a subtopic that would not exist", func() {
+			body = `{"id":"3","next":{"title":"Trade","state":"published","subtopics_ids":["2","4"]}}`
+			w, _ := importTaxonomy("")
+
+			Convey("Then the response is a 400 detailing the subtopic, and nothing is changed", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var problem models.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Errors, ShouldResemble, []apierrors.FieldError{
+					{Field: "line 1: next.subtopics_ids[1]", Message: "must be the id of an imported or existing topic"},
+				})
+				So(mongoDBMock.InsertTopicCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When an unknown strategy is requested, the response is a 400", func() {
+			w, _ := importTaxonomy("?strategy=merge")
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusBadRequest, apierrors.ErrInvalidImportStrategy))
+		})
+	})
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/bulk"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// postTopicsBulkHandler is a handler that applies updates, read from CSV with a row for each topic, to the next versions
+// of the topics, responding with the outcome of each row. Unless the partial query parameter is true, nothing is updated
+// if any row is invalid.
+func (api *API) postTopicsBulkHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"function":   "postTopicsBulkHandler",
+	}
+
+	partial := false
+	if value := req.URL.Query().Get("partial"); value != "" {
+		var err error
+		if partial, err = strconv.ParseBool(value); err != nil {
+			handleError(ctx, w, apierrors.ErrInvalidPartial, logdata)
+			return
+		}
+	}
+	logdata["partial"] = partial
+
+	defer req.Body.Close()
+	rows, err := bulk.Read(req.Body)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	// every row must be editable by the caller, so that a partial update does not depend on its permissions, as must
+	// every subtopic a row sets, as it gives the owners of the topic of the row rights over them
+	if api.entityParser != nil {
+		ids := make([]string, 0, len(rows))
+		for i := range rows {
+			ids = append(ids, rows[i].ID)
+			ids = append(ids, rows[i].SubtopicIDs()...)
+		}
+		if err := api.checkTopicEditor(ctx, api.callerGroups(ctx, req), ids...); err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+	}
+
+	report, err := bulk.Apply(ctx, api.dataStore.Backend, api.topicAPIURL, callerIdentity(ctx), rows, partial)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	for i := range report.Rows {
+		if report.Rows[i].Status == bulk.StatusUpdated {
+			api.notifier.Notify(ctx, models.WebhookEventTopicUpdated, report.Rows[i].ID, report.Rows[i].State)
+		}
+	}
+
+	logdata["updated"] = report.Count(bulk.StatusUpdated)
+	logdata["invalid"] = report.Count(bulk.StatusInvalid)
+	if err := WriteJSONBody(ctx, report, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/bulk"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPostTopicsBulkHandler(t *testing.T) {
+	Convey("Given a topic API in publishing mode with a topic that has an unpublished change", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+				if id != "1" {
+					return nil, apierrors.ErrTopicNotFound
+				}
+				return &models.TopicResponse{
+					ID:      "1",
+					Current: &models.Topic{ID: "1", Title: "Economy", State: models.StatePublished.String()},
+					Next:    &models.Topic{ID: "1", Title: "Economy", State: models.StateCreated.String()},
+				}, nil
+			},
+			CheckTopicExistsFunc: func(ctx context.Context, id string) error { return nil },
+			IsSlugInUseFunc:      func(ctx context.Context, id, slug string) (bool, error) { return false, nil },
+			UpdateTopicFunc:      func(ctx context.Context, host, id string, topic *models.TopicUpdate) error { return nil },
+		}
+		notifier := &notifierStub{}
+		topicAPI := getAPIWithNotifier(cfg, mongoDBMock, notifier)
+
+		postCSV := func(query, body string) *httptest.ResponseRecorder {
+			request, err := createRequestWithAuth(http.MethodPost, "http://localhost:25300/topics/bulk"+query, bytes.NewBufferString(body))
+			So(err, ShouldBeNil)
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			return w
+		}
+
+		body := "id,description,keywords,release_date\n" +
+			"1,The economy,gdp;growth,2022-10-10T08:30:00Z\n" +
+			"2,Trade,,2022-10-10T08:30:00Z\n"
+
+		Convey("When a valid row is posted", func() {
+			w := postCSV("", "id,description,keywords,release_date\n1,The economy,gdp;growth,2022-10-10T08:30:00Z\n")
+
+			Convey("Then the next version is updated, keeping the fields without values, and recording its editor", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpdateTopicCalls(), ShouldHaveLength, 1)
+				So(mongoDBMock.UpdateTopicCalls()[0].Topic, ShouldResemble, &models.TopicUpdate{
+					Title:        "Economy",
+					Description:  "The economy",
+					Keywords:     &[]string{"gdp", "growth"},
+					ReleaseDate:  "2022-10-10T08:30:00Z",
+					State:        models.StateCreated.String(),
+					LastEditedBy: "someone@ons.gov.uk",
+				})
+
+				var report bulk.Report
+				So(json.Unmarshal(w.Body.Bytes(), &report), ShouldBeNil)
+				So(report.Rows, ShouldResemble, []bulk.Result{
+					{Line: 2, ID: "1", Status: bulk.StatusUpdated, State: models.StateCreated.String()},
+				})
+				So(notifier.notifications, ShouldResemble, []notification{
+					{models.WebhookEventTopicUpdated, "1", models.StateCreated.String()},
+				})
+			})
+		})
+
+		Convey("When a valid and an invalid row are posted", func() {
+			w := postCSV("", body)
+
+			Convey("Then the response is a 400 detailing the invalid row, and nothing is updated", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+				var problem models.Problem
+				So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+				So(problem.Errors, ShouldResemble, []apierrors.FieldError{
+					{Field: "line 3: id", Message: "must be the id of an existing topic"},
+				})
+				So(mongoDBMock.UpdateTopicCalls(), ShouldBeEmpty)
+				So(notifier.notifications, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a valid and an invalid row are posted for partial application", func() {
+			w := postCSV("?partial=true", body)
+
+			Convey("Then the valid row is updated, and the invalid row is reported", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.UpdateTopicCalls(), ShouldHaveLength, 1)
+
+				var report bulk.Report
+				So(json.Unmarshal(w.Body.Bytes(), &report), ShouldBeNil)
+				So(report.Partial, ShouldBeTrue)
+				So(report.Rows[0].Status, ShouldEqual, bulk.StatusUpdated)
+				So(report.Rows[1], ShouldResemble, bulk.Result{
+					Line:   3,
+					ID:     "2",
+					Status: bulk.StatusInvalid,
+					Errors: []apierrors.FieldError{{Field: "id", Message: "must be the id of an existing topic"}},
+				})
+			})
+		})
+
+		Convey("When a row would publish its topic, it is invalid", func() {
+			w := postCSV("", "id,description,release_date,state\n1,The economy,2022-10-10T08:30:00Z,published\n")
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(mongoDBMock.UpdateTopicCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When a row would approve its topic, it is invalid, as only a review can approve it", func() {
+			w := postCSV("", "id,description,release_date,state\n1,The economy,2022-10-10T08:30:00Z,approved\n")
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+			var problem models.Problem
+			So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+			So(problem.Errors, ShouldResemble, []apierrors.FieldError{
+				{Field: "line 2: state", Message: "must be a state the topic can move to from created"},
+			})
+			So(mongoDBMock.UpdateTopicCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When a row would archive its topic, it is invalid, as only the state of a topic archives it", func() {
+			w := postCSV("", "id,description,release_date,state\n1,The economy,2022-10-10T08:30:00Z,archived\n")
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+			var problem models.Problem
+			So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+			So(problem.Errors, ShouldResemble, []apierrors.FieldError{
+				{Field: "line 2: state", Message: "must not archive, or restore, a topic by a bulk update"},
+			})
+			So(mongoDBMock.UpdateTopicCalls(), ShouldBeEmpty)
+		})
+
+		Convey("When partial is not a boolean, the response is a 400", func() {
+			w := postCSV("?partial=some", body)
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+			So(w.Body.Bytes(), ShouldResemble, problemPayload(http.StatusBadRequest, apierrors.ErrInvalidPartial))
+		})
+	})
+}
@@ -2,11 +2,15 @@ package api
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"time"
 
 	dprequest "github.com/ONSdigital/dp-net/v3/request"
 	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/metrics"
 	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/dp-topic-api/store"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 )
@@ -21,9 +25,23 @@ func (api *API) getRootTopicsPrivateHandler(w http.ResponseWriter, req *http.Req
 		"function":   "getTopicsListPrivateHandler",
 	}
 
+	// the topics in a state, such as the review queue, are listed instead when the state is given
+	if state := req.URL.Query().Get("state"); state != "" {
+		logdata["state"] = state
+		api.getTopicsByState(ctx, state, logdata, w)
+		return
+	}
+
+	include, err := includeArchived(req)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["include_archived"] = include
+
 	// The mongo document with id: `topic_root` contains the list of subtopics,
 	// so we directly return that list
-	api.getSubtopicsPrivateByID(ctx, id, logdata, w)
+	api.getSubtopicsPrivateByID(ctx, id, include, logdata, w)
 }
 
 // getTopicPrivateHandler is a handler that gets a topic by its id from MongoDB for Publishing
@@ -45,6 +63,7 @@ func (api *API) getTopicPrivateHandler(w http.ResponseWriter, req *http.Request)
 	}
 
 	// User has valid authentication to get raw topic document
+	setLastModified(w, topicLastUpdated(topic)...)
 	if err := WriteJSONBody(ctx, topic, w, logdata); err != nil {
 		// WriteJSONBody has already logged the error
 		return
@@ -63,10 +82,19 @@ func (api *API) getSubtopicsPrivateHandler(w http.ResponseWriter, req *http.Requ
 		"function":   "getSubtopicsPrivateHandler",
 	}
 
-	api.getSubtopicsPrivateByID(ctx, id, logdata, w)
+	include, err := includeArchived(req)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["include_archived"] = include
+
+	api.getSubtopicsPrivateByID(ctx, id, include, logdata, w)
 }
 
-func (api *API) getSubtopicsPrivateByID(ctx context.Context, id string, logdata log.Data, w http.ResponseWriter) {
+// getSubtopicsPrivateByID writes the subtopics of the topic with the id, leaving out those that are archived unless
+// they are included
+func (api *API) getSubtopicsPrivateByID(ctx context.Context, id string, include bool, logdata log.Data, w http.ResponseWriter) {
 	// get topic from mongoDB by id
 	topic, err := api.dataStore.Backend.GetTopic(ctx, id)
 	if err != nil {
@@ -89,6 +117,9 @@ func (api *API) getSubtopicsPrivateByID(ctx context.Context, id string, logdata
 		return
 	}
 
+	metrics.SubtopicsFanOut.Observe(float64(len(*topic.Next.SubtopicIds)))
+	lastUpdated := topicLastUpdated(topic)
+	archived := 0
 	for _, subTopicID := range *topic.Next.SubtopicIds {
 		// get topic from mongoDB by subTopicID
 		topic, err := api.dataStore.Backend.GetTopic(ctx, subTopicID)
@@ -97,6 +128,11 @@ func (api *API) getSubtopicsPrivateByID(ctx context.Context, id string, logdata
 			log.Error(ctx, "missing subtopic for id", err, logdata)
 			continue
 		}
+		if !include && topic.Next != nil && topic.Next.IsArchived() {
+			archived++
+			continue
+		}
+		lastUpdated = append(lastUpdated, topicLastUpdated(topic)...)
 
 		if result.PrivateItems == nil {
 			result.PrivateItems = &[]models.TopicResponse{*topic}
@@ -106,11 +142,17 @@ func (api *API) getSubtopicsPrivateByID(ctx context.Context, id string, logdata
 
 		result.TotalCount++
 	}
+	if result.TotalCount == 0 && archived > 0 {
+		// every subtopic has been archived
+		handleError(ctx, w, apierrors.ErrNotFound, logdata)
+		return
+	}
 	if result.TotalCount == 0 {
 		handleError(ctx, w, apierrors.ErrInternalServer, logdata)
 		return
 	}
 
+	setLastModified(w, lastUpdated...)
 	if err := WriteJSONBody(ctx, result, w, logdata); err != nil {
 		// WriteJSONBody has already logged the error
 		return
@@ -118,6 +160,19 @@ func (api *API) getSubtopicsPrivateByID(ctx context.Context, id string, logdata
 	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
 }
 
+// topicLastUpdated returns the last updated times of the current and next documents of a topic
+func topicLastUpdated(topic *models.TopicResponse) []*time.Time {
+	var lastUpdated []*time.Time
+	if topic.Current != nil {
+		lastUpdated = append(lastUpdated, topic.Current.LastUpdated)
+	}
+	if topic.Next != nil {
+		lastUpdated = append(lastUpdated, topic.Next.LastUpdated)
+	}
+
+	return lastUpdated
+}
+
 func (api *API) putTopicReleaseDatePrivateHandler(w http.ResponseWriter, req *http.Request) {
 	ctx := req.Context()
 	vars := mux.Vars(req)
@@ -145,6 +200,8 @@ func (api *API) putTopicReleaseDatePrivateHandler(w http.ResponseWriter, req *ht
 		return
 	}
 
+	api.notifier.Notify(ctx, models.WebhookEventTopicUpdated, id, "")
+
 	w.WriteHeader(http.StatusOK)
 
 	log.Info(ctx, "request successful", logdata)
@@ -161,18 +218,69 @@ func (api *API) putTopicStatePrivateHandler(w http.ResponseWriter, req *http.Req
 		"function": "putTopicStatePrivateHandler",
 	}
 
-	_, err := models.ParseState(state)
+	target, err := models.ParseState(state)
 	if err != nil {
 		handleError(ctx, w, err, logdata)
 		return
 	}
 
-	if state == models.StatePublished.String() {
+	topic, err := api.dataStore.Backend.GetTopic(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	if topic.Next == nil {
+		topic.Next = &models.Topic{}
+	}
+
+	if err := models.CheckTransition(topic.Next.State, state, api.requireReview); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	switch {
+	case target == models.StatePublished:
 		log.Info(ctx, "attempting to publish topic", logdata)
 		if err := api.publishTopic(ctx, id); err != nil {
 			handleError(ctx, w, err, logdata)
+			return
+		}
+	case target == models.StateInReview:
+		submittedAt := time.Now().UTC()
+		review := &models.Review{SubmittedBy: callerIdentity(ctx), SubmittedAt: &submittedAt}
+		logdata["submitted_by"] = review.SubmittedBy
+		if err := api.dataStore.Backend.UpdateReview(ctx, id, state, review); err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+	case target.IsReviewDecision():
+		review, err := reviewTopic(ctx, req, topic.Next, target)
+		if err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+		logdata["reviewed_by"] = review.ReviewedBy
+		if err := api.dataStore.Backend.UpdateReview(ctx, id, state, review); err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+	case target == models.StateArchived:
+		archive, err := api.readArchive(ctx, req, id)
+		if err != nil {
+			handleError(ctx, w, err, logdata)
+			return
 		}
-	} else {
+		logdata["successor_id"] = archive.SuccessorID
+		if err := api.archiveTopic(ctx, topic, archive.SuccessorID); err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+	case topic.Next.IsArchived():
+		if err := api.restoreTopic(ctx, topic, state); err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+	default:
 		// update topic next.state in mongo db
 		if err := api.dataStore.Backend.UpdateState(ctx, id, state); err != nil {
 			handleError(ctx, w, err, logdata)
@@ -180,6 +288,12 @@ func (api *API) putTopicStatePrivateHandler(w http.ResponseWriter, req *http.Req
 		}
 	}
 
+	api.notifier.Notify(ctx, models.WebhookEventTopicStateChanged, id, state)
+	// archiving replaces the current version, so it is published to web as a publication would be
+	if target == models.StatePublished || target == models.StateArchived {
+		api.notifier.Notify(ctx, models.WebhookEventTopicPublished, id, state)
+	}
+
 	w.WriteHeader(http.StatusOK)
 
 	log.Info(ctx, "request successful", logdata)
@@ -200,15 +314,36 @@ func (api *API) putTopicPrivateHandler(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	if err := topicUpdate.ValidateUpdate(); err != nil {
+	// collect every violation, including those that need the store, so they are all reported together
+	violations := topicUpdate.Violations(id)
+	if err := topicUpdate.CheckReferences(ctx, api.dataStore.Backend, id, violations); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	if err := violations.ErrorOrNil(); err != nil {
 		handleError(ctx, w, err, logdata)
 		return
 	}
 
-	if err := api.dataStore.Backend.CheckTopicExists(ctx, id); err != nil {
+	// making a topic a subtopic gives the owners of its new parent rights over it, so the caller must be able to edit
+	// every subtopic as well as the topic
+	if api.entityParser != nil && topicUpdate.SubtopicIds != nil {
+		if err := api.checkTopicEditor(ctx, api.callerGroups(ctx, req), *topicUpdate.SubtopicIds...); err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+	}
+
+	existing, err := api.dataStore.Backend.GetTopic(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	if err := checkUpdateTransition(existing, topicUpdate, api.requireReview); err != nil {
 		handleError(ctx, w, err, logdata)
 		return
 	}
+	topicUpdate.LastEditedBy = callerIdentity(ctx)
 
 	// update topic in mongo db
 	if err := api.dataStore.Backend.UpdateTopic(ctx, api.topicAPIURL, id, topicUpdate); err != nil {
@@ -216,11 +351,15 @@ func (api *API) putTopicPrivateHandler(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
+	api.notifier.Notify(ctx, models.WebhookEventTopicUpdated, id, topicUpdate.State)
+
 	if topicUpdate.State == models.StatePublished.String() {
 		log.Info(ctx, "attempting to publish topic", logdata)
 		if err := api.publishTopic(ctx, id); err != nil {
 			handleError(ctx, w, err, logdata)
+			return
 		}
+		api.notifier.Notify(ctx, models.WebhookEventTopicPublished, id, topicUpdate.State)
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -228,7 +367,17 @@ func (api *API) putTopicPrivateHandler(w http.ResponseWriter, req *http.Request)
 	log.Info(ctx, "request successful", logdata)
 }
 
-func (api *API) publishTopic(ctx context.Context, id string) error {
+// PublishTopic publishes the next version of the topic with the id in the store given, as the API does, recording its
+// publication and the redirects of any paths it moves, for tools that maintain the database outside of the service,
+// such as topicctl. Subscribers to webhooks are not notified, as they are only known to the running service.
+func PublishTopic(ctx context.Context, backend store.Storer, id string) error {
+	api := &API{dataStore: store.DataStore{Backend: backend}}
+	return api.publishTopic(ctx, id)
+}
+
+func (api *API) publishTopic(ctx context.Context, id string) (err error) {
+	defer func() { metrics.ObservePublish(err) }()
+
 	// TODO - should lock resource, put this in a mongo db transaction or use eTags to
 	// check if the resource has changed since initial request - as it is not a public
 	// endpoint and is not currently used by the publishing system we can ignore this for
@@ -246,16 +395,93 @@ func (api *API) publishTopic(ctx context.Context, id string) error {
 	// update local copy of topic
 	newTopic := syncNextAndCurrentTopic(topic)
 
+	// get the paths of the published topics while the old ones are still published, if they move
+	paths := api.getPublishedPaths(ctx, slugChanges(topic.Current, newTopic.Current))
+
 	// update topic in mongo db
 	err = api.dataStore.Backend.UpsertTopic(ctx, id, newTopic)
 	if err != nil {
 		return err
 	}
 
+	api.recordPublication(ctx, id, newTopic.Current)
+
+	// the topic has already been published, so a failure to redirect its old paths is logged rather than returned
+	if err := api.recordRedirects(ctx, paths); err != nil {
+		log.Error(ctx, "failed to record redirects of topic", err, log.Data{"topic_id": id})
+	}
+
 	return nil
 }
 
+// recordPublication records that a topic has been published, with the content it has gained since it was last
+// published (or all of its content, the first time), for the feeds of recently published topics and content.
+// The topic has already been published, so failures are logged rather than returned.
+func (api *API) recordPublication(ctx context.Context, id string, topic *models.Topic) {
+	logdata := log.Data{"topic_id": id}
+
+	hrefs := []string{}
+	var content []models.PublishedContent
+	stored, err := api.dataStore.Backend.GetContent(ctx, id, queryAllFlags)
+	switch {
+	case err == nil && stored.Current != nil:
+		if items := getRequiredItems(queryAllFlags, stored.Current, id).Items; items != nil {
+			for _, item := range *items {
+				hrefs = append(hrefs, item.Links.Self.HRef)
+				content = append(content, models.PublishedContent{Type: item.Type, Title: item.Title, HRef: item.Links.Self.HRef})
+			}
+		}
+	case err != nil && !errors.Is(err, apierrors.ErrContentNotFound):
+		log.Error(ctx, "failed to get content to record publication", err, logdata)
+		return
+	}
+
+	previous, err := api.dataStore.Backend.GetPublications(ctx, id, 1)
+	if err != nil {
+		log.Error(ctx, "failed to get previous publication", err, logdata)
+		return
+	}
+
+	publishedAt := time.Now().UTC()
+	publication := &models.Publication{
+		TopicID:      id,
+		Title:        topic.Title,
+		Description:  topic.Description,
+		PublishedAt:  &publishedAt,
+		AddedContent: content,
+		ContentHRefs: hrefs,
+	}
+	if len(previous) > 0 {
+		publication.AddedContent = addedContent(content, previous[0].ContentHRefs)
+	}
+
+	if err := api.dataStore.Backend.InsertPublication(ctx, publication); err != nil {
+		log.Error(ctx, "failed to record publication", err, logdata)
+	}
+}
+
+// addedContent returns the content that does not have one of the previous links
+func addedContent(content []models.PublishedContent, previousHRefs []string) []models.PublishedContent {
+	previous := make(map[string]bool, len(previousHRefs))
+	for _, href := range previousHRefs {
+		previous[href] = true
+	}
+
+	var added []models.PublishedContent
+	for _, item := range content {
+		if !previous[item.HRef] {
+			added = append(added, item)
+		}
+	}
+	return added
+}
+
+// syncNextAndCurrentTopic returns the topic with its next version published as its current version. The editorial
+// records of the next version are not published, and are cleared so that its next edit is reviewed afresh.
 func syncNextAndCurrentTopic(topic *models.TopicResponse) *models.TopicResponse {
+	topic.Next.LastEditedBy = ""
+	topic.Next.Review = nil
+
 	return &models.TopicResponse{
 		ID:      topic.ID,
 		Next:    topic.Next,
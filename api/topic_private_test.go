@@ -142,7 +142,7 @@ func TestGetSubtopicsPrivateHandler(t *testing.T) {
 					So(w.Code, ShouldEqual, http.StatusInternalServerError)
 					payload, err := io.ReadAll(w.Body)
 					So(err, ShouldBeNil)
-					So(payload, ShouldResemble, []byte("internal error\n"))
+					So(payload, ShouldResemble, problemPayload(http.StatusInternalServerError, apierrors.ErrInternalServer))
 				})
 			})
 
@@ -157,7 +157,7 @@ func TestGetSubtopicsPrivateHandler(t *testing.T) {
 					So(w.Code, ShouldEqual, http.StatusNotFound)
 					payload, err := io.ReadAll(w.Body)
 					So(err, ShouldBeNil)
-					So(payload, ShouldResemble, []byte("not found\n"))
+					So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrNotFound))
 				})
 			})
 
@@ -291,6 +291,15 @@ func TestPutTopicsPrivateHandler(t *testing.T) {
 				UpsertTopicFunc: func(context.Context, string, *models.TopicResponse) error {
 					return nil
 				},
+				GetContentFunc: func(context.Context, string, int) (*models.ContentResponse, error) {
+					return nil, apierrors.ErrContentNotFound
+				},
+				GetPublicationsFunc: func(context.Context, string, int) ([]models.Publication, error) {
+					return nil, nil
+				},
+				InsertPublicationFunc: func(context.Context, *models.Publication) error {
+					return nil
+				},
 			}
 
 			topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
@@ -347,11 +356,80 @@ func TestPutTopicsPrivateHandler(t *testing.T) {
 			})
 		})
 
+		Convey("And a topic API with mongoDB where the slug is in use and a subtopic does not exist", func() {
+			mongoDBMock := &storeMock.MongoDBMock{
+				CheckTopicExistsFunc: func(ctx context.Context, id string) error {
+					if id == "404" {
+						return apierrors.ErrTopicNotFound
+					}
+					return nil
+				},
+				IsSlugInUseFunc: func(ctx context.Context, id, slug string) (bool, error) {
+					return true, nil
+				},
+				UpdateTopicFunc: func(context.Context, string, string, *models.TopicUpdate) error {
+					return nil
+				},
+			}
+
+			topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+			Convey("When an update is requested with invalid fields", func() {
+				topicUpdateInvalidPayload := `{ "title": "", "description": "New Description", "slug": "economy", "subtopics_ids": ["3", "404", "2"], "keywords": ["keyword_1"], "state": "published", "release_date": "2022-10-10T08:30:00Z"}`
+
+				request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/2", bytes.NewBufferString(topicUpdateInvalidPayload))
+				So(err, ShouldBeNil)
+
+				w := httptest.NewRecorder()
+				topicAPI.Router.ServeHTTP(w, request)
+
+				Convey("Then the response should be a 400 detailing every violation and the topic should not be updated", func() {
+					So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+					var problem models.Problem
+					So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+					So(problem.Code, ShouldEqual, apierrors.CodeTopicMissingFields)
+					So(problem.Errors, ShouldResemble, []apierrors.FieldError{
+						{Field: "title", Message: "must not be empty"},
+						{Field: "subtopics_ids[2]", Message: "must not reference the topic itself"},
+						{Field: "slug", Message: "must not be used by another topic"},
+						{Field: "subtopics_ids[1]", Message: "must be the id of an existing topic"},
+					})
+
+					So(mongoDBMock.IsSlugInUseCalls(), ShouldHaveLength, 1)
+					So(mongoDBMock.CheckTopicExistsCalls(), ShouldHaveLength, 2)
+					So(mongoDBMock.UpdateTopicCalls(), ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("When an update is requested with only a slug that is in use", func() {
+				topicUpdatePayload := `{ "title": "New Title", "description": "New Description", "slug": "economy", "state": "published", "release_date": "2022-10-10T08:30:00Z"}`
+
+				request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/2", bytes.NewBufferString(topicUpdatePayload))
+				So(err, ShouldBeNil)
+
+				w := httptest.NewRecorder()
+				topicAPI.Router.ServeHTTP(w, request)
+
+				Convey("Then the response should be a 400 with the invalid fields code", func() {
+					So(w.Code, ShouldEqual, http.StatusBadRequest)
+
+					var problem models.Problem
+					So(json.Unmarshal(w.Body.Bytes(), &problem), ShouldBeNil)
+					So(problem.Code, ShouldEqual, apierrors.CodeTopicInvalidFields)
+					So(mongoDBMock.UpdateTopicCalls(), ShouldHaveLength, 0)
+				})
+			})
+		})
+
 		Convey("And a topic API which can't find topics", func() {
 			mongoDBMock := &storeMock.MongoDBMock{
 				CheckTopicExistsFunc: func(ctx context.Context, id string) error {
 					return apierrors.ErrTopicNotFound
 				},
+				GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+					return nil, apierrors.ErrTopicNotFound
+				},
 				UpdateTopicFunc: func(context.Context, string, string, *models.TopicUpdate) error {
 					return nil
 				},
@@ -3,9 +3,11 @@ package api
 import (
 	"context"
 	"net/http"
+	"time"
 
 	dprequest "github.com/ONSdigital/dp-net/v3/request"
 	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/metrics"
 	"github.com/ONSdigital/dp-topic-api/models"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
@@ -22,9 +24,16 @@ func (api *API) getRootTopicsPublicHandler(w http.ResponseWriter, req *http.Requ
 		"function":   "getTopicsListPublicHandler",
 	}
 
+	include, err := includeArchived(req)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["include_archived"] = include
+
 	// The mongo document with id: `topic_root` contains the list of subtopics,
 	// so we directly return that list
-	api.getSubtopicsPublicByID(ctx, topicRoot, logdata, w)
+	api.getSubtopicsPublicByID(ctx, topicRoot, api.rootTopicsCacheMaxAge, false, include, logdata, w)
 }
 
 // getTopicPublicHandler is a handler that gets a topic by its id from MongoDB for Web
@@ -43,15 +52,38 @@ func (api *API) getTopicPublicHandler(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
+	preview, err := api.isPreview(req, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["preview"] = preview
+
+	include, err := includeArchived(req)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["include_archived"] = include
+
 	// get topic from mongoDB by id
-	topic, err := api.dataStore.Backend.GetTopic(ctx, id)
+	topic, err := api.publicStore(preview).GetTopic(ctx, id)
 	if err != nil {
 		handleError(ctx, w, err, logdata)
 		return
 	}
 
-	// User is not authenticated and hence has only access to current sub document
-	if err := WriteJSONBody(ctx, topic.Current, w, logdata); err != nil {
+	// User is not authenticated and hence has only access to current sub document, unless previewing the next one
+	view := publicView(topic, preview)
+	if view != nil {
+		setLastModified(w, view.LastUpdated)
+	}
+	setPublicCacheControl(w, preview, api.topicCacheMaxAge)
+	if view != nil && view.IsArchived() && !include {
+		api.writeArchived(ctx, w, view, logdata)
+		return
+	}
+	if err := WriteJSONBody(ctx, view, w, logdata); err != nil {
 		// WriteJSONBody has already logged the error
 		return
 	}
@@ -74,45 +106,76 @@ func (api *API) getSubtopicsPublicHandler(w http.ResponseWriter, req *http.Reque
 		return
 	}
 
-	api.getSubtopicsPublicByID(ctx, id, logdata, w)
+	preview, err := api.isPreview(req, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["preview"] = preview
+
+	include, err := includeArchived(req)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	logdata["include_archived"] = include
+
+	api.getSubtopicsPublicByID(ctx, id, api.subtopicsCacheMaxAge, preview, include, logdata, w)
 }
 
-func (api *API) getSubtopicsPublicByID(ctx context.Context, id string, logdata log.Data, w http.ResponseWriter) {
+// getSubtopicsPublicByID writes the subtopics of the topic with the id, in their current views or, when previewing, in
+// their next views, leaving out those that are archived unless they are included
+func (api *API) getSubtopicsPublicByID(ctx context.Context, id, cacheMaxAge string, preview, include bool, logdata log.Data, w http.ResponseWriter) {
+	dataStore := api.publicStore(preview)
+
 	// get topic from mongoDB by id
-	topic, err := api.dataStore.Backend.GetTopic(ctx, id)
+	topic, err := dataStore.GetTopic(ctx, id)
 	if err != nil {
 		// no topic found to retrieve the subtopics from
 		handleError(ctx, w, err, logdata)
 		return
 	}
 
-	// User is not authenticated and hence has only access to current sub document(s)
+	// User is not authenticated and hence has only access to current sub document(s), unless previewing the next ones
 	var result models.PublicSubtopics
 
-	if topic.Current == nil {
+	view := publicView(topic, preview)
+	if view == nil {
 		handleError(ctx, w, apierrors.ErrContentNotFound, logdata)
 		return
 	}
 
-	if topic.Current.SubtopicIds == nil || len(*topic.Current.SubtopicIds) == 0 {
+	if view.SubtopicIds == nil || len(*view.SubtopicIds) == 0 {
 		// no subtopics exist for the requested ID
 		handleError(ctx, w, apierrors.ErrNotFound, logdata)
 		return
 	}
 
-	for _, subTopicID := range *topic.Current.SubtopicIds {
+	metrics.SubtopicsFanOut.Observe(float64(len(*view.SubtopicIds)))
+	lastUpdated := []*time.Time{view.LastUpdated}
+	for _, subTopicID := range *view.SubtopicIds {
 		// get sub topic from mongoDB by subTopicID
-		topic, err := api.dataStore.Backend.GetTopic(ctx, subTopicID)
+		topic, err := dataStore.GetTopic(ctx, subTopicID)
 		if err != nil {
 			logdata["missing subtopic for id"] = subTopicID
 			log.Error(ctx, "missing subtopic for id", err, logdata)
 			continue
 		}
+		subtopic := publicView(topic, preview)
+		if subtopic == nil {
+			logdata["missing subtopic for id"] = subTopicID
+			log.Error(ctx, "missing subtopic for id", apierrors.ErrContentNotFound, logdata)
+			continue
+		}
+		if !include && subtopic.IsArchived() {
+			continue
+		}
+		lastUpdated = append(lastUpdated, subtopic.LastUpdated)
 
 		if result.PublicItems == nil {
-			result.PublicItems = &[]models.Topic{*topic.Current}
+			result.PublicItems = &[]models.Topic{*subtopic}
 		} else {
-			*result.PublicItems = append(*result.PublicItems, *topic.Current)
+			*result.PublicItems = append(*result.PublicItems, *subtopic)
 		}
 
 		result.TotalCount++
@@ -122,6 +185,8 @@ func (api *API) getSubtopicsPublicByID(ctx context.Context, id string, logdata l
 		return
 	}
 
+	setLastModified(w, lastUpdated...)
+	setPublicCacheControl(w, preview, cacheMaxAge)
 	if err := WriteJSONBody(ctx, result, w, logdata); err != nil {
 		// WriteJSONBody has already logged the error
 		return
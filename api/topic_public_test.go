@@ -137,7 +137,7 @@ func TestGetSubtopicsPublicHandler(t *testing.T) {
 					So(w.Code, ShouldEqual, http.StatusNotFound)
 					payload, err := io.ReadAll(w.Body)
 					So(err, ShouldBeNil)
-					So(payload, ShouldResemble, []byte("content not found\n"))
+					So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrContentNotFound))
 				})
 			})
 
@@ -151,7 +151,7 @@ func TestGetSubtopicsPublicHandler(t *testing.T) {
 					So(w.Code, ShouldEqual, http.StatusNotFound)
 					payload, err := io.ReadAll(w.Body)
 					So(err, ShouldBeNil)
-					So(payload, ShouldResemble, []byte("not found\n"))
+					So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrNotFound))
 				})
 			})
 
@@ -172,7 +172,7 @@ func TestGetSubtopicsPublicHandler(t *testing.T) {
 					So(w.Code, ShouldEqual, http.StatusNotFound)
 					payload, err := io.ReadAll(w.Body)
 					So(err, ShouldBeNil)
-					So(payload, ShouldResemble, []byte("topic not found\n"))
+					So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrTopicNotFound))
 				})
 			})
 		})
@@ -231,7 +231,7 @@ func TestGetTopicsListPublicHandler(t *testing.T) {
 					So(w.Code, ShouldEqual, http.StatusNotFound)
 					payload, err := io.ReadAll(w.Body)
 					So(err, ShouldBeNil)
-					So(payload, ShouldResemble, []byte("topic not found\n"))
+					So(payload, ShouldResemble, problemPayload(http.StatusNotFound, apierrors.ErrTopicNotFound))
 				})
 			})
 
@@ -249,5 +249,5 @@ func GetAPIWithMocks(cfg *config.Config, mockedDataStore store.Storer) *API {
 
 	permissions := mocks.NewAuthHandlerMock()
 
-	return Setup(testContext, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, permissions, testTopicAPIURL)
+	return Setup(testContext, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, permissions, nil, nil, nil, testTopicAPIURL)
 }
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/dp-topic-api/webhook"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultDeliveriesLimit = 20
+	maxDeliveriesLimit     = 1000
+)
+
+// postWebhookHandler is a handler that registers a new webhook subscription for Publishing
+func (api *API) postWebhookHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"function":   "postWebhookHandler",
+	}
+
+	webhookReq, err := models.ReadWebhook(req.Body)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	if err := webhookReq.Validate(); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	webhookReq.ID = id.String()
+	logdata["webhook_id"] = webhookReq.ID
+
+	if webhookReq.Secret == "" {
+		if webhookReq.Secret, err = webhook.NewSecret(); err != nil {
+			handleError(ctx, w, err, logdata)
+			return
+		}
+	}
+
+	createdAt := time.Now().UTC()
+	webhookReq.CreatedAt = &createdAt
+
+	if err := api.dataStore.Backend.CreateWebhook(ctx, webhookReq); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	// The secret is only returned once, so the subscriber can verify delivery signatures
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusCreated)
+	if err := WriteJSONBody(ctx, webhookReq, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// getWebhooksHandler is a handler that gets all webhook subscriptions for Publishing
+func (api *API) getWebhooksHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"function":   "getWebhooksHandler",
+	}
+
+	webhooks, err := api.dataStore.Backend.GetWebhooks(ctx)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	result := models.Webhooks{
+		TotalCount: len(webhooks),
+		Items:      make([]models.Webhook, 0, len(webhooks)),
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+		result.Items = append(result.Items, webhooks[i])
+	}
+
+	if err := WriteJSONBody(ctx, result, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// getWebhookHandler is a handler that gets a webhook subscription by its id for Publishing
+func (api *API) getWebhookHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	id := vars["id"]
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"webhook_id": id,
+		"function":   "getWebhookHandler",
+	}
+
+	webhookResp, err := api.dataStore.Backend.GetWebhook(ctx, id)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+	webhookResp.Secret = ""
+
+	if err := WriteJSONBody(ctx, webhookResp, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
+
+// deleteWebhookHandler is a handler that removes a webhook subscription by its id for Publishing
+func (api *API) deleteWebhookHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	id := vars["id"]
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"webhook_id": id,
+		"function":   "deleteWebhookHandler",
+	}
+
+	if err := api.dataStore.Backend.DeleteWebhook(ctx, id); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	log.Info(ctx, "request successful", logdata)
+}
+
+// getWebhookDeliveriesHandler is a handler that gets the most recent deliveries of a webhook subscription for Publishing
+func (api *API) getWebhookDeliveriesHandler(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	id := vars["id"]
+	logdata := log.Data{
+		"request_id": ctx.Value(dprequest.RequestIdKey),
+		"webhook_id": id,
+		"function":   "getWebhookDeliveriesHandler",
+	}
+
+	limit := defaultDeliveriesLimit
+	if limitStr := req.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxDeliveriesLimit {
+			handleError(ctx, w, apierrors.ErrInvalidLimit, logdata)
+			return
+		}
+	}
+
+	if _, err := api.dataStore.Backend.GetWebhook(ctx, id); err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	deliveries, err := api.dataStore.Backend.GetWebhookDeliveries(ctx, id, limit)
+	if err != nil {
+		handleError(ctx, w, err, logdata)
+		return
+	}
+
+	result := models.WebhookDeliveries{
+		TotalCount: len(deliveries),
+		Items:      deliveries,
+	}
+	if result.Items == nil {
+		result.Items = []models.WebhookDelivery{}
+	}
+
+	if err := WriteJSONBody(ctx, result, w, logdata); err != nil {
+		// WriteJSONBody has already logged the error
+		return
+	}
+	log.Info(ctx, "request successful", logdata) // NOTE: name of function is in logdata
+}
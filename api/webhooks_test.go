@@ -0,0 +1,261 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/mocks"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/dp-topic-api/store"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+	"github.com/gorilla/mux"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type notification struct {
+	eventType string
+	topicID   string
+	state     string
+}
+
+type notifierStub struct {
+	mu            sync.Mutex
+	notifications []notification
+}
+
+func (n *notifierStub) Notify(_ context.Context, eventType, topicID, state string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.notifications = append(n.notifications, notification{eventType, topicID, state})
+}
+
+func getAPIWithNotifier(cfg *config.Config, mockedDataStore store.Storer, notifier Notifier) *API {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return Setup(testContext, cfg, mux.NewRouter(), store.DataStore{Backend: mockedDataStore}, mocks.NewAuthHandlerMock(), nil, notifier, nil, testTopicAPIURL)
+}
+
+func TestPostWebhookHandler(t *testing.T) {
+	Convey("Given a topic API in publishing mode (private endpoints enabled)", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+
+		mongoDBMock := &storeMock.MongoDBMock{
+			CreateWebhookFunc: func(ctx context.Context, webhook *models.Webhook) error { return nil },
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		Convey("When a valid webhook subscription is posted without a secret", func() {
+			request, err := createRequestWithAuth(http.MethodPost, "http://localhost:25300/webhooks",
+				bytes.NewBufferString(`{"url":"https://example.com/hook","events":["topic.published"]}`))
+			So(err, ShouldBeNil)
+
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then it is stored with a generated id and secret, which are returned with status code 201", func() {
+				So(w.Code, ShouldEqual, http.StatusCreated)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "application/json; charset=utf-8")
+				So(mongoDBMock.CreateWebhookCalls(), ShouldHaveLength, 1)
+
+				var webhook models.Webhook
+				So(json.Unmarshal(w.Body.Bytes(), &webhook), ShouldBeNil)
+				So(webhook.ID, ShouldNotBeEmpty)
+				So(webhook.Secret, ShouldNotBeEmpty)
+				So(webhook.CreatedAt, ShouldNotBeNil)
+				So(mongoDBMock.CreateWebhookCalls()[0].Webhook.Secret, ShouldEqual, webhook.Secret)
+			})
+		})
+
+		Convey("When a webhook subscription with an unknown event is posted", func() {
+			request, err := createRequestWithAuth(http.MethodPost, "http://localhost:25300/webhooks",
+				bytes.NewBufferString(`{"url":"https://example.com/hook","events":["topic.coffee"]}`))
+			So(err, ShouldBeNil)
+
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then the response is a 400 and nothing is stored", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+				So(w.Body.String(), ShouldContainSubstring, apierrors.ErrWebhookInvalidEvent.Error())
+				So(mongoDBMock.CreateWebhookCalls(), ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+func TestGetWebhooksHandler(t *testing.T) {
+	Convey("Given a topic API in publishing mode with a stored webhook", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetWebhooksFunc: func(ctx context.Context) ([]models.Webhook, error) {
+				return []models.Webhook{{ID: "hook1", URL: "https://example.com", Events: []string{models.WebhookEventTopicUpdated}, Secret: "s3cret"}}, nil
+			},
+			GetWebhookFunc: func(ctx context.Context, id string) (*models.Webhook, error) {
+				if id == "hook1" {
+					return &models.Webhook{ID: "hook1", URL: "https://example.com", Secret: "s3cret"}, nil
+				}
+				return nil, apierrors.ErrWebhookNotFound
+			},
+			GetWebhookDeliveriesFunc: func(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+				return []models.WebhookDelivery{{ID: "delivery1", WebhookID: webhookID, Status: models.WebhookDeliveryFailed}}, nil
+			},
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		Convey("When the list of webhooks is requested", func() {
+			request, err := createRequestWithAuth(http.MethodGet, "http://localhost:25300/webhooks", http.NoBody)
+			So(err, ShouldBeNil)
+
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then the webhooks are returned without their secrets", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				var webhooks models.Webhooks
+				So(json.Unmarshal(w.Body.Bytes(), &webhooks), ShouldBeNil)
+				So(webhooks.TotalCount, ShouldEqual, 1)
+				So(webhooks.Items[0].ID, ShouldEqual, "hook1")
+				So(webhooks.Items[0].Secret, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a nonexistent webhook is requested", func() {
+			request, err := createRequestWithAuth(http.MethodGet, "http://localhost:25300/webhooks/inexistent", http.NoBody)
+			So(err, ShouldBeNil)
+
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("When the delivery log of a webhook is requested", func() {
+			request, err := createRequestWithAuth(http.MethodGet, "http://localhost:25300/webhooks/hook1/deliveries?limit=5", http.NoBody)
+			So(err, ShouldBeNil)
+
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then the deliveries are returned using the requested limit", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(mongoDBMock.GetWebhookDeliveriesCalls()[0].Limit, ShouldEqual, 5)
+				var deliveries models.WebhookDeliveries
+				So(json.Unmarshal(w.Body.Bytes(), &deliveries), ShouldBeNil)
+				So(deliveries.TotalCount, ShouldEqual, 1)
+				So(deliveries.Items[0].Status, ShouldEqual, models.WebhookDeliveryFailed)
+			})
+		})
+
+		Convey("When the delivery log is requested with an invalid limit", func() {
+			request, err := createRequestWithAuth(http.MethodGet, "http://localhost:25300/webhooks/hook1/deliveries?limit=-1", http.NoBody)
+			So(err, ShouldBeNil)
+
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			So(w.Code, ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+func TestDeleteWebhookHandler(t *testing.T) {
+	Convey("Given a topic API in publishing mode", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+
+		mongoDBMock := &storeMock.MongoDBMock{
+			DeleteWebhookFunc: func(ctx context.Context, id string) error {
+				if id == "hook1" {
+					return nil
+				}
+				return apierrors.ErrWebhookNotFound
+			},
+		}
+		topicAPI := GetAPIWithMocks(cfg, mongoDBMock)
+
+		Convey("When an existing webhook is deleted, then the response is a 204", func() {
+			request, err := createRequestWithAuth(http.MethodDelete, "http://localhost:25300/webhooks/hook1", http.NoBody)
+			So(err, ShouldBeNil)
+
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			So(w.Code, ShouldEqual, http.StatusNoContent)
+		})
+
+		Convey("When a nonexistent webhook is deleted, then the response is a 404", func() {
+			request, err := createRequestWithAuth(http.MethodDelete, "http://localhost:25300/webhooks/inexistent", http.NoBody)
+			So(err, ShouldBeNil)
+
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func TestTopicLifecycleNotifications(t *testing.T) {
+	Convey("Given a topic API in publishing mode with a webhook notifier", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		cfg.EnablePrivateEndpoints = true
+
+		mongoDBMock := &storeMock.MongoDBMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+				return dbTopic(models.StateCompleted), nil
+			},
+			UpdateStateFunc: func(ctx context.Context, id, state string) error { return nil },
+			UpsertTopicFunc: func(ctx context.Context, id string, topic *models.TopicResponse) error { return nil },
+			GetContentFunc: func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+				return nil, apierrors.ErrContentNotFound
+			},
+			GetPublicationsFunc:   func(ctx context.Context, topicID string, limit int) ([]models.Publication, error) { return nil, nil },
+			InsertPublicationFunc: func(ctx context.Context, publication *models.Publication) error { return nil },
+		}
+		notifier := &notifierStub{}
+		topicAPI := getAPIWithNotifier(cfg, mongoDBMock, notifier)
+
+		Convey("When a topic state is changed to completed", func() {
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/"+testTopicID1+"/state/completed", http.NoBody)
+			So(err, ShouldBeNil)
+
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then a state changed event is notified", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(notifier.notifications, ShouldResemble, []notification{
+					{models.WebhookEventTopicStateChanged, testTopicID1, "completed"},
+				})
+			})
+		})
+
+		Convey("When a topic is published", func() {
+			request, err := createRequestWithAuth(http.MethodPut, "http://localhost:25300/topics/"+testTopicID1+"/state/published", http.NoBody)
+			So(err, ShouldBeNil)
+
+			w := httptest.NewRecorder()
+			topicAPI.Router.ServeHTTP(w, request)
+
+			Convey("Then both state changed and published events are notified", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(notifier.notifications, ShouldResemble, []notification{
+					{models.WebhookEventTopicStateChanged, testTopicID1, "published"},
+					{models.WebhookEventTopicPublished, testTopicID1, "published"},
+				})
+			})
+		})
+	})
+}
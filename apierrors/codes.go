@@ -0,0 +1,134 @@
+package apierrors
+
+import (
+	"errors"
+)
+
+// A list of the stable, machine readable codes returned in error responses, one for each error
+const (
+	CodeCollectionEmpty                = "collection_empty"
+	CodeCollectionInvalidFields        = "invalid_collection_fields"
+	CodeCollectionNotFound             = "collection_not_found"
+	CodeCollectionPublished            = "collection_published"
+	CodeContentNotFound                = "content_not_found"
+	CodeContentUnrecognisedParameter   = "content_query_not_recognised"
+	CodeEmptyRequestBody               = "empty_request_body"
+	CodeInternalServer                 = "internal_error"
+	CodeInvalidIncludeArchived         = "invalid_include_archived"
+	CodeInvalidImportStrategy          = "invalid_import_strategy"
+	CodeInvalidLimit                   = "invalid_limit"
+	CodeInvalidPreviewToken            = "invalid_preview_token"
+	CodeInvalidPartial                 = "invalid_partial"
+	CodeInvalidReleaseDate             = "invalid_release_date"
+	CodeInvalidConceptSchemeFormat     = "invalid_concept_scheme_format"
+	CodeInvalidTaxonomyFormat          = "invalid_taxonomy_format"
+	CodeInvalidTaxonomyVersion         = "invalid_taxonomy_version"
+	CodeInvalidValidateOnly            = "invalid_validate_only"
+	CodeNotFound                       = "not_found"
+	CodeRedirectNotFound               = "redirect_not_found"
+	CodeTopicInCollection              = "topic_in_collection"
+	CodeTopicArchived                  = "topic_archived"
+	CodeTopicForbidden                 = "topic_forbidden"
+	CodeTopicInvalidFields             = "invalid_fields"
+	CodeTopicMissingFields             = "missing_fields"
+	CodeTopicInvalidState              = "invalid_state"
+	CodeTopicNotFound                  = "topic_not_found"
+	CodeTopicSelfReview                = "self_review"
+	CodeTopicStateTransitionNotAllowed = "state_transition_not_allowed"
+	CodeTopicUploadEmpty               = "topic_upload_empty"
+	CodeUnableToParseJSON              = "invalid_json"
+	CodeUnableToReadMessage            = "unreadable_body"
+	CodeWebhookInvalidEvent            = "invalid_webhook_event"
+	CodeWebhookInvalidURL              = "invalid_webhook_url"
+	CodeWebhookNotFound                = "webhook_not_found"
+)
+
+var codes = map[error]string{
+	ErrCollectionEmpty:                CodeCollectionEmpty,
+	ErrCollectionInvalidFields:        CodeCollectionInvalidFields,
+	ErrCollectionNotFound:             CodeCollectionNotFound,
+	ErrCollectionPublished:            CodeCollectionPublished,
+	ErrContentNotFound:                CodeContentNotFound,
+	ErrContentUnrecognisedParameter:   CodeContentUnrecognisedParameter,
+	ErrEmptyRequestBody:               CodeEmptyRequestBody,
+	ErrInternalServer:                 CodeInternalServer,
+	ErrInvalidConceptSchemeFormat:     CodeInvalidConceptSchemeFormat,
+	ErrInvalidIncludeArchived:         CodeInvalidIncludeArchived,
+	ErrInvalidImportStrategy:          CodeInvalidImportStrategy,
+	ErrInvalidLimit:                   CodeInvalidLimit,
+	ErrInvalidPartial:                 CodeInvalidPartial,
+	ErrInvalidPreviewToken:            CodeInvalidPreviewToken,
+	ErrInvalidReleaseDate:             CodeInvalidReleaseDate,
+	ErrInvalidTaxonomyFormat:          CodeInvalidTaxonomyFormat,
+	ErrInvalidTaxonomyVersion:         CodeInvalidTaxonomyVersion,
+	ErrInvalidValidateOnly:            CodeInvalidValidateOnly,
+	ErrNotFound:                       CodeNotFound,
+	ErrRedirectNotFound:               CodeRedirectNotFound,
+	ErrTopicInCollection:              CodeTopicInCollection,
+	ErrTopicArchived:                  CodeTopicArchived,
+	ErrTopicForbidden:                 CodeTopicForbidden,
+	ErrTopicInvalidFields:             CodeTopicInvalidFields,
+	ErrTopicMissingFields:             CodeTopicMissingFields,
+	ErrTopicInvalidState:              CodeTopicInvalidState,
+	ErrTopicNotFound:                  CodeTopicNotFound,
+	ErrTopicSelfReview:                CodeTopicSelfReview,
+	ErrTopicStateTransitionNotAllowed: CodeTopicStateTransitionNotAllowed,
+	ErrTopicUploadEmpty:               CodeTopicUploadEmpty,
+	ErrUnableToParseJSON:              CodeUnableToParseJSON,
+	ErrUnableToReadMessage:            CodeUnableToReadMessage,
+	ErrWebhookInvalidEvent:            CodeWebhookInvalidEvent,
+	ErrWebhookInvalidURL:              CodeWebhookInvalidURL,
+	ErrWebhookNotFound:                CodeWebhookNotFound,
+}
+
+// Code returns the code of the first error in err's chain that is one of the errors above, or
+// CodeInternalServer if there is none
+func Code(err error) string {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if code, ok := codes[err]; ok {
+			return code
+		}
+	}
+
+	return CodeInternalServer
+}
+
+// FieldError describes why a single field of a request is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when one or more fields of a request are invalid. It wraps the
+// error describing the overall problem, so it can be compared to that error with errors.Is.
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+// Add records that a field is invalid. The error of the first field added describes the overall problem.
+func (e *ValidationError) Add(err error, field, message string) {
+	if e.Err == nil {
+		e.Err = err
+	}
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// ErrorOrNil returns the ValidationError if any invalid fields have been added, or nil if there are none
+func (e *ValidationError) ErrorOrNil() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// Error returns the message of the wrapped error
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -6,17 +6,39 @@ import (
 
 // A list of error messages for Topic API
 var (
+	ErrCollectionEmpty                = errors.New("collection has no changes to publish")
+	ErrCollectionInvalidFields        = errors.New("collection has invalid fields")
+	ErrCollectionNotFound             = errors.New("collection not found")
+	ErrCollectionPublished            = errors.New("collection has already been published")
 	ErrContentNotFound                = errors.New("content not found")
 	ErrContentUnrecognisedParameter   = errors.New("content query not recognised")
 	ErrEmptyRequestBody               = errors.New("request body empty")
 	ErrInternalServer                 = errors.New("internal error")
+	ErrInvalidConceptSchemeFormat     = errors.New("invalid format query parameter, must be skos-turtle or skos-jsonld")
+	ErrInvalidIncludeArchived         = errors.New("invalid include_archived query parameter, must be true or false")
+	ErrInvalidImportStrategy          = errors.New("invalid strategy query parameter, must be upsert or replace")
+	ErrInvalidLimit                   = errors.New("invalid limit query parameter")
+	ErrInvalidPreviewToken            = errors.New("invalid or expired preview token")
+	ErrInvalidPartial                 = errors.New("invalid partial query parameter, must be true or false")
 	ErrInvalidReleaseDate             = errors.New("invalid topic release date, must have the following format: 2022-05-22T09:21:45Z")
+	ErrInvalidTaxonomyFormat          = errors.New("invalid format query parameter, must be ndjson, skos-turtle or skos-jsonld")
+	ErrInvalidTaxonomyVersion         = errors.New("invalid version query parameter, must be current, next or both")
+	ErrInvalidValidateOnly            = errors.New("invalid validate_only query parameter, must be true or false")
 	ErrNotFound                       = errors.New("not found")
+	ErrRedirectNotFound               = errors.New("redirect not found")
+	ErrTopicInCollection              = errors.New("topic change is already in another collection")
+	ErrTopicArchived                  = errors.New("topic has been archived")
+	ErrTopicForbidden                 = errors.New("not permitted to edit topic")
+	ErrTopicInvalidFields             = errors.New("topic has invalid fields")
 	ErrTopicMissingFields             = errors.New("missing topic update mandatory fields")
 	ErrTopicInvalidState              = errors.New("topic state is not a valid state name")
 	ErrTopicNotFound                  = errors.New("topic not found")
+	ErrTopicSelfReview                = errors.New("topic cannot be reviewed by its last editor")
 	ErrTopicStateTransitionNotAllowed = errors.New("topic state transition not allowed")
 	ErrTopicUploadEmpty               = errors.New("topic upload section is not populated")
 	ErrUnableToParseJSON              = errors.New("failed to parse json body")
 	ErrUnableToReadMessage            = errors.New("failed to read message body")
+	ErrWebhookInvalidEvent            = errors.New("webhook events must be a non empty list of recognised event types")
+	ErrWebhookInvalidURL              = errors.New("webhook url must be an absolute http or https url")
+	ErrWebhookNotFound                = errors.New("webhook not found")
 )
@@ -0,0 +1,139 @@
+// Package authz authorises requests against the permissions bundle of the permissions API, identifying callers from
+// JWTs verified locally against the configured public keys. It is the successor of the Zebedee permissions checks of
+// dp-authorisation/auth, which it can be swapped in for with AUTH_BACKEND=jwt.
+package authz
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dp-authorisation/auth"
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
+	"github.com/ONSdigital/dp-authorisation/v2/permissions"
+	"github.com/ONSdigital/dp-authorisation/v2/zebedeeclient"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// The permissions of the topic API, as granted to users, groups and services by the policies of the permissions bundle
+const (
+	PermissionCreate = "topics:create"
+	PermissionRead   = "topics:read"
+	PermissionUpdate = "topics:update"
+	PermissionDelete = "topics:delete"
+)
+
+// Handler checks callers have the permissions a request requires. It satisfies the AuthHandler of the API, so that it
+// can replace the Zebedee permissions checks without the handlers changing.
+type Handler struct {
+	middleware authorisation.Middleware
+}
+
+// NewHandler creates a Handler from the config. Its permissions bundle is read from PERMISSIONS_BUNDLE_PATH when it
+// is set, or otherwise from the permissions API, and is cached and updated in the background until it is closed.
+// Service tokens, which are not JWTs, are still identified by Zebedee.
+func NewHandler(ctx context.Context, cfg *config.Config) (*Handler, error) {
+	jwtParser, err := authorisation.NewCognitoRSAParser(cfg.JWTVerificationPublicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	var store permissions.Store = permsdk.NewClient(cfg.PermissionsAPIURL)
+	if cfg.PermissionsBundlePath != "" {
+		if store, err = NewFileStore(cfg.PermissionsBundlePath); err != nil {
+			return nil, err
+		}
+	}
+
+	cache := permissions.NewCachingStore(store)
+	cache.StartCacheUpdater(ctx, cfg.PermissionsCacheUpdateInterval, cfg.PermissionsMaxCacheTime)
+
+	return NewHandlerFromDependencies(authorisation.NewMiddlewareFromDependencies(
+		jwtParser,
+		permissions.NewCheckerForStore(cache),
+		zebedeeclient.NewZebedeeClient(cfg.ZebedeeURL),
+		nil,
+	)), nil
+}
+
+// NewHandlerFromDependencies creates a Handler checking permissions with the middleware given
+func NewHandlerFromDependencies(middleware authorisation.Middleware) *Handler {
+	return &Handler{middleware: middleware}
+}
+
+// Require wraps a http.HandlerFunc in another that only calls it if the caller has every one of the permissions
+// required, responding 401 to callers that cannot be identified and 403 to those without a permission
+func (h *Handler) Require(required auth.Permissions, handler http.HandlerFunc) http.HandlerFunc {
+	names := Permissions(required)
+	for i := len(names) - 1; i >= 0; i-- {
+		handler = h.middleware.Require(names[i], handler)
+	}
+	return handler
+}
+
+// Permissions returns the permissions of the topic API that are equivalent to the CRUD permissions of
+// dp-authorisation/auth, in the order they are checked
+func Permissions(required auth.Permissions) []string {
+	var names []string
+	if required.Create {
+		names = append(names, PermissionCreate)
+	}
+	if required.Read {
+		names = append(names, PermissionRead)
+	}
+	if required.Update {
+		names = append(names, PermissionUpdate)
+	}
+	if required.Delete {
+		names = append(names, PermissionDelete)
+	}
+	return names
+}
+
+// Parse verifies a JWT, returning the entity it identifies along with its groups
+func (h *Handler) Parse(token string) (*permsdk.EntityData, error) {
+	return h.middleware.Parse(token)
+}
+
+// Identity returns a middleware identifying callers with a JWT by its claims, so that their identity is known without
+// Zebedee. The requests of other callers, with service tokens, are passed to the identity middleware given.
+func (h *Handler) Identity(fallback func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		identifyByZebedee := fallback(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			token := strings.TrimPrefix(req.Header.Get(dprequest.AuthHeaderKey), dprequest.BearerPrefix)
+			if !strings.Contains(token, ".") {
+				identifyByZebedee.ServeHTTP(w, req)
+				return
+			}
+
+			ctx := req.Context()
+			entity, err := h.middleware.Parse(token)
+			if err != nil {
+				// the caller remains unidentified, which the handlers that require identity reject
+				log.Info(ctx, "failed to identify caller from JWT", log.Data{"error": err.Error()})
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			ctx = dprequest.SetCaller(ctx, entity.UserID)
+			ctx = dprequest.SetUser(ctx, entity.UserID)
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+// Checker reports the health of the permissions bundle, which is critical once it has been cached for too long
+func (h *Handler) Checker(ctx context.Context, state *healthcheck.CheckState) error {
+	return h.middleware.HealthCheck(ctx, state)
+}
+
+// Close stops updating the permissions bundle
+func (h *Handler) Close(ctx context.Context) error {
+	return h.middleware.Close(ctx)
+}
@@ -0,0 +1,205 @@
+package authz
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ONSdigital/dp-authorisation/auth"
+	"github.com/ONSdigital/dp-authorisation/v2/authorisationtest"
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	dprequest "github.com/ONSdigital/dp-net/v3/request"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
+	"github.com/ONSdigital/dp-topic-api/config"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testBundlePath is the local stand-in bundle, relative to this package
+var testBundlePath = filepath.Base(LocalBundlePath)
+
+func newTestHandler(bundlePath string) (*Handler, error) {
+	cfg, err := config.Get()
+	if err != nil {
+		return nil, err
+	}
+	authCfg := *cfg
+	authCfg.PermissionsBundlePath = bundlePath
+	return NewHandler(context.Background(), &authCfg)
+}
+
+func TestRequire(t *testing.T) {
+	Convey("Given a handler authorising against the local stand-in permissions bundle", t, func() {
+		handler, err := newTestHandler(testBundlePath)
+		So(err, ShouldBeNil)
+		defer handler.Close(context.Background())
+
+		called := false
+		wrapped := handler.Require(auth.Permissions{Update: true}, func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		})
+
+		request := func(token string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPut, "http://localhost:25300/topics/1", http.NoBody)
+			if token != "" {
+				req.Header.Set(dprequest.AuthHeaderKey, token)
+			}
+			w := httptest.NewRecorder()
+			wrapped(w, req)
+			return w
+		}
+
+		Convey("When a publisher with a JWT makes the request", func() {
+			w := request(authorisationtest.PublisherJWTToken)
+
+			Convey("Then the JWT is verified locally and the request is permitted", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(called, ShouldBeTrue)
+			})
+		})
+
+		Convey("When the request has no token", func() {
+			w := request("")
+
+			Convey("Then 401 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+				So(called, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the request has a JWT that cannot be verified", func() {
+			w := request("Bearer header.payload.signature")
+
+			Convey("Then 401 is returned", func() {
+				So(w.Code, ShouldEqual, http.StatusUnauthorized)
+				So(called, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a handler authorising against a bundle only granting publishers the read permission", t, func() {
+		bundlePath := filepath.Join(t.TempDir(), "bundle.json")
+		So(os.WriteFile(bundlePath, []byte(`{"topics:read": {"groups/role-publisher": [{"id": "1"}]}}`), 0o600), ShouldBeNil)
+		handler, err := newTestHandler(bundlePath)
+		So(err, ShouldBeNil)
+		defer handler.Close(context.Background())
+
+		Convey("When a publisher makes a request requiring the read and update permissions", func() {
+			called := false
+			wrapped := handler.Require(auth.Permissions{Read: true, Update: true}, func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			})
+			req := httptest.NewRequest(http.MethodPut, "http://localhost:25300/topics/1", http.NoBody)
+			req.Header.Set(dprequest.AuthHeaderKey, authorisationtest.PublisherJWTToken)
+			w := httptest.NewRecorder()
+			wrapped(w, req)
+
+			Convey("Then 403 is returned, as every permission is required", func() {
+				So(w.Code, ShouldEqual, http.StatusForbidden)
+				So(called, ShouldBeFalse)
+			})
+		})
+
+		Convey("Then the permissions bundle is healthy", func() {
+			state := healthcheck.NewCheckState("Permissions")
+			So(handler.Checker(context.Background(), state), ShouldBeNil)
+			So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+		})
+	})
+}
+
+func TestPermissions(t *testing.T) {
+	Convey("The CRUD permissions of dp-authorisation/auth map to the permissions of the topic API", t, func() {
+		So(Permissions(auth.Permissions{Read: true}), ShouldResemble, []string{PermissionRead})
+		So(Permissions(auth.Permissions{Create: true, Read: true, Update: true, Delete: true}), ShouldResemble,
+			[]string{PermissionCreate, PermissionRead, PermissionUpdate, PermissionDelete})
+		So(Permissions(auth.Permissions{}), ShouldBeEmpty)
+	})
+}
+
+func TestIdentity(t *testing.T) {
+	Convey("Given the identity middleware of a handler, falling back to another for service tokens", t, func() {
+		handler, err := newTestHandler(testBundlePath)
+		So(err, ShouldBeNil)
+		defer handler.Close(context.Background())
+
+		fellBack := false
+		fallback := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fellBack = true
+				next.ServeHTTP(w, r.WithContext(dprequest.SetCaller(r.Context(), "service")))
+			})
+		}
+
+		var caller string
+		identity := handler.Identity(fallback)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			caller = dprequest.Caller(r.Context())
+		}))
+
+		serve := func(token string) {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:25300/topics", http.NoBody)
+			req.Header.Set(dprequest.AuthHeaderKey, token)
+			identity.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		Convey("When a caller has a JWT", func() {
+			serve(authorisationtest.AdminJWTToken)
+
+			Convey("Then it is identified from its claims, without falling back", func() {
+				So(caller, ShouldEqual, "janedoe@example.com")
+				So(fellBack, ShouldBeFalse)
+			})
+		})
+
+		Convey("When a caller has a JWT that cannot be verified", func() {
+			serve("Bearer header.payload.signature")
+
+			Convey("Then it remains unidentified", func() {
+				So(caller, ShouldBeEmpty)
+				So(fellBack, ShouldBeFalse)
+			})
+		})
+
+		Convey("When a caller has a service token", func() {
+			serve(authorisationtest.ZebedeeServiceToken)
+
+			Convey("Then it is identified by the fallback", func() {
+				So(caller, ShouldEqual, "service")
+				So(fellBack, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestFileStore(t *testing.T) {
+	Convey("Given the local stand-in permissions bundle", t, func() {
+		store, err := NewFileStore(testBundlePath)
+		So(err, ShouldBeNil)
+
+		Convey("Then admins and publishers have every permission, and viewers can read", func() {
+			bundle, err := store.GetPermissionsBundle(context.Background(), permsdk.Headers{})
+			So(err, ShouldBeNil)
+			for _, permission := range []string{PermissionCreate, PermissionRead, PermissionUpdate, PermissionDelete} {
+				So(bundle[permission], ShouldContainKey, "groups/role-admin")
+				So(bundle[permission], ShouldContainKey, "groups/role-publisher")
+			}
+			So(bundle[PermissionRead], ShouldContainKey, "groups/role-viewer")
+			So(bundle[PermissionUpdate], ShouldNotContainKey, "groups/role-viewer")
+		})
+	})
+
+	Convey("Given a bundle that does not exist, creating a store fails", t, func() {
+		_, err := NewFileStore(filepath.Join(t.TempDir(), "missing.json"))
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Given a bundle that is not JSON, creating a store fails", t, func() {
+		bundlePath := filepath.Join(t.TempDir(), "bundle.json")
+		So(os.WriteFile(bundlePath, []byte("not json"), 0o600), ShouldBeNil)
+		_, err := NewFileStore(bundlePath)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,48 @@
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ONSdigital/dp-authorisation/v2/permissions"
+	permsdk "github.com/ONSdigital/dp-permissions-api/sdk"
+)
+
+// LocalBundlePath is the path, from the root of the repository, of the stand-in permissions bundle for running the
+// service locally and in component tests without the permissions API. It grants the admin and publisher groups every
+// permission of the topic API, and the viewer group the read permission.
+const LocalBundlePath = "authz/local-permissions-bundle.json"
+
+// check that FileStore satisfies the permissions.Store interface
+var _ permissions.Store = (*FileStore)(nil)
+
+// FileStore is a permissions store of a bundle in a JSON file, in the format returned by the permissions API
+type FileStore struct {
+	path string
+}
+
+// NewFileStore creates a FileStore of the bundle in the file at path, checking it can be read
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path}
+	if _, err := s.GetPermissionsBundle(context.Background(), permsdk.Headers{}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetPermissionsBundle reads the bundle from the file, so that changes to it are picked up each time the cached bundle
+// is updated
+func (s *FileStore) GetPermissionsBundle(_ context.Context, _ permsdk.Headers) (permsdk.Bundle, error) {
+	payload, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read permissions bundle: %w", err)
+	}
+
+	var bundle permsdk.Bundle
+	if err := json.Unmarshal(payload, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse permissions bundle %s: %w", s.path, err)
+	}
+	return bundle, nil
+}
@@ -0,0 +1,402 @@
+// Package bulk applies editorial updates, read from CSV, to the next versions of many topics at once.
+package bulk
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+)
+
+// ContentType is the media type of the updates
+const ContentType = "text/csv"
+
+// The columns of the updates, each mapped to the field of models.TopicUpdate with the same JSON name.
+// Every column other than id is optional.
+const (
+	ColumnID          = "id"
+	ColumnTitle       = "title"
+	ColumnDescription = "description"
+	ColumnKeywords    = "keywords"
+	ColumnReleaseDate = "release_date"
+	ColumnState       = "state"
+	ColumnSubtopicIDs = "subtopics_ids"
+	ColumnSlug        = "slug"
+	ColumnWelshTitle  = "welsh_title"
+)
+
+// columns is every column, in the order they are described
+var columns = []string{ColumnID, ColumnTitle, ColumnDescription, ColumnKeywords, ColumnReleaseDate, ColumnState, ColumnSubtopicIDs, ColumnSlug, ColumnWelshTitle}
+
+// ListSeparator separates the values of the keywords and subtopics_ids columns
+const ListSeparator = ";"
+
+// The statuses of a row in a Report
+const (
+	StatusUpdated   = "updated"
+	StatusUnchanged = "unchanged"
+	StatusValid     = "valid"
+	StatusInvalid   = "invalid"
+)
+
+// Store represents the methods required to update topics
+type Store interface {
+	models.TopicLookup
+	GetTopic(ctx context.Context, id string) (*models.TopicResponse, error)
+	UpdateTopic(ctx context.Context, host, id string, topic *models.TopicUpdate) error
+	ReplaceTopic(ctx context.Context, topic *models.TopicResponse) error
+}
+
+// Row is the update of a topic, read from a line of CSV. Only the columns with a value replace the fields of the
+// next version of the topic, the others are left unchanged.
+type Row struct {
+	Line   int
+	ID     string
+	Values map[string]string
+	// violations are those found when the row was read
+	violations *apierrors.ValidationError
+}
+
+// Result is the outcome of a row
+type Result struct {
+	Line   int    `json:"line"`
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	// State is the state of the next version of the topic once the row is applied
+	State  string                 `json:"state,omitempty"`
+	Errors []apierrors.FieldError `json:"errors,omitempty"`
+}
+
+// Report is the outcome of every row, in the order they were read
+type Report struct {
+	Partial bool     `json:"partial"`
+	Rows    []Result `json:"rows"`
+}
+
+// Updated returns the ids of the topics that were updated
+func (r *Report) Updated() []string {
+	ids := []string{}
+	for _, row := range r.Rows {
+		if row.Status == StatusUpdated {
+			ids = append(ids, row.ID)
+		}
+	}
+	return ids
+}
+
+// Count returns the number of rows with the status
+func (r *Report) Count(status string) int {
+	count := 0
+	for _, row := range r.Rows {
+		if row.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Read reads the rows of CSV with a header naming its columns. Errors in the header, or CSV that cannot be parsed, are
+// returned as an *apierrors.ValidationError; errors in a row are reported when the rows are applied.
+func Read(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	switch {
+	case err == io.EOF:
+		return nil, apierrors.ErrEmptyRequestBody
+	case err != nil:
+		return nil, parseError(err)
+	}
+
+	if err := checkHeader(header); err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	lines := make(map[string]int)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, parseError(err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := Row{Line: line, Values: make(map[string]string), violations: &apierrors.ValidationError{}}
+		for i, column := range header {
+			row.Values[column] = strings.TrimSpace(record[i])
+		}
+		row.ID = row.Values[ColumnID]
+
+		if row.ID == "" {
+			row.violations.Add(apierrors.ErrTopicMissingFields, ColumnID, "must not be empty")
+		} else if previous, ok := lines[row.ID]; ok {
+			row.violations.Add(apierrors.ErrTopicInvalidFields, ColumnID, fmt.Sprintf("must not duplicate the topic on line %d", previous))
+		} else {
+			lines[row.ID] = line
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, apierrors.ErrEmptyRequestBody
+	}
+
+	return rows, nil
+}
+
+// checkHeader checks that the header has an id column, and only the known columns once each. It normalises the names
+// of the columns, and removes the byte order mark that spreadsheets can start CSV with.
+func checkHeader(header []string) error {
+	violations := &apierrors.ValidationError{}
+	known := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		known[column] = true
+	}
+
+	seen := make(map[string]bool, len(header))
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")))
+		field := fmt.Sprintf("header[%d]", i)
+		switch {
+		case !known[header[i]]:
+			violations.Add(apierrors.ErrTopicInvalidFields, field, fmt.Sprintf("must be one of %s", strings.Join(columns, ", ")))
+		case seen[header[i]]:
+			violations.Add(apierrors.ErrTopicInvalidFields, field, "must not duplicate another column")
+		}
+		seen[header[i]] = true
+	}
+
+	if !seen[ColumnID] {
+		violations.Add(apierrors.ErrTopicMissingFields, "header", "must have an id column")
+	}
+
+	return violations.ErrorOrNil()
+}
+
+// parseError returns the error of CSV that cannot be parsed
+func parseError(err error) error {
+	var csvErr *csv.ParseError
+	if !errors.As(err, &csvErr) {
+		return apierrors.ErrUnableToReadMessage
+	}
+
+	message := "must be valid CSV"
+	if errors.Is(csvErr.Err, csv.ErrFieldCount) {
+		message = "must have a value, which may be empty, for each column"
+	}
+
+	violations := &apierrors.ValidationError{}
+	violations.Add(apierrors.ErrTopicInvalidFields, fmt.Sprintf("line %d", csvErr.Line), message)
+	return violations
+}
+
+// Update returns the update of the next version of the topic that results from applying the row to it
+func (r *Row) Update(next *models.Topic) *models.TopicUpdate {
+	update := &models.TopicUpdate{}
+	if next != nil {
+		update.Title = next.Title
+		update.WelshTitle = next.WelshTitle
+		update.Description = next.Description
+		update.Keywords = next.Keywords
+		update.State = next.State
+		update.SubtopicIds = next.SubtopicIds
+		update.RelatedTopicIDs = next.RelatedTopicIDs
+		update.RelatedTopicTypes = next.RelatedTopicTypes
+		update.Slug = next.Slug
+		if next.ReleaseDate != nil {
+			update.ReleaseDate = next.ReleaseDate.Format(time.RFC3339)
+		}
+	}
+
+	if value := r.Values[ColumnTitle]; value != "" {
+		update.Title = value
+	}
+	if value := r.Values[ColumnDescription]; value != "" {
+		update.Description = value
+	}
+	if value := r.Values[ColumnKeywords]; value != "" {
+		update.Keywords = splitList(value)
+	}
+	if value := r.Values[ColumnReleaseDate]; value != "" {
+		update.ReleaseDate = value
+	}
+	if value := r.Values[ColumnState]; value != "" {
+		update.State = value
+	}
+	if value := r.Values[ColumnSubtopicIDs]; value != "" {
+		update.SubtopicIds = splitList(value)
+	}
+	if value := r.Values[ColumnSlug]; value != "" {
+		update.Slug = value
+	}
+	if value := r.Values[ColumnWelshTitle]; value != "" {
+		update.WelshTitle = value
+	}
+
+	return update
+}
+
+// SubtopicIDs returns the ids of the subtopics the row sets, or nil when it leaves them unchanged
+func (r *Row) SubtopicIDs() []string {
+	value := r.Values[ColumnSubtopicIDs]
+	if value == "" {
+		return nil
+	}
+	return *splitList(value)
+}
+
+// splitList splits the value of a list column, ignoring empty values
+func splitList(value string) *[]string {
+	list := []string{}
+	for _, item := range strings.Split(value, ListSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return &list
+}
+
+// Apply validates every row, as an update of the next version of its topic, and applies the valid rows.
+// Unless partial, nothing is applied if any row is invalid, and the violations of every row are returned as an
+// *apierrors.ValidationError, with the fields prefixed by the number of the line they are on. With partial, the valid
+// rows are applied and the invalid rows are reported with their violations. The editor is recorded as the last editor
+// of every topic updated. If a row fails to be written, the rows already written are restored as they were, so that a
+// bulk update is never left half applied.
+func Apply(ctx context.Context, store Store, host, editor string, rows []Row, partial bool) (*Report, error) {
+	report := &Report{Partial: partial, Rows: make([]Result, len(rows))}
+	updates := make([]*models.TopicUpdate, len(rows))
+	originals := make([]*models.TopicResponse, len(rows))
+	all := &apierrors.ValidationError{}
+
+	for i := range rows {
+		original, update, violations, err := validate(ctx, store, &rows[i])
+		if err != nil {
+			return nil, err
+		}
+		originals[i] = original
+
+		report.Rows[i] = Result{Line: rows[i].Line, ID: rows[i].ID, Status: StatusValid}
+		if len(violations.Fields) > 0 {
+			report.Rows[i].Status = StatusInvalid
+			report.Rows[i].Errors = violations.Fields
+			for _, field := range violations.Fields {
+				all.Add(violations.Err, fmt.Sprintf("line %d: %s", rows[i].Line, field.Field), field.Message)
+			}
+			continue
+		}
+		report.Rows[i].State = update.State
+		if original.Next != nil && update.State == original.Next.State && !update.Changes(original.Next) {
+			// a row that changes nothing is not applied, so that it neither makes the caller the last editor of the
+			// topic nor is notified as an update
+			report.Rows[i].Status = StatusUnchanged
+			continue
+		}
+		updates[i] = update
+	}
+
+	if !partial {
+		if err := all.ErrorOrNil(); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range rows {
+		if report.Rows[i].Status != StatusValid {
+			continue
+		}
+		updates[i].LastEditedBy = editor
+		if err := store.UpdateTopic(ctx, host, rows[i].ID, updates[i]); err != nil {
+			err = fmt.Errorf("failed to update topic %s on line %d: %w", rows[i].ID, rows[i].Line, err)
+			return nil, restore(ctx, store, originals[:i], report.Rows[:i], err)
+		}
+		report.Rows[i].Status = StatusUpdated
+	}
+
+	return report, nil
+}
+
+// restore replaces the topics of the rows that have been updated with their originals, in reverse order, returning
+// the cause of the failure they are restored after along with any failure to restore them
+func restore(ctx context.Context, store Store, originals []*models.TopicResponse, rows []Result, cause error) error {
+	// the topics must be restored even if the request is cancelled
+	ctx = context.WithoutCancel(ctx)
+
+	errs := []error{cause}
+	for i := len(rows) - 1; i >= 0; i-- {
+		if rows[i].Status != StatusUpdated {
+			continue
+		}
+		if err := store.ReplaceTopic(ctx, originals[i]); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore topic %s on line %d: %w", rows[i].ID, rows[i].Line, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate returns the topic of a row as it is, the update of the row and its violations, including those that need
+// the store to find
+func validate(ctx context.Context, store Store, row *Row) (*models.TopicResponse, *models.TopicUpdate, *apierrors.ValidationError, error) {
+	if len(row.violations.Fields) > 0 {
+		return nil, nil, row.violations, nil
+	}
+
+	topic, err := store.GetTopic(ctx, row.ID)
+	switch {
+	case errors.Is(err, apierrors.ErrTopicNotFound):
+		violations := &apierrors.ValidationError{}
+		violations.Add(apierrors.ErrTopicInvalidFields, ColumnID, "must be the id of an existing topic")
+		return nil, nil, violations, nil
+	case err != nil:
+		return nil, nil, nil, err
+	}
+
+	update := row.Update(topic.Next)
+	violations := update.Violations(row.ID)
+	if update.State == models.StatePublished.String() {
+		// publishing replaces the current version, so it is left to the publishing workflow
+		violations.Add(apierrors.ErrTopicInvalidState, ColumnState, "must not be published by a bulk update")
+	}
+	if state, err := models.ParseState(update.State); err == nil {
+		var from string
+		if topic.Next != nil {
+			from = topic.Next.State
+		}
+		// a row that leaves the state unchanged only edits the other fields of the topic, so makes no transition,
+		// except that an edit of an approved topic takes it back to created, to be reviewed again. As through the
+		// API, a topic in review cannot be edited until it has been reviewed.
+		changed := update.State != from
+		edited := update.Changes(topic.Next)
+		if !changed && edited && state == models.StateApproved {
+			update.State = models.StateCreated.String()
+		}
+		switch {
+		case from == models.StateInReview.String() && (changed || edited):
+			violations.Add(apierrors.ErrTopicInvalidState, ColumnState, "must not edit a topic in review, which must be reviewed first")
+		case changed && models.CheckTransition(from, update.State, false) != nil:
+			violations.Add(apierrors.ErrTopicInvalidState, ColumnState, fmt.Sprintf("must be a state the topic can move to from %s", from))
+		case changed && state.IsReviewDecision():
+			// the decisions of reviewers are left to the review workflow, which records the reviewer
+			violations.Add(apierrors.ErrTopicInvalidState, ColumnState, "must not be approved or rejected by a bulk update")
+		case state == models.StateArchived || from == models.StateArchived.String():
+			// archiving changes every version of a topic, which is left to its state endpoint
+			violations.Add(apierrors.ErrTopicInvalidState, ColumnState, "must not archive, or restore, a topic by a bulk update")
+		}
+	}
+	if err := update.CheckReferences(ctx, store, row.ID, violations); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return topic, update, violations, nil
+}
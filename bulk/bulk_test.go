@@ -0,0 +1,212 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRead(t *testing.T) {
+	Convey("Given CSV exported from a spreadsheet, with a byte order mark and blank line", t, func() {
+		body := "\ufeffID, Keywords\n1,\"prices; inflation\"\n\n2,\n1,trade\n,trade\n"
+
+		Convey("When it is read, each row has its values by column and the number of its line", func() {
+			rows, err := Read(strings.NewReader(body))
+			So(err, ShouldBeNil)
+			So(rows, ShouldHaveLength, 4)
+			So(rows[0].ID, ShouldEqual, "1")
+			So(rows[0].Values, ShouldResemble, map[string]string{ColumnID: "1", ColumnKeywords: "prices; inflation"})
+			So(rows[1].Line, ShouldEqual, 4)
+
+			Convey("Then rows without an id, or duplicating the id of another row, are invalid", func() {
+				So(rows[1].violations.Fields, ShouldBeEmpty)
+				So(rows[2].violations.Fields, ShouldResemble, []apierrors.FieldError{
+					{Field: ColumnID, Message: "must not duplicate the topic on line 2"},
+				})
+				So(rows[3].violations.Fields, ShouldResemble, []apierrors.FieldError{
+					{Field: ColumnID, Message: "must not be empty"},
+				})
+			})
+		})
+	})
+
+	Convey("Given CSV with unknown and duplicate columns, and no id column", t, func() {
+		_, err := Read(strings.NewReader("title,colour,title\nEconomy,red,Economy\n"))
+
+		Convey("When it is read, every problem with the header is returned", func() {
+			violations, ok := err.(*apierrors.ValidationError)
+			So(ok, ShouldBeTrue)
+			So(violations.Fields, ShouldResemble, []apierrors.FieldError{
+				{Field: "header[1]", Message: "must be one of id, title, description, keywords, release_date, state, subtopics_ids, slug, welsh_title"},
+				{Field: "header[2]", Message: "must not duplicate another column"},
+				{Field: "header", Message: "must have an id column"},
+			})
+		})
+	})
+
+	Convey("Given CSV with a row missing a column, reading it returns the line of the row", t, func() {
+		_, err := Read(strings.NewReader("id,title\n1,Economy\n2\n"))
+		violations, ok := err.(*apierrors.ValidationError)
+		So(ok, ShouldBeTrue)
+		So(violations.Fields, ShouldResemble, []apierrors.FieldError{
+			{Field: "line 3", Message: "must have a value, which may be empty, for each column"},
+		})
+	})
+
+	Convey("Given CSV with only a header, reading it returns an error", t, func() {
+		_, err := Read(strings.NewReader("id,title\n"))
+		So(err, ShouldEqual, apierrors.ErrEmptyRequestBody)
+	})
+}
+
+func TestRowUpdate(t *testing.T) {
+	Convey("Given the next version of a topic", t, func() {
+		releaseDate := time.Date(2022, 10, 10, 8, 30, 0, 0, time.UTC)
+		next := &models.Topic{
+			Title:       "Economy",
+			Description: "The economy",
+			Keywords:    &[]string{"economy"},
+			ReleaseDate: &releaseDate,
+			State:       models.StateCompleted.String(),
+			Slug:        "economy",
+			WelshTitle:  "Economi",
+			// the related topics have no column, so are always kept
+			RelatedTopicIDs:   &[]string{"housing"},
+			RelatedTopicTypes: map[string]string{"housing": models.RelationSeeAlso},
+		}
+
+		Convey("When a row with some empty values is applied to it", func() {
+			row := Row{ID: "1", Values: map[string]string{ColumnID: "1", ColumnTitle: "", ColumnKeywords: "gdp; ; growth", ColumnWelshTitle: "Yr economi"}}
+			update := row.Update(next)
+
+			Convey("Then only the fields with values are replaced", func() {
+				So(update, ShouldResemble, &models.TopicUpdate{
+					Title:             "Economy",
+					Description:       "The economy",
+					Keywords:          &[]string{"gdp", "growth"},
+					ReleaseDate:       "2022-10-10T08:30:00Z",
+					State:             models.StateCompleted.String(),
+					Slug:              "economy",
+					WelshTitle:        "Yr economi",
+					RelatedTopicIDs:   &[]string{"housing"},
+					RelatedTopicTypes: map[string]string{"housing": models.RelationSeeAlso},
+				})
+			})
+		})
+	})
+}
+
+// store is a store of topics kept in a map, whose updates fail for the topic given by failing
+type store struct {
+	topics  map[string]*models.TopicResponse
+	failing string
+}
+
+func (s *store) CheckTopicExists(_ context.Context, id string) error {
+	if _, ok := s.topics[id]; !ok {
+		return apierrors.ErrTopicNotFound
+	}
+	return nil
+}
+
+func (s *store) IsSlugInUse(context.Context, string, string) (bool, error) {
+	return false, nil
+}
+
+func (s *store) GetTopic(_ context.Context, id string) (*models.TopicResponse, error) {
+	topic, ok := s.topics[id]
+	if !ok {
+		return nil, apierrors.ErrTopicNotFound
+	}
+	next := *topic.Next
+	return &models.TopicResponse{ID: id, Current: topic.Current, Next: &next}, nil
+}
+
+func (s *store) UpdateTopic(_ context.Context, _, id string, update *models.TopicUpdate) error {
+	if id == s.failing {
+		return errFailed
+	}
+	s.topics[id].Next.Description = update.Description
+	s.topics[id].Next.State = update.State
+	return nil
+}
+
+func (s *store) ReplaceTopic(_ context.Context, topic *models.TopicResponse) error {
+	s.topics[topic.ID] = topic
+	return nil
+}
+
+var errFailed = errors.New("failed")
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	releaseDate := time.Now().UTC().Truncate(time.Second)
+	topic := func(id, state string) *models.TopicResponse {
+		return &models.TopicResponse{ID: id, Next: &models.Topic{
+			ID: id, Title: id, Description: "old", State: state, ReleaseDate: &releaseDate,
+		}}
+	}
+	rows := func(csv string) []Row {
+		rows, err := Read(strings.NewReader(csv))
+		So(err, ShouldBeNil)
+		return rows
+	}
+
+	Convey("Given topics in the states of the review workflow", t, func() {
+		s := &store{topics: map[string]*models.TopicResponse{
+			"review":   topic("review", models.StateInReview.String()),
+			"approved": topic("approved", models.StateApproved.String()),
+			"rejected": topic("rejected", models.StateRejected.String()),
+		}}
+
+		Convey("When only the descriptions of those approved or rejected are updated, then the rows are applied, and the approved topic goes back to created", func() {
+			report, err := Apply(ctx, s, "http://localhost:25300", "editor", rows("id,description\napproved,new\nrejected,new\n"), false)
+			So(err, ShouldBeNil)
+			So(report.Rows[0].Status, ShouldEqual, StatusUpdated)
+			So(report.Rows[1].Status, ShouldEqual, StatusUpdated)
+			So(s.topics["approved"].Next.State, ShouldEqual, models.StateCreated.String())
+			So(s.topics["rejected"].Next.State, ShouldEqual, models.StateRejected.String())
+		})
+
+		Convey("When the description of the topic in review is updated, then the row is invalid, as it must be reviewed first", func() {
+			report, err := Apply(ctx, s, "http://localhost:25300", "editor", rows("id,description\nreview,new\n"), true)
+			So(err, ShouldBeNil)
+			So(report.Rows[0].Status, ShouldEqual, StatusInvalid)
+			So(report.Rows[0].Errors[0].Field, ShouldEqual, ColumnState)
+			So(s.topics["review"].Next.Description, ShouldEqual, "old")
+			So(s.topics["review"].Next.State, ShouldEqual, models.StateInReview.String())
+		})
+
+		Convey("When the topics are given their own values, then the rows are unchanged and not applied", func() {
+			s.failing = "approved"
+			report, err := Apply(ctx, s, "http://localhost:25300", "editor", rows("id,description\nreview,old\napproved,old\n"), false)
+			So(err, ShouldBeNil)
+			So(report.Rows[0].Status, ShouldEqual, StatusUnchanged)
+			So(report.Rows[1].Status, ShouldEqual, StatusUnchanged)
+			So(report.Rows[1].State, ShouldEqual, models.StateApproved.String())
+			So(s.topics["approved"].Next.State, ShouldEqual, models.StateApproved.String())
+		})
+	})
+
+	Convey("Given topics whose update fails for the last of them", t, func() {
+		s := &store{topics: map[string]*models.TopicResponse{
+			"economy":  topic("economy", models.StateCreated.String()),
+			"business": topic("business", models.StateCreated.String()),
+			"trade":    topic("trade", models.StateCreated.String()),
+		}, failing: "trade"}
+
+		Convey("When they are updated, then the failure is returned and the topics already updated are restored", func() {
+			_, err := Apply(ctx, s, "http://localhost:25300", "editor", rows("id,description\neconomy,new\nbusiness,new\ntrade,new\n"), false)
+			So(err, ShouldWrap, errFailed)
+			So(s.topics["economy"].Next.Description, ShouldEqual, "old")
+			So(s.topics["business"].Next.Description, ShouldEqual, "old")
+		})
+	})
+}
@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache is a thread safe, size limited, in-memory LRU cache whose entries expire after a TTL.
+// Every entry belongs to a group (a topic id) so that all the entries of a group can be invalidated together.
+type Cache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	lru        *list.List
+	entries    map[string]*list.Element
+	groups     map[string]map[string]struct{}
+	generation uint64
+	now        func() time.Time
+}
+
+type entry struct {
+	key     string
+	group   string
+	value   interface{}
+	expires time.Time
+}
+
+// New creates a Cache holding at most maxEntries entries, each for no longer than ttl
+func New(ttl time.Duration, maxEntries int) *Cache {
+	return &Cache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+		groups:     make(map[string]map[string]struct{}),
+		now:        time.Now,
+	}
+}
+
+// Get returns the value stored against key, if present and not expired
+func (c *Cache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := element.Value.(*entry)
+	if c.now().After(e.expires) {
+		c.remove(element)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(element)
+	return e.value, true
+}
+
+// Generation returns a counter that changes whenever entries are invalidated or purged
+func (c *Cache) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+// Set stores value against key as part of group, evicting the least recently used entry if the cache is full.
+// since is the Generation read before the value was loaded, the value is discarded if anything has been
+// invalidated since then, as it may have been loaded before the change that invalidated it.
+func (c *Cache) Set(group, key string, value interface{}, since uint64) {
+	if c.maxEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != since {
+		return
+	}
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	for c.lru.Len() >= c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+
+	c.entries[key] = c.lru.PushFront(&entry{
+		key:     key,
+		group:   group,
+		value:   value,
+		expires: c.now().Add(c.ttl),
+	})
+
+	if c.groups[group] == nil {
+		c.groups[group] = make(map[string]struct{})
+	}
+	c.groups[group][key] = struct{}{}
+}
+
+// Invalidate removes every entry of a group
+func (c *Cache) Invalidate(group string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for key := range c.groups[group] {
+		c.remove(c.entries[key])
+	}
+}
+
+// Purge removes every entry
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.lru.Init()
+	c.entries = make(map[string]*list.Element)
+	c.groups = make(map[string]map[string]struct{})
+}
+
+// Len returns the number of entries, including any that have expired but not yet been removed
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+func (c *Cache) remove(element *list.Element) {
+	e := c.lru.Remove(element).(*entry)
+	delete(c.entries, e.key)
+
+	delete(c.groups[e.group], e.key)
+	if len(c.groups[e.group]) == 0 {
+		delete(c.groups, e.group)
+	}
+}
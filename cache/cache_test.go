@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCache(t *testing.T) {
+	Convey("Given an empty cache holding at most 2 entries for a minute", t, func() {
+		now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+		c := New(time.Minute, 2)
+		c.now = func() time.Time { return now }
+
+		Convey("When a value is set", func() {
+			c.Set("topic1", "a", 1, c.Generation())
+
+			Convey("Then it can be got until it expires", func() {
+				value, ok := c.Get("a")
+				So(ok, ShouldBeTrue)
+				So(value, ShouldEqual, 1)
+
+				now = now.Add(time.Minute + time.Second)
+				_, ok = c.Get("a")
+				So(ok, ShouldBeFalse)
+				So(c.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When more values are set than the cache can hold", func() {
+			c.Set("topic1", "a", 1, c.Generation())
+			c.Set("topic2", "b", 2, c.Generation())
+			_, _ = c.Get("a")
+			c.Set("topic3", "c", 3, c.Generation())
+
+			Convey("Then the least recently used value is evicted", func() {
+				So(c.Len(), ShouldEqual, 2)
+				_, ok := c.Get("b")
+				So(ok, ShouldBeFalse)
+				_, ok = c.Get("a")
+				So(ok, ShouldBeTrue)
+				_, ok = c.Get("c")
+				So(ok, ShouldBeTrue)
+			})
+		})
+
+		Convey("When a group is invalidated", func() {
+			c.Set("topic1", "a", 1, c.Generation())
+			c.Set("topic2", "b", 2, c.Generation())
+			c.Invalidate("topic1")
+
+			Convey("Then only the entries of that group are removed", func() {
+				_, ok := c.Get("a")
+				So(ok, ShouldBeFalse)
+				_, ok = c.Get("b")
+				So(ok, ShouldBeTrue)
+			})
+		})
+
+		Convey("When a value loaded before an invalidation is set", func() {
+			generation := c.Generation()
+			c.Invalidate("topic1")
+			c.Set("topic1", "a", 1, generation)
+
+			Convey("Then it is discarded", func() {
+				_, ok := c.Get("a")
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the cache is purged", func() {
+			c.Set("topic1", "a", 1, c.Generation())
+			c.Set("topic2", "b", 2, c.Generation())
+			c.Purge()
+
+			Convey("Then it is empty", func() {
+				So(c.Len(), ShouldEqual, 0)
+				_, ok := c.Get("a")
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
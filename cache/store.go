@@ -0,0 +1,229 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/dp-topic-api/store"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// check that Store satisfies the store.Storer interface
+var _ store.Storer = (*Store)(nil)
+
+// WatchRetryInterval is the time to wait before re-opening a change stream that has failed
+const WatchRetryInterval = 30 * time.Second
+
+// archivedGroup is the group of the cached ids of the archived topics, which is invalidated by a change to any topic
+const archivedGroup = "archived"
+
+// Watcher represents the method required to follow changes to topics and content.
+// Watch blocks until the context is done or the stream fails, calling onChange with the id
+// of every changed topic or content document, or with an empty id if the id is unknown.
+// onOpen is called each time the stream has been opened, before any changes are reported.
+type Watcher interface {
+	Watch(ctx context.Context, onOpen func(), onChange func(id string)) error
+}
+
+// Store is a store.Storer that serves the current (published) view of topics and content from
+// a Cache in front of another store.Storer. It is intended for web, where only current views are read.
+// Values returned from the cache are shared and must not be modified.
+type Store struct {
+	store.Storer
+	cache *Cache
+}
+
+// NewStore creates a Store caching the current views read from backend
+func NewStore(backend store.Storer, ttl time.Duration, maxEntries int) *Store {
+	return &Store{
+		Storer: backend,
+		cache:  New(ttl, maxEntries),
+	}
+}
+
+// GetTopic retrieves the current view of a topic by its ID
+func (s *Store) GetTopic(ctx context.Context, id string) (*models.TopicResponse, error) {
+	key := "topic/" + id
+	generation := s.cache.Generation()
+	if value, ok := s.cache.Get(key); ok {
+		return value.(*models.TopicResponse), nil
+	}
+
+	topic, err := s.Storer.GetTopic(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	current := &models.TopicResponse{ID: topic.ID, Current: topic.Current}
+	s.cache.Set(id, key, current, generation)
+
+	return current, nil
+}
+
+// CheckTopicExists checks that the topic exists
+func (s *Store) CheckTopicExists(ctx context.Context, id string) error {
+	key := "exists/" + id
+	generation := s.cache.Generation()
+	if _, ok := s.cache.Get(key); ok {
+		return nil
+	}
+
+	if err := s.Storer.CheckTopicExists(ctx, id); err != nil {
+		return err
+	}
+
+	s.cache.Set(id, key, true, generation)
+
+	return nil
+}
+
+// GetArchivedTopicIDs retrieves the ids of the topics whose current view has been archived
+func (s *Store) GetArchivedTopicIDs(ctx context.Context) ([]string, error) {
+	key := "archived"
+	generation := s.cache.Generation()
+	if value, ok := s.cache.Get(key); ok {
+		return value.([]string), nil
+	}
+
+	ids, err := s.Storer.GetArchivedTopicIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Set(archivedGroup, key, ids, generation)
+
+	return ids, nil
+}
+
+// GetContent retrieves the current view of a content document by its ID
+func (s *Store) GetContent(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+	key := "content/" + id + "/" + strconv.Itoa(queryTypeFlags)
+	generation := s.cache.Generation()
+	if value, ok := s.cache.Get(key); ok {
+		return value.(*models.ContentResponse), nil
+	}
+
+	content, err := s.Storer.GetContent(ctx, id, queryTypeFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	current := &models.ContentResponse{ID: content.ID, Current: content.Current}
+	s.cache.Set(id, key, current, generation)
+
+	return current, nil
+}
+
+// UpdateReleaseDate updates the release date of a topic and invalidates its cached views
+func (s *Store) UpdateReleaseDate(ctx context.Context, id string, releaseDate time.Time) error {
+	defer s.Invalidate(id)
+	return s.Storer.UpdateReleaseDate(ctx, id, releaseDate)
+}
+
+// UpdateState updates the state of a topic and invalidates its cached views
+func (s *Store) UpdateState(ctx context.Context, id, state string) error {
+	defer s.Invalidate(id)
+	return s.Storer.UpdateState(ctx, id, state)
+}
+
+// UpdateReview updates the state and review of a topic and invalidates its cached views
+func (s *Store) UpdateReview(ctx context.Context, id, state string, review *models.Review) error {
+	defer s.Invalidate(id)
+	return s.Storer.UpdateReview(ctx, id, state, review)
+}
+
+// UpsertTopic creates or overwrites a topic and invalidates its cached views
+func (s *Store) UpsertTopic(ctx context.Context, id string, topic *models.TopicResponse) error {
+	defer s.Invalidate(id)
+	return s.Storer.UpsertTopic(ctx, id, topic)
+}
+
+// UpdateTopic updates a topic and invalidates its cached views
+func (s *Store) UpdateTopic(ctx context.Context, host, id string, topic *models.TopicUpdate) error {
+	defer s.Invalidate(id)
+	return s.Storer.UpdateTopic(ctx, host, id, topic)
+}
+
+// InsertTopic inserts a topic and invalidates its cached views
+func (s *Store) InsertTopic(ctx context.Context, topic *models.TopicResponse) error {
+	defer s.Invalidate(topic.ID)
+	return s.Storer.InsertTopic(ctx, topic)
+}
+
+// InsertContent inserts the content of a topic and invalidates its cached views
+func (s *Store) InsertContent(ctx context.Context, content *models.ContentResponse) error {
+	defer s.Invalidate(content.ID)
+	return s.Storer.InsertContent(ctx, content)
+}
+
+// UpsertContent creates or overwrites the content of a topic and invalidates its cached views
+func (s *Store) UpsertContent(ctx context.Context, id string, content *models.ContentResponse) error {
+	defer s.Invalidate(id)
+	return s.Storer.UpsertContent(ctx, id, content)
+}
+
+// ReplaceTopic replaces the versions of a topic and invalidates its cached views
+func (s *Store) ReplaceTopic(ctx context.Context, topic *models.TopicResponse) error {
+	defer s.Invalidate(topic.ID)
+	return s.Storer.ReplaceTopic(ctx, topic)
+}
+
+// ReplaceContent replaces the versions of the content of a topic and invalidates its cached views
+func (s *Store) ReplaceContent(ctx context.Context, content *models.ContentResponse) error {
+	defer s.Invalidate(content.ID)
+	return s.Storer.ReplaceContent(ctx, content)
+}
+
+// DeleteTopic removes a topic and its content and invalidates their cached views
+func (s *Store) DeleteTopic(ctx context.Context, id string) error {
+	defer s.Invalidate(id)
+	return s.Storer.DeleteTopic(ctx, id)
+}
+
+// DeleteContent removes the content of a topic and invalidates its cached views
+func (s *Store) DeleteContent(ctx context.Context, id string) error {
+	defer s.Invalidate(id)
+	return s.Storer.DeleteContent(ctx, id)
+}
+
+// Uncached returns the store.Storer the current views are cached from, which also holds the next views
+func (s *Store) Uncached() store.Storer {
+	return s.Storer
+}
+
+// Invalidate removes the cached views of a topic and its content, and the ids of the archived topics, which the topic
+// may have joined or left. An empty id removes everything.
+func (s *Store) Invalidate(id string) {
+	if id == "" {
+		s.cache.Purge()
+		return
+	}
+
+	s.cache.Invalidate(id)
+	s.cache.Invalidate(archivedGroup)
+}
+
+// Watch invalidates cached views as the watcher reports changes, until the context is done.
+// Everything is purged whenever the stream is (re)opened, as changes may have been missed while it was closed.
+func (s *Store) Watch(ctx context.Context, watcher Watcher, retryInterval time.Duration) {
+	for {
+		err := watcher.Watch(ctx, s.cache.Purge, s.Invalidate)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = errors.New("change stream closed")
+		}
+
+		log.Error(ctx, "cache invalidation change stream failed, retrying", err, log.Data{"retry_interval": retryInterval.String()})
+
+		select {
+		case <-time.After(retryInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	storeMock "github.com/ONSdigital/dp-topic-api/store/mock"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type watcherStub struct {
+	changes []string
+	err     error
+}
+
+func (w *watcherStub) Watch(ctx context.Context, onOpen func(), onChange func(id string)) error {
+	onOpen()
+	for _, id := range w.changes {
+		onChange(id)
+	}
+	<-ctx.Done()
+	return w.err
+}
+
+func TestStore(t *testing.T) {
+	Convey("Given a cached store in front of a backend holding a topic with current and next views", t, func() {
+		ctx := context.Background()
+		backend := &storeMock.StorerMock{
+			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+				if id != "topic1" {
+					return nil, apierrors.ErrTopicNotFound
+				}
+				return &models.TopicResponse{
+					ID:      "topic1",
+					Current: &models.Topic{ID: "topic1", Title: "current"},
+					Next:    &models.Topic{ID: "topic1", Title: "next"},
+				}, nil
+			},
+			CheckTopicExistsFunc: func(ctx context.Context, id string) error { return nil },
+			GetContentFunc: func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+				return &models.ContentResponse{ID: id, Current: &models.Content{State: "published"}, Next: &models.Content{State: "completed"}}, nil
+			},
+			GetArchivedTopicIDsFunc: func(ctx context.Context) ([]string, error) { return []string{"topic2"}, nil },
+			UpdateStateFunc:         func(ctx context.Context, id, state string) error { return nil },
+		}
+		s := NewStore(backend, time.Minute, 100)
+
+		Convey("When a topic is read twice", func() {
+			topic1, err1 := s.GetTopic(ctx, "topic1")
+			topic2, err2 := s.GetTopic(ctx, "topic1")
+
+			Convey("Then the backend is only read once and only the current view is returned", func() {
+				So(err1, ShouldBeNil)
+				So(err2, ShouldBeNil)
+				So(backend.GetTopicCalls(), ShouldHaveLength, 1)
+				So(topic1, ShouldEqual, topic2)
+				So(topic1.Current.Title, ShouldEqual, "current")
+				So(topic1.Next, ShouldBeNil)
+			})
+		})
+
+		Convey("When a topic that does not exist is read twice", func() {
+			_, err1 := s.GetTopic(ctx, "inexistent")
+			_, err2 := s.GetTopic(ctx, "inexistent")
+
+			Convey("Then the error is not cached", func() {
+				So(err1, ShouldEqual, apierrors.ErrTopicNotFound)
+				So(err2, ShouldEqual, apierrors.ErrTopicNotFound)
+				So(backend.GetTopicCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When content is read with different type flags", func() {
+			_, _ = s.GetContent(ctx, "topic1", 1)
+			_, _ = s.GetContent(ctx, "topic1", 1)
+			content, err := s.GetContent(ctx, "topic1", 2)
+
+			Convey("Then each combination of flags is cached separately", func() {
+				So(err, ShouldBeNil)
+				So(backend.GetContentCalls(), ShouldHaveLength, 2)
+				So(content.Next, ShouldBeNil)
+			})
+		})
+
+		Convey("When the state of a cached topic is updated through the store", func() {
+			_, _ = s.GetTopic(ctx, "topic1")
+			_ = s.CheckTopicExists(ctx, "topic1")
+			_, _ = s.GetContent(ctx, "topic1", 1)
+			So(s.UpdateState(ctx, "topic1", models.StatePublished.String()), ShouldBeNil)
+
+			Convey("Then all of its cached views are read again", func() {
+				_, _ = s.GetTopic(ctx, "topic1")
+				_ = s.CheckTopicExists(ctx, "topic1")
+				_, _ = s.GetContent(ctx, "topic1", 1)
+				So(backend.GetTopicCalls(), ShouldHaveLength, 2)
+				So(backend.CheckTopicExistsCalls(), ShouldHaveLength, 2)
+				So(backend.GetContentCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When the archived topics are read twice, and read again after any topic changes", func() {
+			ids, err := s.GetArchivedTopicIDs(ctx)
+			_, _ = s.GetArchivedTopicIDs(ctx)
+			So(backend.GetArchivedTopicIDsCalls(), ShouldHaveLength, 1)
+			s.Invalidate("topic1")
+			_, _ = s.GetArchivedTopicIDs(ctx)
+
+			Convey("Then the backend is only read again once the topic has changed", func() {
+				So(err, ShouldBeNil)
+				So(ids, ShouldResemble, []string{"topic2"})
+				So(backend.GetArchivedTopicIDsCalls(), ShouldHaveLength, 2)
+			})
+		})
+
+		Convey("When a change to a cached topic is watched", func() {
+			_, _ = s.GetTopic(ctx, "topic1")
+
+			watchCtx, cancel := context.WithCancel(ctx)
+			done := make(chan struct{})
+			go func() {
+				s.Watch(watchCtx, &watcherStub{changes: []string{"topic1"}, err: errors.New("closed")}, time.Millisecond)
+				close(done)
+			}()
+
+			So(func() bool {
+				for i := 0; i < 100 && s.cache.Len() > 0; i++ {
+					time.Sleep(time.Millisecond)
+				}
+				return s.cache.Len() == 0
+			}(), ShouldBeTrue)
+
+			Convey("Then the topic is invalidated, and watching stops when the context is done", func() {
+				cancel()
+				<-done
+				_, _ = s.GetTopic(ctx, "topic1")
+				So(backend.GetTopicCalls(), ShouldHaveLength, 2)
+			})
+		})
+	})
+}
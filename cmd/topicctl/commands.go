@@ -0,0 +1,647 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-mongodb/v3/dplock"
+	"github.com/ONSdigital/dp-topic-api/api"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/bulk"
+	"github.com/ONSdigital/dp-topic-api/integrity"
+	"github.com/ONSdigital/dp-topic-api/memory"
+	"github.com/ONSdigital/dp-topic-api/migrations"
+	"github.com/ONSdigital/dp-topic-api/models"
+)
+
+// rootID is the id of the topic whose subtopics are the top level topics
+const rootID = "topic_root"
+
+// editor is recorded as the last editor of the topics updated by topicctl
+const editor = "topicctl"
+
+// The alphabet and length of the ids generated for added topics
+const (
+	idAlphabet = "123456789"
+	idSize     = 4
+)
+
+//go:embed data/seed.json
+var defaultSeedData []byte
+
+var commands = []command{
+	{
+		name:        "seed",
+		description: "Seed an empty database with the root topic and a tree of topics",
+		flags: func(fs *flag.FlagSet, opts *options) {
+			fs.BoolVar(&opts.dryRun, "dry-run", false, "report the changes without making them")
+			fs.StringVar(&opts.file, "file", "", "a JSON file of the topics to seed (defaults to the built in seed data)")
+		},
+		run: seed,
+	},
+	{
+		name:        "add",
+		description: "Add topics (and their subtopics) under existing parent topics",
+		flags: func(fs *flag.FlagSet, opts *options) {
+			fs.BoolVar(&opts.dryRun, "dry-run", false, "report the changes without making them")
+			fs.StringVar(&opts.file, "file", "", "a JSON file of the topics to add, each with a parent_id (required)")
+		},
+		run: add,
+	},
+	{
+		name:        "wipe",
+		description: "Delete every topic and content document",
+		flags: func(fs *flag.FlagSet, opts *options) {
+			fs.BoolVar(&opts.dryRun, "dry-run", false, "report the changes without making them")
+			fs.BoolVar(&opts.yes, "yes", false, "confirm that everything should be deleted (required unless --dry-run)")
+		},
+		run: wipe,
+	},
+	{
+		name:        "set-release-date",
+		description: "Set the release date of the next version of a topic",
+		flags: func(fs *flag.FlagSet, opts *options) {
+			fs.BoolVar(&opts.dryRun, "dry-run", false, "report the changes without making them")
+			fs.StringVar(&opts.id, "id", "", "the id of the topic (required)")
+			fs.StringVar(&opts.releaseDate, "date", "", "the release date, in RFC3339 format e.g. 2022-10-10T08:30:00Z (required)")
+		},
+		run: setReleaseDate,
+	},
+	{
+		name:        "publish",
+		description: "Publish a topic, replacing its current version with its next version",
+		flags: func(fs *flag.FlagSet, opts *options) {
+			fs.BoolVar(&opts.dryRun, "dry-run", false, "report the changes without making them")
+			fs.StringVar(&opts.id, "id", "", "the id of the topic (required)")
+		},
+		run: publish,
+	},
+	{
+		name:        "bulk-update",
+		description: "Update the next versions of topics from a CSV file, with a row for each topic",
+		flags: func(fs *flag.FlagSet, opts *options) {
+			fs.BoolVar(&opts.dryRun, "dry-run", false, "report the changes without making them")
+			fs.StringVar(&opts.file, "file", "", "a CSV file with an id column and a column for each field to update (required)")
+			fs.BoolVar(&opts.partial, "partial", false, "apply the valid rows even if other rows are invalid")
+		},
+		run: bulkUpdate,
+	},
+	{
+		name:        "tree",
+		description: "Print the tree of topics",
+		flags: func(fs *flag.FlagSet, opts *options) {
+			fs.StringVar(&opts.root, "root", rootID, "the id of the topic at the top of the tree")
+			fs.BoolVar(&opts.next, "next", false, "print the next versions of the topics, rather than the current ones")
+		},
+		run: tree,
+	},
+	{
+		name:        "export",
+		description: "Export every topic and content document as a JSON fixture, which can seed the memory store",
+		flags: func(fs *flag.FlagSet, opts *options) {
+			fs.StringVar(&opts.output, "output", "", "the file to write to (defaults to stdout)")
+		},
+		run: export,
+	},
+	{
+		name:        "check",
+		description: "Check the references between topics, and to their content, optionally fixing those that can be",
+		flags: func(fs *flag.FlagSet, opts *options) {
+			fs.BoolVar(&opts.dryRun, "dry-run", false, "report the changes --fix would make without making them")
+			fs.BoolVar(&opts.fix, "fix", false, "fix the problems that do not need an editor to decide how")
+		},
+		run: check,
+	},
+	{
+		name:        "migrate",
+		description: "Apply the pending schema migrations",
+		flags: func(fs *flag.FlagSet, opts *options) {
+			fs.BoolVar(&opts.dryRun, "dry-run", false, "report how many documents each pending migration would change, without changing them")
+			fs.BoolVar(&opts.status, "status", false, "list the migrations that have been applied and those that are pending, without applying them")
+		},
+		run: migrate,
+	},
+}
+
+// migrationStore represents the methods the migrate command requires from the database
+type migrationStore interface {
+	migrations.Database
+	NewMigrationsLock(ctx context.Context) *dplock.Lock
+}
+
+// topicData is the definition of a topic to create, as read from a seed or add file
+type topicData struct {
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	ParentID    string      `json:"parent_id,omitempty"`
+	Subtopics   []topicData `json:"subtopics,omitempty"`
+}
+
+func seed(ctx context.Context, a *app, opts *options, _ []string) error {
+	data, err := readTopicData(opts.file, defaultSeedData)
+	if err != nil {
+		return err
+	}
+
+	err = a.store.CheckTopicExists(ctx, rootID)
+	switch {
+	case err == nil:
+		return fmt.Errorf("the database has already been seeded, as the %s topic exists", rootID)
+	case !errors.Is(err, apierrors.ErrTopicNotFound):
+		return err
+	}
+
+	root := &models.TopicResponse{
+		ID:      rootID,
+		Current: &models.Topic{ID: rootID, State: models.StatePublished.String(), SubtopicIds: &[]string{}},
+		Next:    &models.Topic{ID: rootID, State: models.StatePublished.String(), SubtopicIds: &[]string{}},
+	}
+
+	var topics []*models.TopicResponse
+	for i, d := range data {
+		id := fmt.Sprintf("topic-%d", i)
+		topics = append(topics, a.buildTopics(d, id)...)
+		addSubtopic(root, id)
+	}
+
+	if err := checkSlugs(ctx, a.store, topics); err != nil {
+		return err
+	}
+
+	return a.insertTopics(ctx, append([]*models.TopicResponse{root}, topics...))
+}
+
+func add(ctx context.Context, a *app, opts *options, _ []string) error {
+	if opts.file == "" {
+		return fmt.Errorf("%w: --file is required", errUsage)
+	}
+
+	data, err := readTopicData(opts.file, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range data {
+		if d.ParentID == "" {
+			return fmt.Errorf("topic %q has no parent_id", d.Title)
+		}
+
+		parent, err := a.store.GetTopic(ctx, d.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to get parent topic %s: %w", d.ParentID, err)
+		}
+
+		id, err := generateUnusedID(ctx, a.store)
+		if err != nil {
+			return err
+		}
+
+		topics := a.buildTopics(d, id)
+		if err := checkSlugs(ctx, a.store, topics); err != nil {
+			return err
+		}
+		if err := a.insertTopics(ctx, topics); err != nil {
+			return err
+		}
+
+		addSubtopic(parent, id)
+		setSubtopicsLink(parent, a.topicURL(parent.ID))
+		if err := a.store.UpsertTopic(ctx, parent.ID, parent); err != nil {
+			return fmt.Errorf("failed to add topic %s to parent topic %s: %w", id, parent.ID, err)
+		}
+
+		fmt.Fprintf(a.out, "added topic %s (%s) under %s\n", id, d.Title, parent.ID)
+	}
+
+	return nil
+}
+
+func wipe(ctx context.Context, a *app, opts *options, _ []string) error {
+	if !opts.yes && !opts.dryRun {
+		return fmt.Errorf("%w: wipe deletes every topic and content document, pass --yes to confirm", errUsage)
+	}
+
+	if err := a.store.DeleteAllTopicsAndContent(ctx); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(a.out, "wiped every topic and content document")
+	return nil
+}
+
+func setReleaseDate(ctx context.Context, a *app, opts *options, _ []string) error {
+	if opts.id == "" || opts.releaseDate == "" {
+		return fmt.Errorf("%w: --id and --date are required", errUsage)
+	}
+
+	releaseDate, err := time.Parse(time.RFC3339, opts.releaseDate)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, apierrors.ErrInvalidReleaseDate)
+	}
+
+	if err := a.store.UpdateReleaseDate(ctx, opts.id, releaseDate); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.out, "set the release date of topic %s to %s\n", opts.id, releaseDate.Format(time.RFC3339))
+	return nil
+}
+
+func publish(ctx context.Context, a *app, opts *options, _ []string) error {
+	if opts.id == "" {
+		return fmt.Errorf("%w: --id is required", errUsage)
+	}
+
+	topic, err := a.store.GetTopic(ctx, opts.id)
+	if err != nil {
+		return err
+	}
+	if topic.Next == nil {
+		return fmt.Errorf("topic %s has no next version to publish", opts.id)
+	}
+
+	if err := models.CheckTransition(topic.Next.State, models.StatePublished.String(), a.cfg.EnableTopicReview); err != nil {
+		return err
+	}
+
+	// the topic is published as the API publishes it, recording its publication for the feeds and the redirects of
+	// any paths it moves
+	if err := api.PublishTopic(ctx, a.store, opts.id); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.out, "published topic %s\n", opts.id)
+	return nil
+}
+
+func bulkUpdate(ctx context.Context, a *app, opts *options, _ []string) error {
+	if opts.file == "" {
+		return fmt.Errorf("%w: --file is required", errUsage)
+	}
+
+	f, err := os.Open(opts.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rows, err := bulk.Read(f)
+	if err != nil {
+		return printViolations(a.out, err)
+	}
+
+	report, err := bulk.Apply(ctx, a.store, a.cfg.TopicAPIURL, editor, rows, opts.partial)
+	if err != nil {
+		return printViolations(a.out, err)
+	}
+
+	for _, row := range report.Rows {
+		for _, field := range row.Errors {
+			fmt.Fprintf(a.out, "line %d: %s: %s\n", row.Line, field.Field, field.Message)
+		}
+	}
+	fmt.Fprintf(a.out, "updated %d topics, skipping %d invalid rows\n", report.Count(bulk.StatusUpdated), report.Count(bulk.StatusInvalid))
+	return nil
+}
+
+// printViolations prints every invalid field of a validation error, and returns the error
+func printViolations(out io.Writer, err error) error {
+	var violations *apierrors.ValidationError
+	if errors.As(err, &violations) {
+		for _, field := range violations.Fields {
+			fmt.Fprintf(out, "%s: %s\n", field.Field, field.Message)
+		}
+		return fmt.Errorf("no topics were updated: %w", err)
+	}
+	return err
+}
+
+// check reports every problem with the references of the taxonomy, failing if any is left unfixed
+func check(ctx context.Context, a *app, opts *options, _ []string) error {
+	report, err := integrity.Run(ctx, a.store, a.cfg.TopicAPIURL, opts.fix)
+	if err != nil {
+		return err
+	}
+
+	for _, problem := range report.Problems {
+		topic := problem.TopicID
+		if problem.Version != "" {
+			topic += " (" + problem.Version + ")"
+		}
+		status := ""
+		switch {
+		case problem.Fixed:
+			status = " [fixed]"
+		case !problem.Fixable:
+			status = " [needs an editor]"
+		}
+		fmt.Fprintf(a.out, "%s: %s: %s%s\n", problem.Kind, topic, problem.Detail, status)
+	}
+	fmt.Fprintf(a.out, "found %d problems, fixed %d\n", len(report.Problems), report.Count(true))
+
+	if unfixed := report.Count(false); unfixed > 0 {
+		return fmt.Errorf("%d problems are not fixed", unfixed)
+	}
+	return nil
+}
+
+func tree(ctx context.Context, a *app, opts *options, _ []string) error {
+	return a.printTree(ctx, opts.root, opts.next, 0, map[string]bool{})
+}
+
+func (a *app) printTree(ctx context.Context, id string, next bool, depth int, visited map[string]bool) error {
+	indent := strings.Repeat("  ", depth)
+	if visited[id] {
+		fmt.Fprintf(a.out, "%s%s (cycle)\n", indent, id)
+		return nil
+	}
+	visited[id] = true
+
+	topic, err := a.store.GetTopic(ctx, id)
+	if errors.Is(err, apierrors.ErrTopicNotFound) && depth > 0 {
+		fmt.Fprintf(a.out, "%s%s (missing)\n", indent, id)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	view := topic.Current
+	if next {
+		view = topic.Next
+	}
+	if view == nil {
+		fmt.Fprintf(a.out, "%s%s (unpublished)\n", indent, id)
+		return nil
+	}
+
+	fmt.Fprintf(a.out, "%s%s", indent, id)
+	if view.Title != "" {
+		fmt.Fprintf(a.out, "  %s", view.Title)
+	}
+	fmt.Fprintf(a.out, " [%s]\n", view.State)
+
+	if view.SubtopicIds == nil {
+		return nil
+	}
+	for _, subtopicID := range *view.SubtopicIds {
+		if err := a.printTree(ctx, subtopicID, next, depth+1, visited); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func export(ctx context.Context, a *app, opts *options, _ []string) error {
+	topics, err := a.store.GetAllTopics(ctx)
+	if err != nil {
+		return err
+	}
+
+	content, err := a.store.GetAllContent(ctx)
+	if err != nil {
+		return err
+	}
+
+	out := a.out
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(memory.Fixture{Topics: topics, Content: content})
+}
+
+func migrate(ctx context.Context, a *app, opts *options, _ []string) error {
+	// migrations report their own dry run, so they need the database itself
+	store := a.store
+	if d, ok := store.(*dryRunStore); ok {
+		store = d.topicStore
+	}
+	db, ok := store.(migrationStore)
+	if !ok {
+		return errors.New("schema migrations are only supported by mongo db")
+	}
+
+	if opts.status {
+		return printMigrationStatus(ctx, a.out, db)
+	}
+
+	lock := db.NewMigrationsLock(ctx)
+	defer lock.Close(ctx)
+
+	results, err := migrations.New(db, lock, migrations.All).Migrate(ctx, opts.dryRun)
+	for _, result := range results {
+		verb := "applied"
+		if opts.dryRun {
+			verb = "would apply"
+		}
+		fmt.Fprintf(a.out, "%s %s, changing %d documents\n", verb, result.ID, result.DocumentsChanged)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(a.out, "no pending migrations")
+	}
+	return nil
+}
+
+func printMigrationStatus(ctx context.Context, out io.Writer, db migrations.Database) error {
+	applied, err := db.GetSchemaMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	appliedAt := make(map[string]string, len(applied))
+	for _, migration := range applied {
+		appliedAt[migration.ID] = "applied"
+		if migration.AppliedAt != nil {
+			appliedAt[migration.ID] += " " + migration.AppliedAt.Format(time.RFC3339)
+		}
+	}
+
+	for _, migration := range migrations.All {
+		status, ok := appliedAt[migration.ID]
+		if !ok {
+			status = "pending"
+		}
+		fmt.Fprintf(out, "%-30s %-28s %s\n", migration.ID, status, migration.Description)
+	}
+
+	return nil
+}
+
+// readTopicData reads the topics to create from a JSON file, or from defaultData if no file is given
+func readTopicData(path string, defaultData []byte) ([]topicData, error) {
+	b := defaultData
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		if b, err = io.ReadAll(f); err != nil {
+			return nil, err
+		}
+	}
+
+	var data []topicData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse topics: %w", err)
+	}
+
+	return data, nil
+}
+
+// buildTopics returns the published topic documents for a topic and all its subtopics, whose ids are derived from id
+func (a *app) buildTopics(d topicData, id string) []*models.TopicResponse {
+	topic := &models.Topic{
+		ID:          id,
+		Title:       d.Title,
+		Description: d.Description,
+		State:       models.StatePublished.String(),
+		Slug:        models.GenerateSlug(d.Title),
+		Links: &models.TopicLinks{
+			Self:    &models.LinkObject{HRef: a.topicURL(id), ID: id},
+			Content: &models.LinkObject{HRef: a.topicURL(id) + "/content"},
+		},
+	}
+
+	var subtopics []*models.TopicResponse
+	if len(d.Subtopics) > 0 {
+		ids := make([]string, 0, len(d.Subtopics))
+		for i, subtopic := range d.Subtopics {
+			subtopicID := fmt.Sprintf("%s-sub-%d", id, i)
+			ids = append(ids, subtopicID)
+			subtopics = append(subtopics, a.buildTopics(subtopic, subtopicID)...)
+		}
+		topic.SubtopicIds = &ids
+		topic.Links.Subtopics = &models.LinkObject{HRef: a.topicURL(id) + "/subtopics"}
+	}
+
+	next := *topic
+	return append([]*models.TopicResponse{{ID: id, Current: topic, Next: &next}}, subtopics...)
+}
+
+// insertTopics inserts topic documents, each with an empty published content document
+func (a *app) insertTopics(ctx context.Context, topics []*models.TopicResponse) error {
+	for _, topic := range topics {
+		if err := a.store.InsertTopic(ctx, topic); err != nil {
+			return fmt.Errorf("failed to insert topic %s: %w", topic.ID, err)
+		}
+
+		content := &models.ContentResponse{
+			ID:      topic.ID,
+			Current: &models.Content{State: models.StatePublished.String()},
+			Next:    &models.Content{State: models.StatePublished.String()},
+		}
+		if err := a.store.InsertContent(ctx, content); err != nil {
+			return fmt.Errorf("failed to insert content %s: %w", topic.ID, err)
+		}
+	}
+
+	if a.dryRun() {
+		fmt.Fprintf(a.out, "would insert %d topics\n", len(topics))
+		return nil
+	}
+	fmt.Fprintf(a.out, "inserted %d topics\n", len(topics))
+	return nil
+}
+
+// dryRun returns whether the changes of the command are only reported, without being made
+func (a *app) dryRun() bool {
+	_, ok := a.store.(*dryRunStore)
+	return ok
+}
+
+func (a *app) topicURL(id string) string {
+	return a.cfg.TopicAPIURL + "/topics/" + id
+}
+
+// checkSlugs checks that the slugs of new topics are unique, both amongst themselves and in the database
+func checkSlugs(ctx context.Context, store topicStore, topics []*models.TopicResponse) error {
+	seen := make(map[string]string)
+	for _, topic := range topics {
+		slug := topic.Next.Slug
+		if other, ok := seen[slug]; ok {
+			return fmt.Errorf("topics %s and %s would have the same slug %q", other, topic.ID, slug)
+		}
+		seen[slug] = topic.ID
+
+		inUse, err := store.IsSlugInUse(ctx, topic.ID, slug)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return fmt.Errorf("the slug %q of topic %s is already in use", slug, topic.ID)
+		}
+	}
+
+	return nil
+}
+
+// addSubtopic adds a subtopic to both the current and next versions of a topic
+func addSubtopic(topic *models.TopicResponse, subtopicID string) {
+	for _, view := range []*models.Topic{topic.Current, topic.Next} {
+		if view == nil {
+			continue
+		}
+		if view.SubtopicIds == nil {
+			view.SubtopicIds = &[]string{}
+		}
+		*view.SubtopicIds = append(*view.SubtopicIds, subtopicID)
+	}
+}
+
+// setSubtopicsLink sets the subtopics link of both the current and next versions of a topic, if it is missing
+func setSubtopicsLink(topic *models.TopicResponse, topicURL string) {
+	for _, view := range []*models.Topic{topic.Current, topic.Next} {
+		if view == nil {
+			continue
+		}
+		if view.Links == nil {
+			view.Links = &models.TopicLinks{}
+		}
+		if view.Links.Subtopics == nil {
+			view.Links.Subtopics = &models.LinkObject{HRef: topicURL + "/subtopics"}
+		}
+	}
+}
+
+// generateUnusedID generates a random id that no topic has
+func generateUnusedID(ctx context.Context, store topicStore) (string, error) {
+	for {
+		b := make([]byte, idSize)
+		for i := range b {
+			b[i] = idAlphabet[rand.IntN(len(idAlphabet))]
+		}
+		id := string(b)
+
+		err := store.CheckTopicExists(ctx, id)
+		switch {
+		case errors.Is(err, apierrors.ErrTopicNotFound):
+			return id, nil
+		case err != nil:
+			return "", err
+		}
+	}
+}
@@ -0,0 +1,220 @@
+// topicctl is a command line tool for maintaining the topics database.
+//
+// Usage:
+//
+//	topicctl <command> [flags]
+//
+// The database is configured through the same environment variables as the service (see config.Get).
+// It exits with 0 on success, 1 if the command failed, and 2 if it was used incorrectly.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/dp-topic-api/mongo"
+	"github.com/ONSdigital/dp-topic-api/store"
+)
+
+// Exit codes
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// topicStore represents the methods topicctl requires from the database: those of the service, so that topics are
+// published as the API publishes them, and the administration of the whole database
+type topicStore interface {
+	store.Storer
+	DeleteAllTopicsAndContent(ctx context.Context) error
+}
+
+// command is a topicctl subcommand, run with its flags already parsed
+type command struct {
+	name        string
+	description string
+	flags       func(fs *flag.FlagSet, opts *options)
+	run         func(ctx context.Context, app *app, opts *options, args []string) error
+}
+
+// options holds the values of the flags of every command, each command only registers the flags it uses
+type options struct {
+	dryRun      bool
+	file        string
+	id          string
+	releaseDate string
+	output      string
+	root        string
+	next        bool
+	yes         bool
+	status      bool
+	partial     bool
+	fix         bool
+}
+
+// app holds what a command needs to run
+type app struct {
+	cfg   *config.Config
+	store topicStore
+	out   io.Writer
+}
+
+// errUsage is returned by a command that has been used incorrectly
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr, openMongo))
+}
+
+func openMongo(ctx context.Context, cfg *config.Config) (topicStore, func(context.Context) error, error) {
+	m, err := mongo.NewDBConnection(ctx, cfg.MongoConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, m.Close, nil
+}
+
+// run runs the command named by the first argument, using open to connect to the database, and returns the exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, open func(context.Context, *config.Config) (topicStore, func(context.Context) error, error)) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		printUsage(stderr)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	opts := &options{}
+	fs := flag.NewFlagSet("topicctl "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	if cmd.flags != nil {
+		cmd.flags(fs, opts)
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	cfg, err := config.Get()
+	if err != nil {
+		fmt.Fprintf(stderr, "error getting config: %v\n", err)
+		return exitFailure
+	}
+
+	store, closeStore, err := open(ctx, cfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "error connecting to the database: %v\n", err)
+		return exitFailure
+	}
+	defer func() { _ = closeStore(ctx) }()
+
+	a := &app{cfg: cfg, store: store, out: stdout}
+	if opts.dryRun {
+		a.store = &dryRunStore{topicStore: store, out: stdout}
+		fmt.Fprintln(stdout, "dry run: no changes will be made")
+	}
+
+	if err := cmd.run(ctx, a, opts, fs.Args()); err != nil {
+		fmt.Fprintf(stderr, "%s failed: %v\n", cmd.name, err)
+		if errors.Is(err, errUsage) {
+			fs.Usage()
+			return exitUsage
+		}
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: topicctl <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-18s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'topicctl <command> -h' for the flags of a command.")
+}
+
+// dryRunStore reports the changes that would be made to the database, without making them
+type dryRunStore struct {
+	topicStore
+	out io.Writer
+}
+
+func (d *dryRunStore) InsertTopic(_ context.Context, topic *models.TopicResponse) error {
+	fmt.Fprintf(d.out, "would insert topic %s\n", topic.ID)
+	return nil
+}
+
+func (d *dryRunStore) InsertContent(_ context.Context, content *models.ContentResponse) error {
+	fmt.Fprintf(d.out, "would insert content %s\n", content.ID)
+	return nil
+}
+
+func (d *dryRunStore) UpsertTopic(_ context.Context, id string, _ *models.TopicResponse) error {
+	fmt.Fprintf(d.out, "would update topic %s\n", id)
+	return nil
+}
+
+func (d *dryRunStore) ReplaceTopic(_ context.Context, topic *models.TopicResponse) error {
+	fmt.Fprintf(d.out, "would replace topic %s\n", topic.ID)
+	return nil
+}
+
+func (d *dryRunStore) UpdateReleaseDate(_ context.Context, id string, releaseDate time.Time) error {
+	fmt.Fprintf(d.out, "would set the release date of topic %s to %s\n", id, releaseDate.Format(time.RFC3339))
+	return nil
+}
+
+func (d *dryRunStore) UpdateTopic(_ context.Context, _, id string, _ *models.TopicUpdate) error {
+	fmt.Fprintf(d.out, "would update the next version of topic %s\n", id)
+	return nil
+}
+
+func (d *dryRunStore) InsertPublication(_ context.Context, publication *models.Publication) error {
+	fmt.Fprintf(d.out, "would record the publication of topic %s\n", publication.TopicID)
+	return nil
+}
+
+func (d *dryRunStore) UpsertRedirect(_ context.Context, redirect *models.Redirect) error {
+	fmt.Fprintf(d.out, "would redirect %s to %s\n", redirect.From, redirect.To)
+	return nil
+}
+
+func (d *dryRunStore) DeleteRedirect(_ context.Context, from string) error {
+	fmt.Fprintf(d.out, "would delete any redirect from %s\n", from)
+	return nil
+}
+
+func (d *dryRunStore) DeleteAllTopicsAndContent(_ context.Context) error {
+	fmt.Fprintln(d.out, "would delete every topic and content document")
+	return nil
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/memory"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func openStore(s *memory.Store) func(context.Context, *config.Config) (topicStore, func(context.Context) error, error) {
+	return func(context.Context, *config.Config) (topicStore, func(context.Context) error, error) {
+		return s, s.Close, nil
+	}
+}
+
+func runCommand(s *memory.Store, args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = run(context.Background(), args, &out, &errOut, openStore(s))
+	return code, out.String(), errOut.String()
+}
+
+func TestRun(t *testing.T) {
+	Convey("Given an empty database", t, func() {
+		ctx := context.Background()
+		s := memory.New()
+
+		Convey("When no command is given, the usage is printed and the exit code is 2", func() {
+			code, _, stderr := runCommand(s)
+			So(code, ShouldEqual, exitUsage)
+			So(stderr, ShouldContainSubstring, "Usage: topicctl <command> [flags]")
+		})
+
+		Convey("When an unknown command is given, the exit code is 2", func() {
+			code, _, stderr := runCommand(s, "unknown")
+			So(code, ShouldEqual, exitUsage)
+			So(stderr, ShouldContainSubstring, `unknown command "unknown"`)
+		})
+
+		Convey("When an unknown flag is given, the exit code is 2", func() {
+			code, _, _ := runCommand(s, "seed", "--unknown")
+			So(code, ShouldEqual, exitUsage)
+		})
+
+		Convey("When migrating, the exit code is 1 as the memory store has no schema migrations", func() {
+			code, _, stderr := runCommand(s, "migrate", "--dry-run")
+			So(code, ShouldEqual, exitFailure)
+			So(stderr, ShouldContainSubstring, "only supported by mongo db")
+		})
+
+		Convey("When seeding is dry run, nothing is inserted", func() {
+			code, stdout, _ := runCommand(s, "seed", "--dry-run")
+			So(code, ShouldEqual, exitOK)
+			So(stdout, ShouldContainSubstring, "would insert topic topic_root")
+			So(stdout, ShouldContainSubstring, "would insert 22 topics")
+			So(stdout, ShouldNotContainSubstring, "inserted")
+
+			topics, err := s.GetAllTopics(ctx)
+			So(err, ShouldBeNil)
+			So(topics, ShouldBeEmpty)
+		})
+
+		Convey("When the database is seeded", func() {
+			code, _, _ := runCommand(s, "seed")
+			So(code, ShouldEqual, exitOK)
+
+			Convey("Then the root topic lists the top level topics, which list their subtopics", func() {
+				root, err := s.GetTopic(ctx, rootID)
+				So(err, ShouldBeNil)
+				So((*root.Current.SubtopicIds)[0], ShouldEqual, "topic-0")
+
+				topic, err := s.GetTopic(ctx, "topic-0")
+				So(err, ShouldBeNil)
+				So(topic.Current.Title, ShouldEqual, "Business, industry and trade")
+				So(topic.Current.Slug, ShouldEqual, "businessindustryandtrade")
+				So((*topic.Next.SubtopicIds)[0], ShouldEqual, "topic-0-sub-0")
+				So(topic.Next.Links.Self.HRef, ShouldEqual, "http://localhost:25300/topics/topic-0")
+
+				_, err = s.GetContent(ctx, "topic-0-sub-0", 0)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("Then seeding again fails with exit code 1", func() {
+				code, _, stderr := runCommand(s, "seed")
+				So(code, ShouldEqual, exitFailure)
+				So(stderr, ShouldContainSubstring, "already been seeded")
+			})
+
+			Convey("Then the tree can be printed", func() {
+				code, stdout, _ := runCommand(s, "tree", "--root", "topic-0")
+				So(code, ShouldEqual, exitOK)
+				So(stdout, ShouldStartWith, "topic-0  Business, industry and trade [published]\n  topic-0-sub-0  Business [published]\n")
+			})
+
+			Convey("When topics are added under an existing topic", func() {
+				file := filepath.Join(t.TempDir(), "add.json")
+				So(os.WriteFile(file, []byte(`[{"title": "New topic", "description": "New", "parent_id": "topic-0",
+					"subtopics": [{"title": "New subtopic", "description": "New"}]}]`), 0o600), ShouldBeNil)
+
+				code, stdout, _ := runCommand(s, "add", "--file", file)
+				So(code, ShouldEqual, exitOK)
+
+				Convey("Then they are added to the parent topic", func() {
+					parent, err := s.GetTopic(ctx, "topic-0")
+					So(err, ShouldBeNil)
+					ids := *parent.Next.SubtopicIds
+					id := ids[len(ids)-1]
+					So(stdout, ShouldContainSubstring, "added topic "+id)
+					So(*parent.Current.SubtopicIds, ShouldResemble, ids)
+
+					subtopic, err := s.GetTopic(ctx, id+"-sub-0")
+					So(err, ShouldBeNil)
+					So(subtopic.Current.Slug, ShouldEqual, "newsubtopic")
+				})
+
+				Convey("Then adding them again fails as their slugs are in use", func() {
+					code, _, stderr := runCommand(s, "add", "--file", file)
+					So(code, ShouldEqual, exitFailure)
+					So(stderr, ShouldContainSubstring, `the slug "newtopic"`)
+				})
+			})
+
+			Convey("When the database is exported", func() {
+				code, stdout, _ := runCommand(s, "export")
+				So(code, ShouldEqual, exitOK)
+
+				Convey("Then the export can seed the memory store", func() {
+					var fixture memory.Fixture
+					So(json.Unmarshal([]byte(stdout), &fixture), ShouldBeNil)
+					So(fixture.Topics[0].ID, ShouldEqual, "topic-0")
+					So(fixture.Content, ShouldHaveLength, len(fixture.Topics))
+				})
+			})
+
+			Convey("When wipe is not confirmed, nothing is deleted and the exit code is 2", func() {
+				code, _, _ := runCommand(s, "wipe")
+				So(code, ShouldEqual, exitUsage)
+				So(s.CheckTopicExists(ctx, rootID), ShouldBeNil)
+			})
+
+			Convey("When wipe is confirmed, everything is deleted", func() {
+				code, _, _ := runCommand(s, "wipe", "--yes")
+				So(code, ShouldEqual, exitOK)
+
+				topics, err := s.GetAllTopics(ctx)
+				So(err, ShouldBeNil)
+				So(topics, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a database with an unpublished change to a topic", t, func() {
+		ctx := context.Background()
+		s := memory.New()
+		So(s.InsertTopic(ctx, &models.TopicResponse{
+			ID:      "1",
+			Current: &models.Topic{ID: "1", Title: "old", State: models.StatePublished.String()},
+			Next:    &models.Topic{ID: "1", Title: "new", State: models.StateCompleted.String()},
+		}), ShouldBeNil)
+
+		Convey("When the release date is set", func() {
+			code, _, _ := runCommand(s, "set-release-date", "--id", "1", "--date", "2022-10-10T08:30:00Z")
+			So(code, ShouldEqual, exitOK)
+
+			topic, err := s.GetTopic(ctx, "1")
+			So(err, ShouldBeNil)
+			So(topic.Next.ReleaseDate.Format("2006-01-02"), ShouldEqual, "2022-10-10")
+			So(topic.Current.ReleaseDate, ShouldBeNil)
+		})
+
+		Convey("When the release date is not RFC3339, the exit code is 2", func() {
+			code, _, _ := runCommand(s, "set-release-date", "--id", "1", "--date", "10/10/2022")
+			So(code, ShouldEqual, exitUsage)
+		})
+
+		Convey("When the topic is published, its next version becomes current, and its publication is recorded as the API records it", func() {
+			code, _, _ := runCommand(s, "publish", "--id", "1")
+			So(code, ShouldEqual, exitOK)
+
+			topic, err := s.GetTopic(ctx, "1")
+			So(err, ShouldBeNil)
+			So(topic.Current.Title, ShouldEqual, "new")
+			So(topic.Current.State, ShouldEqual, models.StatePublished.String())
+
+			publications, err := s.GetPublications(ctx, "1", 1)
+			So(err, ShouldBeNil)
+			So(publications, ShouldHaveLength, 1)
+			So(publications[0].Title, ShouldEqual, "new")
+		})
+
+		Convey("When the publication is dry run, nothing is published or recorded", func() {
+			code, stdout, _ := runCommand(s, "publish", "--id", "1", "--dry-run")
+			So(code, ShouldEqual, exitOK)
+			So(stdout, ShouldContainSubstring, "would record the publication of topic 1")
+
+			topic, err := s.GetTopic(ctx, "1")
+			So(err, ShouldBeNil)
+			So(topic.Current.Title, ShouldEqual, "old")
+			publications, err := s.GetPublications(ctx, "1", 1)
+			So(err, ShouldBeNil)
+			So(publications, ShouldBeEmpty)
+		})
+
+		Convey("When an unknown topic is published, the exit code is 1", func() {
+			code, _, stderr := runCommand(s, "publish", "--id", "2")
+			So(code, ShouldEqual, exitFailure)
+			So(stderr, ShouldContainSubstring, "topic not found")
+		})
+
+		writeCSV := func(body string) string {
+			path := filepath.Join(t.TempDir(), "topics.csv")
+			So(os.WriteFile(path, []byte(body), 0o600), ShouldBeNil)
+			return path
+		}
+
+		Convey("When the topics are bulk updated from CSV, the next version is updated", func() {
+			path := writeCSV("id,description,keywords,release_date\n1,Described,a;b,2022-10-10T08:30:00Z\n")
+			code, stdout, _ := runCommand(s, "bulk-update", "--file", path)
+			So(code, ShouldEqual, exitOK)
+			So(stdout, ShouldContainSubstring, "updated 1 topics")
+
+			topic, err := s.GetTopic(ctx, "1")
+			So(err, ShouldBeNil)
+			So(topic.Next.Title, ShouldEqual, "new")
+			So(topic.Next.Description, ShouldEqual, "Described")
+			So(*topic.Next.Keywords, ShouldResemble, []string{"a", "b"})
+			So(topic.Current.Description, ShouldBeEmpty)
+		})
+
+		Convey("When a row of the CSV is invalid, nothing is updated and the exit code is 1", func() {
+			path := writeCSV("id,description,release_date\n1,Described,2022-10-10T08:30:00Z\n2,Unknown,2022-10-10T08:30:00Z\n")
+			code, stdout, _ := runCommand(s, "bulk-update", "--file", path)
+			So(code, ShouldEqual, exitFailure)
+			So(stdout, ShouldContainSubstring, "line 3: id: must be the id of an existing topic")
+
+			topic, err := s.GetTopic(ctx, "1")
+			So(err, ShouldBeNil)
+			So(topic.Next.Description, ShouldBeEmpty)
+
+			Convey("Then with --partial, the valid rows are updated", func() {
+				code, stdout, _ := runCommand(s, "bulk-update", "--file", path, "--partial")
+				So(code, ShouldEqual, exitOK)
+				So(stdout, ShouldContainSubstring, "updated 1 topics, skipping 1 invalid rows")
+			})
+		})
+	})
+
+	Convey("Given a database whose root topic has a subtopic that does not exist", t, func() {
+		ctx := context.Background()
+		s := memory.New()
+		So(s.InsertTopic(ctx, &models.TopicResponse{
+			ID:      rootID,
+			Current: &models.Topic{ID: rootID, State: models.StatePublished.String(), SubtopicIds: &[]string{"deleted"}},
+		}), ShouldBeNil)
+		So(s.InsertContent(ctx, &models.ContentResponse{ID: rootID}), ShouldBeNil)
+
+		Convey("When it is checked, the problem is reported and the exit code is 1", func() {
+			code, stdout, stderr := runCommand(s, "check")
+			So(code, ShouldEqual, exitFailure)
+			So(stdout, ShouldContainSubstring, "dangling_subtopic: topic_root (current): subtopic deleted does not exist\n")
+			So(stderr, ShouldContainSubstring, "1 problems are not fixed")
+		})
+
+		Convey("When it is fixed as a dry run, nothing is changed", func() {
+			code, stdout, _ := runCommand(s, "check", "--fix", "--dry-run")
+			So(code, ShouldEqual, exitOK)
+			So(stdout, ShouldContainSubstring, "would replace topic topic_root")
+
+			topic, err := s.GetTopic(ctx, rootID)
+			So(err, ShouldBeNil)
+			So(*topic.Current.SubtopicIds, ShouldResemble, []string{"deleted"})
+		})
+
+		Convey("When it is fixed, the subtopic is removed", func() {
+			code, stdout, _ := runCommand(s, "check", "--fix")
+			So(code, ShouldEqual, exitOK)
+			So(stdout, ShouldContainSubstring, "[fixed]")
+			So(stdout, ShouldContainSubstring, "found 1 problems, fixed 1")
+
+			topic, err := s.GetTopic(ctx, rootID)
+			So(err, ShouldBeNil)
+			So(*topic.Current.SubtopicIds, ShouldBeEmpty)
+		})
+	})
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"time"
 
+	"github.com/ONSdigital/dp-authorisation/v2/authorisation"
 	"github.com/ONSdigital/dp-mongodb/v3/mongodb"
 
 	"github.com/kelseyhightower/envconfig"
@@ -10,25 +11,83 @@ import (
 
 type MongoConfig = mongodb.MongoDriverConfig
 
+// AuthorisationConfig is the config of the JWTs of callers and of the permissions bundle they are authorised against
+type AuthorisationConfig = authorisation.Config
+
 // Config represents service config for dp-topic-api
 type Config struct {
+	AuthorisationConfig
+	AuthBackend                string        `envconfig:"AUTH_BACKEND"`
 	BindAddr                   string        `envconfig:"BIND_ADDR"`
+	CacheMaxEntries            int           `envconfig:"CACHE_MAX_ENTRIES"`
+	CacheTTL                   time.Duration `envconfig:"CACHE_TTL"`
+	ConceptSchemeCacheMaxAge   time.Duration `envconfig:"CONCEPT_SCHEME_CACHE_MAX_AGE"`
+	ConceptSchemeURL           string        `envconfig:"CONCEPT_SCHEME_URL"`
+	ContentCacheMaxAge         time.Duration `envconfig:"CONTENT_CACHE_MAX_AGE"`
+	EnableCache                bool          `envconfig:"ENABLE_CACHE"`
+	EnableMetrics              bool          `envconfig:"ENABLE_METRICS"`
 	EnablePermissionsAuth      bool          `envconfig:"ENABLE_PERMISSIONS_AUTHZ"`
 	EnablePrivateEndpoints     bool          `envconfig:"ENABLE_PRIVATE_ENDPOINTS"`
+	EnableTopicOwnership       bool          `envconfig:"ENABLE_TOPIC_OWNERSHIP"`
+	EnableTopicReview          bool          `envconfig:"ENABLE_TOPIC_REVIEW"`
+	EnableWebhooks             bool          `envconfig:"ENABLE_WEBHOOKS"`
+	FeedCacheMaxAge            time.Duration `envconfig:"FEED_CACHE_MAX_AGE"`
+	FeedMaxEntries             int           `envconfig:"FEED_MAX_ENTRIES"`
 	GracefulShutdownTimeout    time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
 	HealthCheckCriticalTimeout time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
 	HealthCheckInterval        time.Duration `envconfig:"HEALTHCHECK_INTERVAL"`
+	MigrateOnStartup           bool          `envconfig:"MIGRATE_ON_STARTUP"`
 	MongoConfig
 	NavigationCacheMaxAge time.Duration `envconfig:"NAVIGATION_CACHE_MAX_AGE"`
+	OTelExporter          string        `envconfig:"OTEL_EXPORTER"`
+	OTelExporterFile      string        `envconfig:"OTEL_EXPORTER_FILE"`
+	OTelOTLPEndpoint      string        `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTelServiceName       string        `envconfig:"OTEL_SERVICE_NAME"`
+	PermissionsBundlePath string        `envconfig:"PERMISSIONS_BUNDLE_PATH"`
+	PreviewTokenSecret    string        `envconfig:"PREVIEW_TOKEN_SECRET" json:"-"`
+	PreviewTokenTTL       time.Duration `envconfig:"PREVIEW_TOKEN_TTL"`
+	RedirectsCacheMaxAge  time.Duration `envconfig:"REDIRECTS_CACHE_MAX_AGE"`
+	RootTopicsCacheMaxAge time.Duration `envconfig:"ROOT_TOPICS_CACHE_MAX_AGE"`
+	SitemapCacheMaxAge    time.Duration `envconfig:"SITEMAP_CACHE_MAX_AGE"`
+	SitemapMaxURLs        int           `envconfig:"SITEMAP_MAX_URLS"`
+	StoreBackend          string        `envconfig:"STORE_BACKEND"`
+	StoreFixturePath      string        `envconfig:"STORE_FIXTURE_PATH"`
+	SubtopicsCacheMaxAge  time.Duration `envconfig:"SUBTOPICS_CACHE_MAX_AGE"`
+	TopicAdminGroups      []string      `envconfig:"TOPIC_ADMIN_GROUPS"`
+	TopicCacheMaxAge      time.Duration `envconfig:"TOPIC_CACHE_MAX_AGE"`
 	TopicAPIURL           string        `envconfig:""`
+	WebsiteURL            string        `envconfig:"WEBSITE_URL"`
+	WelshWebsiteURL       string        `envconfig:"WELSH_WEBSITE_URL"`
+	WebhookMaxRetries     int           `envconfig:"WEBHOOK_MAX_RETRIES"`
+	WebhookRetryBackoff   time.Duration `envconfig:"WEBHOOK_RETRY_BACKOFF"`
+	WebhookTimeout        time.Duration `envconfig:"WEBHOOK_TIMEOUT"`
 	ZebedeeURL            string        `envconfig:"ZEBEDEE_URL"`
 }
 
 var cfg *Config
 
+// The backends that can be selected to check the permissions of callers. Zebedee is the legacy backend, whereas the
+// JWT backend verifies the JWTs of callers locally and checks their permissions against the permissions bundle.
+const (
+	AuthBackendZebedee = "zebedee"
+	AuthBackendJWT     = "jwt"
+)
+
+// The backends that can be selected to store topics
 const (
-	TopicsCollection  = "TopicsCollection"
-	ContentCollection = "ContentCollection"
+	StoreBackendMongo  = "mongo"
+	StoreBackendMemory = "memory"
+)
+
+const (
+	TopicsCollection            = "TopicsCollection"
+	ContentCollection           = "ContentCollection"
+	SchemaMigrationsCollection  = "SchemaMigrationsCollection"
+	PublicationsCollection      = "PublicationsCollection"
+	WebhooksCollection          = "WebhooksCollection"
+	WebhookDeliveriesCollection = "WebhookDeliveriesCollection"
+	CollectionsCollection       = "CollectionsCollection"
+	RedirectsCollection         = "RedirectsCollection"
 )
 
 // Get returns the default config with any modifications through environment
@@ -39,18 +98,42 @@ func Get() (*Config, error) {
 	}
 
 	cfg = &Config{
+		AuthorisationConfig:        *authorisation.NewDefaultConfig(),
+		AuthBackend:                AuthBackendZebedee,
 		BindAddr:                   "localhost:25300",
+		CacheMaxEntries:            10000,
+		CacheTTL:                   5 * time.Minute,
+		ConceptSchemeCacheMaxAge:   time.Hour,
+		ConceptSchemeURL:           "https://api.beta.ons.gov.uk/v1/topics",
+		ContentCacheMaxAge:         5 * time.Minute,
+		EnableCache:                false,
+		EnableMetrics:              false,
 		EnablePermissionsAuth:      false,
 		EnablePrivateEndpoints:     false,
+		EnableTopicOwnership:       false,
+		EnableTopicReview:          false,
+		EnableWebhooks:             false,
+		FeedCacheMaxAge:            5 * time.Minute,
+		FeedMaxEntries:             50,
 		GracefulShutdownTimeout:    10 * time.Second,
 		HealthCheckCriticalTimeout: 90 * time.Second,
 		HealthCheckInterval:        30 * time.Second,
+		MigrateOnStartup:           false,
 		MongoConfig: MongoConfig{
-			ClusterEndpoint:               "localhost:27017",
-			Username:                      "",
-			Password:                      "",
-			Database:                      "topics",
-			Collections:                   map[string]string{TopicsCollection: "topics", ContentCollection: "content"},
+			ClusterEndpoint: "localhost:27017",
+			Username:        "",
+			Password:        "",
+			Database:        "topics",
+			Collections: map[string]string{
+				TopicsCollection:            "topics",
+				ContentCollection:           "content",
+				SchemaMigrationsCollection:  "schema_migrations",
+				PublicationsCollection:      "publications",
+				WebhooksCollection:          "webhooks",
+				WebhookDeliveriesCollection: "webhook_deliveries",
+				CollectionsCollection:       "collections",
+				RedirectsCollection:         "redirects",
+			},
 			ReplicaSet:                    "",
 			IsStrongReadConcernEnabled:    false,
 			IsWriteConcernMajorityEnabled: true,
@@ -61,7 +144,28 @@ func Get() (*Config, error) {
 			},
 		},
 		NavigationCacheMaxAge: 30 * time.Minute,
+		OTelExporter:          "none",
+		OTelExporterFile:      "",
+		OTelOTLPEndpoint:      "localhost:4318",
+		OTelServiceName:       "dp-topic-api",
+		PermissionsBundlePath: "",
+		PreviewTokenSecret:    "",
+		PreviewTokenTTL:       time.Hour,
+		RedirectsCacheMaxAge:  time.Hour,
+		RootTopicsCacheMaxAge: 5 * time.Minute,
+		SitemapCacheMaxAge:    time.Hour,
+		SitemapMaxURLs:        50000,
+		StoreBackend:          StoreBackendMongo,
+		StoreFixturePath:      "",
+		SubtopicsCacheMaxAge:  5 * time.Minute,
+		TopicAdminGroups:      []string{"role-admin"},
+		TopicCacheMaxAge:      5 * time.Minute,
 		TopicAPIURL:           "http://localhost:25300",
+		WebsiteURL:            "https://www.ons.gov.uk",
+		WelshWebsiteURL:       "https://cy.ons.gov.uk",
+		WebhookMaxRetries:     3,
+		WebhookRetryBackoff:   2 * time.Second,
+		WebhookTimeout:        10 * time.Second,
 		ZebedeeURL:            "http://localhost:8082",
 	}
 
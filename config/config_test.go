@@ -32,7 +32,16 @@ func TestConfig(t *testing.T) {
 
 				So(config.ClusterEndpoint, ShouldEqual, "localhost:27017")
 				So(config.Database, ShouldEqual, "topics")
-				So(config.Collections, ShouldResemble, map[string]string{TopicsCollection: "topics", ContentCollection: "content"})
+				So(config.Collections, ShouldResemble, map[string]string{
+					TopicsCollection:            "topics",
+					ContentCollection:           "content",
+					SchemaMigrationsCollection:  "schema_migrations",
+					PublicationsCollection:      "publications",
+					WebhooksCollection:          "webhooks",
+					WebhookDeliveriesCollection: "webhook_deliveries",
+					CollectionsCollection:       "collections",
+					RedirectsCollection:         "redirects",
+				})
 				So(cfg.Username, ShouldEqual, "")
 				So(cfg.Password, ShouldEqual, "")
 				So(cfg.IsSSL, ShouldEqual, false)
@@ -41,6 +50,52 @@ func TestConfig(t *testing.T) {
 				So(cfg.IsStrongReadConcernEnabled, ShouldEqual, false)
 				So(cfg.IsWriteConcernMajorityEnabled, ShouldEqual, true)
 
+				So(cfg.EnableWebhooks, ShouldBeFalse)
+				So(cfg.WebhookMaxRetries, ShouldEqual, 3)
+				So(cfg.WebhookRetryBackoff, ShouldEqual, 2*time.Second)
+				So(cfg.WebhookTimeout, ShouldEqual, 10*time.Second)
+
+				So(cfg.MigrateOnStartup, ShouldBeFalse)
+				So(cfg.StoreBackend, ShouldEqual, StoreBackendMongo)
+				So(cfg.StoreFixturePath, ShouldEqual, "")
+
+				So(cfg.EnableCache, ShouldBeFalse)
+				So(cfg.EnableMetrics, ShouldBeFalse)
+				So(cfg.CacheTTL, ShouldEqual, 5*time.Minute)
+				So(cfg.CacheMaxEntries, ShouldEqual, 10000)
+
+				So(cfg.NavigationCacheMaxAge, ShouldEqual, 30*time.Minute)
+				So(cfg.RootTopicsCacheMaxAge, ShouldEqual, 5*time.Minute)
+				So(cfg.TopicCacheMaxAge, ShouldEqual, 5*time.Minute)
+				So(cfg.SubtopicsCacheMaxAge, ShouldEqual, 5*time.Minute)
+				So(cfg.ContentCacheMaxAge, ShouldEqual, 5*time.Minute)
+
+				So(cfg.FeedCacheMaxAge, ShouldEqual, 5*time.Minute)
+				So(cfg.FeedMaxEntries, ShouldEqual, 50)
+				So(cfg.SitemapCacheMaxAge, ShouldEqual, time.Hour)
+				So(cfg.SitemapMaxURLs, ShouldEqual, 50000)
+				So(cfg.WebsiteURL, ShouldEqual, "https://www.ons.gov.uk")
+				So(cfg.WelshWebsiteURL, ShouldEqual, "https://cy.ons.gov.uk")
+				So(cfg.ConceptSchemeURL, ShouldEqual, "https://api.beta.ons.gov.uk/v1/topics")
+				So(cfg.ConceptSchemeCacheMaxAge, ShouldEqual, time.Hour)
+
+				So(cfg.OTelExporter, ShouldEqual, "none")
+				So(cfg.OTelExporterFile, ShouldEqual, "")
+				So(cfg.OTelOTLPEndpoint, ShouldEqual, "localhost:4318")
+				So(cfg.OTelServiceName, ShouldEqual, "dp-topic-api")
+
+				So(cfg.AuthBackend, ShouldEqual, AuthBackendZebedee)
+				So(cfg.PermissionsAPIURL, ShouldEqual, "http://localhost:25400")
+				So(cfg.PermissionsBundlePath, ShouldEqual, "")
+				So(cfg.EnableTopicOwnership, ShouldBeFalse)
+				So(cfg.EnableTopicReview, ShouldBeFalse)
+				So(cfg.JWTVerificationPublicKeys, ShouldNotBeEmpty)
+				So(cfg.TopicAdminGroups, ShouldResemble, []string{"role-admin"})
+
+				So(cfg.PreviewTokenSecret, ShouldEqual, "")
+				So(cfg.PreviewTokenTTL, ShouldEqual, time.Hour)
+				So(cfg.RedirectsCacheMaxAge, ShouldEqual, time.Hour)
+
 				So(cfg.TopicAPIURL, ShouldEqual, "http://localhost:25300")
 				So(cfg.ZebedeeURL, ShouldEqual, "http://localhost:8082")
 			})
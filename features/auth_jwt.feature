@@ -0,0 +1,97 @@
+Feature: Behaviour of application when authorising requests with JWTs against the local permissions bundle
+
+    # A Background applies to all scenarios in this Feature
+    Background:
+        Given I have these topics:
+            """
+            [
+                {
+                    "id": "businessindustryandtrade",
+                    "current": {
+                        "id": "businessindustryandtrade",
+                        "state": "published",
+                        "subtopics_ids": [
+                            "changestobusiness",
+                            "business"
+                        ]
+                    },
+                    "next": {
+                        "id": "businessindustryandtrade",
+                        "state": "published",
+                        "subtopics_ids": [
+                            "changestobusiness",
+                            "business"
+                        ]
+                    }
+                },
+                {
+                    "id": "changestobusiness",
+                    "current": {
+                        "id": "changestobusiness",
+                        "state": "published"
+                    },
+                    "next": {
+                        "id": "changestobusiness",
+                        "state": "published"
+                    }
+                },
+                {
+                    "id": "business",
+                    "current": {
+                        "id": "business",
+                        "state": "published"
+                    },
+                    "next": {
+                        "id": "business",
+                        "state": "published"
+                    }
+                }
+            ]
+            """
+
+    Scenario: [Test #35] A publisher with a JWT can update a topic
+        Given private endpoints are enabled
+        And permissions are checked against the local permissions bundle
+        And I am a publisher user
+
+        When I PUT "/topics/businessindustryandtrade/release-date"
+            """
+            {
+                "release_date": "2022-11-02T09:30:00Z"
+            }
+            """
+        Then the HTTP status code should be "200"
+
+    Scenario: [Test #36] A publisher with a JWT can read the next version of a topic
+        Given private endpoints are enabled
+        And permissions are checked against the local permissions bundle
+        And I am a publisher user
+
+        When I GET "/topics/businessindustryandtrade"
+        Then the HTTP status code should be "200"
+
+    Scenario: [Test #37] A caller with a JWT that cannot be verified is unauthorised
+        Given private endpoints are enabled
+        And permissions are checked against the local permissions bundle
+        And I set the "Authorization" header to "Bearer header.payload.signature"
+
+        When I PUT "/topics/businessindustryandtrade/release-date"
+            """
+            {
+                "release_date": "2022-11-02T09:30:00Z"
+            }
+            """
+        Then the HTTP status code should be "401"
+
+    Scenario: [Test #38] A caller without a token is unauthorised
+        Given private endpoints are enabled
+        And permissions are checked against the local permissions bundle
+        And I am not authenticated
+
+        When I PUT "/topics/businessindustryandtrade/release-date"
+            """
+            {
+                "release_date": "2022-11-02T09:30:00Z"
+            }
+            """
+        Then the HTTP status code should be "401"
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/ONSdigital/dp-topic-api/authz"
 	"github.com/ONSdigital/dp-topic-api/config"
 	"github.com/stretchr/testify/assert"
 
@@ -87,6 +88,15 @@ func (f *TopicComponent) privateEndpointsAreEnabled() error {
 	return nil
 }
 
+// permissionsAreCheckedAgainstTheLocalPermissionsBundle uses the JWT auth backend, with the stand-in permissions
+// bundle in place of the permissions API
+func (f *TopicComponent) permissionsAreCheckedAgainstTheLocalPermissionsBundle() error {
+	f.Config.EnablePermissionsAuth = true
+	f.Config.AuthBackend = config.AuthBackendJWT
+	f.Config.PermissionsBundlePath = authz.LocalBundlePath
+	return nil
+}
+
 func (f *TopicComponent) theDocumentInTheDatabaseForIDShouldBe(documentID string, documentJSON *godog.DocString) error {
 	var expectedTopic models.Topic
 	currentTime := time.Now()
@@ -55,6 +55,8 @@ func NewTopicComponent(mongoURL, zebedeeURL string) (*TopicComponent, error) {
 	f.Config.ZebedeeURL = zebedeeURL
 	f.Config.Database = utils.RandomDatabase()
 	f.Config.EnablePrivateEndpoints = false
+	f.Config.EnablePermissionsAuth = false
+	f.Config.AuthBackend = config.AuthBackendZebedee
 	// The following is to reset the Username and Password that have been set is Config from the previous
 	// config.Get()
 	f.Config.Username, f.Config.Password = "", ""
@@ -85,8 +87,7 @@ func createCredsInDB(mongoConfig *mongodriver.MongoDriverConfig) (string, string
 	username := "admin"
 	password, _ := uuid.NewV4()
 
-	//nolint:govet // Lint:bson.D{...} is correctly used based on example inside bson.D method.
-	createCollectionResponse := mongoConnection.RunCommand(context.TODO(), bson.D{{"create", "test"}})
+	createCollectionResponse := mongoConnection.RunCommand(context.TODO(), bson.D{{Key: "create", Value: "test"}})
 
 	if createCollectionResponse != nil {
 		panic("expected collection creation to go through")
@@ -106,6 +107,7 @@ func createCredsInDB(mongoConfig *mongodriver.MongoDriverConfig) (string, string
 
 func (f *TopicComponent) RegisterSteps(ctx *godog.ScenarioContext) {
 	ctx.Step(`^private endpoints are enabled$`, f.privateEndpointsAreEnabled)
+	ctx.Step(`^permissions are checked against the local permissions bundle$`, f.permissionsAreCheckedAgainstTheLocalPermissionsBundle)
 	ctx.Step(`^I have these topics:$`, f.iHaveTheseTopics)
 	ctx.Step(`^I have these contents:$`, f.iHaveTheseContents)
 	ctx.Step(`^the document in the database for id "([^"]*)" should be:`, f.theDocumentInTheDatabaseForIDShouldBe)
@@ -151,6 +153,6 @@ func (f *TopicComponent) DoGetHTTPServer(bindAddr string, router http.Handler) s
 }
 
 // DoGetMongoDB returns a MongoDB
-func (f *TopicComponent) DoGetMongoDB(_ context.Context, _ config.MongoConfig) (store.MongoDB, error) {
+func (f *TopicComponent) DoGetMongoDB(_ context.Context, _ *config.Config) (store.MongoDB, error) {
 	return f.MongoClient, nil
 }
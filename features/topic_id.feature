@@ -35,11 +35,17 @@ Feature: Behaviour of application when doing the GET /topics/{id} endpoint, usin
 #            """
         When I GET "/topics/unknown"
         Then the HTTP status code should be "404"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
+        And the response header "Content-Type" should be "application/problem+json"
 
-        And I should receive the following response:
+        And I should receive the following JSON response:
             """
-            topic not found
+            {
+                "type": "about:blank",
+                "title": "Not Found",
+                "status": 404,
+                "detail": "topic not found",
+                "code": "topic_not_found"
+            }
             """
 
     Scenario: [Test #5] GET /topics/economy in private mode
@@ -179,17 +179,29 @@ Feature: Behaviour of application when doing the GET /topics/{id}/content?type=<
     Scenario: [Test #13] GET /topics/missingcontent/content in public mode
         When I GET "/topics/missingcontent/content"
         Then the HTTP status code should be "404"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
-        And I should receive the following response:
+        And the response header "Content-Type" should be "application/problem+json"
+        And I should receive the following JSON response:
             """
-            content not found
+            {
+                "type": "about:blank",
+                "title": "Not Found",
+                "status": 404,
+                "detail": "content not found",
+                "code": "content_not_found"
+            }
             """
 
     Scenario: [Test #14] GET /topics/internationaltrade/content?type=bad in public mode
         When I GET "/topics/internationaltrade/content?type=bad"
         Then the HTTP status code should be "400"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
-        And I should receive the following response:
+        And the response header "Content-Type" should be "application/problem+json"
+        And I should receive the following JSON response:
             """
-            content query not recognised
+            {
+                "type": "about:blank",
+                "title": "Bad Request",
+                "status": 400,
+                "detail": "content query not recognised",
+                "code": "content_query_not_recognised"
+            }
             """
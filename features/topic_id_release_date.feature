@@ -95,10 +95,16 @@ Feature: Behaviour of application when doing the PUT /topics/{id}/release_date e
             """
             """
         Then the HTTP status code should be "400"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
-        And I should receive the following response:
+        And the response header "Content-Type" should be "application/problem+json"
+        And I should receive the following JSON response:
             """
-            request body empty
+            {
+                "type": "about:blank",
+                "title": "Bad Request",
+                "status": 400,
+                "detail": "request body empty",
+                "code": "empty_request_body"
+            }
             """
 
     Scenario: [Test #20] Invalid request body in PUT /topics/businessindustryandtrade/release-date in private mode
@@ -111,10 +117,16 @@ Feature: Behaviour of application when doing the PUT /topics/{id}/release_date e
             {}
             """
         Then the HTTP status code should be "400"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
-        And I should receive the following response:
+        And the response header "Content-Type" should be "application/problem+json"
+        And I should receive the following JSON response:
             """
-            invalid topic release date, must have the following format: 2022-05-22T09:21:45Z
+            {
+                "type": "about:blank",
+                "title": "Bad Request",
+                "status": 400,
+                "detail": "invalid topic release date, must have the following format: 2022-05-22T09:21:45Z",
+                "code": "invalid_release_date"
+            }
             """
 
     Scenario: [Test #21] Invalid release date in PUT /topics/businessindustryandtrade/release-date in private mode
@@ -129,10 +141,16 @@ Feature: Behaviour of application when doing the PUT /topics/{id}/release_date e
             }
             """
         Then the HTTP status code should be "400"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
-        And I should receive the following response:
+        And the response header "Content-Type" should be "application/problem+json"
+        And I should receive the following JSON response:
             """
-            invalid topic release date, must have the following format: 2022-05-22T09:21:45Z
+            {
+                "type": "about:blank",
+                "title": "Bad Request",
+                "status": 400,
+                "detail": "invalid topic release date, must have the following format: 2022-05-22T09:21:45Z",
+                "code": "invalid_release_date"
+            }
             """
 
     Scenario: [Test #22] Invalid Topic id in PUT /topics/invalid-id/release-date in private mode
@@ -147,8 +165,14 @@ Feature: Behaviour of application when doing the PUT /topics/{id}/release_date e
             }
             """
         Then the HTTP status code should be "404"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
-        And I should receive the following response:
+        And the response header "Content-Type" should be "application/problem+json"
+        And I should receive the following JSON response:
             """
-            topic not found
+            {
+                "type": "about:blank",
+                "title": "Not Found",
+                "status": 404,
+                "detail": "topic not found",
+                "code": "topic_not_found"
+            }
             """
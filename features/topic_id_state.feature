@@ -92,10 +92,16 @@ Feature: Behaviour of application when doing the PUT /topics/{id}/release_date e
         n/a
         """
         Then the HTTP status code should be "404"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
-        And I should receive the following response:
+        And the response header "Content-Type" should be "application/problem+json"
+        And I should receive the following JSON response:
             """
-            topic not found
+            {
+                "type": "about:blank",
+                "title": "Not Found",
+                "status": 404,
+                "detail": "topic not found",
+                "code": "topic_not_found"
+            }
             """
 
     Scenario: [Test #27] Invalid state in PUT /topics/businessindustryandtrade/state/coffee in private mode
@@ -108,8 +114,14 @@ Feature: Behaviour of application when doing the PUT /topics/{id}/release_date e
         n/a
         """
         Then the HTTP status code should be "400"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
-        And I should receive the following response:
+        And the response header "Content-Type" should be "application/problem+json"
+        And I should receive the following JSON response:
             """
-            topic state is not a valid state name
+            {
+                "type": "about:blank",
+                "title": "Bad Request",
+                "status": 400,
+                "detail": "topic state is not a valid state name",
+                "code": "invalid_state"
+            }
             """
@@ -77,7 +77,7 @@ Feature: Behaviour of application when doing the PUT /topics/{id} endpoint, usin
                 "title": "Business, Data and Trade",
                 "description": "Lots of information about Trade",
                 "release_date": "2022-10-10T08:30:00Z",
-                "subtopics_ids": ["changestobusiness", "economy"],
+                "subtopics_ids": ["changestobusiness", "business"],
                 "keywords": ["keyword1", "keyword2"],
                 "state": "published"
             }
@@ -106,7 +106,7 @@ Feature: Behaviour of application when doing the PUT /topics/{id} endpoint, usin
                 "state": "published",
                 "subtopics_ids": [
                     "changestobusiness",
-                    "economy"
+                    "business"
                 ]
             }
             """
@@ -143,6 +143,42 @@ Feature: Behaviour of application when doing the PUT /topics/{id} endpoint, usin
             """
         Then the HTTP status code should be "400"
 
+    Scenario: [Test #31a] Invalid PUT /topics/businessindustryandtrade in private mode reports every invalid field
+        Given private endpoints are enabled
+        And I am identified as "user@ons.gov.uk"
+        And I am authorised
+
+        When I PUT "/topics/businessindustryandtrade"
+            """
+            {
+                "title": "Business, Data and Trade",
+                "description": "Lots of information about Trade",
+                "release_date": "2022-10-10T08:30:00Z",
+                "slug": "Business Data",
+                "subtopics_ids": ["economy", "businessindustryandtrade", "business", "business"],
+                "keywords": ["keyword1", "Keyword1"],
+                "state": "published"
+            }
+            """
+        Then the HTTP status code should be "400"
+        And I should receive the following JSON response:
+            """
+            {
+                "type": "about:blank",
+                "title": "Bad Request",
+                "status": 400,
+                "detail": "topic has invalid fields",
+                "code": "invalid_fields",
+                "errors": [
+                    {"field": "slug", "message": "must only contain lower case letters, digits and single hyphens between words"},
+                    {"field": "keywords[1]", "message": "must not duplicate another keyword"},
+                    {"field": "subtopics_ids[1]", "message": "must not reference the topic itself"},
+                    {"field": "subtopics_ids[3]", "message": "must not duplicate another subtopic"},
+                    {"field": "subtopics_ids[0]", "message": "must be the id of an existing topic"}
+                ]
+            }
+            """
+
     Scenario: [Test #32] Missing auth header in PUT /topics/businessindustryandtrade in private mode
         Given private endpoints are enabled
         When I PUT "/topics/businessindustryandtrade"
@@ -168,10 +204,16 @@ Feature: Behaviour of application when doing the PUT /topics/{id} endpoint, usin
             """
             """
         Then the HTTP status code should be "400"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
-        And I should receive the following response:
+        And the response header "Content-Type" should be "application/problem+json"
+        And I should receive the following JSON response:
             """
-            request body empty
+            {
+                "type": "about:blank",
+                "title": "Bad Request",
+                "status": 400,
+                "detail": "request body empty",
+                "code": "empty_request_body"
+            }
             """
 
     Scenario: [Test #34] Invalid Topic id in PUT /topics/invalid-id in private mode
@@ -191,8 +233,14 @@ Feature: Behaviour of application when doing the PUT /topics/{id} endpoint, usin
             }
             """
         Then the HTTP status code should be "404"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
-        And I should receive the following response:
+        And the response header "Content-Type" should be "application/problem+json"
+        And I should receive the following JSON response:
             """
-            topic not found
+            {
+                "type": "about:blank",
+                "title": "Not Found",
+                "status": 404,
+                "detail": "topic not found",
+                "code": "topic_not_found"
+            }
             """
@@ -22,9 +22,15 @@ Feature: Behaviour of application when doing the GET /topics/{id}/content endpoi
 
         When I GET "/topics/nocontent/content"
         Then the HTTP status code should be "404"
-        And the response header "Content-Type" should be "text/plain; charset=utf-8"
-        And I should receive the following response:
+        And the response header "Content-Type" should be "application/problem+json"
+        And I should receive the following JSON response:
             """
-            content not found
+            {
+                "type": "about:blank",
+                "title": "Not Found",
+                "status": 404,
+                "detail": "content not found",
+                "code": "content_not_found"
+            }
             """
 
@@ -0,0 +1,476 @@
+// Package integrity checks the references of the taxonomy, between topics and from topics to their content, and
+// repairs those that can be repaired without an editor deciding how.
+package integrity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ONSdigital/dp-topic-api/models"
+)
+
+// rootID is the id of the topic whose subtopics are the top level topics
+const rootID = "topic_root"
+
+// The kinds of Problem
+const (
+	KindDanglingSubtopic = "dangling_subtopic"
+	KindCycle            = "cycle"
+	KindSeveralParents   = "several_parents"
+	KindOrphan           = "orphan"
+	KindMissingContent   = "missing_content"
+	KindLinkMismatch     = "link_mismatch"
+)
+
+// Problem is a broken reference in the taxonomy
+type Problem struct {
+	Kind    string `json:"kind"`
+	TopicID string `json:"topic_id"`
+	// Version is the version of the topic (current or next) the problem is in, unless it is in the topic as a whole
+	Version string `json:"version,omitempty"`
+	Detail  string `json:"detail"`
+	// Fixable is set unless an editor has to decide how to repair the problem, as for orphans and topics with
+	// several parents
+	Fixable bool `json:"fixable"`
+	Fixed   bool `json:"fixed"`
+}
+
+// Report is every problem found, grouped by kind in the order the kinds are checked, and by topic id within a kind
+type Report struct {
+	Fix      bool      `json:"fix"`
+	Problems []Problem `json:"problems"`
+}
+
+// Count returns the number of problems that have, or have not, been fixed
+func (r *Report) Count(fixed bool) int {
+	count := 0
+	for i := range r.Problems {
+		if r.Problems[i].Fixed == fixed {
+			count++
+		}
+	}
+	return count
+}
+
+// Store represents the methods required to check and repair the taxonomy
+type Store interface {
+	GetAllTopics(ctx context.Context) ([]models.TopicResponse, error)
+	GetAllContent(ctx context.Context) ([]models.ContentResponse, error)
+	ReplaceTopic(ctx context.Context, topic *models.TopicResponse) error
+	InsertContent(ctx context.Context, content *models.ContentResponse) error
+}
+
+// Run checks every topic and content document. With fix, the fixable problems are repaired: dangling subtopics and
+// the subtopics that close cycles are removed, the links of topics are rebuilt for the API at host, and topics
+// without content are given empty content in the states of their versions. Every problem is reported either way.
+func Run(ctx context.Context, store Store, host string, fix bool) (*Report, error) {
+	topics, err := store.GetAllTopics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := store.GetAllContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newChecker(topics, contents, host)
+	c.check()
+	c.report.Fix = fix
+	if !fix {
+		return c.report, nil
+	}
+
+	for _, id := range c.ids {
+		if len(c.topicProblems[id]) == 0 {
+			continue
+		}
+		if err := store.ReplaceTopic(ctx, c.topics[id]); err != nil {
+			return nil, fmt.Errorf("failed to repair topic %s: %w", id, err)
+		}
+		c.markFixed(c.topicProblems[id])
+	}
+
+	for _, id := range c.ids {
+		content, ok := c.missingContent[id]
+		if !ok {
+			continue
+		}
+		if err := store.InsertContent(ctx, content); err != nil {
+			return nil, fmt.Errorf("failed to insert content %s: %w", id, err)
+		}
+		c.markFixed(c.contentProblems[id])
+	}
+
+	return c.report, nil
+}
+
+// checker checks copies of the topics, repairing the copies as it goes, so that each check sees the taxonomy as it is
+// once the problems found before it are fixed
+type checker struct {
+	host    string
+	ids     []string
+	topics  map[string]*models.TopicResponse
+	content map[string]bool
+	report  *Report
+	// the indexes in the report of the fixable problems of each topic, and of its content
+	topicProblems   map[string][]int
+	contentProblems map[string][]int
+	missingContent  map[string]*models.ContentResponse
+}
+
+func newChecker(topics []models.TopicResponse, contents []models.ContentResponse, host string) *checker {
+	c := &checker{
+		host:            host,
+		topics:          make(map[string]*models.TopicResponse, len(topics)),
+		content:         make(map[string]bool, len(contents)),
+		report:          &Report{Problems: []Problem{}},
+		topicProblems:   map[string][]int{},
+		contentProblems: map[string][]int{},
+		missingContent:  map[string]*models.ContentResponse{},
+	}
+
+	for i := range topics {
+		topic := &models.TopicResponse{ID: topics[i].ID}
+		if topics[i].Current != nil {
+			current := *topics[i].Current
+			topic.Current = &current
+		}
+		if topics[i].Next != nil {
+			next := *topics[i].Next
+			topic.Next = &next
+		}
+		c.topics[topic.ID] = topic
+		c.ids = append(c.ids, topic.ID)
+	}
+	sort.Strings(c.ids)
+
+	for i := range contents {
+		c.content[contents[i].ID] = true
+	}
+
+	return c
+}
+
+func (c *checker) check() {
+	c.checkDanglingSubtopics()
+	c.checkCycles()
+	c.checkSeveralParents()
+	c.checkOrphans()
+	c.checkContent()
+	c.checkLinks()
+}
+
+// add adds a problem to the report, recording the index of a fixable problem against the topic, or content, it is in
+func (c *checker) add(problem Problem, fixes map[string][]int) {
+	if problem.Fixable {
+		fixes[problem.TopicID] = append(fixes[problem.TopicID], len(c.report.Problems))
+	}
+	c.report.Problems = append(c.report.Problems, problem)
+}
+
+func (c *checker) markFixed(indexes []int) {
+	for _, i := range indexes {
+		c.report.Problems[i].Fixed = true
+	}
+}
+
+// checkDanglingSubtopics finds subtopics that are not topics, and removes them
+func (c *checker) checkDanglingSubtopics() {
+	for _, id := range c.ids {
+		for _, v := range versions(c.topics[id]) {
+			c.removeSubtopics(v.topic, func(subtopicID string) bool {
+				if _, ok := c.topics[subtopicID]; ok {
+					return false
+				}
+				c.add(Problem{
+					Kind:    KindDanglingSubtopic,
+					TopicID: id,
+					Version: v.name,
+					Detail:  fmt.Sprintf("subtopic %s does not exist", subtopicID),
+					Fixable: true,
+				}, c.topicProblems)
+				return true
+			})
+		}
+	}
+}
+
+// checkCycles finds the subtopics that close a cycle in the tree of each version, starting from the root topic, and
+// removes them
+func (c *checker) checkCycles() {
+	for _, name := range []string{models.TaxonomyVersionCurrent, models.TaxonomyVersionNext} {
+		const (
+			unvisited = iota
+			visiting
+			visited
+		)
+		state := map[string]int{}
+
+		var visit func(id string, path []string)
+		visit = func(id string, path []string) {
+			state[id] = visiting
+			path = append(path, id)
+			c.removeSubtopics(versionOf(c.topics[id], name), func(subtopicID string) bool {
+				switch state[subtopicID] {
+				case unvisited:
+					if versionOf(c.topics[subtopicID], name) != nil {
+						visit(subtopicID, path)
+					}
+				case visiting:
+					cycle := append(append([]string{}, path[indexOf(path, subtopicID):]...), subtopicID)
+					c.add(Problem{
+						Kind:    KindCycle,
+						TopicID: id,
+						Version: name,
+						Detail:  fmt.Sprintf("subtopic %s closes the cycle %s", subtopicID, strings.Join(cycle, " -> ")),
+						Fixable: true,
+					}, c.topicProblems)
+					return true
+				}
+				return false
+			})
+			state[id] = visited
+		}
+
+		for _, id := range append([]string{rootID}, c.ids...) {
+			if state[id] == unvisited && versionOf(c.topics[id], name) != nil {
+				visit(id, nil)
+			}
+		}
+	}
+}
+
+// checkSeveralParents finds the topics that are the subtopic of more than one topic in the same version
+func (c *checker) checkSeveralParents() {
+	for _, name := range []string{models.TaxonomyVersionCurrent, models.TaxonomyVersionNext} {
+		parents := map[string][]string{}
+		for _, id := range c.ids {
+			for _, subtopicID := range subtopicsOf(versionOf(c.topics[id], name)) {
+				parents[subtopicID] = append(parents[subtopicID], id)
+			}
+		}
+
+		for _, id := range c.ids {
+			if len(parents[id]) > 1 {
+				c.add(Problem{
+					Kind:    KindSeveralParents,
+					TopicID: id,
+					Version: name,
+					Detail:  fmt.Sprintf("is a subtopic of %s", strings.Join(parents[id], ", ")),
+				}, c.topicProblems)
+			}
+		}
+	}
+}
+
+// checkOrphans finds the topics that are not the subtopic of any topic below the root topic, in either version.
+// Archived topics are not in the tree of topics, so are not orphans.
+func (c *checker) checkOrphans() {
+	reached := map[string]bool{rootID: true}
+	queue := []string{rootID}
+	for len(queue) > 0 {
+		topic := c.topics[queue[0]]
+		queue = queue[1:]
+		if topic == nil {
+			continue
+		}
+		for _, v := range versions(topic) {
+			for _, subtopicID := range subtopicsOf(v.topic) {
+				if !reached[subtopicID] {
+					reached[subtopicID] = true
+					queue = append(queue, subtopicID)
+				}
+			}
+		}
+	}
+
+	for _, id := range c.ids {
+		if reached[id] || (c.topics[id].Next != nil && c.topics[id].Next.IsArchived()) {
+			continue
+		}
+		c.add(Problem{
+			Kind:    KindOrphan,
+			TopicID: id,
+			Detail:  fmt.Sprintf("is not reachable from %s", rootID),
+		}, c.topicProblems)
+	}
+}
+
+// checkContent finds the topics without a content document, which are given empty content in the states of their
+// versions
+func (c *checker) checkContent() {
+	for _, id := range c.ids {
+		if c.content[id] {
+			continue
+		}
+
+		content := &models.ContentResponse{ID: id}
+		if current := c.topics[id].Current; current != nil {
+			content.Current = &models.Content{State: current.State}
+		}
+		if next := c.topics[id].Next; next != nil {
+			content.Next = &models.Content{State: next.State}
+		}
+		c.missingContent[id] = content
+
+		c.add(Problem{
+			Kind:    KindMissingContent,
+			TopicID: id,
+			Detail:  "has no content document",
+			Fixable: true,
+		}, c.contentProblems)
+	}
+}
+
+// checkLinks finds the versions of topics whose links are not those of the topic, or whose self links differ between
+// their current and next versions, and rebuilds their links. The root topic is not a page of its own, so it has no
+// links to check.
+func (c *checker) checkLinks() {
+	for _, id := range c.ids {
+		if id == rootID {
+			continue
+		}
+		topic := c.topics[id]
+
+		mismatched := false
+		for _, v := range versions(topic) {
+			if detail := linkMismatch(id, v.topic); detail != "" {
+				c.add(Problem{Kind: KindLinkMismatch, TopicID: id, Version: v.name, Detail: detail, Fixable: true}, c.topicProblems)
+				mismatched = true
+			}
+		}
+		if !mismatched && topic.Current != nil && topic.Next != nil && topic.Current.Links.Self.HRef != topic.Next.Links.Self.HRef {
+			c.add(Problem{
+				Kind:    KindLinkMismatch,
+				TopicID: id,
+				Detail:  fmt.Sprintf("the current version links to %s and the next version to %s", topic.Current.Links.Self.HRef, topic.Next.Links.Self.HRef),
+				Fixable: true,
+			}, c.topicProblems)
+			mismatched = true
+		}
+
+		if mismatched {
+			for _, v := range versions(topic) {
+				v.topic.ID = id
+				v.topic.Links = c.links(id, v.topic)
+			}
+		}
+	}
+}
+
+// linkMismatch describes how the links of a version of a topic are not those of the topic, or returns "" if they are
+func linkMismatch(id string, version *models.Topic) string {
+	links := version.Links
+	switch {
+	case version.ID != id:
+		return fmt.Sprintf("has the id %s", version.ID)
+	case links == nil || links.Self == nil:
+		return "has no self link"
+	case links.Self.ID != id || !strings.HasSuffix(links.Self.HRef, "/topics/"+id):
+		return fmt.Sprintf("has the self link %s to %s", links.Self.HRef, links.Self.ID)
+	case links.Content == nil || links.Content.HRef != links.Self.HRef+"/content":
+		return "has no content link, or one to the content of another topic"
+	case len(subtopicsOf(version)) > 0 && (links.Subtopics == nil || links.Subtopics.HRef != links.Self.HRef+"/subtopics"):
+		return "has no subtopics link, or one to the subtopics of another topic"
+	case len(subtopicsOf(version)) == 0 && links.Subtopics != nil:
+		return "has a subtopics link without subtopics"
+	case hasRelated(version) && (links.Related == nil || links.Related.HRef != links.Self.HRef+"/related"):
+		return "has no related topics link, or one to the related topics of another topic"
+	case !hasRelated(version) && links.Related != nil:
+		return "has a related topics link without related topics"
+	}
+	return ""
+}
+
+// links returns the links of a version of a topic, as they are built when it is updated
+func (c *checker) links(id string, version *models.Topic) *models.TopicLinks {
+	topicURL := fmt.Sprintf("%s/topics/%s", c.host, id)
+	links := &models.TopicLinks{
+		Self:    &models.LinkObject{HRef: topicURL, ID: id},
+		Content: &models.LinkObject{HRef: topicURL + "/content"},
+	}
+	if len(subtopicsOf(version)) > 0 {
+		links.Subtopics = &models.LinkObject{HRef: topicURL + "/subtopics"}
+	}
+	if hasRelated(version) {
+		links.Related = &models.LinkObject{HRef: topicURL + "/related"}
+	}
+	return links
+}
+
+// removeSubtopics removes the subtopics of a version for which remove returns true, and its subtopics link once it
+// has none left
+func (c *checker) removeSubtopics(version *models.Topic, remove func(subtopicID string) bool) {
+	if version == nil || version.SubtopicIds == nil {
+		return
+	}
+
+	kept := []string{}
+	for _, subtopicID := range *version.SubtopicIds {
+		if !remove(subtopicID) {
+			kept = append(kept, subtopicID)
+		}
+	}
+	if len(kept) == len(*version.SubtopicIds) {
+		return
+	}
+
+	version.SubtopicIds = &kept
+	if len(kept) == 0 && version.Links != nil {
+		links := *version.Links
+		links.Subtopics = nil
+		version.Links = &links
+	}
+}
+
+// namedVersion is a version of a topic, with its name
+type namedVersion struct {
+	name  string
+	topic *models.Topic
+}
+
+// versions returns the versions a topic has
+func versions(topic *models.TopicResponse) []namedVersion {
+	var result []namedVersion
+	if topic.Current != nil {
+		result = append(result, namedVersion{models.TaxonomyVersionCurrent, topic.Current})
+	}
+	if topic.Next != nil {
+		result = append(result, namedVersion{models.TaxonomyVersionNext, topic.Next})
+	}
+	return result
+}
+
+// versionOf returns the named version of a topic, which is nil if the topic, or its version, does not exist
+func versionOf(topic *models.TopicResponse, name string) *models.Topic {
+	switch {
+	case topic == nil:
+		return nil
+	case name == models.TaxonomyVersionCurrent:
+		return topic.Current
+	default:
+		return topic.Next
+	}
+}
+
+func subtopicsOf(version *models.Topic) []string {
+	if version == nil || version.SubtopicIds == nil {
+		return nil
+	}
+	return *version.SubtopicIds
+}
+
+func hasRelated(version *models.Topic) bool {
+	return version.RelatedTopicIDs != nil && len(*version.RelatedTopicIDs) > 0
+}
+
+func indexOf(ids []string, id string) int {
+	for i := range ids {
+		if ids[i] == id {
+			return i
+		}
+	}
+	return -1
+}
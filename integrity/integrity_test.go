@@ -0,0 +1,235 @@
+package integrity
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const host = "http://localhost:25300"
+
+// topic returns a published topic, with the links it is given by the API at host, and the subtopics given in both of
+// its versions
+func topic(id string, subtopicIDs ...string) *models.TopicResponse {
+	version := func() *models.Topic {
+		subtopics := append([]string{}, subtopicIDs...)
+		v := &models.Topic{ID: id, State: models.StatePublished.String(), SubtopicIds: &subtopics}
+		v.Links = (&checker{host: host}).links(id, v)
+		return v
+	}
+	return &models.TopicResponse{ID: id, Current: version(), Next: version()}
+}
+
+// store is a store of topics and content, kept in maps
+type store struct {
+	topics  map[string]*models.TopicResponse
+	content map[string]*models.ContentResponse
+}
+
+func (s *store) GetAllTopics(context.Context) ([]models.TopicResponse, error) {
+	topics := []models.TopicResponse{}
+	for _, topic := range s.topics {
+		topics = append(topics, *topic)
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].ID < topics[j].ID })
+	return topics, nil
+}
+
+func (s *store) GetAllContent(context.Context) ([]models.ContentResponse, error) {
+	contents := []models.ContentResponse{}
+	for _, content := range s.content {
+		contents = append(contents, *content)
+	}
+	return contents, nil
+}
+
+func (s *store) ReplaceTopic(_ context.Context, topic *models.TopicResponse) error {
+	s.topics[topic.ID] = topic
+	return nil
+}
+
+func (s *store) InsertContent(_ context.Context, content *models.ContentResponse) error {
+	s.content[content.ID] = content
+	return nil
+}
+
+// storeOf returns a store with the topics, each with content
+func storeOf(topics ...*models.TopicResponse) *store {
+	s := &store{topics: map[string]*models.TopicResponse{}, content: map[string]*models.ContentResponse{}}
+	for _, topic := range topics {
+		s.topics[topic.ID] = topic
+		s.content[topic.ID] = &models.ContentResponse{ID: topic.ID}
+	}
+	return s
+}
+
+// kinds returns the kind, topic and version of each problem
+func kinds(report *Report) [][3]string {
+	result := [][3]string{}
+	for _, problem := range report.Problems {
+		result = append(result, [3]string{problem.Kind, problem.TopicID, problem.Version})
+	}
+	return result
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a taxonomy without problems, then none are reported", t, func() {
+		s := storeOf(topic(rootID, "economy"), topic("economy", "gdp"), topic("gdp"))
+		report, err := Run(ctx, s, host, false)
+		So(err, ShouldBeNil)
+		So(report.Problems, ShouldBeEmpty)
+	})
+
+	Convey("Given a taxonomy with a dangling subtopic", t, func() {
+		s := storeOf(topic(rootID, "economy"), topic("economy", "gdp", "deleted"), topic("gdp"))
+
+		Convey("When it is checked, then the subtopic is reported in both versions, and nothing is changed", func() {
+			report, err := Run(ctx, s, host, false)
+			So(err, ShouldBeNil)
+			So(kinds(report), ShouldResemble, [][3]string{
+				{KindDanglingSubtopic, "economy", models.TaxonomyVersionCurrent},
+				{KindDanglingSubtopic, "economy", models.TaxonomyVersionNext},
+			})
+			So(report.Problems[0].Detail, ShouldEqual, "subtopic deleted does not exist")
+			So(report.Count(false), ShouldEqual, 2)
+
+			So(*s.topics["economy"].Next.SubtopicIds, ShouldResemble, []string{"gdp", "deleted"})
+		})
+
+		Convey("When it is fixed, then the subtopic is removed from both versions", func() {
+			report, err := Run(ctx, s, host, true)
+			So(err, ShouldBeNil)
+			So(report.Count(true), ShouldEqual, 2)
+
+			economy := s.topics["economy"]
+			So(*economy.Current.SubtopicIds, ShouldResemble, []string{"gdp"})
+			So(*economy.Next.SubtopicIds, ShouldResemble, []string{"gdp"})
+
+			report, err = Run(ctx, s, host, false)
+			So(err, ShouldBeNil)
+			So(report.Problems, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a taxonomy with a cycle in the next version", t, func() {
+		gdp := topic("gdp")
+		gdp.Next.SubtopicIds = &[]string{"economy"}
+		gdp.Next.Links = (&checker{host: host}).links("gdp", gdp.Next)
+		s := storeOf(topic(rootID, "economy"), topic("economy", "gdp"), gdp)
+
+		Convey("When it is fixed, then the subtopic closing the cycle is removed, with the subtopics link", func() {
+			report, err := Run(ctx, s, host, true)
+			So(err, ShouldBeNil)
+			So(kinds(report), ShouldResemble, [][3]string{{KindCycle, "gdp", models.TaxonomyVersionNext}})
+			So(report.Problems[0].Detail, ShouldEqual, "subtopic economy closes the cycle economy -> gdp -> economy")
+			So(report.Problems[0].Fixed, ShouldBeTrue)
+
+			stored := s.topics["gdp"]
+			So(*stored.Next.SubtopicIds, ShouldBeEmpty)
+			So(stored.Next.Links.Subtopics, ShouldBeNil)
+		})
+	})
+
+	Convey("Given a taxonomy with a topic with several parents, an orphan and an archived topic outside the tree", t, func() {
+		archived := topic("retired")
+		archived.Next.State = models.StateArchived.String()
+		s := storeOf(topic(rootID, "economy", "business"), topic("economy", "gdp"), topic("business", "gdp"), topic("gdp"),
+			topic("lost"), archived)
+
+		Convey("When it is fixed, then they are reported, but not fixed", func() {
+			report, err := Run(ctx, s, host, true)
+			So(err, ShouldBeNil)
+			So(kinds(report), ShouldResemble, [][3]string{
+				{KindSeveralParents, "gdp", models.TaxonomyVersionCurrent},
+				{KindSeveralParents, "gdp", models.TaxonomyVersionNext},
+				{KindOrphan, "lost", ""},
+			})
+			So(report.Problems[0].Detail, ShouldEqual, "is a subtopic of business, economy")
+			So(report.Problems[2].Fixable, ShouldBeFalse)
+			So(report.Count(true), ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given a taxonomy with a topic without content", t, func() {
+		s := storeOf(topic(rootID, "economy"))
+		economy := topic("economy")
+		economy.Next.State = models.StateCompleted.String()
+		s.topics["economy"] = economy
+
+		Convey("When it is fixed, then the topic is given empty content in the states of its versions", func() {
+			report, err := Run(ctx, s, host, true)
+			So(err, ShouldBeNil)
+			So(kinds(report), ShouldResemble, [][3]string{{KindMissingContent, "economy", ""}})
+			So(report.Problems[0].Fixed, ShouldBeTrue)
+
+			content := s.content["economy"]
+			So(content.Current.State, ShouldEqual, models.StatePublished.String())
+			So(content.Next.State, ShouldEqual, models.StateCompleted.String())
+		})
+	})
+
+	Convey("Given a taxonomy with topics whose links do not match them", t, func() {
+		economy := topic("economy")
+		economy.Next.Links.Self = &models.LinkObject{HRef: host + "/topics/gdp", ID: "gdp"}
+		business := topic("business")
+		business.Current.Links = (&checker{host: "http://old-host"}).links("business", business.Current)
+		s := storeOf(topic(rootID, "economy", "business"), economy, business)
+
+		Convey("When it is fixed, then their links are rebuilt", func() {
+			report, err := Run(ctx, s, host, true)
+			So(err, ShouldBeNil)
+			So(kinds(report), ShouldResemble, [][3]string{
+				{KindLinkMismatch, "business", ""},
+				{KindLinkMismatch, "economy", models.TaxonomyVersionNext},
+			})
+			So(report.Problems[1].Detail, ShouldEqual, "has the self link "+host+"/topics/gdp to gdp")
+
+			So(s.topics["economy"].Next.Links, ShouldResemble, topic("economy").Next.Links)
+			So(s.topics["business"].Current.Links.Self.HRef, ShouldEqual, host+"/topics/business")
+		})
+	})
+
+	Convey("Given a taxonomy with related topics, one without its related topics link", t, func() {
+		housing := topic("housing")
+		housing.Next.RelatedTopicIDs = &[]string{"economy"}
+		housing.Current.RelatedTopicIDs = &[]string{"economy"}
+		housing.Current.Links = (&checker{host: host}).links("housing", housing.Current)
+		s := storeOf(topic(rootID, "economy", "housing"), topic("economy"), housing)
+
+		Convey("When it is fixed, then the link is added", func() {
+			report, err := Run(ctx, s, host, true)
+			So(err, ShouldBeNil)
+			So(kinds(report), ShouldResemble, [][3]string{{KindLinkMismatch, "housing", models.TaxonomyVersionNext}})
+			So(report.Problems[0].Detail, ShouldEqual, "has no related topics link, or one to the related topics of another topic")
+
+			So(s.topics["housing"].Next.Links.Related.HRef, ShouldEqual, host+"/topics/housing/related")
+		})
+	})
+
+	Convey("Given a store that fails", t, func() {
+		s := &failingStore{store: storeOf(topic(rootID, "deleted"))}
+
+		Convey("When it is fixed, then the failure is returned", func() {
+			_, err := Run(ctx, s, host, true)
+			So(err, ShouldWrap, errFailed)
+		})
+	})
+}
+
+var errFailed = errors.New("failed")
+
+// failingStore is a store that fails to replace topics
+type failingStore struct {
+	*store
+}
+
+func (f *failingStore) ReplaceTopic(context.Context, *models.TopicResponse) error {
+	return errFailed
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/ONSdigital/dp-topic-api/models"
+)
+
+// Fixture represents the documents, in their stored form, used to seed a Store
+type Fixture struct {
+	Topics  []models.TopicResponse   `json:"topics"`
+	Content []models.ContentResponse `json:"content"`
+}
+
+// NewFromFile creates a Store seeded from the JSON fixture file at path
+func NewFromFile(path string) (*Store, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := New()
+	if err := s.Seed(f); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Seed adds the topics and content of a JSON fixture to the store, overwriting any with the same id
+func (s *Store) Seed(r io.Reader) error {
+	var fixture Fixture
+	if err := json.NewDecoder(r).Decode(&fixture); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range fixture.Topics {
+		if err := s.putTopic(&fixture.Topics[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range fixture.Content {
+		content, err := clone(&fixture.Content[i])
+		if err != nil {
+			return err
+		}
+		s.content[content.ID] = content
+	}
+
+	return nil
+}
@@ -0,0 +1,789 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-topic-api/api"
+	errs "github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/dp-topic-api/store"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// check that Store satisfies the store.MongoDB interface
+var _ store.MongoDB = (*Store)(nil)
+
+// Store is a thread safe, in-memory implementation of store.MongoDB for local development and tests.
+// It follows the error semantics of the mongo implementation, and documents are copied in and out through
+// bson, so that callers see the same values (e.g. times truncated to milliseconds) as they would from mongo.
+type Store struct {
+	mu                sync.RWMutex
+	topics            map[string]*models.TopicResponse
+	content           map[string]*models.ContentResponse
+	webhooks          []*models.Webhook
+	webhookDeliveries map[string]*models.WebhookDelivery
+	publications      []*models.Publication
+	collections       map[string]*models.Collection
+	redirects         map[string]*models.Redirect
+}
+
+// New creates an empty Store
+func New() *Store {
+	return &Store{
+		topics:            make(map[string]*models.TopicResponse),
+		content:           make(map[string]*models.ContentResponse),
+		webhookDeliveries: make(map[string]*models.WebhookDelivery),
+		collections:       make(map[string]*models.Collection),
+		redirects:         make(map[string]*models.Redirect),
+	}
+}
+
+// Close does nothing, as there is no connection to close
+func (s *Store) Close(_ context.Context) error {
+	return nil
+}
+
+// Checker is called by the healthcheck library, the in-memory store is always healthy
+func (s *Store) Checker(_ context.Context, state *healthcheck.CheckState) error {
+	return state.Update(healthcheck.StatusOK, "in-memory store is ok", 0)
+}
+
+// GetTopic retrieves a topic document by its ID
+func (s *Store) GetTopic(_ context.Context, id string) (*models.TopicResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	topic, ok := s.topics[id]
+	if !ok {
+		return nil, errs.ErrTopicNotFound
+	}
+
+	return clone(topic)
+}
+
+// CheckTopicExists checks that the topic exists
+func (s *Store) CheckTopicExists(_ context.Context, id string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.topics[id]; !ok {
+		return errs.ErrTopicNotFound
+	}
+
+	return nil
+}
+
+// IsSlugInUse checks whether a topic other than the one with the given id has the slug, in either its current or next document
+func (s *Store) IsSlugInUse(_ context.Context, id, slug string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for topicID, topic := range s.topics {
+		if topicID == id {
+			continue
+		}
+		if (topic.Current != nil && topic.Current.Slug == slug) || (topic.Next != nil && topic.Next.Slug == slug) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetContent retrieves a content document by its ID, with only the types of content selected by queryTypeFlags
+func (s *Store) GetContent(_ context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, ok := s.content[id]
+	if !ok {
+		return nil, errs.ErrContentNotFound
+	}
+
+	content, err := clone(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	project(content.Next, queryTypeFlags)
+	project(content.Current, queryTypeFlags)
+
+	return content, nil
+}
+
+// project removes the types of content that are not selected by queryTypeFlags, as the mongo projection does
+func project(content *models.Content, queryTypeFlags int) {
+	if content == nil {
+		return
+	}
+
+	if (queryTypeFlags & api.QuerySpotlightFlag) == 0 {
+		content.Spotlight = nil
+	}
+	if (queryTypeFlags & api.QueryArticlesFlag) == 0 {
+		content.Articles = nil
+	}
+	if (queryTypeFlags & api.QueryBulletinsFlag) == 0 {
+		content.Bulletins = nil
+	}
+	if (queryTypeFlags & api.QueryMethodologiesFlag) == 0 {
+		content.Methodologies = nil
+	}
+	if (queryTypeFlags & api.QueryMethodologyArticlesFlag) == 0 {
+		content.MethodologyArticles = nil
+	}
+	if (queryTypeFlags & api.QueryStaticDatasetsFlag) == 0 {
+		content.StaticDatasets = nil
+	}
+	if (queryTypeFlags & api.QueryTimeseriesFlag) == 0 {
+		content.Timeseries = nil
+	}
+}
+
+// UpdateReleaseDate update releaseDate of document by its topic ID
+func (s *Store) UpdateReleaseDate(_ context.Context, id string, releaseDate time.Time) error {
+	return s.updateNext(id, func(next *models.Topic) {
+		next.ReleaseDate = &releaseDate
+	})
+}
+
+// UpdateOwners replaces the groups that own a topic
+func (s *Store) UpdateOwners(_ context.Context, id string, owners []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.topics[id]
+	if !ok {
+		return errs.ErrTopicNotFound
+	}
+
+	topic, err := clone(stored)
+	if err != nil {
+		return err
+	}
+	topic.Owners = append([]string{}, owners...)
+
+	return s.putTopic(topic)
+}
+
+// UpdateState updates state field against next object
+func (s *Store) UpdateState(_ context.Context, id, state string) error {
+	return s.updateNext(id, func(next *models.Topic) {
+		next.State = state
+	})
+}
+
+// UpdateReview updates state field against next object, along with the record of its review
+func (s *Store) UpdateReview(_ context.Context, id, state string, review *models.Review) error {
+	return s.updateNext(id, func(next *models.Topic) {
+		next.State = state
+		next.Review = review
+	})
+}
+
+// UpdateTopic updates the next instance with new values, as the update query built for mongo does
+func (s *Store) UpdateTopic(_ context.Context, host, id string, topic *models.TopicUpdate) error {
+	var releaseDate *time.Time
+	if parsed, err := time.Parse(time.RFC3339, topic.ReleaseDate); err == nil {
+		releaseDate = &parsed
+	}
+
+	return s.updateNext(id, func(next *models.Topic) {
+		next.Description = topic.Description
+		next.ReleaseDate = releaseDate
+		next.State = topic.State
+		next.Title = topic.Title
+		next.WelshTitle = topic.WelshTitle
+		next.LastEditedBy = topic.LastEditedBy
+		if !topic.KeepsReview() {
+			next.Review = nil
+		}
+		if topic.Slug != "" {
+			next.Slug = topic.Slug
+		}
+
+		if next.Links == nil {
+			next.Links = &models.TopicLinks{}
+		}
+		next.Links.Content = &models.LinkObject{HRef: host + "/topics/" + id + "/content"}
+		next.Links.Self = &models.LinkObject{HRef: host + "/topics/" + id, ID: id}
+
+		next.Keywords = nil
+		if topic.Keywords != nil && len(*topic.Keywords) > 0 {
+			next.Keywords = topic.Keywords
+		}
+
+		next.SubtopicIds = nil
+		next.Links.Subtopics = nil
+		if topic.SubtopicIds != nil && len(*topic.SubtopicIds) > 0 {
+			next.SubtopicIds = topic.SubtopicIds
+			next.Links.Subtopics = &models.LinkObject{HRef: host + "/topics/" + id + "/subtopics"}
+		}
+
+		next.RelatedTopicIDs = nil
+		next.RelatedTopicTypes = nil
+		next.Links.Related = nil
+		if topic.RelatedTopicIDs != nil && len(*topic.RelatedTopicIDs) > 0 {
+			next.RelatedTopicIDs = topic.RelatedTopicIDs
+			next.RelatedTopicTypes = topic.RelatedTopicTypes
+			next.Links.Related = &models.LinkObject{HRef: host + "/topics/" + id + "/related"}
+		}
+	})
+}
+
+// updateNext applies update to the next document of a topic, creating it if needed, and sets its last updated time
+func (s *Store) updateNext(id string, update func(next *models.Topic)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.topics[id]
+	if !ok {
+		return errs.ErrTopicNotFound
+	}
+
+	topic, err := clone(stored)
+	if err != nil {
+		return err
+	}
+
+	if topic.Next == nil {
+		topic.Next = &models.Topic{}
+	}
+	update(topic.Next)
+	currentTime := time.Now()
+	topic.Next.LastUpdated = &currentTime
+
+	return s.putTopic(topic)
+}
+
+// UpsertTopic creates or overwrites an existing topic (based on id) with a new document.
+// As with mongo, ErrTopicNotFound is returned when the topic did not already exist, even though it has been created.
+func (s *Store) UpsertTopic(_ context.Context, id string, topic *models.TopicResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Update the last updated timestamp
+	currentTime := time.Now()
+	if topic.Current != nil {
+		topic.Current.LastUpdated = &currentTime
+	}
+	if topic.Next != nil {
+		topic.Next.LastUpdated = &currentTime
+	}
+
+	update, err := clone(topic)
+	if err != nil {
+		return err
+	}
+
+	stored, existed := s.topics[id]
+	if !existed {
+		stored = &models.TopicResponse{}
+	}
+	merged, err := clone(stored)
+	if err != nil {
+		return err
+	}
+
+	// only the fields that are set replace the stored ones, as with a mongo $set
+	merged.ID = id
+	if update.ID != "" {
+		merged.ID = update.ID
+	}
+	if update.Current != nil {
+		merged.Current = update.Current
+	}
+	if update.Next != nil {
+		merged.Next = update.Next
+	}
+	if update.Owners != nil {
+		merged.Owners = update.Owners
+	}
+
+	if err := s.putTopic(merged); err != nil {
+		return err
+	}
+
+	if !existed {
+		return errs.ErrTopicNotFound
+	}
+
+	return nil
+}
+
+// putTopic stores a topic against its id, the lock must be held
+func (s *Store) putTopic(topic *models.TopicResponse) error {
+	stored, err := clone(topic)
+	if err != nil {
+		return err
+	}
+
+	s.topics[topic.ID] = stored
+
+	return nil
+}
+
+// CreateWebhook inserts a new webhook subscription
+func (s *Store) CreateWebhook(_ context.Context, webhook *models.Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := clone(webhook)
+	if err != nil {
+		return err
+	}
+
+	s.webhooks = append(s.webhooks, stored)
+
+	return nil
+}
+
+// GetWebhook retrieves a webhook subscription by its ID
+func (s *Store) GetWebhook(_ context.Context, id string) (*models.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, webhook := range s.webhooks {
+		if webhook.ID == id {
+			return clone(webhook)
+		}
+	}
+
+	return nil, errs.ErrWebhookNotFound
+}
+
+// GetWebhooks retrieves all webhook subscriptions, oldest first
+func (s *Store) GetWebhooks(_ context.Context) ([]models.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var webhooks []models.Webhook
+	for _, stored := range s.webhooks {
+		webhook, err := clone(stored)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		return timeOf(webhooks[i].CreatedAt).Before(timeOf(webhooks[j].CreatedAt))
+	})
+
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook subscription by its ID
+func (s *Store) DeleteWebhook(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, webhook := range s.webhooks {
+		if webhook.ID == id {
+			s.webhooks = append(s.webhooks[:i], s.webhooks[i+1:]...)
+			return nil
+		}
+	}
+
+	return errs.ErrWebhookNotFound
+}
+
+// UpsertWebhookDelivery creates or overwrites a delivery log entry (based on id)
+func (s *Store) UpsertWebhookDelivery(_ context.Context, delivery *models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	currentTime := time.Now()
+	delivery.LastUpdated = &currentTime
+
+	stored, err := clone(delivery)
+	if err != nil {
+		return err
+	}
+
+	s.webhookDeliveries[delivery.ID] = stored
+
+	return nil
+}
+
+// GetWebhookDeliveries retrieves the most recent deliveries for a webhook subscription, newest first
+func (s *Store) GetWebhookDeliveries(_ context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var deliveries []models.WebhookDelivery
+	for _, stored := range s.webhookDeliveries {
+		if stored.WebhookID != webhookID {
+			continue
+		}
+		delivery, err := clone(stored)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return timeOf(deliveries[i].CreatedAt).After(timeOf(deliveries[j].CreatedAt))
+	})
+
+	// as with mongo, a limit of zero means no limit
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+func timeOf(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// clone returns a deep copy of a document, made by a round trip through bson
+func clone[T any](document *T) (*T, error) {
+	b, err := bson.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var copied T
+	if err := bson.Unmarshal(b, &copied); err != nil {
+		return nil, err
+	}
+
+	return &copied, nil
+}
+
+// InsertTopic inserts a new topic document, overwriting any with the same id
+func (s *Store) InsertTopic(_ context.Context, topic *models.TopicResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.putTopic(topic)
+}
+
+// InsertContent inserts a new content document, overwriting any with the same id
+func (s *Store) InsertContent(_ context.Context, content *models.ContentResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := clone(content)
+	if err != nil {
+		return err
+	}
+
+	s.content[content.ID] = stored
+
+	return nil
+}
+
+// UpsertContent creates or overwrites the content document with the given id
+func (s *Store) UpsertContent(ctx context.Context, id string, content *models.ContentResponse) error {
+	stored := *content
+	stored.ID = id
+
+	return s.InsertContent(ctx, &stored)
+}
+
+// ReplaceTopic replaces the current and next documents of a topic with those given, removing any it does not have,
+// and keeping their last updated timestamps, e.g. to restore a topic as it was before it was changed
+func (s *Store) ReplaceTopic(_ context.Context, topic *models.TopicResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.topics[topic.ID]
+	if !ok {
+		return errs.ErrTopicNotFound
+	}
+
+	replaced, err := clone(stored)
+	if err != nil {
+		return err
+	}
+	replaced.Current = topic.Current
+	replaced.Next = topic.Next
+
+	return s.putTopic(replaced)
+}
+
+// ReplaceContent replaces the current and next content of a topic with those given, removing any it does not have,
+// e.g. to restore content as it was before it was changed
+func (s *Store) ReplaceContent(ctx context.Context, content *models.ContentResponse) error {
+	s.mu.RLock()
+	_, ok := s.content[content.ID]
+	s.mu.RUnlock()
+	if !ok {
+		return errs.ErrContentNotFound
+	}
+
+	return s.InsertContent(ctx, content)
+}
+
+// DeleteTopic removes a topic document and its content document
+func (s *Store) DeleteTopic(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.topics, id)
+	delete(s.content, id)
+
+	return nil
+}
+
+// DeleteContent removes the content document of a topic, leaving the topic itself
+func (s *Store) DeleteContent(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.content, id)
+
+	return nil
+}
+
+// GetAllTopics retrieves every topic document, ordered by id
+func (s *Store) GetAllTopics(_ context.Context) ([]models.TopicResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	topics := make([]models.TopicResponse, 0, len(s.topics))
+	for _, stored := range s.topics {
+		topic, err := clone(stored)
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, *topic)
+	}
+
+	sort.Slice(topics, func(i, j int) bool { return topics[i].ID < topics[j].ID })
+
+	return topics, nil
+}
+
+// GetArchivedTopicIDs retrieves the ids of the topics whose current document has been archived, ordered by id
+func (s *Store) GetArchivedTopicIDs(_ context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := []string{}
+	for id, topic := range s.topics {
+		if topic.Current != nil && topic.Current.IsArchived() {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// GetAllContent retrieves every content document, ordered by id
+func (s *Store) GetAllContent(_ context.Context) ([]models.ContentResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	contents := make([]models.ContentResponse, 0, len(s.content))
+	for _, stored := range s.content {
+		content, err := clone(stored)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, *content)
+	}
+
+	sort.Slice(contents, func(i, j int) bool { return contents[i].ID < contents[j].ID })
+
+	return contents, nil
+}
+
+// DeleteAllTopicsAndContent removes every topic and content document
+func (s *Store) DeleteAllTopicsAndContent(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.topics = make(map[string]*models.TopicResponse)
+	s.content = make(map[string]*models.ContentResponse)
+
+	return nil
+}
+
+// InsertPublication records that a topic was published
+func (s *Store) InsertPublication(_ context.Context, publication *models.Publication) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := clone(publication)
+	if err != nil {
+		return err
+	}
+
+	s.publications = append(s.publications, stored)
+
+	return nil
+}
+
+// GetPublications retrieves the most recent publications of a topic, or of every topic if topicID is empty, newest first
+func (s *Store) GetPublications(_ context.Context, topicID string, limit int) ([]models.Publication, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var publications []models.Publication
+	for _, stored := range s.publications {
+		if topicID != "" && stored.TopicID != topicID {
+			continue
+		}
+		publication, err := clone(stored)
+		if err != nil {
+			return nil, err
+		}
+		publications = append(publications, *publication)
+	}
+
+	sort.SliceStable(publications, func(i, j int) bool {
+		return timeOf(publications[i].PublishedAt).After(timeOf(publications[j].PublishedAt))
+	})
+
+	// as with mongo, a limit of zero means no limit
+	if limit > 0 && len(publications) > limit {
+		publications = publications[:limit]
+	}
+
+	return publications, nil
+}
+
+// CreateCollection inserts a new collection
+func (s *Store) CreateCollection(_ context.Context, collection *models.Collection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := clone(collection)
+	if err != nil {
+		return err
+	}
+
+	s.collections[collection.ID] = stored
+
+	return nil
+}
+
+// GetCollection retrieves a collection by its ID
+func (s *Store) GetCollection(_ context.Context, id string) (*models.Collection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	collection, ok := s.collections[id]
+	if !ok {
+		return nil, errs.ErrCollectionNotFound
+	}
+
+	return clone(collection)
+}
+
+// GetCollections retrieves all collections, ordered by their release date
+func (s *Store) GetCollections(_ context.Context) ([]models.Collection, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var collections []models.Collection
+	for _, stored := range s.collections {
+		collection, err := clone(stored)
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, *collection)
+	}
+
+	sort.Slice(collections, func(i, j int) bool {
+		a, b := timeOf(collections[i].ReleaseDate), timeOf(collections[j].ReleaseDate)
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return collections[i].ID < collections[j].ID
+	})
+
+	return collections, nil
+}
+
+// UpsertCollection creates or overwrites a collection (based on id)
+func (s *Store) UpsertCollection(_ context.Context, collection *models.Collection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	currentTime := time.Now()
+	collection.LastUpdated = &currentTime
+
+	stored, err := clone(collection)
+	if err != nil {
+		return err
+	}
+
+	s.collections[collection.ID] = stored
+
+	return nil
+}
+
+// GetRedirect retrieves the redirect of a path
+func (s *Store) GetRedirect(_ context.Context, from string) (*models.Redirect, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	redirect, ok := s.redirects[from]
+	if !ok {
+		return nil, errs.ErrRedirectNotFound
+	}
+
+	return clone(redirect)
+}
+
+// GetRedirects retrieves all redirects, ordered by the path they redirect
+func (s *Store) GetRedirects(_ context.Context) ([]models.Redirect, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var redirects []models.Redirect
+	for _, stored := range s.redirects {
+		redirect, err := clone(stored)
+		if err != nil {
+			return nil, err
+		}
+		redirects = append(redirects, *redirect)
+	}
+
+	sort.Slice(redirects, func(i, j int) bool {
+		return redirects[i].From < redirects[j].From
+	})
+
+	return redirects, nil
+}
+
+// UpsertRedirect creates or overwrites the redirect of a path (based on from)
+func (s *Store) UpsertRedirect(_ context.Context, redirect *models.Redirect) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := clone(redirect)
+	if err != nil {
+		return err
+	}
+
+	s.redirects[redirect.From] = stored
+
+	return nil
+}
+
+// DeleteRedirect removes the redirect of a path, if there is one
+func (s *Store) DeleteRedirect(_ context.Context, from string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.redirects, from)
+
+	return nil
+}
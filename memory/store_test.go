@@ -0,0 +1,290 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-topic-api/api"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTopics(t *testing.T) {
+	Convey("Given a store seeded from a fixture file", t, func() {
+		ctx := context.Background()
+		s, err := NewFromFile("testdata/fixture.json")
+		So(err, ShouldBeNil)
+
+		Convey("Then it is healthy", func() {
+			state := healthcheck.NewCheckState("mongo")
+			So(s.Checker(ctx, state), ShouldBeNil)
+			So(state.Status(), ShouldEqual, healthcheck.StatusOK)
+		})
+
+		Convey("When a seeded topic is retrieved, it is returned", func() {
+			topic, err := s.GetTopic(ctx, "economy")
+			So(err, ShouldBeNil)
+			So(topic.Current.Title, ShouldEqual, "Economy")
+			So(s.CheckTopicExists(ctx, "economy"), ShouldBeNil)
+
+			Convey("And changing it does not change the stored topic", func() {
+				topic.Current.Title = "changed"
+				stored, err := s.GetTopic(ctx, "economy")
+				So(err, ShouldBeNil)
+				So(stored.Current.Title, ShouldEqual, "Economy")
+			})
+		})
+
+		Convey("When an unknown topic is requested, topic not found is returned", func() {
+			_, err := s.GetTopic(ctx, "unknown")
+			So(err, ShouldEqual, apierrors.ErrTopicNotFound)
+			So(s.CheckTopicExists(ctx, "unknown"), ShouldEqual, apierrors.ErrTopicNotFound)
+			So(s.UpdateState(ctx, "unknown", models.StateCompleted.String()), ShouldEqual, apierrors.ErrTopicNotFound)
+		})
+
+		Convey("When a slug is checked, only those used by other topics are in use", func() {
+			inUse, err := s.IsSlugInUse(ctx, "topic_root", "economy")
+			So(err, ShouldBeNil)
+			So(inUse, ShouldBeTrue)
+
+			inUse, err = s.IsSlugInUse(ctx, "economy", "economy")
+			So(err, ShouldBeNil)
+			So(inUse, ShouldBeFalse)
+		})
+
+		Convey("When a topic is archived, it is the only archived topic", func() {
+			ids, err := s.GetArchivedTopicIDs(ctx)
+			So(err, ShouldBeNil)
+			So(ids, ShouldBeEmpty)
+
+			archived := &models.Topic{ID: "economy", State: models.StateArchived.String()}
+			So(s.UpsertTopic(ctx, "economy", &models.TopicResponse{ID: "economy", Current: archived, Next: archived}), ShouldBeNil)
+			ids, err = s.GetArchivedTopicIDs(ctx)
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []string{"economy"})
+		})
+
+		Convey("When a topic is updated, only its next document changes", func() {
+			err := s.UpdateTopic(ctx, "http://localhost:25300", "economy", &models.TopicUpdate{
+				Title:       "New title",
+				Description: "New description",
+				ReleaseDate: "2023-01-02T09:30:00Z",
+				State:       models.StateCompleted.String(),
+				SubtopicIds: &[]string{"topic_root"},
+			})
+			So(err, ShouldBeNil)
+
+			topic, err := s.GetTopic(ctx, "economy")
+			So(err, ShouldBeNil)
+			So(topic.Current.Title, ShouldEqual, "Economy")
+			So(topic.Next.Title, ShouldEqual, "New title")
+			So(topic.Next.Slug, ShouldEqual, "economy")
+			So(topic.Next.State, ShouldEqual, models.StateCompleted.String())
+			So(topic.Next.ReleaseDate.Equal(time.Date(2023, 1, 2, 9, 30, 0, 0, time.UTC)), ShouldBeTrue)
+			So(topic.Next.Links.Self.HRef, ShouldEqual, "http://localhost:25300/topics/economy")
+			So(topic.Next.Links.Subtopics.HRef, ShouldEqual, "http://localhost:25300/topics/economy/subtopics")
+			So(topic.Next.Keywords, ShouldBeNil)
+			So(topic.Next.LastUpdated, ShouldNotBeNil)
+		})
+
+		Convey("When a rejected topic is updated", func() {
+			rejected := &models.Topic{ID: "economy", Title: "Economy", State: models.StateRejected.String(), Review: &models.Review{ReviewedBy: "reviewer", Reason: "too long"}}
+			So(s.UpsertTopic(ctx, "economy", &models.TopicResponse{ID: "economy", Next: rejected}), ShouldBeNil)
+			update := &models.TopicUpdate{Title: "Economy", Description: "Shorter", State: models.StateRejected.String()}
+
+			Convey("Then its review is kept while it stays rejected, and removed once it leaves the review workflow", func() {
+				So(s.UpdateTopic(ctx, "http://localhost:25300", "economy", update), ShouldBeNil)
+				topic, err := s.GetTopic(ctx, "economy")
+				So(err, ShouldBeNil)
+				So(topic.Next.Review.Reason, ShouldEqual, "too long")
+
+				update.State = models.StateCreated.String()
+				So(s.UpdateTopic(ctx, "http://localhost:25300", "economy", update), ShouldBeNil)
+				topic, err = s.GetTopic(ctx, "economy")
+				So(err, ShouldBeNil)
+				So(topic.Next.Review, ShouldBeNil)
+			})
+		})
+
+		Convey("When an existing topic is upserted, it is overwritten", func() {
+			topic := &models.TopicResponse{
+				ID:      "economy",
+				Current: &models.Topic{ID: "economy", Title: "Published", State: models.StatePublished.String()},
+				Next:    &models.Topic{ID: "economy", Title: "Published", State: models.StatePublished.String()},
+			}
+			So(s.UpsertTopic(ctx, "economy", topic), ShouldBeNil)
+
+			stored, err := s.GetTopic(ctx, "economy")
+			So(err, ShouldBeNil)
+			So(stored.Current.Title, ShouldEqual, "Published")
+			So(stored.Current.LastUpdated, ShouldNotBeNil)
+		})
+
+		Convey("When a new topic is upserted, it is created but topic not found is returned, as it is by mongo", func() {
+			topic := &models.TopicResponse{
+				ID:      "new",
+				Current: &models.Topic{ID: "new"},
+				Next:    &models.Topic{ID: "new"},
+			}
+			So(s.UpsertTopic(ctx, "new", topic), ShouldEqual, apierrors.ErrTopicNotFound)
+			So(s.CheckTopicExists(ctx, "new"), ShouldBeNil)
+		})
+	})
+}
+
+func TestContent(t *testing.T) {
+	Convey("Given a store seeded with content", t, func() {
+		ctx := context.Background()
+		s := New()
+		So(s.Seed(strings.NewReader(`{"content": [{"id": "economy", "current": {"state": "published",
+			"spotlight": [{"href": "/spotlight"}], "articles": [{"href": "/article"}]}}]}`)), ShouldBeNil)
+
+		Convey("When content is requested for some types, only those types are returned", func() {
+			content, err := s.GetContent(ctx, "economy", api.QuerySpotlightFlag)
+			So(err, ShouldBeNil)
+			So(content.Current.State, ShouldEqual, models.StatePublished.String())
+			So(*content.Current.Spotlight, ShouldHaveLength, 1)
+			So(content.Current.Articles, ShouldBeNil)
+			So(content.Next, ShouldBeNil)
+
+			Convey("And the stored content is unchanged", func() {
+				content, err := s.GetContent(ctx, "economy", api.QuerySpotlightFlag|api.QueryArticlesFlag)
+				So(err, ShouldBeNil)
+				So(*content.Current.Articles, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When unknown content is requested, content not found is returned", func() {
+			_, err := s.GetContent(ctx, "unknown", api.QuerySpotlightFlag)
+			So(err, ShouldEqual, apierrors.ErrContentNotFound)
+		})
+
+		Convey("When the content is deleted, content not found is returned for it", func() {
+			So(s.DeleteContent(ctx, "economy"), ShouldBeNil)
+			_, err := s.GetContent(ctx, "economy", api.QuerySpotlightFlag)
+			So(err, ShouldEqual, apierrors.ErrContentNotFound)
+		})
+	})
+
+	Convey("Given a fixture that is not valid JSON, seeding fails", t, func() {
+		So(New().Seed(strings.NewReader(`{`)), ShouldNotBeNil)
+	})
+}
+
+func TestWebhooks(t *testing.T) {
+	Convey("Given a store with webhooks and deliveries", t, func() {
+		ctx := context.Background()
+		s := New()
+		older, newer := time.Now().Add(-time.Hour), time.Now()
+		So(s.CreateWebhook(ctx, &models.Webhook{ID: "newer", CreatedAt: &newer}), ShouldBeNil)
+		So(s.CreateWebhook(ctx, &models.Webhook{ID: "older", CreatedAt: &older}), ShouldBeNil)
+		So(s.UpsertWebhookDelivery(ctx, &models.WebhookDelivery{ID: "d1", WebhookID: "older", CreatedAt: &older}), ShouldBeNil)
+		So(s.UpsertWebhookDelivery(ctx, &models.WebhookDelivery{ID: "d2", WebhookID: "older", CreatedAt: &newer}), ShouldBeNil)
+		So(s.UpsertWebhookDelivery(ctx, &models.WebhookDelivery{ID: "d3", WebhookID: "newer", CreatedAt: &newer}), ShouldBeNil)
+
+		Convey("Then webhooks are listed oldest first", func() {
+			webhooks, err := s.GetWebhooks(ctx)
+			So(err, ShouldBeNil)
+			So(webhooks, ShouldHaveLength, 2)
+			So(webhooks[0].ID, ShouldEqual, "older")
+		})
+
+		Convey("Then the deliveries of a webhook are listed newest first, up to the limit", func() {
+			deliveries, err := s.GetWebhookDeliveries(ctx, "older", 1)
+			So(err, ShouldBeNil)
+			So(deliveries, ShouldHaveLength, 1)
+			So(deliveries[0].ID, ShouldEqual, "d2")
+		})
+
+		Convey("When a webhook is deleted, it can no longer be retrieved", func() {
+			So(s.DeleteWebhook(ctx, "older"), ShouldBeNil)
+			_, err := s.GetWebhook(ctx, "older")
+			So(err, ShouldEqual, apierrors.ErrWebhookNotFound)
+			So(s.DeleteWebhook(ctx, "older"), ShouldEqual, apierrors.ErrWebhookNotFound)
+		})
+	})
+}
+
+func TestCollections(t *testing.T) {
+	Convey("Given a store with collections released on different dates", t, func() {
+		ctx := context.Background()
+		s := New()
+		march, april := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC), time.Date(2024, 4, 1, 9, 30, 0, 0, time.UTC)
+		So(s.CreateCollection(ctx, &models.Collection{ID: "april", ReleaseDate: &april}), ShouldBeNil)
+		So(s.CreateCollection(ctx, &models.Collection{ID: "march", ReleaseDate: &march}), ShouldBeNil)
+
+		Convey("Then collections are listed by release date", func() {
+			collections, err := s.GetCollections(ctx)
+			So(err, ShouldBeNil)
+			So(collections, ShouldHaveLength, 2)
+			So(collections[0].ID, ShouldEqual, "march")
+		})
+
+		Convey("When a collection is updated, the update is stored", func() {
+			So(s.UpsertCollection(ctx, &models.Collection{ID: "march", ReleaseDate: &march, Topics: []string{"economy"}}), ShouldBeNil)
+			collection, err := s.GetCollection(ctx, "march")
+			So(err, ShouldBeNil)
+			So(collection.Topics, ShouldResemble, []string{"economy"})
+			So(collection.LastUpdated, ShouldNotBeNil)
+		})
+
+		Convey("When an unknown collection is requested, collection not found is returned", func() {
+			_, err := s.GetCollection(ctx, "unknown")
+			So(err, ShouldEqual, apierrors.ErrCollectionNotFound)
+		})
+	})
+
+	Convey("Given a store with a topic that has been published", t, func() {
+		ctx := context.Background()
+		s := New()
+		So(s.Seed(strings.NewReader(`{"topics": [{"id": "economy",
+			"current": {"id": "economy", "state": "published"}, "next": {"id": "economy", "state": "published"}}]}`)), ShouldBeNil)
+
+		Convey("When it is replaced by a topic that has never been published, its current document is removed", func() {
+			So(s.ReplaceTopic(ctx, &models.TopicResponse{ID: "economy", Next: &models.Topic{ID: "economy", State: "completed"}}), ShouldBeNil)
+			topic, err := s.GetTopic(ctx, "economy")
+			So(err, ShouldBeNil)
+			So(topic.Current, ShouldBeNil)
+			So(topic.Next.State, ShouldEqual, "completed")
+		})
+
+		Convey("When an unknown topic is replaced, topic not found is returned", func() {
+			So(s.ReplaceTopic(ctx, &models.TopicResponse{ID: "unknown"}), ShouldEqual, apierrors.ErrTopicNotFound)
+		})
+	})
+}
+
+func TestRedirects(t *testing.T) {
+	Convey("Given a store with redirects", t, func() {
+		ctx := context.Background()
+		s := New()
+		So(s.UpsertRedirect(ctx, &models.Redirect{From: "/oldeconomy", To: "/economy", TopicID: "economy"}), ShouldBeNil)
+		So(s.UpsertRedirect(ctx, &models.Redirect{From: "/economy/oldgdp", To: "/economy/gdp", TopicID: "gdp"}), ShouldBeNil)
+
+		Convey("Then redirects are listed by the path they are from", func() {
+			redirects, err := s.GetRedirects(ctx)
+			So(err, ShouldBeNil)
+			So(redirects, ShouldHaveLength, 2)
+			So(redirects[0].From, ShouldEqual, "/economy/oldgdp")
+		})
+
+		Convey("When a redirect is updated, the update is stored", func() {
+			So(s.UpsertRedirect(ctx, &models.Redirect{From: "/oldeconomy", To: "/theeconomy", TopicID: "economy"}), ShouldBeNil)
+			redirect, err := s.GetRedirect(ctx, "/oldeconomy")
+			So(err, ShouldBeNil)
+			So(redirect.To, ShouldEqual, "/theeconomy")
+		})
+
+		Convey("When a redirect is deleted, redirect not found is returned for its path", func() {
+			So(s.DeleteRedirect(ctx, "/oldeconomy"), ShouldBeNil)
+			_, err := s.GetRedirect(ctx, "/oldeconomy")
+			So(err, ShouldEqual, apierrors.ErrRedirectNotFound)
+			So(s.DeleteRedirect(ctx, "/oldeconomy"), ShouldBeNil)
+		})
+	})
+}
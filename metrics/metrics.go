@@ -0,0 +1,152 @@
+// Package metrics holds the Prometheus metrics of the service, and serves them at /metrics when they are enabled.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is the path the metrics are served at
+const Path = "/metrics"
+
+// RouteUnmatched is the route of requests that do not match a route, so that they do not each have their own series
+const RouteUnmatched = "unmatched"
+
+// The outcomes of publishing a topic
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Registry holds the metrics of the service, along with those of the Go runtime and the process
+var Registry = prometheus.NewRegistry()
+
+var (
+	// Requests counts the HTTP requests by method, route template and status
+	Requests = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: "topic_api",
+		Name:      "http_requests_total",
+		Help:      "The number of HTTP requests, by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	// RequestDuration observes the latency of the HTTP requests by method, route template and status
+	RequestDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "topic_api",
+		Name:      "http_request_duration_seconds",
+		Help:      "The latency of HTTP requests, by method, route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// MongoDuration observes the duration of the operations of the mongo store by method
+	MongoDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "topic_api",
+		Name:      "mongo_operation_duration_seconds",
+		Help:      "The duration of mongo operations, by the method of the store.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 15},
+	}, []string{"method"})
+
+	// SubtopicsFanOut observes the number of subtopics that are looked up when the subtopics of a topic are requested
+	SubtopicsFanOut = promauto.With(Registry).NewHistogram(prometheus.HistogramOpts{
+		Namespace: "topic_api",
+		Name:      "subtopics_fanout",
+		Help:      "The number of subtopics looked up for a request of the subtopics of a topic.",
+		Buckets:   []float64{1, 2, 5, 10, 20, 50, 100},
+	})
+
+	// Publishes counts the topics published, by outcome
+	Publishes = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: "topic_api",
+		Name:      "publishes_total",
+		Help:      "The number of topics published, by outcome (success or failure).",
+	}, []string{"outcome"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// ObserveMongo observes the duration of a mongo operation that started at start. It is called by the function that ends
+// the operation, returned by startOperation of the mongo store, which each of its methods defers
+func ObserveMongo(method string, start time.Time) {
+	MongoDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// ObservePublish counts a topic being published, and whether it failed
+func ObservePublish(err error) {
+	if err != nil {
+		Publishes.WithLabelValues(OutcomeFailure).Inc()
+		return
+	}
+	Publishes.WithLabelValues(OutcomeSuccess).Inc()
+}
+
+// Middleware serves the metrics at Path, and observes every other request by the template of the route of the router
+// it matches
+func Middleware(router *mux.Router) func(http.Handler) http.Handler {
+	metricsHandler := promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodGet && req.URL.Path == Path {
+				metricsHandler.ServeHTTP(w, req)
+				return
+			}
+
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			h.ServeHTTP(recorder, req)
+
+			labels := prometheus.Labels{
+				"method": req.Method,
+				"route":  routeOf(router, req),
+				"status": strconv.Itoa(recorder.status),
+			}
+			Requests.With(labels).Inc()
+			RequestDuration.With(labels).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// routeOf returns the template of the route the request matches, or RouteUnmatched
+func routeOf(router *mux.Router, req *http.Request) string {
+	var match mux.RouteMatch
+	if !router.Match(req, &match) || match.Route == nil {
+		return RouteUnmatched
+	}
+	template, err := match.Route.GetPathTemplate()
+	if err != nil {
+		return RouteUnmatched
+	}
+	return template
+}
+
+// statusRecorder records the status of a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader records the status before writing it
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap returns the underlying response writer, so that http.ResponseController can flush streamed responses
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMiddleware(t *testing.T) {
+	Convey("Given a router with the metrics middleware", t, func() {
+		router := mux.NewRouter()
+		router.HandleFunc("/topics/{id}", func(w http.ResponseWriter, req *http.Request) {
+			if mux.Vars(req)["id"] == "unknown" {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}).Methods(http.MethodGet)
+		handler := Middleware(router)(router)
+
+		serve := func(path string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://localhost:25300"+path, http.NoBody))
+			return w
+		}
+
+		Convey("When requests are made, they are counted by the template of the route they match and their status", func() {
+			found := Requests.WithLabelValues(http.MethodGet, "/topics/{id}", "200")
+			notFound := Requests.WithLabelValues(http.MethodGet, "/topics/{id}", "404")
+			unmatched := Requests.WithLabelValues(http.MethodGet, RouteUnmatched, "404")
+			before := []float64{testutil.ToFloat64(found), testutil.ToFloat64(notFound), testutil.ToFloat64(unmatched)}
+
+			serve("/topics/1")
+			serve("/topics/2")
+			serve("/topics/unknown")
+			serve("/not/a/route")
+
+			So(testutil.ToFloat64(found)-before[0], ShouldEqual, 2)
+			So(testutil.ToFloat64(notFound)-before[1], ShouldEqual, 1)
+			So(testutil.ToFloat64(unmatched)-before[2], ShouldEqual, 1)
+
+			Convey("And the metrics are served, including the latency of the requests", func() {
+				w := serve(Path)
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Body.String(), ShouldContainSubstring, `topic_api_http_request_duration_seconds_count{method="GET",route="/topics/{id}",status="200"}`)
+				So(w.Body.String(), ShouldContainSubstring, "go_goroutines")
+			})
+		})
+	})
+}
+
+func TestObserve(t *testing.T) {
+	Convey("When topics are published, successes and failures are counted", t, func() {
+		success := testutil.ToFloat64(Publishes.WithLabelValues(OutcomeSuccess))
+		failure := testutil.ToFloat64(Publishes.WithLabelValues(OutcomeFailure))
+
+		ObservePublish(nil)
+		ObservePublish(errors.New("mongo is unavailable"))
+
+		So(testutil.ToFloat64(Publishes.WithLabelValues(OutcomeSuccess))-success, ShouldEqual, 1)
+		So(testutil.ToFloat64(Publishes.WithLabelValues(OutcomeFailure))-failure, ShouldEqual, 1)
+	})
+
+	Convey("When a mongo operation is observed, its duration is observed by the method of the store", t, func() {
+		ObserveMongo("GetTopic", time.Now().Add(-time.Second))
+
+		So(testutil.CollectAndCount(MongoDuration, "topic_api_mongo_operation_duration_seconds"), ShouldBeGreaterThanOrEqualTo, 1)
+	})
+}
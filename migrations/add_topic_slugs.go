@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// addTopicSlugs gives every topic created before slugs were introduced a slug generated from its title
+var addTopicSlugs = Migration{
+	ID:          "0001_add_topic_slugs",
+	Description: "generate the slugs of topics without one from their titles",
+	Run: func(ctx context.Context, db Database, dryRun bool) (int, error) {
+		collection := db.Collection(config.TopicsCollection)
+		filter := bson.M{"$or": bson.A{missingSlug("current"), missingSlug("next")}}
+
+		if dryRun {
+			return collection.Count(ctx, filter)
+		}
+
+		var topics []models.TopicResponse
+		if _, err := collection.Find(ctx, filter, &topics); err != nil {
+			return 0, err
+		}
+
+		changed := 0
+		for i := range topics {
+			update := bson.M{}
+			if current := topics[i].Current; current != nil && current.Slug == "" && current.Title != "" {
+				update["current.slug"] = models.GenerateSlug(current.Title)
+			}
+			if next := topics[i].Next; next != nil && next.Slug == "" && next.Title != "" {
+				update["next.slug"] = models.GenerateSlug(next.Title)
+			}
+
+			result, err := collection.UpdateOne(ctx, bson.M{"id": topics[i].ID}, bson.M{"$set": update})
+			if err != nil {
+				return changed, err
+			}
+			changed += result.ModifiedCount
+		}
+
+		return changed, nil
+	},
+}
+
+// missingSlug matches documents where the given version of the topic has a title but no slug
+func missingSlug(version string) bson.M {
+	return bson.M{
+		version + ".title": bson.M{"$nin": bson.A{nil, ""}},
+		version + ".slug":  bson.M{"$in": bson.A{nil, ""}},
+	}
+}
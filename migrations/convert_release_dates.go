@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// convertReleaseDates converts the release dates of topics that were stored as RFC3339 strings into dates, so that they
+// can be read into models.Topic. Release dates that cannot be converted are left unchanged, and are counted again if the
+// migration is dry run.
+var convertReleaseDates = Migration{
+	ID:          "0002_convert_release_dates",
+	Description: "convert topic release dates stored as strings into dates",
+	Run: func(ctx context.Context, db Database, dryRun bool) (int, error) {
+		collection := db.Collection(config.TopicsCollection)
+		filter := bson.M{"$or": bson.A{stringReleaseDate("current"), stringReleaseDate("next")}}
+
+		count, err := collection.Count(ctx, filter)
+		if err != nil || dryRun {
+			return count, err
+		}
+
+		for _, version := range []string{"current", "next"} {
+			field := version + ".release_date"
+			convert := bson.M{"$convert": bson.M{"input": "$" + field, "to": "date", "onError": "$" + field}}
+
+			if _, err := collection.UpdateMany(ctx, stringReleaseDate(version), bson.A{bson.M{"$set": bson.M{field: convert}}}); err != nil {
+				return 0, err
+			}
+		}
+
+		return count, nil
+	},
+}
+
+// stringReleaseDate matches documents where the release date of the given version of the topic is a string
+func stringReleaseDate(version string) bson.M {
+	return bson.M{version + ".release_date": bson.M{"$type": "string"}}
+}
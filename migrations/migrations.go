@@ -0,0 +1,144 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+)
+
+// migrationsLockID is the id of the resource locked while migrations are applied
+const migrationsLockID = "migrate"
+
+// All is every migration of the topics database, in the order they are applied. New migrations must be appended, with the
+// next ID, and must never be removed or reordered once released.
+var All = []Migration{
+	addTopicSlugs,
+	convertReleaseDates,
+}
+
+//go:generate moq -out mock/database.go -pkg mock . Database
+
+// Database represents the methods required to record migrations, and the collections they change
+type Database interface {
+	GetSchemaMigrations(ctx context.Context) ([]models.SchemaMigration, error)
+	InsertSchemaMigration(ctx context.Context, migration *models.SchemaMigration) error
+	Collection(name string) *mongodriver.Collection
+}
+
+// Locker represents a lock that is held, across every instance of the service, while migrations are applied
+type Locker interface {
+	Acquire(ctx context.Context, id string) (lockID string, err error)
+	Unlock(ctx context.Context, lockID string)
+}
+
+// Migration is a versioned change to the shape of the documents in the database.
+// Migrations are applied once each, in the order of their IDs, and must be idempotent as a migration that fails
+// part way through is applied again in full.
+type Migration struct {
+	// ID uniquely identifies the migration and orders it, e.g. "0001_add_topic_slugs"
+	ID          string
+	Description string
+	// Run applies the migration, or with dryRun only counts the documents it would change, returning the number of documents changed
+	Run func(ctx context.Context, db Database, dryRun bool) (int, error)
+}
+
+// Result reports the outcome of a migration
+type Result struct {
+	ID               string `json:"id"`
+	Description      string `json:"description"`
+	DocumentsChanged int    `json:"documents_changed"`
+}
+
+// Migrator applies pending migrations to a database
+type Migrator struct {
+	db         Database
+	locker     Locker
+	migrations []Migration
+}
+
+// New creates a Migrator for the given migrations, which must be ordered by ID. The locker may be nil if only one instance
+// can run migrations at a time, e.g. in tests.
+func New(db Database, locker Locker, migrations []Migration) *Migrator {
+	return &Migrator{
+		db:         db,
+		locker:     locker,
+		migrations: migrations,
+	}
+}
+
+// Pending returns the migrations that have not been applied, in the order they will be applied
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.db.GetSchemaMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	isApplied := make(map[string]bool, len(applied))
+	for _, migration := range applied {
+		isApplied[migration.ID] = true
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if !isApplied[migration.ID] {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// Migrate applies every pending migration in order, recording each as it is applied, and stopping at the first that fails.
+// With dryRun, nothing is changed and the results report how many documents each pending migration would change.
+// The lock is held throughout, so that only one instance applies migrations.
+func (m *Migrator) Migrate(ctx context.Context, dryRun bool) ([]Result, error) {
+	if m.locker != nil && !dryRun {
+		lockID, err := m.locker.Acquire(ctx, migrationsLockID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire the migrations lock: %w", err)
+		}
+		defer m.locker.Unlock(ctx, lockID)
+	}
+
+	// the pending migrations are only found once the lock is held, as another instance may have just applied them
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(pending))
+	for _, migration := range pending {
+		logData := log.Data{"migration": migration.ID, "dry_run": dryRun}
+		log.Info(ctx, "running migration", logData)
+
+		changed, err := migration.Run(ctx, m.db, dryRun)
+		if err != nil {
+			return results, fmt.Errorf("migration %s failed: %w", migration.ID, err)
+		}
+		logData["documents_changed"] = changed
+
+		results = append(results, Result{ID: migration.ID, Description: migration.Description, DocumentsChanged: changed})
+		if dryRun {
+			log.Info(ctx, "migration dry run complete", logData)
+			continue
+		}
+
+		appliedAt := time.Now()
+		if err := m.db.InsertSchemaMigration(ctx, &models.SchemaMigration{
+			ID:               migration.ID,
+			Description:      migration.Description,
+			DocumentsChanged: changed,
+			AppliedAt:        &appliedAt,
+		}); err != nil {
+			return results, fmt.Errorf("failed to record migration %s: %w", migration.ID, err)
+		}
+		log.Info(ctx, "migration applied", logData)
+	}
+
+	return results, nil
+}
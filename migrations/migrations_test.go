@@ -0,0 +1,161 @@
+package migrations_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/migrations"
+	"github.com/ONSdigital/dp-topic-api/migrations/mock"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var errTest = errors.New("test error")
+
+// fakeLocker records whether the lock is held
+type fakeLocker struct {
+	held       bool
+	acquireErr error
+}
+
+func (l *fakeLocker) Acquire(_ context.Context, _ string) (string, error) {
+	if l.acquireErr != nil {
+		return "", l.acquireErr
+	}
+	l.held = true
+	return "lock", nil
+}
+
+func (l *fakeLocker) Unlock(_ context.Context, _ string) {
+	l.held = false
+}
+
+// testMigration returns a migration that changes the given number of documents, recording its runs and whether they were dry run
+func testMigration(id string, changed int, err error, runs *[]string) migrations.Migration {
+	return migrations.Migration{
+		ID:          id,
+		Description: "test migration " + id,
+		Run: func(_ context.Context, _ migrations.Database, dryRun bool) (int, error) {
+			if dryRun {
+				*runs = append(*runs, id+" (dry run)")
+			} else {
+				*runs = append(*runs, id)
+			}
+			return changed, err
+		},
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	Convey("Given a database where the first of three migrations has been applied", t, func() {
+		ctx := context.Background()
+		var runs []string
+		var recorded []*models.SchemaMigration
+		locker := &fakeLocker{}
+
+		db := &mock.DatabaseMock{
+			GetSchemaMigrationsFunc: func(_ context.Context) ([]models.SchemaMigration, error) {
+				return []models.SchemaMigration{{ID: "0001"}}, nil
+			},
+			InsertSchemaMigrationFunc: func(_ context.Context, migration *models.SchemaMigration) error {
+				So(locker.held, ShouldBeTrue)
+				recorded = append(recorded, migration)
+				return nil
+			},
+		}
+		all := []migrations.Migration{
+			testMigration("0001", 1, nil, &runs),
+			testMigration("0002", 2, nil, &runs),
+			testMigration("0003", 3, nil, &runs),
+		}
+		migrator := migrations.New(db, locker, all)
+
+		Convey("When the pending migrations are listed, the applied migration is not included", func() {
+			pending, err := migrator.Pending(ctx)
+			So(err, ShouldBeNil)
+			So(pending, ShouldHaveLength, 2)
+			So(pending[0].ID, ShouldEqual, "0002")
+			So(pending[1].ID, ShouldEqual, "0003")
+		})
+
+		Convey("When the database is migrated", func() {
+			results, err := migrator.Migrate(ctx, false)
+
+			Convey("Then the pending migrations are applied in order and recorded while the lock is held", func() {
+				So(err, ShouldBeNil)
+				So(runs, ShouldResemble, []string{"0002", "0003"})
+				So(results, ShouldResemble, []migrations.Result{
+					{ID: "0002", Description: "test migration 0002", DocumentsChanged: 2},
+					{ID: "0003", Description: "test migration 0003", DocumentsChanged: 3},
+				})
+				So(recorded, ShouldHaveLength, 2)
+				So(recorded[1].ID, ShouldEqual, "0003")
+				So(recorded[1].DocumentsChanged, ShouldEqual, 3)
+				So(recorded[1].AppliedAt, ShouldNotBeNil)
+			})
+
+			Convey("Then the lock is released", func() {
+				So(locker.held, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the database is migrated as a dry run", func() {
+			results, err := migrator.Migrate(ctx, true)
+
+			Convey("Then the pending migrations are dry run and their changes reported, but not recorded", func() {
+				So(err, ShouldBeNil)
+				So(runs, ShouldResemble, []string{"0002 (dry run)", "0003 (dry run)"})
+				So(results, ShouldHaveLength, 2)
+				So(results[0].DocumentsChanged, ShouldEqual, 2)
+				So(db.InsertSchemaMigrationCalls(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a migration fails", func() {
+			all[1] = testMigration("0002", 0, errTest, &runs)
+			results, err := migrations.New(db, locker, all).Migrate(ctx, false)
+
+			Convey("Then it is not recorded, and the migrations after it are not applied", func() {
+				So(err, ShouldWrap, errTest)
+				So(results, ShouldBeEmpty)
+				So(runs, ShouldResemble, []string{"0002"})
+				So(recorded, ShouldBeEmpty)
+				So(locker.held, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the lock is held by another instance", func() {
+			locker.acquireErr = errTest
+			_, err := migrator.Migrate(ctx, false)
+
+			Convey("Then no migrations are applied", func() {
+				So(err, ShouldWrap, errTest)
+				So(runs, ShouldBeEmpty)
+				So(db.GetSchemaMigrationsCalls(), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given the applied migrations cannot be read", t, func() {
+		db := &mock.DatabaseMock{
+			GetSchemaMigrationsFunc: func(_ context.Context) ([]models.SchemaMigration, error) {
+				return nil, errTest
+			},
+		}
+
+		Convey("When the database is migrated, an error is returned", func() {
+			_, err := migrations.New(db, nil, migrations.All).Migrate(context.Background(), false)
+			So(err, ShouldWrap, errTest)
+		})
+	})
+}
+
+func TestAll(t *testing.T) {
+	Convey("The registered migrations have unique IDs, in order", t, func() {
+		for i := 1; i < len(migrations.All); i++ {
+			So(migrations.All[i].ID, ShouldBeGreaterThan, migrations.All[i-1].ID)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mock
+
+import (
+	"context"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+	"github.com/ONSdigital/dp-topic-api/migrations"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"sync"
+)
+
+// Ensure, that DatabaseMock does implement migrations.Database.
+// If this is not the case, regenerate this file with moq.
+var _ migrations.Database = &DatabaseMock{}
+
+// DatabaseMock is a mock implementation of migrations.Database.
+//
+//	func TestSomethingThatUsesDatabase(t *testing.T) {
+//
+//		// make and configure a mocked migrations.Database
+//		mockedDatabase := &DatabaseMock{
+//			CollectionFunc: func(name string) *mongodriver.Collection {
+//				panic("mock out the Collection method")
+//			},
+//			GetSchemaMigrationsFunc: func(ctx context.Context) ([]models.SchemaMigration, error) {
+//				panic("mock out the GetSchemaMigrations method")
+//			},
+//			InsertSchemaMigrationFunc: func(ctx context.Context, migration *models.SchemaMigration) error {
+//				panic("mock out the InsertSchemaMigration method")
+//			},
+//		}
+//
+//		// use mockedDatabase in code that requires migrations.Database
+//		// and then make assertions.
+//
+//	}
+type DatabaseMock struct {
+	// CollectionFunc mocks the Collection method.
+	CollectionFunc func(name string) *mongodriver.Collection
+
+	// GetSchemaMigrationsFunc mocks the GetSchemaMigrations method.
+	GetSchemaMigrationsFunc func(ctx context.Context) ([]models.SchemaMigration, error)
+
+	// InsertSchemaMigrationFunc mocks the InsertSchemaMigration method.
+	InsertSchemaMigrationFunc func(ctx context.Context, migration *models.SchemaMigration) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Collection holds details about calls to the Collection method.
+		Collection []struct {
+			// Name is the name argument value.
+			Name string
+		}
+		// GetSchemaMigrations holds details about calls to the GetSchemaMigrations method.
+		GetSchemaMigrations []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// InsertSchemaMigration holds details about calls to the InsertSchemaMigration method.
+		InsertSchemaMigration []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Migration is the migration argument value.
+			Migration *models.SchemaMigration
+		}
+	}
+	lockCollection            sync.RWMutex
+	lockGetSchemaMigrations   sync.RWMutex
+	lockInsertSchemaMigration sync.RWMutex
+}
+
+// Collection calls CollectionFunc.
+func (mock *DatabaseMock) Collection(name string) *mongodriver.Collection {
+	if mock.CollectionFunc == nil {
+		panic("DatabaseMock.CollectionFunc: method is nil but Database.Collection was just called")
+	}
+	callInfo := struct {
+		Name string
+	}{
+		Name: name,
+	}
+	mock.lockCollection.Lock()
+	mock.calls.Collection = append(mock.calls.Collection, callInfo)
+	mock.lockCollection.Unlock()
+	return mock.CollectionFunc(name)
+}
+
+// CollectionCalls gets all the calls that were made to Collection.
+// Check the length with:
+//
+//	len(mockedDatabase.CollectionCalls())
+func (mock *DatabaseMock) CollectionCalls() []struct {
+	Name string
+} {
+	var calls []struct {
+		Name string
+	}
+	mock.lockCollection.RLock()
+	calls = mock.calls.Collection
+	mock.lockCollection.RUnlock()
+	return calls
+}
+
+// GetSchemaMigrations calls GetSchemaMigrationsFunc.
+func (mock *DatabaseMock) GetSchemaMigrations(ctx context.Context) ([]models.SchemaMigration, error) {
+	if mock.GetSchemaMigrationsFunc == nil {
+		panic("DatabaseMock.GetSchemaMigrationsFunc: method is nil but Database.GetSchemaMigrations was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetSchemaMigrations.Lock()
+	mock.calls.GetSchemaMigrations = append(mock.calls.GetSchemaMigrations, callInfo)
+	mock.lockGetSchemaMigrations.Unlock()
+	return mock.GetSchemaMigrationsFunc(ctx)
+}
+
+// GetSchemaMigrationsCalls gets all the calls that were made to GetSchemaMigrations.
+// Check the length with:
+//
+//	len(mockedDatabase.GetSchemaMigrationsCalls())
+func (mock *DatabaseMock) GetSchemaMigrationsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetSchemaMigrations.RLock()
+	calls = mock.calls.GetSchemaMigrations
+	mock.lockGetSchemaMigrations.RUnlock()
+	return calls
+}
+
+// InsertSchemaMigration calls InsertSchemaMigrationFunc.
+func (mock *DatabaseMock) InsertSchemaMigration(ctx context.Context, migration *models.SchemaMigration) error {
+	if mock.InsertSchemaMigrationFunc == nil {
+		panic("DatabaseMock.InsertSchemaMigrationFunc: method is nil but Database.InsertSchemaMigration was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Migration *models.SchemaMigration
+	}{
+		Ctx:       ctx,
+		Migration: migration,
+	}
+	mock.lockInsertSchemaMigration.Lock()
+	mock.calls.InsertSchemaMigration = append(mock.calls.InsertSchemaMigration, callInfo)
+	mock.lockInsertSchemaMigration.Unlock()
+	return mock.InsertSchemaMigrationFunc(ctx, migration)
+}
+
+// InsertSchemaMigrationCalls gets all the calls that were made to InsertSchemaMigration.
+// Check the length with:
+//
+//	len(mockedDatabase.InsertSchemaMigrationCalls())
+func (mock *DatabaseMock) InsertSchemaMigrationCalls() []struct {
+	Ctx       context.Context
+	Migration *models.SchemaMigration
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Migration *models.SchemaMigration
+	}
+	mock.lockInsertSchemaMigration.RLock()
+	calls = mock.calls.InsertSchemaMigration
+	mock.lockInsertSchemaMigration.RUnlock()
+	return calls
+}
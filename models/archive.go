@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+)
+
+// Archive represents the optional incoming request structure of archiving a topic, giving the topic that succeeds it
+type Archive struct {
+	SuccessorID string `json:"successor_id"`
+}
+
+// Validate checks that the topic with the id is not archived in favour of itself. Errors are returned as an
+// *apierrors.ValidationError detailing every invalid field.
+func (a *Archive) Validate(id string) error {
+	violations := &apierrors.ValidationError{}
+
+	if a.SuccessorID != "" && a.SuccessorID == id {
+		violations.Add(apierrors.ErrTopicInvalidFields, "successor_id", "must not be the archived topic")
+	}
+
+	return violations.ErrorOrNil()
+}
+
+// IsArchived returns whether the topic has been archived
+func (t *Topic) IsArchived() bool {
+	return t.State == StateArchived.String()
+}
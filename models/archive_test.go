@@ -0,0 +1,27 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestArchiveValidate(t *testing.T) {
+	Convey("Given an archive naming another topic as its successor, then it is valid", t, func() {
+		archive := models.Archive{SuccessorID: "well-being"}
+		So(archive.Validate("wellbeing"), ShouldBeNil)
+	})
+
+	Convey("Given an archive without a successor, then it is valid", t, func() {
+		archive := models.Archive{}
+		So(archive.Validate("wellbeing"), ShouldBeNil)
+	})
+
+	Convey("Given an archive naming the archived topic as its own successor, then the successor is invalid", t, func() {
+		archive := models.Archive{SuccessorID: "wellbeing"}
+		So(errors.Is(archive.Validate("wellbeing"), apierrors.ErrTopicInvalidFields), ShouldBeTrue)
+	})
+}
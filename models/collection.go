@@ -0,0 +1,134 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+)
+
+// Possible values for the state of a collection
+const (
+	CollectionStateOpen      = "open"
+	CollectionStatePublished = "published"
+)
+
+// MaxCollectionNameLength is the maximum length of the name of a collection
+const MaxCollectionNameLength = 200
+
+// Collection represents a release bundle, grouping the changes to the next versions of topics and of their content
+// that are published together, on a single release date. Topics are the ids of the topics whose next version is
+// released, and Content the ids of the topics whose next content is released.
+type Collection struct {
+	ID          string     `bson:"id"                      json:"id"`
+	Name        string     `bson:"name"                    json:"name"`
+	ReleaseDate *time.Time `bson:"release_date"            json:"release_date"`
+	State       string     `bson:"state"                   json:"state"`
+	Topics      []string   `bson:"topics"                  json:"topics"`
+	Content     []string   `bson:"content"                 json:"content"`
+	CreatedAt   *time.Time `bson:"created_at"              json:"created_at,omitempty"`
+	LastUpdated *time.Time `bson:"last_updated"            json:"last_updated,omitempty"`
+	PublishedAt *time.Time `bson:"published_at,omitempty"  json:"published_at,omitempty"`
+	PublishedBy string     `bson:"published_by,omitempty"  json:"published_by,omitempty"`
+}
+
+// Collections is used for returning a list of collections in REST API response
+type Collections struct {
+	TotalCount int          `json:"total_count"`
+	Items      []Collection `json:"items"`
+}
+
+// ReadCollection manages the creation of a collection object from a reader
+func ReadCollection(r io.Reader) (*Collection, error) {
+	var collection Collection
+
+	err := json.NewDecoder(r).Decode(&collection)
+	switch {
+	case err == io.EOF:
+		return nil, apierrors.ErrEmptyRequestBody
+	case err != nil:
+		return nil, apierrors.ErrUnableToReadMessage
+	}
+
+	return &collection, nil
+}
+
+// Validate checks that a new collection has a name and a release date. Errors are returned as an
+// *apierrors.ValidationError detailing every invalid field.
+func (c *Collection) Validate() error {
+	violations := &apierrors.ValidationError{}
+
+	switch {
+	case strings.TrimSpace(c.Name) == "":
+		violations.Add(apierrors.ErrCollectionInvalidFields, "name", "must not be empty")
+	case utf8.RuneCountInString(c.Name) > MaxCollectionNameLength:
+		violations.Add(apierrors.ErrCollectionInvalidFields, "name", fmt.Sprintf("must be at most %d characters", MaxCollectionNameLength))
+	}
+
+	if c.ReleaseDate == nil || c.ReleaseDate.IsZero() {
+		violations.Add(apierrors.ErrCollectionInvalidFields, "release_date", "must not be empty")
+	}
+
+	return violations.ErrorOrNil()
+}
+
+// IsOpen returns true if changes can still be added to, or removed from, the collection
+func (c *Collection) IsOpen() bool {
+	return c.State == CollectionStateOpen
+}
+
+// IsEmpty returns true if the collection has no changes to publish
+func (c *Collection) IsEmpty() bool {
+	return len(c.Topics) == 0 && len(c.Content) == 0
+}
+
+// HasChange returns true if the collection releases the next version of the topic, or of its content when content
+// is set
+func (c *Collection) HasChange(topicID string, content bool) bool {
+	return slices.Contains(c.changes(content), topicID)
+}
+
+// AddChange adds the next version of the topic, or of its content when content is set, to the collection, returning
+// false if it was already in the collection
+func (c *Collection) AddChange(topicID string, content bool) bool {
+	if c.HasChange(topicID, content) {
+		return false
+	}
+
+	if content {
+		c.Content = append(c.Content, topicID)
+	} else {
+		c.Topics = append(c.Topics, topicID)
+	}
+
+	return true
+}
+
+// RemoveChange removes the next version of the topic, or of its content when content is set, from the collection,
+// returning false if it was not in the collection
+func (c *Collection) RemoveChange(topicID string, content bool) bool {
+	if !c.HasChange(topicID, content) {
+		return false
+	}
+
+	if content {
+		c.Content = slices.DeleteFunc(c.Content, func(id string) bool { return id == topicID })
+	} else {
+		c.Topics = slices.DeleteFunc(c.Topics, func(id string) bool { return id == topicID })
+	}
+
+	return true
+}
+
+// changes returns the ids of the topics whose next version, or next content when content is set, is in the collection
+func (c *Collection) changes(content bool) []string {
+	if content {
+		return c.Content
+	}
+	return c.Topics
+}
@@ -0,0 +1,62 @@
+package models_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCollectionValidate(t *testing.T) {
+	releaseDate := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	Convey("Given a collection with a name and a release date, then it is valid", t, func() {
+		collection := models.Collection{Name: "March release", ReleaseDate: &releaseDate}
+		So(collection.Validate(), ShouldBeNil)
+	})
+
+	Convey("Given a collection without a name or release date, then both fields are invalid", t, func() {
+		collection := models.Collection{Name: " "}
+		err := collection.Validate()
+		So(errors.Is(err, apierrors.ErrCollectionInvalidFields), ShouldBeTrue)
+
+		var violations *apierrors.ValidationError
+		So(errors.As(err, &violations), ShouldBeTrue)
+		So(violations.Fields, ShouldHaveLength, 2)
+	})
+
+	Convey("Given a collection with a name that is too long, then the name is invalid", t, func() {
+		collection := models.Collection{Name: strings.Repeat("a", models.MaxCollectionNameLength+1), ReleaseDate: &releaseDate}
+		So(errors.Is(collection.Validate(), apierrors.ErrCollectionInvalidFields), ShouldBeTrue)
+	})
+}
+
+func TestCollectionChanges(t *testing.T) {
+	Convey("Given an empty collection", t, func() {
+		collection := models.Collection{State: models.CollectionStateOpen}
+		So(collection.IsOpen(), ShouldBeTrue)
+		So(collection.IsEmpty(), ShouldBeTrue)
+
+		Convey("When the next content of a topic is added, only its content is in the collection", func() {
+			So(collection.AddChange("economy", true), ShouldBeTrue)
+			So(collection.HasChange("economy", true), ShouldBeTrue)
+			So(collection.HasChange("economy", false), ShouldBeFalse)
+			So(collection.IsEmpty(), ShouldBeFalse)
+
+			Convey("And adding it again changes nothing", func() {
+				So(collection.AddChange("economy", true), ShouldBeFalse)
+				So(collection.Content, ShouldResemble, []string{"economy"})
+			})
+
+			Convey("And removing it leaves the collection empty", func() {
+				So(collection.RemoveChange("economy", false), ShouldBeFalse)
+				So(collection.RemoveChange("economy", true), ShouldBeTrue)
+				So(collection.IsEmpty(), ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -104,6 +104,13 @@ func TestContentStateTransitionAllowed(t *testing.T) {
 		content := models.Content{}
 		contentValidateTransitionsToCreated(content)
 	})
+
+	Convey("Given an content in review, then it follows the review workflow of topics", t, func() {
+		content := models.Content{State: models.StateInReview.String()}
+		So(content.StateTransitionAllowed(models.StateApproved.String()), ShouldBeTrue)
+		So(content.StateTransitionAllowed(models.StateRejected.String()), ShouldBeTrue)
+		So(content.StateTransitionAllowed(models.StatePublished.String()), ShouldBeFalse)
+	})
 }
 
 // validateTransitionsToCreated validates that the provided content can transition to created state,
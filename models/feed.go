@@ -0,0 +1,71 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Publication records that a topic was published, for the feeds of recently published topics and content
+type Publication struct {
+	TopicID     string     `bson:"topic_id"                 json:"topic_id"`
+	Title       string     `bson:"title"                    json:"title"`
+	Description string     `bson:"description,omitempty"    json:"description,omitempty"`
+	PublishedAt *time.Time `bson:"published_at"             json:"published_at"`
+	// AddedContent is the content of the topic that it did not have when it was last published
+	AddedContent []PublishedContent `bson:"added_content,omitempty"  json:"added_content,omitempty"`
+	// ContentHRefs are the links of all the content of the topic when it was published, to find what is added next time
+	ContentHRefs []string `bson:"content_hrefs,omitempty"  json:"-"`
+}
+
+// PublishedContent is a link to content, e.g. a bulletin, of a published topic
+type PublishedContent struct {
+	Type  string `bson:"type"   json:"type"`
+	Title string `bson:"title"  json:"title"`
+	HRef  string `bson:"href"   json:"href"`
+}
+
+// AtomContentType is the media type of Atom feeds
+const AtomContentType = "application/atom+xml; charset=utf-8"
+
+// AtomNamespace is the XML namespace of Atom feeds
+const AtomNamespace = "http://www.w3.org/2005/Atom"
+
+// AtomFeed is an Atom feed (RFC 4287), listing its most recent entries first
+type AtomFeed struct {
+	XMLName   xml.Name    `xml:"feed"`
+	Namespace string      `xml:"xmlns,attr"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Updated   string      `xml:"updated"`
+	Author    AtomAuthor  `xml:"author"`
+	Links     []AtomLink  `xml:"link"`
+	Entries   []AtomEntry `xml:"entry"`
+}
+
+// AtomEntry is an entry of an Atom feed
+type AtomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Summary    string         `xml:"summary,omitempty"`
+	Links      []AtomLink     `xml:"link"`
+	Categories []AtomCategory `xml:"category"`
+}
+
+// AtomAuthor is the author of an Atom feed
+type AtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// AtomLink is a link from an Atom feed or entry, which is to an alternate representation unless it has a rel
+type AtomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	HRef string `xml:"href,attr"`
+}
+
+// AtomCategory categorises an Atom entry, e.g. as a topic or the type of its content
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// SchemaMigration records a migration that has been applied to the database
+type SchemaMigration struct {
+	ID               string     `bson:"id"                 json:"id"`
+	Description      string     `bson:"description"        json:"description"`
+	DocumentsChanged int        `bson:"documents_changed"  json:"documents_changed"`
+	AppliedAt        *time.Time `bson:"applied_at"         json:"applied_at"`
+}
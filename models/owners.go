@@ -0,0 +1,47 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+)
+
+// MaxOwners is the maximum number of groups that can own a topic
+const MaxOwners = 20
+
+// TopicOwners represents the incoming request structure replacing the groups that own a topic
+type TopicOwners struct {
+	Owners []string `json:"owners"`
+}
+
+// Validate checks that the owners are the ids of groups, each given once. An empty list leaves the topic to be owned by
+// the owners of its ancestors. Errors are returned as an *apierrors.ValidationError detailing every invalid field.
+func (o *TopicOwners) Validate() error {
+	violations := &apierrors.ValidationError{}
+
+	if o.Owners == nil {
+		violations.Add(apierrors.ErrTopicMissingFields, "owners", "must be a list of groups, which may be empty")
+		return violations
+	}
+
+	if len(o.Owners) > MaxOwners {
+		violations.Add(apierrors.ErrTopicInvalidFields, "owners", fmt.Sprintf("must have at most %d groups", MaxOwners))
+	}
+
+	seen := make(map[string]bool)
+	for i, group := range o.Owners {
+		field := fmt.Sprintf("owners[%d]", i)
+		switch {
+		case strings.TrimSpace(group) == "":
+			violations.Add(apierrors.ErrTopicInvalidFields, field, "must not be empty")
+		case group != strings.TrimSpace(group):
+			violations.Add(apierrors.ErrTopicInvalidFields, field, "must not start or end with white space")
+		case seen[group]:
+			violations.Add(apierrors.ErrTopicInvalidFields, field, "must not duplicate another group")
+		}
+		seen[group] = true
+	}
+
+	return violations.ErrorOrNil()
+}
@@ -0,0 +1,43 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTopicOwnersValidate(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given owners that are distinct groups, validation passes", t, func() {
+		owners := models.TopicOwners{Owners: []string{"census-team", "population-team"}}
+		So(owners.Validate(), ShouldBeNil)
+	})
+
+	Convey("Given no owners, validation passes, leaving the topic owned by the owners of its ancestors", t, func() {
+		owners := models.TopicOwners{Owners: []string{}}
+		So(owners.Validate(), ShouldBeNil)
+	})
+
+	Convey("Given a request without owners, validation fails", t, func() {
+		owners := models.TopicOwners{}
+		So(errors.Is(owners.Validate(), apierrors.ErrTopicMissingFields), ShouldBeTrue)
+	})
+
+	Convey("Given empty and duplicated groups, validation fails detailing each of them", t, func() {
+		owners := models.TopicOwners{Owners: []string{"census-team", "", " census-team", "census-team"}}
+		err := owners.Validate()
+		So(errors.Is(err, apierrors.ErrTopicInvalidFields), ShouldBeTrue)
+
+		var validationErr *apierrors.ValidationError
+		So(errors.As(err, &validationErr), ShouldBeTrue)
+		So(validationErr.Fields, ShouldResemble, []apierrors.FieldError{
+			{Field: "owners[1]", Message: "must not be empty"},
+			{Field: "owners[2]", Message: "must not start or end with white space"},
+			{Field: "owners[3]", Message: "must not duplicate another group"},
+		})
+	})
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// PreviewToken represents the response structure of a token giving access to the next view of a topic, with its
+// subtopics and content, on the public API until it expires
+type PreviewToken struct {
+	Token     string    `json:"token"`
+	TopicID   string    `json:"topic_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
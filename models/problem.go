@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+)
+
+// ProblemContentType is the media type of error responses
+const ProblemContentType = "application/problem+json"
+
+// Problem represents the RFC 7807 problem details returned in the body of error responses.
+// Code is the stable, machine readable code of the error, see apierrors.Code.
+type Problem struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Code      string                 `json:"code"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Errors    []apierrors.FieldError `json:"errors,omitempty"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Redirect represents a public path of a topic that has moved, because the slug of the topic, or of one of the topics
+// above it, has changed, and the path it has moved to
+type Redirect struct {
+	From      string     `bson:"from"                  json:"from"`
+	To        string     `bson:"to"                    json:"to"`
+	TopicID   string     `bson:"topic_id"              json:"topic_id"`
+	CreatedAt *time.Time `bson:"created_at,omitempty"  json:"created_at,omitempty"`
+}
+
+// Redirects is used for returning a list of redirects in REST API response
+type Redirects struct {
+	TotalCount int        `json:"total_count"`
+	Items      []Redirect `json:"items"`
+}
+
+// NormaliseRedirectPath returns a path in the form redirects are recorded in, which is lower case, starts with a slash
+// and has no trailing slash
+func NormaliseRedirectPath(path string) string {
+	path = strings.Trim(strings.ToLower(strings.TrimSpace(path)), "/")
+	return "/" + path
+}
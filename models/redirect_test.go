@@ -0,0 +1,16 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNormaliseRedirectPath(t *testing.T) {
+	Convey("Given paths in different forms, then they are normalised to the form redirects are recorded in", t, func() {
+		So(NormaliseRedirectPath("/economy/gdp"), ShouldEqual, "/economy/gdp")
+		So(NormaliseRedirectPath("Economy/GDP/"), ShouldEqual, "/economy/gdp")
+		So(NormaliseRedirectPath(" /economy/ "), ShouldEqual, "/economy")
+		So(NormaliseRedirectPath("/"), ShouldEqual, "/")
+	})
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+)
+
+// The types of the relation between a topic and a topic related to it, across the branches of the tree of topics.
+// A related topic without a type is simply related.
+const (
+	RelationRelated           = "related"
+	RelationSeeAlso           = "see-also"
+	RelationBroaderEquivalent = "broader-equivalent"
+)
+
+// relationTypes is every type of relation, in the order they are described
+var relationTypes = []string{RelationRelated, RelationSeeAlso, RelationBroaderEquivalent}
+
+// RelatedTopic is a topic related to another, with the type of the relation
+type RelatedTopic struct {
+	RelationType string `json:"relation_type"`
+	Topic        *Topic `json:"topic"`
+}
+
+// RelatedTopics is used for returning the topics related to a topic in REST API response
+type RelatedTopics struct {
+	TotalCount int            `json:"total_count"`
+	Items      []RelatedTopic `json:"items"`
+}
+
+// RelationType returns the type of the relation between the topic and a topic related to it
+func (t *Topic) RelationType(relatedID string) string {
+	if relationType, ok := t.RelatedTopicTypes[relatedID]; ok {
+		return relationType
+	}
+	return RelationRelated
+}
+
+// validateRelated adds the violations of the related topics of the topic with the given id, and of the types of their
+// relations, which must each be the type of one of the related topics
+func validateRelated(violations *apierrors.ValidationError, id string, relatedIDs *[]string, relatedTypes map[string]string) {
+	related := make(map[string]bool)
+	if relatedIDs != nil {
+		for i, relatedID := range *relatedIDs {
+			field := fmt.Sprintf("related_topic_ids[%d]", i)
+			switch {
+			case relatedID == "":
+				violations.Add(apierrors.ErrTopicInvalidFields, field, "must not be empty")
+			case id != "" && relatedID == id:
+				violations.Add(apierrors.ErrTopicInvalidFields, field, "must not reference the topic itself")
+			case related[relatedID]:
+				violations.Add(apierrors.ErrTopicInvalidFields, field, "must not duplicate another related topic")
+			}
+			related[relatedID] = true
+		}
+	}
+
+	// the types are checked in the order of the ids they are for, so that violations are reported in a stable order
+	relatedTypeIDs := make([]string, 0, len(relatedTypes))
+	for relatedID := range relatedTypes {
+		relatedTypeIDs = append(relatedTypeIDs, relatedID)
+	}
+	sort.Strings(relatedTypeIDs)
+
+	for _, relatedID := range relatedTypeIDs {
+		field := fmt.Sprintf("related_topic_types.%s", relatedID)
+		switch {
+		case !related[relatedID]:
+			violations.Add(apierrors.ErrTopicInvalidFields, field, "must be the type of the relation to a related topic")
+		case !isRelationType(relatedTypes[relatedID]):
+			violations.Add(apierrors.ErrTopicInvalidFields, field, fmt.Sprintf("must be one of %s", strings.Join(relationTypes, ", ")))
+		}
+	}
+}
+
+func isRelationType(relationType string) bool {
+	for _, valid := range relationTypes {
+		if relationType == valid {
+			return true
+		}
+	}
+	return false
+}
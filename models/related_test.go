@@ -0,0 +1,57 @@
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRelatedTopicsValidate(t *testing.T) {
+	valid := func() *models.TopicUpdate {
+		return &models.TopicUpdate{
+			Title:       "Housing",
+			Description: "Housing",
+			State:       models.StateCreated.String(),
+			ReleaseDate: "2022-10-10T08:30:00Z",
+		}
+	}
+
+	Convey("Given topics related to a topic, with the types of some of the relations, then they are valid", t, func() {
+		update := valid()
+		update.RelatedTopicIDs = &[]string{"finances", "benefits"}
+		update.RelatedTopicTypes = map[string]string{"benefits": models.RelationSeeAlso}
+		So(update.ValidateUpdate("housing"), ShouldBeNil)
+	})
+
+	Convey("Given invalid related topics and types of relations, validation fails detailing each of them", t, func() {
+		update := valid()
+		update.RelatedTopicIDs = &[]string{"finances", "", "housing", "finances"}
+		update.RelatedTopicTypes = map[string]string{"finances": "cousin", "economy": models.RelationRelated}
+		err := update.ValidateUpdate("housing")
+		So(errors.Is(err, apierrors.ErrTopicInvalidFields), ShouldBeTrue)
+
+		var validationErr *apierrors.ValidationError
+		So(errors.As(err, &validationErr), ShouldBeTrue)
+		So(validationErr.Fields, ShouldResemble, []apierrors.FieldError{
+			{Field: "related_topic_ids[1]", Message: "must not be empty"},
+			{Field: "related_topic_ids[2]", Message: "must not reference the topic itself"},
+			{Field: "related_topic_ids[3]", Message: "must not duplicate another related topic"},
+			{Field: "related_topic_types.economy", Message: "must be the type of the relation to a related topic"},
+			{Field: "related_topic_types.finances", Message: "must be one of related, see-also, broader-equivalent"},
+		})
+	})
+}
+
+func TestRelationType(t *testing.T) {
+	Convey("Given a topic with related topics, then the type of each relation is returned, defaulting to related", t, func() {
+		topic := models.Topic{
+			RelatedTopicIDs:   &[]string{"finances", "benefits"},
+			RelatedTopicTypes: map[string]string{"benefits": models.RelationBroaderEquivalent},
+		}
+		So(topic.RelationType("finances"), ShouldEqual, models.RelationRelated)
+		So(topic.RelationType("benefits"), ShouldEqual, models.RelationBroaderEquivalent)
+	})
+}
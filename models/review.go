@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+)
+
+// MaxReviewReasonLength is the maximum length of the reason a topic was rejected for
+const MaxReviewReasonLength = 2000
+
+// Review records the review of the next version of a topic: who submitted it for review, and who approved or rejected
+// it, with the reason it was rejected for
+type Review struct {
+	SubmittedBy string     `bson:"submitted_by,omitempty"  json:"submitted_by,omitempty"`
+	SubmittedAt *time.Time `bson:"submitted_at,omitempty"  json:"submitted_at,omitempty"`
+	ReviewedBy  string     `bson:"reviewed_by,omitempty"   json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `bson:"reviewed_at,omitempty"   json:"reviewed_at,omitempty"`
+	Reason      string     `bson:"reason,omitempty"        json:"reason,omitempty"`
+}
+
+// ReviewDecision represents the optional incoming request structure of a state change, giving the reason a topic is
+// rejected for
+type ReviewDecision struct {
+	Reason string `json:"reason"`
+}
+
+// Validate checks that a rejection gives its reason, and that the reason is not too long. Errors are returned as an
+// *apierrors.ValidationError detailing every invalid field.
+func (d *ReviewDecision) Validate(state State) error {
+	violations := &apierrors.ValidationError{}
+
+	switch {
+	case state == StateRejected && strings.TrimSpace(d.Reason) == "":
+		violations.Add(apierrors.ErrTopicMissingFields, "reason", "must not be empty when rejecting a topic")
+	case state != StateRejected && d.Reason != "":
+		violations.Add(apierrors.ErrTopicInvalidFields, "reason", "must only be given when rejecting a topic")
+	case utf8.RuneCountInString(d.Reason) > MaxReviewReasonLength:
+		violations.Add(apierrors.ErrTopicInvalidFields, "reason", fmt.Sprintf("must be at most %d characters", MaxReviewReasonLength))
+	}
+
+	return violations.ErrorOrNil()
+}
+
+// Editor returns who last edited the next version of the topic, or who submitted it for review when its last editor
+// is not known
+func (t *Topic) Editor() string {
+	if t.LastEditedBy != "" {
+		return t.LastEditedBy
+	}
+	if t.Review != nil {
+		return t.Review.SubmittedBy
+	}
+	return ""
+}
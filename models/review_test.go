@@ -0,0 +1,57 @@
+package models_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReviewDecisionValidate(t *testing.T) {
+	Convey("Given a rejection giving its reason, then it is valid", t, func() {
+		decision := models.ReviewDecision{Reason: "The title is misspelt"}
+		So(decision.Validate(models.StateRejected), ShouldBeNil)
+	})
+
+	Convey("Given an approval without a reason, then it is valid", t, func() {
+		decision := models.ReviewDecision{}
+		So(decision.Validate(models.StateApproved), ShouldBeNil)
+	})
+
+	Convey("Given a rejection without a reason, then the reason is missing", t, func() {
+		decision := models.ReviewDecision{Reason: "  "}
+		err := decision.Validate(models.StateRejected)
+		So(errors.Is(err, apierrors.ErrTopicMissingFields), ShouldBeTrue)
+	})
+
+	Convey("Given an approval with a reason, then the reason is invalid", t, func() {
+		decision := models.ReviewDecision{Reason: "Looks good"}
+		err := decision.Validate(models.StateApproved)
+		So(errors.Is(err, apierrors.ErrTopicInvalidFields), ShouldBeTrue)
+	})
+
+	Convey("Given a rejection with a reason that is too long, then the reason is invalid", t, func() {
+		decision := models.ReviewDecision{Reason: strings.Repeat("a", models.MaxReviewReasonLength+1)}
+		err := decision.Validate(models.StateRejected)
+		So(errors.Is(err, apierrors.ErrTopicInvalidFields), ShouldBeTrue)
+	})
+}
+
+func TestTopicEditor(t *testing.T) {
+	Convey("Given a topic with a last editor, then its editor is the last editor", t, func() {
+		topic := models.Topic{LastEditedBy: "editor@ons.gov.uk", Review: &models.Review{SubmittedBy: "submitter@ons.gov.uk"}}
+		So(topic.Editor(), ShouldEqual, "editor@ons.gov.uk")
+	})
+
+	Convey("Given a topic without a last editor, then its editor is who submitted it for review", t, func() {
+		topic := models.Topic{Review: &models.Review{SubmittedBy: "submitter@ons.gov.uk"}}
+		So(topic.Editor(), ShouldEqual, "submitter@ons.gov.uk")
+	})
+
+	Convey("Given a topic without any editorial records, then its editor is not known", t, func() {
+		So((&models.Topic{}).Editor(), ShouldEqual, "")
+	})
+}
@@ -0,0 +1,48 @@
+package models
+
+import "encoding/xml"
+
+// SitemapContentType is the media type of sitemaps and sitemap indexes
+const SitemapContentType = "application/xml; charset=utf-8"
+
+// The XML namespaces of sitemaps, and of the links to their alternate languages
+const (
+	SitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	XHTMLNamespace   = "http://www.w3.org/1999/xhtml"
+)
+
+// SitemapURLSet is a sitemap, listing the URLs of pages on the website
+type SitemapURLSet struct {
+	XMLName        xml.Name     `xml:"urlset"`
+	Namespace      string       `xml:"xmlns,attr"`
+	XHTMLNamespace string       `xml:"xmlns:xhtml,attr"`
+	URLs           []SitemapURL `xml:"url"`
+}
+
+// SitemapURL is the URL of a page in a sitemap, with the date it was last modified (if known) and links to the page
+// in each language
+type SitemapURL struct {
+	Loc        string             `xml:"loc"`
+	LastMod    string             `xml:"lastmod,omitempty"`
+	Alternates []SitemapAlternate `xml:"xhtml:link"`
+}
+
+// SitemapAlternate is a link to the page in a language, given by its hreflang
+type SitemapAlternate struct {
+	Rel      string `xml:"rel,attr"`
+	HrefLang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+// SitemapIndex lists the files of a sitemap that is split because it has too many URLs for one file
+type SitemapIndex struct {
+	XMLName   xml.Name      `xml:"sitemapindex"`
+	Namespace string        `xml:"xmlns,attr"`
+	Sitemaps  []SitemapFile `xml:"sitemap"`
+}
+
+// SitemapFile is a file of a split sitemap, with the date its pages were last modified (if known)
+type SitemapFile struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
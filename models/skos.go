@@ -0,0 +1,309 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// The formats a taxonomy can be exported in. NDJSON is the format that can be imported, whereas the SKOS formats
+// describe the published vocabulary for linked-data consumers.
+const (
+	TaxonomyFormatNDJSON     = "ndjson"
+	TaxonomyFormatSKOSTurtle = "skos-turtle"
+	TaxonomyFormatSKOSJSONLD = "skos-jsonld"
+)
+
+// The media types of a taxonomy exported as SKOS
+const (
+	TurtleContentType = "text/turtle; charset=utf-8"
+	JSONLDContentType = "application/ld+json"
+)
+
+// SKOSNamespace is the namespace of the SKOS vocabulary
+const SKOSNamespace = "http://www.w3.org/2004/02/skos/core#"
+
+// The language tags of the labels of concepts
+const (
+	LanguageEnglish = "en"
+	LanguageWelsh   = "cy"
+)
+
+// ConceptSchemeLabel is the label of the concept scheme of the taxonomy
+const ConceptSchemeLabel = "Office for National Statistics topics"
+
+// ConceptScheme is the taxonomy as a SKOS concept scheme, with a concept for each topic
+type ConceptScheme struct {
+	URI         string
+	Label       string
+	TopConcepts []string
+	Concepts    []Concept
+}
+
+// Concept is a topic as a SKOS concept. Its URI is stable, as it is based on the id of the topic, and the concepts it is
+// broader and narrower than are given by their URIs.
+type Concept struct {
+	URI        string
+	Notation   string
+	PrefLabels map[string]string
+	AltLabels  []string
+	Definition string
+	Broader    []string
+	Narrower   []string
+	TopConcept bool
+}
+
+// NewConceptScheme creates the concept scheme of the topics, with the URI given, using the version of each topic given
+// (current, next, or both for the next version where there is one). The subtopics of the root topic are the top
+// concepts of the scheme, and topics without the version are left out. Topics whose title has been translated have
+// their Welsh title as their Welsh label.
+func NewConceptScheme(uri string, topics []TopicResponse, rootID, version string) *ConceptScheme {
+	scheme := &ConceptScheme{URI: uri, Label: ConceptSchemeLabel, TopConcepts: []string{}, Concepts: []Concept{}}
+
+	versions := make(map[string]*Topic, len(topics))
+	for i := range topics {
+		if topic := versionOf(&topics[i], version); topic != nil {
+			versions[topics[i].ID] = topic
+		}
+	}
+
+	broader := make(map[string][]string)
+	topConcepts := make(map[string]bool)
+	for id, topic := range versions {
+		if topic.SubtopicIds == nil {
+			continue
+		}
+		for _, subtopicID := range *topic.SubtopicIds {
+			if versions[subtopicID] == nil {
+				continue
+			}
+			if id == rootID {
+				topConcepts[subtopicID] = true
+				scheme.TopConcepts = append(scheme.TopConcepts, scheme.ConceptURI(subtopicID))
+				continue
+			}
+			broader[subtopicID] = append(broader[subtopicID], scheme.ConceptURI(id))
+		}
+	}
+
+	for i := range topics {
+		id := topics[i].ID
+		topic := versions[id]
+		if id == rootID || topic == nil {
+			continue
+		}
+
+		concept := Concept{
+			URI:        scheme.ConceptURI(id),
+			Notation:   id,
+			PrefLabels: map[string]string{LanguageEnglish: topic.Title},
+			AltLabels:  []string{},
+			Definition: topic.Description,
+			Broader:    broader[id],
+			Narrower:   []string{},
+			TopConcept: topConcepts[id],
+		}
+		if topic.WelshTitle != "" {
+			concept.PrefLabels[LanguageWelsh] = topic.WelshTitle
+		}
+		if topic.Keywords != nil {
+			concept.AltLabels = append(concept.AltLabels, *topic.Keywords...)
+		}
+		if topic.SubtopicIds != nil {
+			for _, subtopicID := range *topic.SubtopicIds {
+				if versions[subtopicID] != nil {
+					concept.Narrower = append(concept.Narrower, scheme.ConceptURI(subtopicID))
+				}
+			}
+		}
+		sort.Strings(concept.Broader)
+
+		scheme.Concepts = append(scheme.Concepts, concept)
+	}
+
+	sort.Strings(scheme.TopConcepts)
+	sort.Slice(scheme.Concepts, func(i, j int) bool { return scheme.Concepts[i].Notation < scheme.Concepts[j].Notation })
+
+	return scheme
+}
+
+// versionOf returns the version of a topic given (current, next, or both for the next version where there is one)
+func versionOf(topic *TopicResponse, version string) *Topic {
+	switch version {
+	case TaxonomyVersionCurrent:
+		return topic.Current
+	case TaxonomyVersionNext:
+		return topic.Next
+	default:
+		if topic.Next != nil {
+			return topic.Next
+		}
+		return topic.Current
+	}
+}
+
+// ConceptURI returns the URI of the concept of the topic with the id
+func (s *ConceptScheme) ConceptURI(id string) string {
+	return s.URI + "/" + url.PathEscape(id)
+}
+
+// turtleEscaper escapes the characters that cannot appear in a quoted Turtle string
+var turtleEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// WriteTurtle writes the concept scheme as Turtle
+func (s *ConceptScheme) WriteTurtle(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@prefix skos: <%s> .\n", SKOSNamespace)
+
+	writeSubject(&b, s.URI, "skos:ConceptScheme", [][2]string{
+		{"skos:prefLabel", turtleLiteral(s.Label, LanguageEnglish)},
+		{"skos:hasTopConcept", turtleIRIs(s.TopConcepts)},
+	})
+
+	for i := range s.Concepts {
+		concept := &s.Concepts[i]
+		predicates := [][2]string{{"skos:inScheme", turtleIRI(s.URI)}}
+		if concept.TopConcept {
+			predicates = append(predicates, [2]string{"skos:topConceptOf", turtleIRI(s.URI)})
+		}
+		predicates = append(predicates, [2]string{"skos:notation", turtleLiteral(concept.Notation, "")})
+
+		prefLabels := []string{turtleLiteral(concept.PrefLabels[LanguageEnglish], LanguageEnglish)}
+		if label, ok := concept.PrefLabels[LanguageWelsh]; ok {
+			prefLabels = append(prefLabels, turtleLiteral(label, LanguageWelsh))
+		}
+		predicates = append(predicates, [2]string{"skos:prefLabel", strings.Join(prefLabels, ", ")})
+
+		altLabels := make([]string, len(concept.AltLabels))
+		for j, label := range concept.AltLabels {
+			altLabels[j] = turtleLiteral(label, LanguageEnglish)
+		}
+		predicates = append(predicates, [2]string{"skos:altLabel", strings.Join(altLabels, ", ")})
+		if concept.Definition != "" {
+			predicates = append(predicates, [2]string{"skos:definition", turtleLiteral(concept.Definition, LanguageEnglish)})
+		}
+		predicates = append(predicates,
+			[2]string{"skos:broader", turtleIRIs(concept.Broader)},
+			[2]string{"skos:narrower", turtleIRIs(concept.Narrower)},
+		)
+
+		writeSubject(&b, concept.URI, "skos:Concept", predicates)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeSubject writes the triples of a subject of the type given, leaving out the predicates without objects
+func writeSubject(b *strings.Builder, uri, subjectType string, predicates [][2]string) {
+	fmt.Fprintf(b, "\n%s\n    a %s", turtleIRI(uri), subjectType)
+	for _, predicate := range predicates {
+		if predicate[1] != "" {
+			fmt.Fprintf(b, " ;\n    %s %s", predicate[0], predicate[1])
+		}
+	}
+	b.WriteString(" .\n")
+}
+
+// turtleIRI returns an IRI in Turtle
+func turtleIRI(uri string) string {
+	return "<" + uri + ">"
+}
+
+// turtleIRIs returns a list of IRIs in Turtle
+func turtleIRIs(uris []string) string {
+	iris := make([]string, len(uris))
+	for i, uri := range uris {
+		iris[i] = turtleIRI(uri)
+	}
+	return strings.Join(iris, ", ")
+}
+
+// turtleLiteral returns a string in Turtle, tagged with its language if it has one
+func turtleLiteral(value, language string) string {
+	literal := `"` + turtleEscaper.Replace(value) + `"`
+	if language != "" {
+		literal += "@" + language
+	}
+	return literal
+}
+
+// jsonLDContext maps the terms of the JSON-LD to the SKOS vocabulary. Labels are maps of language to value, and the
+// concepts related to a concept are given by their URIs.
+var jsonLDContext = map[string]interface{}{
+	"skos":          SKOSNamespace,
+	"notation":      "skos:notation",
+	"prefLabel":     map[string]string{"@id": "skos:prefLabel", "@container": "@language"},
+	"altLabel":      map[string]string{"@id": "skos:altLabel", "@container": "@language"},
+	"definition":    map[string]string{"@id": "skos:definition", "@container": "@language"},
+	"inScheme":      map[string]string{"@id": "skos:inScheme", "@type": "@id"},
+	"hasTopConcept": map[string]string{"@id": "skos:hasTopConcept", "@type": "@id"},
+	"topConceptOf":  map[string]string{"@id": "skos:topConceptOf", "@type": "@id"},
+	"broader":       map[string]string{"@id": "skos:broader", "@type": "@id"},
+	"narrower":      map[string]string{"@id": "skos:narrower", "@type": "@id"},
+}
+
+// JSONLDDocument is a concept scheme as JSON-LD, with the scheme and its concepts in its graph
+type JSONLDDocument struct {
+	Context map[string]interface{} `json:"@context"`
+	Graph   []JSONLDNode           `json:"@graph"`
+}
+
+// JSONLDNode is the concept scheme, or one of its concepts, in a JSONLDDocument
+type JSONLDNode struct {
+	ID            string              `json:"@id"`
+	Type          string              `json:"@type"`
+	Notation      string              `json:"notation,omitempty"`
+	PrefLabel     map[string]string   `json:"prefLabel,omitempty"`
+	AltLabel      map[string][]string `json:"altLabel,omitempty"`
+	Definition    map[string]string   `json:"definition,omitempty"`
+	InScheme      string              `json:"inScheme,omitempty"`
+	HasTopConcept []string            `json:"hasTopConcept,omitempty"`
+	TopConceptOf  string              `json:"topConceptOf,omitempty"`
+	Broader       []string            `json:"broader,omitempty"`
+	Narrower      []string            `json:"narrower,omitempty"`
+}
+
+// JSONLD returns the concept scheme as JSON-LD
+func (s *ConceptScheme) JSONLD() *JSONLDDocument {
+	document := &JSONLDDocument{Context: jsonLDContext, Graph: make([]JSONLDNode, 0, len(s.Concepts)+1)}
+	document.Graph = append(document.Graph, JSONLDNode{
+		ID:            s.URI,
+		Type:          "skos:ConceptScheme",
+		PrefLabel:     map[string]string{LanguageEnglish: s.Label},
+		HasTopConcept: s.TopConcepts,
+	})
+
+	for i := range s.Concepts {
+		concept := &s.Concepts[i]
+		node := JSONLDNode{
+			ID:        concept.URI,
+			Type:      "skos:Concept",
+			Notation:  concept.Notation,
+			PrefLabel: concept.PrefLabels,
+			InScheme:  s.URI,
+			Broader:   concept.Broader,
+			Narrower:  concept.Narrower,
+		}
+		if len(concept.AltLabels) > 0 {
+			node.AltLabel = map[string][]string{LanguageEnglish: concept.AltLabels}
+		}
+		if concept.Definition != "" {
+			node.Definition = map[string]string{LanguageEnglish: concept.Definition}
+		}
+		if concept.TopConcept {
+			node.TopConceptOf = s.URI
+		}
+		document.Graph = append(document.Graph, node)
+	}
+
+	return document
+}
+
+// WriteJSONLD writes the concept scheme as JSON-LD
+func (s *ConceptScheme) WriteJSONLD(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s.JSONLD())
+}
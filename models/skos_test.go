@@ -0,0 +1,87 @@
+package models
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewConceptScheme(t *testing.T) {
+	Convey("Given the root topic, a top level topic with a subtopic, and a topic that has not been published", t, func() {
+		rootSubtopics := []string{"economy", "unpublished"}
+		economySubtopics := []string{"inflation"}
+		keywords := []string{"cpi", "rpi"}
+		topics := []TopicResponse{
+			{ID: "topic_root", Current: &Topic{ID: "topic_root", SubtopicIds: &rootSubtopics}},
+			{ID: "economy", Current: &Topic{ID: "economy", Title: "Economy", WelshTitle: "Yr economi", Description: "The \"UK\" economy", SubtopicIds: &economySubtopics}},
+			{ID: "inflation", Current: &Topic{ID: "inflation", Title: "Inflation", Keywords: &keywords}},
+			{ID: "unpublished", Next: &Topic{ID: "unpublished", Title: "Unpublished"}},
+		}
+
+		Convey("When the concept scheme of the current versions is created", func() {
+			scheme := NewConceptScheme("http://example.com/topics", topics, "topic_root", TaxonomyVersionCurrent)
+
+			Convey("Then each published topic other than the root is a concept, related by its subtopics", func() {
+				So(scheme.TopConcepts, ShouldResemble, []string{"http://example.com/topics/economy"})
+				So(scheme.Concepts, ShouldHaveLength, 2)
+
+				So(scheme.Concepts[0].URI, ShouldEqual, "http://example.com/topics/economy")
+				So(scheme.Concepts[0].TopConcept, ShouldBeTrue)
+				So(scheme.Concepts[0].PrefLabels, ShouldResemble, map[string]string{LanguageEnglish: "Economy", LanguageWelsh: "Yr economi"})
+				So(scheme.Concepts[0].Broader, ShouldBeNil)
+				So(scheme.Concepts[0].Narrower, ShouldResemble, []string{"http://example.com/topics/inflation"})
+
+				So(scheme.Concepts[1].Notation, ShouldEqual, "inflation")
+				So(scheme.Concepts[1].TopConcept, ShouldBeFalse)
+				So(scheme.Concepts[1].AltLabels, ShouldResemble, []string{"cpi", "rpi"})
+				So(scheme.Concepts[1].Broader, ShouldResemble, []string{"http://example.com/topics/economy"})
+			})
+
+			Convey("And it is written as Turtle, with its strings escaped", func() {
+				var b bytes.Buffer
+				So(scheme.WriteTurtle(&b), ShouldBeNil)
+				So(b.String(), ShouldEqual, `@prefix skos: <http://www.w3.org/2004/02/skos/core#> .
+
+<http://example.com/topics>
+    a skos:ConceptScheme ;
+    skos:prefLabel "Office for National Statistics topics"@en ;
+    skos:hasTopConcept <http://example.com/topics/economy> .
+
+<http://example.com/topics/economy>
+    a skos:Concept ;
+    skos:inScheme <http://example.com/topics> ;
+    skos:topConceptOf <http://example.com/topics> ;
+    skos:notation "economy" ;
+    skos:prefLabel "Economy"@en, "Yr economi"@cy ;
+    skos:definition "The \"UK\" economy"@en ;
+    skos:narrower <http://example.com/topics/inflation> .
+
+<http://example.com/topics/inflation>
+    a skos:Concept ;
+    skos:inScheme <http://example.com/topics> ;
+    skos:notation "inflation" ;
+    skos:prefLabel "Inflation"@en ;
+    skos:altLabel "cpi"@en, "rpi"@en ;
+    skos:broader <http://example.com/topics/economy> .
+`)
+			})
+
+			Convey("And as JSON-LD, with the scheme first in its graph", func() {
+				document := scheme.JSONLD()
+				So(document.Graph, ShouldHaveLength, 3)
+				So(document.Graph[0].Type, ShouldEqual, "skos:ConceptScheme")
+				So(document.Graph[0].HasTopConcept, ShouldResemble, []string{"http://example.com/topics/economy"})
+				So(document.Graph[1].TopConceptOf, ShouldEqual, "http://example.com/topics")
+				So(document.Graph[2].AltLabel, ShouldResemble, map[string][]string{LanguageEnglish: {"cpi", "rpi"}})
+				So(document.Graph[2].Definition, ShouldBeNil)
+			})
+		})
+
+		Convey("When the concept scheme of the latest versions is created, the unpublished topic is a top concept", func() {
+			scheme := NewConceptScheme("http://example.com/topics", topics, "topic_root", TaxonomyVersionBoth)
+			So(scheme.Concepts, ShouldHaveLength, 3)
+			So(scheme.TopConcepts, ShouldResemble, []string{"http://example.com/topics/economy", "http://example.com/topics/unpublished"})
+		})
+	})
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/ONSdigital/dp-topic-api/apierrors"
 )
 
@@ -22,6 +25,25 @@ import (
 // Deleted and Failed I dont think will be needed (we wont want to allow people to delete pages,
 // and failed publishes in this area I would expect not to be reported through a state change.
 
+// The review workflow ...
+//
+// A second pair of eyes can be required before a topic is published. The editor submits it for
+// review, and a reviewer, who must not be its last editor, approves or rejects it with a reason:
+//
+// Created -> InReview (or Completed -> InReview)
+// InReview -> Approved -> Published
+// InReview -> Rejected -> Created (or back to InReview)
+//
+// Editing an approved topic takes it back to created, so that the edit is reviewed in turn.
+
+// Archiving ...
+//
+// A topic that has been merged into, or renamed to, another is archived, optionally naming the
+// topic that succeeds it. Both its current and next versions are archived, and it is restored by
+// moving it back to created, to be published again:
+//
+// Created, Completed or Published -> Archived -> Created
+
 // State - iota enum of possible topic states
 type State int
 
@@ -31,47 +53,81 @@ const (
 	StateCreated State = iota // this is 'in_progress'
 	StatePublished
 	StateCompleted
+	StateInReview
+	StateApproved
+	StateRejected
+	StateArchived
 )
 
 type stateTransition struct {
 	state            State
 	name             string
 	validTransitions []State
+	// review is set for the states of the review workflow, whose transitions are always checked
+	review bool
+	// decision is set for the states a reviewer moves a topic in review to, which record the reviewer
+	decision bool
 }
 
 var stateTransitionTable = []stateTransition{
 	{
 		state:            StateCreated, // this is 'in_progress'
 		name:             "created",
-		validTransitions: []State{StateCompleted}},
+		validTransitions: []State{StateCompleted, StateInReview, StateArchived}},
 	{
 		state:            StatePublished,
 		name:             "published",
-		validTransitions: []State{StateCreated}},
+		validTransitions: []State{StateCreated, StateArchived}},
 	{
 		state:            StateCompleted,
 		name:             "completed",
-		validTransitions: []State{StatePublished}},
+		validTransitions: []State{StatePublished, StateInReview, StateArchived}},
+	{
+		state:            StateInReview,
+		name:             "in_review",
+		validTransitions: []State{StateApproved, StateRejected},
+		review:           true},
+	{
+		state:            StateApproved,
+		name:             "approved",
+		validTransitions: []State{StatePublished, StateCreated},
+		review:           true,
+		decision:         true},
+	{
+		state:            StateRejected,
+		name:             "rejected",
+		validTransitions: []State{StateCreated, StateInReview},
+		review:           true,
+		decision:         true},
+	{
+		state:            StateArchived,
+		name:             "archived",
+		validTransitions: []State{StateCreated}},
+}
+
+// lookup returns the entry of the state in the transition table, or nil if it has none
+func (s State) lookup() *stateTransition {
+	for i := range stateTransitionTable {
+		if stateTransitionTable[i].state == s {
+			return &stateTransitionTable[i]
+		}
+	}
+	return nil
 }
 
 // String returns the string representation of a state
 func (s State) String() string {
-	var name string
-
-	// search for state in table to find name
-	for _, aState := range stateTransitionTable {
-		if s == aState.state {
-			name = aState.name
-		}
+	if transition := s.lookup(); transition != nil {
+		return transition.name
 	}
-	return name
+	return ""
 }
 
 // ParseState returns a state from its string representation
 func ParseState(stateStr string) (State, error) {
-	for i, aState := range stateTransitionTable {
+	for _, aState := range stateTransitionTable {
 		if stateStr == aState.name {
-			return State(i), nil
+			return aState.state, nil
 		}
 	}
 	return -1, apierrors.ErrTopicInvalidState
@@ -79,20 +135,46 @@ func ParseState(stateStr string) (State, error) {
 
 // TransitionAllowed returns true only if the transition from the current state and the provided next is allowed
 func (s State) TransitionAllowed(next State) bool {
-	if (s >= 0) && (int(s) < len(stateTransitionTable)) {
-		// search for state in table
-		// (to allow for states in table not being in the same order as 'StateCreated' iota list)
-		for _, transition := range stateTransitionTable {
-			if s == transition.state {
-				// see if transition allowed
-				for _, allowedState := range transition.validTransitions {
-					if next == allowedState {
-						return true
-					}
-				}
-			}
-		}
+	transition := s.lookup()
+	return transition != nil && slices.Contains(transition.validTransitions, next)
+}
+
+// IsReview returns whether the state is part of the review workflow
+func (s State) IsReview() bool {
+	transition := s.lookup()
+	return transition != nil && transition.review
+}
+
+// IsReviewDecision returns whether the state is a decision of a reviewer, which can only be made through the review
+// of a topic so that the reviewer is recorded
+func (s State) IsReviewDecision() bool {
+	transition := s.lookup()
+	return transition != nil && transition.decision
+}
+
+// CheckTransition returns an error wrapping apierrors.ErrTopicStateTransitionNotAllowed if a topic or content in the
+// state from cannot move to the state to. Transitions into or out of the review workflow, or of the archived state,
+// are checked against the transition table, whereas editors remain free to move between the other states as before.
+// When review is required, publishing also needs approval. A missing or unrecognised state from is treated as created.
+func CheckTransition(from, to string, requireReview bool) error {
+	fromState, err := ParseState(from)
+	if err != nil {
+		fromState = StateCreated
+	}
+	toState, err := ParseState(to)
+	if err != nil {
+		return err
+	}
+
+	if requireReview && toState == StatePublished && fromState != StateApproved {
+		return fmt.Errorf("%w: publishing requires approval, but the state is %s",
+			apierrors.ErrTopicStateTransitionNotAllowed, fromState)
+	}
+
+	checked := fromState.IsReview() || toState.IsReview() || fromState == StateArchived || toState == StateArchived
+	if checked && !fromState.TransitionAllowed(toState) {
+		return fmt.Errorf("%w: from %s to %s", apierrors.ErrTopicStateTransitionNotAllowed, fromState, toState)
 	}
 
-	return false
+	return nil
 }
@@ -1,8 +1,10 @@
 package models_test
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/ONSdigital/dp-topic-api/apierrors"
 	"github.com/ONSdigital/dp-topic-api/models"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -13,6 +15,8 @@ func TestStateValidation(t *testing.T) {
 		So(models.StateCreated.TransitionAllowed(models.StateCreated), ShouldBeFalse)
 		So(models.StateCreated.TransitionAllowed(models.StatePublished), ShouldBeFalse)
 		So(models.StateCreated.TransitionAllowed(models.StateCompleted), ShouldBeTrue)
+		So(models.StateCreated.TransitionAllowed(models.StateInReview), ShouldBeTrue)
+		So(models.StateCreated.TransitionAllowed(models.StateApproved), ShouldBeFalse)
 	})
 
 	Convey("Given a Published State, then only transitions to created allowed", t, func() {
@@ -25,5 +29,97 @@ func TestStateValidation(t *testing.T) {
 		So(models.StateCompleted.TransitionAllowed(models.StateCreated), ShouldBeFalse)
 		So(models.StateCompleted.TransitionAllowed(models.StatePublished), ShouldBeTrue)
 		So(models.StateCompleted.TransitionAllowed(models.StateCompleted), ShouldBeFalse)
+		So(models.StateCompleted.TransitionAllowed(models.StateInReview), ShouldBeTrue)
+	})
+
+	Convey("Given an InReview State, then only transitions to approved or rejected allowed", t, func() {
+		So(models.StateInReview.TransitionAllowed(models.StateApproved), ShouldBeTrue)
+		So(models.StateInReview.TransitionAllowed(models.StateRejected), ShouldBeTrue)
+		So(models.StateInReview.TransitionAllowed(models.StateInReview), ShouldBeFalse)
+		So(models.StateInReview.TransitionAllowed(models.StatePublished), ShouldBeFalse)
+		So(models.StateInReview.TransitionAllowed(models.StateCreated), ShouldBeFalse)
+	})
+
+	Convey("Given an Approved State, then only transitions to published or created allowed", t, func() {
+		So(models.StateApproved.TransitionAllowed(models.StatePublished), ShouldBeTrue)
+		So(models.StateApproved.TransitionAllowed(models.StateCreated), ShouldBeTrue)
+		So(models.StateApproved.TransitionAllowed(models.StateRejected), ShouldBeFalse)
+	})
+
+	Convey("Given a Rejected State, then only transitions to created or in_review allowed", t, func() {
+		So(models.StateRejected.TransitionAllowed(models.StateCreated), ShouldBeTrue)
+		So(models.StateRejected.TransitionAllowed(models.StateInReview), ShouldBeTrue)
+		So(models.StateRejected.TransitionAllowed(models.StatePublished), ShouldBeFalse)
+	})
+}
+
+func TestParseState(t *testing.T) {
+	Convey("Every state can be parsed from its string representation", t, func() {
+		for _, state := range []models.State{models.StateCreated, models.StatePublished, models.StateCompleted,
+			models.StateInReview, models.StateApproved, models.StateRejected} {
+			parsed, err := models.ParseState(state.String())
+			So(err, ShouldBeNil)
+			So(parsed, ShouldEqual, state)
+		}
+	})
+
+	Convey("An unknown state cannot be parsed", t, func() {
+		_, err := models.ParseState("deleted")
+		So(err, ShouldEqual, apierrors.ErrTopicInvalidState)
+	})
+
+	Convey("Only the review workflow states are review states, and only approved and rejected are decisions", t, func() {
+		So(models.StateCreated.IsReview(), ShouldBeFalse)
+		So(models.StatePublished.IsReview(), ShouldBeFalse)
+		So(models.StateInReview.IsReview(), ShouldBeTrue)
+		So(models.StateInReview.IsReviewDecision(), ShouldBeFalse)
+		So(models.StateApproved.IsReviewDecision(), ShouldBeTrue)
+		So(models.StateRejected.IsReviewDecision(), ShouldBeTrue)
+	})
+}
+
+func TestCheckTransition(t *testing.T) {
+	Convey("Given review is not required", t, func() {
+		Convey("Then editors move freely between the states outside the review workflow", func() {
+			So(models.CheckTransition("published", "published", false), ShouldBeNil)
+			So(models.CheckTransition("created", "published", false), ShouldBeNil)
+			So(models.CheckTransition("", "completed", false), ShouldBeNil)
+		})
+
+		Convey("Then transitions into and out of review follow the transition table", func() {
+			So(models.CheckTransition("created", "in_review", false), ShouldBeNil)
+			So(models.CheckTransition("in_review", "approved", false), ShouldBeNil)
+			So(models.CheckTransition("approved", "published", false), ShouldBeNil)
+			So(models.CheckTransition("rejected", "created", false), ShouldBeNil)
+
+			err := models.CheckTransition("in_review", "published", false)
+			So(errors.Is(err, apierrors.ErrTopicStateTransitionNotAllowed), ShouldBeTrue)
+			So(err.Error(), ShouldEqual, "topic state transition not allowed: from in_review to published")
+
+			So(errors.Is(models.CheckTransition("in_review", "in_review", false), apierrors.ErrTopicStateTransitionNotAllowed), ShouldBeTrue)
+			So(errors.Is(models.CheckTransition("created", "approved", false), apierrors.ErrTopicStateTransitionNotAllowed), ShouldBeTrue)
+		})
+
+		Convey("Then transitions into and out of the archived state follow the transition table", func() {
+			So(models.CheckTransition("published", "archived", false), ShouldBeNil)
+			So(models.CheckTransition("created", "archived", false), ShouldBeNil)
+			So(models.CheckTransition("archived", "created", false), ShouldBeNil)
+
+			So(errors.Is(models.CheckTransition("archived", "published", false), apierrors.ErrTopicStateTransitionNotAllowed), ShouldBeTrue)
+			So(errors.Is(models.CheckTransition("in_review", "archived", false), apierrors.ErrTopicStateTransitionNotAllowed), ShouldBeTrue)
+		})
+
+		Convey("Then an unknown target state is invalid", func() {
+			So(models.CheckTransition("created", "deleted", false), ShouldEqual, apierrors.ErrTopicInvalidState)
+		})
+	})
+
+	Convey("Given review is required, publishing needs approval", t, func() {
+		So(models.CheckTransition("approved", "published", true), ShouldBeNil)
+		So(models.CheckTransition("created", "completed", true), ShouldBeNil)
+
+		err := models.CheckTransition("completed", "published", true)
+		So(errors.Is(err, apierrors.ErrTopicStateTransitionNotAllowed), ShouldBeTrue)
+		So(err.Error(), ShouldEqual, "topic state transition not allowed: publishing requires approval, but the state is completed")
 	})
 }
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+)
+
+// TaxonomyContentType is the media type of an exported taxonomy, which has a TaxonomyRecord on each line
+const TaxonomyContentType = "application/x-ndjson"
+
+// MaxTaxonomyLineSize is the maximum size, in bytes, of a line of an imported taxonomy
+const MaxTaxonomyLineSize = 1024 * 1024
+
+// The versions of topics and content that can be exported
+const (
+	TaxonomyVersionCurrent = "current"
+	TaxonomyVersionNext    = "next"
+	TaxonomyVersionBoth    = "both"
+)
+
+// The strategies for importing a taxonomy. Upsert creates and updates the imported topics, leaving any others
+// unchanged, whereas replace also deletes the topics (and their content) that are not imported.
+const (
+	ImportStrategyUpsert  = "upsert"
+	ImportStrategyReplace = "replace"
+)
+
+// TaxonomyRecord is a line of an exported taxonomy, holding a topic and its content. A version of the topic or its content
+// that is not included is left unchanged when the record is imported.
+type TaxonomyRecord struct {
+	ID      string           `json:"id"`
+	Current *Topic           `json:"current,omitempty"`
+	Next    *Topic           `json:"next,omitempty"`
+	Content *ContentResponse `json:"content,omitempty"`
+	// Line is the number of the line the record was read from
+	Line int `json:"-"`
+}
+
+// NewTaxonomyRecord creates the record of a topic and its content, including only the given version of each
+func NewTaxonomyRecord(topic *TopicResponse, content *ContentResponse, version string) TaxonomyRecord {
+	record := TaxonomyRecord{ID: topic.ID}
+	if content != nil {
+		record.Content = &ContentResponse{ID: content.ID}
+	}
+
+	if version != TaxonomyVersionNext {
+		record.Current = topic.Current
+		if content != nil {
+			record.Content.Current = content.Current
+		}
+	}
+	if version != TaxonomyVersionCurrent {
+		record.Next = topic.Next
+		if content != nil {
+			record.Content.Next = content.Next
+		}
+	}
+
+	return record
+}
+
+// ImportReport summarises the import of a taxonomy, listing the ids of the topics in each outcome
+type ImportReport struct {
+	ValidateOnly bool     `json:"validate_only"`
+	Strategy     string   `json:"strategy"`
+	Created      []string `json:"created"`
+	Updated      []string `json:"updated"`
+	Skipped      []string `json:"skipped"`
+	Deleted      []string `json:"deleted"`
+}
+
+// ReadTaxonomy reads the records of a taxonomy from newline delimited JSON, and validates them.
+// Errors are returned as an *apierrors.ValidationError detailing every invalid field, with the fields prefixed by
+// the number of the line they are on.
+func ReadTaxonomy(r io.Reader) ([]TaxonomyRecord, error) {
+	var records []TaxonomyRecord
+	violations := &apierrors.ValidationError{}
+	lines := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxTaxonomyLineSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		prefix := fmt.Sprintf("line %d", line)
+
+		var record TaxonomyRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			violations.Add(apierrors.ErrTopicInvalidFields, prefix, "must be a JSON object")
+			continue
+		}
+
+		if previous, ok := lines[record.ID]; ok {
+			violations.Add(apierrors.ErrTopicInvalidFields, prefix+": id", fmt.Sprintf("must not duplicate the topic on line %d", previous))
+			continue
+		}
+		lines[record.ID] = line
+		record.Line = line
+
+		record.validate(violations, prefix)
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			violations.Add(apierrors.ErrTopicInvalidFields, fmt.Sprintf("line %d", line+1), fmt.Sprintf("must be at most %d bytes", MaxTaxonomyLineSize))
+			return nil, violations
+		}
+		return nil, apierrors.ErrUnableToReadMessage
+	}
+
+	if err := violations.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, apierrors.ErrEmptyRequestBody
+	}
+
+	return records, nil
+}
+
+// validate adds the violations of the record, prefixing its fields
+func (r *TaxonomyRecord) validate(violations *apierrors.ValidationError, prefix string) {
+	if r.ID == "" {
+		violations.Add(apierrors.ErrTopicMissingFields, prefix+": id", "must not be empty")
+	}
+	if r.Current == nil && r.Next == nil {
+		violations.Add(apierrors.ErrTopicMissingFields, prefix+": current", "must not be empty when next is empty")
+	}
+
+	for _, version := range []struct {
+		name  string
+		topic *Topic
+	}{{"current", r.Current}, {"next", r.Next}} {
+		if version.topic == nil {
+			continue
+		}
+		if version.topic.ID == "" {
+			version.topic.ID = r.ID
+		} else if version.topic.ID != r.ID {
+			violations.Add(apierrors.ErrTopicInvalidFields, fmt.Sprintf("%s: %s.id", prefix, version.name), "must match the id of the record")
+		}
+
+		var topicViolations *apierrors.ValidationError
+		if err := version.topic.Validate(); err != nil && errors.As(err, &topicViolations) {
+			for _, field := range topicViolations.Fields {
+				violations.Add(topicViolations.Err, fmt.Sprintf("%s: %s.%s", prefix, version.name, field.Field), field.Message)
+			}
+		}
+	}
+
+	if r.Content != nil && r.Content.ID == "" {
+		r.Content.ID = r.ID
+	} else if r.Content != nil && r.Content.ID != r.ID {
+		violations.Add(apierrors.ErrTopicInvalidFields, prefix+": content.id", "must match the id of the record")
+	}
+}
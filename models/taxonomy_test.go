@@ -0,0 +1,79 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReadTaxonomy(t *testing.T) {
+	Convey("Given newline delimited JSON with a blank line", t, func() {
+		body := `{"id":"1","current":{"title":"Economy","state":"published"},"content":{"current":{"state":"published"}}}
+
+{"id":"2","next":{"id":"2","title":"Prices","state":"completed"}}
+`
+
+		Convey("When it is read, every record is returned with the ids of its versions and content set", func() {
+			records, err := ReadTaxonomy(strings.NewReader(body))
+			So(err, ShouldBeNil)
+			So(records, ShouldHaveLength, 2)
+			So(records[0].Current.ID, ShouldEqual, "1")
+			So(records[0].Content.ID, ShouldEqual, "1")
+			So(records[1].Line, ShouldEqual, 3)
+		})
+	})
+
+	Convey("Given newline delimited JSON with invalid records", t, func() {
+		body := `{"id":"1","current":{"title":"Economy","state":"unknown"}}
+not json
+{"id":"1","current":{"title":"Economy","state":"published"}}
+{"id":"3","next":{"id":"4","title":"Trade","state":"published"}}
+{"id":"5"}
+`
+
+		Convey("When it is read, every violation is returned with the number of its line", func() {
+			_, err := ReadTaxonomy(strings.NewReader(body))
+			So(err, ShouldNotBeNil)
+
+			violations, ok := err.(*apierrors.ValidationError)
+			So(ok, ShouldBeTrue)
+			So(violations.Fields, ShouldResemble, []apierrors.FieldError{
+				{Field: "line 1: current.state", Message: "must be a valid state name"},
+				{Field: "line 2", Message: "must be a JSON object"},
+				{Field: "line 3: id", Message: "must not duplicate the topic on line 1"},
+				{Field: "line 4: next.id", Message: "must match the id of the record"},
+				{Field: "line 5: current", Message: "must not be empty when next is empty"},
+			})
+		})
+	})
+
+	Convey("Given an empty body, reading it returns an error", t, func() {
+		_, err := ReadTaxonomy(strings.NewReader("\n"))
+		So(err, ShouldEqual, apierrors.ErrEmptyRequestBody)
+	})
+}
+
+func TestNewTaxonomyRecord(t *testing.T) {
+	Convey("Given a topic and its content", t, func() {
+		topic := &TopicResponse{ID: "1", Current: &Topic{Title: "current"}, Next: &Topic{Title: "next"}}
+		content := &ContentResponse{ID: "1", Current: &Content{State: "published"}, Next: &Content{State: "completed"}}
+
+		Convey("When a record of the current version is created, only the current versions are included", func() {
+			record := NewTaxonomyRecord(topic, content, TaxonomyVersionCurrent)
+			So(record.Current.Title, ShouldEqual, "current")
+			So(record.Next, ShouldBeNil)
+			So(record.Content.Current.State, ShouldEqual, "published")
+			So(record.Content.Next, ShouldBeNil)
+		})
+
+		Convey("When a record of both versions is created, both versions are included", func() {
+			record := NewTaxonomyRecord(topic, content, TaxonomyVersionBoth)
+			So(record.Current, ShouldNotBeNil)
+			So(record.Next, ShouldNotBeNil)
+			So(record.Content.Next.State, ShouldEqual, "completed")
+		})
+	})
+}
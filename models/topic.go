@@ -1,9 +1,17 @@
 package models
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ONSdigital/dp-topic-api/apierrors"
 )
@@ -27,6 +35,8 @@ type TopicResponse struct {
 	ID      string `bson:"id,omitempty"       json:"id,omitempty"`
 	Current *Topic `bson:"current,omitempty"  json:"current,omitempty"`
 	Next    *Topic `bson:"next,omitempty"     json:"next,omitempty"`
+	// Owners are the groups that may edit the topic and its subtopics, which is not versioned
+	Owners []string `bson:"owners,omitempty"   json:"owners,omitempty"`
 }
 
 // Topic represents topic schema as it is stored in mongoDB
@@ -46,6 +56,17 @@ type Topic struct {
 	SubtopicIds *[]string `bson:"subtopics_ids,omitempty"  json:"subtopics_ids,omitempty"`
 	Title       string    `bson:"title,omitempty"          json:"title,omitempty"`
 	Slug        string    `bson:"slug,omitempty"           json:"slug,omitempty"`
+	// WelshTitle is the title of the topic in Welsh, where it has been translated
+	WelshTitle string `bson:"welsh_title,omitempty"  json:"welsh_title,omitempty"`
+	// LastEditedBy and Review are editorial records of the next version, which are cleared when it is published
+	LastEditedBy string  `bson:"last_edited_by,omitempty"  json:"last_edited_by,omitempty"`
+	Review       *Review `bson:"review,omitempty"          json:"review,omitempty"`
+	// SuccessorID is the topic that succeeds an archived topic, which its old URLs are redirected to
+	SuccessorID string `bson:"successor_id,omitempty"  json:"successor_id,omitempty"`
+	// RelatedTopicIDs are topics in other branches of the tree related to this one, and RelatedTopicTypes the types of
+	// the relations to those that are not simply related, by their ids
+	RelatedTopicIDs   *[]string         `bson:"related_topic_ids,omitempty"    json:"related_topic_ids,omitempty"`
+	RelatedTopicTypes map[string]string `bson:"related_topic_types,omitempty"  json:"related_topic_types,omitempty"`
 }
 
 // TopicUpdate represents the incoming request structure containing a topic update
@@ -58,6 +79,13 @@ type TopicUpdate struct {
 	SubtopicIds *[]string `bson:"subtopics_ids,omitempty"  json:"subtopics_ids,omitempty"`
 	Title       string    `bson:"title"                    json:"title"`
 	Slug        string    `bson:"slug"                     json:"slug"`
+	// WelshTitle replaces the Welsh title of the next version, which is removed when it is empty
+	WelshTitle string `bson:"welsh_title,omitempty"  json:"welsh_title,omitempty"`
+	// RelatedTopicIDs and RelatedTopicTypes replace the related topics of the next version, as the subtopics are
+	RelatedTopicIDs   *[]string         `bson:"related_topic_ids,omitempty"    json:"related_topic_ids,omitempty"`
+	RelatedTopicTypes map[string]string `bson:"related_topic_types,omitempty"  json:"related_topic_types,omitempty"`
+	// LastEditedBy is the user or service making the update, recorded so that it cannot also review the topic
+	LastEditedBy string `bson:"-"  json:"-"`
 }
 
 // TopicRelease represents the incoming request structure containing release content
@@ -76,6 +104,7 @@ type TopicLinks struct {
 	Content   *LinkObject `bson:"content,omitempty"    json:"content,omitempty"`
 	Self      *LinkObject `bson:"self,omitempty"       json:"self,omitempty"`
 	Subtopics *LinkObject `bson:"subtopics,omitempty"  json:"subtopics,omitempty"`
+	Related   *LinkObject `bson:"related,omitempty"    json:"related,omitempty"`
 }
 
 // ReadReleaseDate manages the creation of a release date object from a reader
@@ -108,34 +137,243 @@ func ReadTopicUpdate(r io.Reader) (*TopicUpdate, error) {
 	return &topicUpdate, nil
 }
 
-// Validate checks that a topic struct complies with the state constraints, if provided. TODO may want to add more in future
+// Limits on the fields of a topic
+const (
+	MaxTitleLength         = 150
+	MaxDescriptionLength   = 2000
+	MaxKeywords            = 20
+	MaxKeywordLength       = 100
+	MaxReleaseDateAgeYears = 10
+)
+
+// slugPattern matches lower case words of letters and digits, separated by single hyphens
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+var nonSlugCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// GenerateSlug generates a slug from a title, by lower casing it and removing everything other than letters and digits
+func GenerateSlug(title string) string {
+	return nonSlugCharacters.ReplaceAllString(strings.ToLower(title), "")
+}
+
+// Validate checks that a topic struct complies with the state constraints, and that its optional fields are well formed.
+// Errors are returned as an *apierrors.ValidationError detailing every invalid field.
 func (t *Topic) Validate() error {
+	violations := &apierrors.ValidationError{}
+
 	if _, err := ParseState(t.State); err != nil {
-		return apierrors.ErrTopicInvalidState
+		violations.Add(apierrors.ErrTopicInvalidState, "state", "must be a valid state name")
 	}
 
-	// TODO add other checks, etc
-	return nil
+	validateFields(violations, t.ID, t.Title, t.Description, t.Slug, t.Keywords, t.SubtopicIds)
+	validateWelshTitle(violations, t.WelshTitle)
+	validateRelated(violations, t.ID, t.RelatedTopicIDs, t.RelatedTopicTypes)
+
+	return violations.ErrorOrNil()
 }
 
-// Validate checks that a topic update struct complies with the state / release date constraints
-func (t *TopicUpdate) ValidateUpdate() error {
-	if t.State == "" || t.Description == "" || t.Title == "" || t.ReleaseDate == "" {
-		return apierrors.ErrTopicMissingFields
+// ValidateUpdate checks that an update to the topic with the given id has all its mandatory fields, and that every field is well formed.
+// Errors are returned as an *apierrors.ValidationError detailing every invalid field.
+func (t *TopicUpdate) ValidateUpdate(id string) error {
+	return t.Violations(id).ErrorOrNil()
+}
+
+// Violations returns the fields of an update to the topic with the given id that are missing or malformed.
+// The checks that need the store (slug uniqueness, and subtopic and related topic existence) are left to the caller,
+// which can add to the returned apierrors.ValidationError so that every violation is reported together.
+func (t *TopicUpdate) Violations(id string) *apierrors.ValidationError {
+	violations := &apierrors.ValidationError{}
+
+	for _, field := range []struct{ name, value string }{
+		{"description", t.Description},
+		{"release_date", t.ReleaseDate},
+		{"state", t.State},
+		{"title", t.Title},
+	} {
+		if field.value == "" {
+			violations.Add(apierrors.ErrTopicMissingFields, field.name, "must not be empty")
+		}
 	}
 
-	if _, err := ParseState(t.State); err != nil {
-		return apierrors.ErrTopicInvalidState
+	if t.State != "" {
+		if _, err := ParseState(t.State); err != nil {
+			violations.Add(apierrors.ErrTopicInvalidState, "state", "must be a valid state name")
+		}
+	}
+
+	if t.ReleaseDate != "" {
+		releaseDate, err := time.Parse(time.RFC3339, t.ReleaseDate)
+		switch {
+		case err != nil:
+			violations.Add(apierrors.ErrInvalidReleaseDate, "release_date", "must be in RFC3339 format")
+		case releaseDate.Before(time.Now().AddDate(-MaxReleaseDateAgeYears, 0, 0)):
+			violations.Add(apierrors.ErrInvalidReleaseDate, "release_date", fmt.Sprintf("must not be more than %d years in the past", MaxReleaseDateAgeYears))
+		}
+	}
+
+	validateFields(violations, id, t.Title, t.Description, t.Slug, t.Keywords, t.SubtopicIds)
+	validateWelshTitle(violations, t.WelshTitle)
+	validateRelated(violations, id, t.RelatedTopicIDs, t.RelatedTopicTypes)
+
+	return violations
+}
+
+// KeepsReview returns whether the update leaves the topic in the state of the review workflow, so that the review of
+// its next version, with its reviewer or the reason it was rejected, is kept
+func (t *TopicUpdate) KeepsReview() bool {
+	state, err := ParseState(t.State)
+	return err == nil && state.IsReview()
+}
+
+// Changes returns whether applying the update to the version of a topic given would change it, other than its state.
+// An empty slug leaves the slug unchanged, and an empty list of keywords, subtopics or related topics removes them.
+func (t *TopicUpdate) Changes(topic *Topic) bool {
+	if topic == nil {
+		return true
+	}
+
+	var releaseDate *time.Time
+	if parsed, err := time.Parse(time.RFC3339, t.ReleaseDate); err == nil {
+		releaseDate = &parsed
+	}
+	sameReleaseDate := (releaseDate == nil && topic.ReleaseDate == nil) ||
+		(releaseDate != nil && topic.ReleaseDate != nil && releaseDate.Equal(*topic.ReleaseDate))
+
+	return t.Title != topic.Title ||
+		t.WelshTitle != topic.WelshTitle ||
+		t.Description != topic.Description ||
+		!sameReleaseDate ||
+		(t.Slug != "" && t.Slug != topic.Slug) ||
+		!slices.Equal(listOf(t.Keywords), listOf(topic.Keywords)) ||
+		!slices.Equal(listOf(t.SubtopicIds), listOf(topic.SubtopicIds)) ||
+		!slices.Equal(listOf(t.RelatedTopicIDs), listOf(topic.RelatedTopicIDs)) ||
+		!maps.Equal(t.RelatedTopicTypes, topic.RelatedTopicTypes)
+}
+
+// listOf returns the items of an optional list, which are none when it is missing
+func listOf(list *[]string) []string {
+	if list == nil {
+		return nil
+	}
+	return *list
+}
+
+// TopicLookup represents the methods required to check the references of a topic update against the stored topics
+type TopicLookup interface {
+	CheckTopicExists(ctx context.Context, id string) error
+	IsSlugInUse(ctx context.Context, id, slug string) (bool, error)
+}
+
+// CheckReferences adds the violations of an update to the topic with the given id that need the store to find: a slug
+// already used by another topic, and subtopics and related topics that do not exist. Fields already reported as invalid
+// are not looked up.
+func (t *TopicUpdate) CheckReferences(ctx context.Context, topics TopicLookup, id string, violations *apierrors.ValidationError) error {
+	invalid := make(map[string]bool)
+	for _, field := range violations.Fields {
+		invalid[field.Field] = true
 	}
 
-	if _, err := time.Parse(time.RFC3339, t.ReleaseDate); err != nil {
-		return apierrors.ErrInvalidReleaseDate
+	if t.Slug != "" && !invalid["slug"] {
+		inUse, err := topics.IsSlugInUse(ctx, id, t.Slug)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			violations.Add(apierrors.ErrTopicInvalidFields, "slug", "must not be used by another topic")
+		}
+	}
+
+	if err := checkTopicsExist(ctx, topics, "subtopics_ids", t.SubtopicIds, invalid, violations); err != nil {
+		return err
+	}
+
+	return checkTopicsExist(ctx, topics, "related_topic_ids", t.RelatedTopicIDs, invalid, violations)
+}
+
+// checkTopicsExist adds a violation for each of the ids of the list field that is not the id of a topic, unless it has
+// already been reported as invalid
+func checkTopicsExist(ctx context.Context, topics TopicLookup, field string, ids *[]string, invalid map[string]bool, violations *apierrors.ValidationError) error {
+	if ids == nil {
+		return nil
+	}
+
+	for i, id := range *ids {
+		itemField := fmt.Sprintf("%s[%d]", field, i)
+		if invalid[itemField] {
+			continue
+		}
+
+		err := topics.CheckTopicExists(ctx, id)
+		switch {
+		case errors.Is(err, apierrors.ErrTopicNotFound):
+			violations.Add(apierrors.ErrTopicInvalidFields, itemField, "must be the id of an existing topic")
+		case err != nil:
+			return err
+		}
 	}
 
-	// TODO add other checks, etc
 	return nil
 }
 
+// validateWelshTitle adds the violation of a Welsh title that is longer than a title may be
+func validateWelshTitle(violations *apierrors.ValidationError, welshTitle string) {
+	if utf8.RuneCountInString(welshTitle) > MaxTitleLength {
+		violations.Add(apierrors.ErrTopicInvalidFields, "welsh_title", fmt.Sprintf("must be at most %d characters", MaxTitleLength))
+	}
+}
+
+// validateFields adds the violations of the limits on the fields shared by topics and topic updates
+func validateFields(violations *apierrors.ValidationError, id, title, description, slug string, keywords, subtopicIDs *[]string) {
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		violations.Add(apierrors.ErrTopicInvalidFields, "title", fmt.Sprintf("must be at most %d characters", MaxTitleLength))
+	}
+
+	if utf8.RuneCountInString(description) > MaxDescriptionLength {
+		violations.Add(apierrors.ErrTopicInvalidFields, "description", fmt.Sprintf("must be at most %d characters", MaxDescriptionLength))
+	}
+
+	if slug != "" && !slugPattern.MatchString(slug) {
+		violations.Add(apierrors.ErrTopicInvalidFields, "slug", "must only contain lower case letters, digits and single hyphens between words")
+	}
+
+	if keywords != nil {
+		if len(*keywords) > MaxKeywords {
+			violations.Add(apierrors.ErrTopicInvalidFields, "keywords", fmt.Sprintf("must have at most %d keywords", MaxKeywords))
+		}
+
+		seen := make(map[string]bool)
+		for i, keyword := range *keywords {
+			field := fmt.Sprintf("keywords[%d]", i)
+			normalised := strings.ToLower(strings.TrimSpace(keyword))
+			switch {
+			case normalised == "":
+				violations.Add(apierrors.ErrTopicInvalidFields, field, "must not be empty")
+			case utf8.RuneCountInString(keyword) > MaxKeywordLength:
+				violations.Add(apierrors.ErrTopicInvalidFields, field, fmt.Sprintf("must be at most %d characters", MaxKeywordLength))
+			case seen[normalised]:
+				violations.Add(apierrors.ErrTopicInvalidFields, field, "must not duplicate another keyword")
+			}
+			seen[normalised] = true
+		}
+	}
+
+	if subtopicIDs != nil {
+		seen := make(map[string]bool)
+		for i, subtopicID := range *subtopicIDs {
+			field := fmt.Sprintf("subtopics_ids[%d]", i)
+			switch {
+			case subtopicID == "":
+				violations.Add(apierrors.ErrTopicInvalidFields, field, "must not be empty")
+			case id != "" && subtopicID == id:
+				violations.Add(apierrors.ErrTopicInvalidFields, field, "must not reference the topic itself")
+			case seen[subtopicID]:
+				violations.Add(apierrors.ErrTopicInvalidFields, field, "must not duplicate another subtopic")
+			}
+			seen[subtopicID] = true
+		}
+	}
+}
+
 // ValidateTransitionFrom checks that this topic state can be validly transitioned from the existing state
 func (t *Topic) ValidateTransitionFrom(existing *Topic) error {
 	// check that state transition is allowed, only if state is provided
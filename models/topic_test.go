@@ -1,6 +1,9 @@
 package models_test
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,7 +26,7 @@ func TestTopicValidation(t *testing.T) {
 	Convey("Given an topic with no state supplied, it fails to validate  with the expected error", t, func() {
 		topic := models.Topic{}
 		err := topic.Validate()
-		So(err, ShouldResemble, apierrors.ErrTopicInvalidState)
+		So(errors.Is(err, apierrors.ErrTopicInvalidState), ShouldBeTrue)
 	})
 
 	Convey("Given an topic with a state that does not correspond to any expected state, it fails to validate with the expected error", t, func() {
@@ -31,7 +34,7 @@ func TestTopicValidation(t *testing.T) {
 			State: "wrong",
 		}
 		err := topic.Validate()
-		So(err, ShouldResemble, apierrors.ErrTopicInvalidState)
+		So(errors.Is(err, apierrors.ErrTopicInvalidState), ShouldBeTrue)
 	})
 
 	Convey("Given a fully populated valid topic with a valid download variant, it is successfully validated", t, func() {
@@ -162,6 +165,147 @@ func TestTopicReleaseDateValidation(t *testing.T) {
 	})
 }
 
+func TestTopicUpdateValidation(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given a topic update with all mandatory fields, it is successfully validated", t, func() {
+		topicUpdate := models.TopicUpdate{
+			Description: "description",
+			ReleaseDate: "2022-10-14T11:30:00Z",
+			State:       models.StateCreated.String(),
+			Title:       "title",
+		}
+		So(topicUpdate.ValidateUpdate("1"), ShouldBeNil)
+	})
+
+	Convey("Given a topic update missing mandatory fields, validation fails detailing each missing field", t, func() {
+		topicUpdate := models.TopicUpdate{
+			Description: "description",
+		}
+		err := topicUpdate.ValidateUpdate("1")
+		So(errors.Is(err, apierrors.ErrTopicMissingFields), ShouldBeTrue)
+
+		var validationErr *apierrors.ValidationError
+		So(errors.As(err, &validationErr), ShouldBeTrue)
+		So(validationErr.Fields, ShouldResemble, []apierrors.FieldError{
+			{Field: "release_date", Message: "must not be empty"},
+			{Field: "state", Message: "must not be empty"},
+			{Field: "title", Message: "must not be empty"},
+		})
+	})
+
+	Convey("Given a topic update with an invalid state, validation fails detailing the state field", t, func() {
+		topicUpdate := models.TopicUpdate{
+			Description: "description",
+			ReleaseDate: "2022-10-14T11:30:00Z",
+			State:       "wrong",
+			Title:       "title",
+		}
+		err := topicUpdate.ValidateUpdate("1")
+		So(errors.Is(err, apierrors.ErrTopicInvalidState), ShouldBeTrue)
+
+		var validationErr *apierrors.ValidationError
+		So(errors.As(err, &validationErr), ShouldBeTrue)
+		So(validationErr.Fields[0].Field, ShouldEqual, "state")
+	})
+}
+
+func TestTopicFieldValidation(t *testing.T) {
+	t.Parallel()
+
+	validUpdate := func() models.TopicUpdate {
+		return models.TopicUpdate{
+			Description: "description",
+			ReleaseDate: time.Now().UTC().Format(time.RFC3339),
+			Slug:        "economic-output",
+			State:       models.StateCreated.String(),
+			Title:       "title",
+			Keywords:    &[]string{"gdp", "output"},
+			SubtopicIds: &[]string{"2", "3"},
+		}
+	}
+
+	Convey("Given a topic update with well formed optional fields, it is successfully validated", t, func() {
+		topicUpdate := validUpdate()
+		So(topicUpdate.ValidateUpdate("1"), ShouldBeNil)
+	})
+
+	Convey("Given a topic update breaking every field limit, validation fails detailing every violation", t, func() {
+		topicUpdate := validUpdate()
+		topicUpdate.Title = strings.Repeat("t", models.MaxTitleLength+1)
+		topicUpdate.Description = strings.Repeat("d", models.MaxDescriptionLength+1)
+		topicUpdate.Slug = "Economic--Output"
+		topicUpdate.Keywords = &[]string{"gdp", " ", strings.Repeat("k", models.MaxKeywordLength+1), "GDP"}
+		topicUpdate.SubtopicIds = &[]string{"2", "1", "2"}
+		topicUpdate.WelshTitle = strings.Repeat("t", models.MaxTitleLength+1)
+
+		err := topicUpdate.ValidateUpdate("1")
+		So(errors.Is(err, apierrors.ErrTopicInvalidFields), ShouldBeTrue)
+
+		var validationErr *apierrors.ValidationError
+		So(errors.As(err, &validationErr), ShouldBeTrue)
+		So(validationErr.Fields, ShouldResemble, []apierrors.FieldError{
+			{Field: "title", Message: "must be at most 150 characters"},
+			{Field: "description", Message: "must be at most 2000 characters"},
+			{Field: "slug", Message: "must only contain lower case letters, digits and single hyphens between words"},
+			{Field: "keywords[1]", Message: "must not be empty"},
+			{Field: "keywords[2]", Message: "must be at most 100 characters"},
+			{Field: "keywords[3]", Message: "must not duplicate another keyword"},
+			{Field: "subtopics_ids[1]", Message: "must not reference the topic itself"},
+			{Field: "subtopics_ids[2]", Message: "must not duplicate another subtopic"},
+			{Field: "welsh_title", Message: "must be at most 150 characters"},
+		})
+	})
+
+	Convey("Given a topic update with too many keywords, validation fails detailing the keywords field", t, func() {
+		keywords := make([]string, models.MaxKeywords+1)
+		for i := range keywords {
+			keywords[i] = fmt.Sprintf("keyword %d", i)
+		}
+		topicUpdate := validUpdate()
+		topicUpdate.Keywords = &keywords
+
+		var validationErr *apierrors.ValidationError
+		So(errors.As(topicUpdate.ValidateUpdate("1"), &validationErr), ShouldBeTrue)
+		So(validationErr.Fields, ShouldResemble, []apierrors.FieldError{{Field: "keywords", Message: "must have at most 20 keywords"}})
+	})
+
+	Convey("Given a topic update with a release date in the distant past, validation fails detailing the release date field", t, func() {
+		topicUpdate := validUpdate()
+		topicUpdate.ReleaseDate = time.Now().AddDate(-models.MaxReleaseDateAgeYears-1, 0, 0).Format(time.RFC3339)
+
+		err := topicUpdate.ValidateUpdate("1")
+		So(errors.Is(err, apierrors.ErrInvalidReleaseDate), ShouldBeTrue)
+
+		var validationErr *apierrors.ValidationError
+		So(errors.As(err, &validationErr), ShouldBeTrue)
+		So(validationErr.Fields, ShouldResemble, []apierrors.FieldError{{Field: "release_date", Message: "must not be more than 10 years in the past"}})
+	})
+
+	Convey("Given a topic update with missing and malformed fields, the missing fields error describes the overall problem", t, func() {
+		topicUpdate := validUpdate()
+		topicUpdate.Title = ""
+		topicUpdate.Slug = "not a slug"
+
+		err := topicUpdate.ValidateUpdate("1")
+		So(errors.Is(err, apierrors.ErrTopicMissingFields), ShouldBeTrue)
+
+		var validationErr *apierrors.ValidationError
+		So(errors.As(err, &validationErr), ShouldBeTrue)
+		So(validationErr.Fields, ShouldHaveLength, 2)
+	})
+
+	Convey("Given a topic whose subtopics include itself, it fails to validate", t, func() {
+		topic := models.Topic{
+			ID:          "1",
+			State:       models.StatePublished.String(),
+			SubtopicIds: &[]string{"1"},
+		}
+		err := topic.Validate()
+		So(errors.Is(err, apierrors.ErrTopicInvalidFields), ShouldBeTrue)
+	})
+}
+
 // validateTransitionsToCreated validates that the provided topic can transition to created state,
 // and not to any forbidden of invalid state
 func validateTransitionsToCreated(topic models.Topic) {
@@ -175,3 +319,43 @@ func validateTransitionsToCreated(topic models.Topic) {
 		So(topic.StateTransitionAllowed("wrong"), ShouldBeFalse)
 	})
 }
+
+func TestTopicUpdateChanges(t *testing.T) {
+	releaseDate := time.Date(2022, 10, 10, 8, 30, 0, 0, time.UTC)
+	topic := &models.Topic{
+		Title:       "Economy",
+		Description: "The economy",
+		Slug:        "economy",
+		Keywords:    &[]string{"gdp"},
+		State:       models.StateApproved.String(),
+		ReleaseDate: &releaseDate,
+	}
+	update := func() *models.TopicUpdate {
+		return &models.TopicUpdate{
+			Title:       "Economy",
+			Description: "The economy",
+			Keywords:    &[]string{"gdp"},
+			State:       models.StatePublished.String(),
+			ReleaseDate: "2022-10-10T08:30:00Z",
+		}
+	}
+
+	Convey("Given an update with the values of a topic, it does not change the topic, whatever its state", t, func() {
+		So(update().Changes(topic), ShouldBeFalse)
+	})
+
+	Convey("Given an update with a different value, it changes the topic", t, func() {
+		for _, change := range []func(*models.TopicUpdate){
+			func(u *models.TopicUpdate) { u.Description = "The UK economy" },
+			func(u *models.TopicUpdate) { u.WelshTitle = "Yr economi" },
+			func(u *models.TopicUpdate) { u.Slug = "uk-economy" },
+			func(u *models.TopicUpdate) { u.Keywords = nil },
+			func(u *models.TopicUpdate) { u.ReleaseDate = "2022-10-11T08:30:00Z" },
+			func(u *models.TopicUpdate) { u.SubtopicIds = &[]string{"2"} },
+		} {
+			topicUpdate := update()
+			change(topicUpdate)
+			So(topicUpdate.Changes(topic), ShouldBeTrue)
+		}
+	})
+}
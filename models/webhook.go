@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+)
+
+// Webhook event types that a subscription can filter on
+const (
+	WebhookEventTopicCreated      = "topic.created"
+	WebhookEventTopicUpdated      = "topic.updated"
+	WebhookEventTopicStateChanged = "topic.state_changed"
+	WebhookEventTopicPublished    = "topic.published"
+	WebhookEventTopicDeleted      = "topic.deleted"
+)
+
+// Possible values for the status of a webhook delivery
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+var webhookEventTypes = map[string]bool{
+	WebhookEventTopicCreated:      true,
+	WebhookEventTopicUpdated:      true,
+	WebhookEventTopicStateChanged: true,
+	WebhookEventTopicPublished:    true,
+	WebhookEventTopicDeleted:      true,
+}
+
+// Webhook represents a subscription registering a URL to be called when topic lifecycle events occur.
+// The Secret is used to sign each delivery and is only returned in the response that created the subscription.
+type Webhook struct {
+	ID        string     `bson:"id"                json:"id"`
+	URL       string     `bson:"url"               json:"url"`
+	Events    []string   `bson:"events"            json:"events"`
+	Secret    string     `bson:"secret"            json:"secret,omitempty"`
+	CreatedAt *time.Time `bson:"created_at"        json:"created_at,omitempty"`
+}
+
+// Webhooks is used for returning a list of webhook subscriptions in REST API response
+type Webhooks struct {
+	TotalCount int       `json:"total_count"`
+	Items      []Webhook `json:"items"`
+}
+
+// WebhookEvent is the payload delivered to webhook subscribers
+type WebhookEvent struct {
+	ID         string    `bson:"id"                json:"id"`
+	Type       string    `bson:"type"              json:"type"`
+	TopicID    string    `bson:"topic_id"          json:"topic_id"`
+	State      string    `bson:"state,omitempty"   json:"state,omitempty"`
+	OccurredAt time.Time `bson:"occurred_at"       json:"occurred_at"`
+}
+
+// WebhookDelivery records the outcome of delivering an event to a webhook subscription
+type WebhookDelivery struct {
+	ID             string       `bson:"id"                         json:"id"`
+	WebhookID      string       `bson:"webhook_id"                 json:"webhook_id"`
+	Event          WebhookEvent `bson:"event"                      json:"event"`
+	Status         string       `bson:"status"                     json:"status"`
+	Attempts       int          `bson:"attempts"                   json:"attempts"`
+	ResponseStatus int          `bson:"response_status,omitempty"  json:"response_status,omitempty"`
+	LastError      string       `bson:"last_error,omitempty"       json:"last_error,omitempty"`
+	CreatedAt      *time.Time   `bson:"created_at"                 json:"created_at,omitempty"`
+	LastUpdated    *time.Time   `bson:"last_updated"               json:"last_updated,omitempty"`
+}
+
+// WebhookDeliveries is used for returning the delivery log of a webhook in REST API response
+type WebhookDeliveries struct {
+	TotalCount int               `json:"total_count"`
+	Items      []WebhookDelivery `json:"items"`
+}
+
+// ReadWebhook manages the creation of a webhook object from a reader
+func ReadWebhook(r io.Reader) (*Webhook, error) {
+	var webhook Webhook
+
+	err := json.NewDecoder(r).Decode(&webhook)
+	switch {
+	case err == io.EOF:
+		return nil, apierrors.ErrEmptyRequestBody
+	case err != nil:
+		return nil, apierrors.ErrUnableToReadMessage
+	}
+
+	return &webhook, nil
+}
+
+// Validate checks that a webhook subscription has an absolute http(s) URL and only recognised event types
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || !u.IsAbs() || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apierrors.ErrWebhookInvalidURL
+	}
+
+	if len(w.Events) == 0 {
+		return apierrors.ErrWebhookInvalidEvent
+	}
+
+	for _, event := range w.Events {
+		if !webhookEventTypes[event] {
+			return apierrors.ErrWebhookInvalidEvent
+		}
+	}
+
+	return nil
+}
+
+// Subscribes returns true if the webhook has subscribed to the provided event type
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, event := range w.Events {
+		if event == eventType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,70 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReadWebhook(t *testing.T) {
+	Convey("Given a valid webhook json body, it is successfully read", t, func() {
+		webhook, err := models.ReadWebhook(strings.NewReader(`{"url":"https://example.com/hook","events":["topic.published"]}`))
+		So(err, ShouldBeNil)
+		So(webhook.URL, ShouldEqual, "https://example.com/hook")
+		So(webhook.Events, ShouldResemble, []string{models.WebhookEventTopicPublished})
+	})
+
+	Convey("Given an empty body, the expected error is returned", t, func() {
+		_, err := models.ReadWebhook(strings.NewReader(""))
+		So(err, ShouldEqual, apierrors.ErrEmptyRequestBody)
+	})
+
+	Convey("Given an invalid json body, the expected error is returned", t, func() {
+		_, err := models.ReadWebhook(strings.NewReader("{"))
+		So(err, ShouldEqual, apierrors.ErrUnableToReadMessage)
+	})
+}
+
+func TestWebhookValidate(t *testing.T) {
+	Convey("Given a webhook with a valid url and events, it is successfully validated", t, func() {
+		webhook := models.Webhook{
+			URL:    "http://localhost:8080/topics",
+			Events: []string{models.WebhookEventTopicCreated, models.WebhookEventTopicStateChanged},
+		}
+		So(webhook.Validate(), ShouldBeNil)
+	})
+
+	Convey("Given a webhook with a relative url, it fails to validate with the expected error", t, func() {
+		webhook := models.Webhook{URL: "/topics", Events: []string{models.WebhookEventTopicUpdated}}
+		So(webhook.Validate(), ShouldEqual, apierrors.ErrWebhookInvalidURL)
+	})
+
+	Convey("Given a webhook with a non http url, it fails to validate with the expected error", t, func() {
+		webhook := models.Webhook{URL: "ftp://example.com", Events: []string{models.WebhookEventTopicUpdated}}
+		So(webhook.Validate(), ShouldEqual, apierrors.ErrWebhookInvalidURL)
+	})
+
+	Convey("Given a webhook with no events, it fails to validate with the expected error", t, func() {
+		webhook := models.Webhook{URL: "https://example.com"}
+		So(webhook.Validate(), ShouldEqual, apierrors.ErrWebhookInvalidEvent)
+	})
+
+	Convey("Given a webhook with an unrecognised event, it fails to validate with the expected error", t, func() {
+		webhook := models.Webhook{URL: "https://example.com", Events: []string{models.WebhookEventTopicPublished, "topic.coffee"}}
+		So(webhook.Validate(), ShouldEqual, apierrors.ErrWebhookInvalidEvent)
+	})
+}
+
+func TestWebhookSubscribes(t *testing.T) {
+	Convey("Given a webhook subscribed to published events", t, func() {
+		webhook := models.Webhook{Events: []string{models.WebhookEventTopicPublished}}
+
+		Convey("Then it subscribes to published events only", func() {
+			So(webhook.Subscribes(models.WebhookEventTopicPublished), ShouldBeTrue)
+			So(webhook.Subscribes(models.WebhookEventTopicUpdated), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,161 @@
+package mongo
+
+import (
+	"context"
+
+	errs "github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// InsertTopic inserts a new topic document
+func (m *Mongo) InsertTopic(ctx context.Context, topic *models.TopicResponse) error {
+	ctx, end := startOperation(ctx, "InsertTopic")
+	defer end()
+
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.TopicsCollection)).InsertOne(ctx, topic); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// InsertContent inserts a new content document
+func (m *Mongo) InsertContent(ctx context.Context, content *models.ContentResponse) error {
+	ctx, end := startOperation(ctx, "InsertContent")
+	defer end()
+
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.ContentCollection)).InsertOne(ctx, content); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpsertContent creates or overwrites the content document with the given id
+func (m *Mongo) UpsertContent(ctx context.Context, id string, content *models.ContentResponse) error {
+	ctx, end := startOperation(ctx, "UpsertContent")
+	defer end()
+
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.ContentCollection)).Upsert(ctx, bson.M{"id": id}, bson.M{"$set": content}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ReplaceContent replaces the current and next content of a topic with those given, removing any it does not have,
+// e.g. to restore content as it was before it was changed
+func (m *Mongo) ReplaceContent(ctx context.Context, content *models.ContentResponse) error {
+	ctx, end := startOperation(ctx, "ReplaceContent")
+	defer end()
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.ContentCollection)).Update(ctx, bson.M{"id": content.ID},
+		replaceVersions(content.Current, content.Next))
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+// replaceVersions returns an update that sets the current and next versions of a document that are given, and
+// unsets those that are not
+func replaceVersions[T any](current, next *T) bson.M {
+	set, unset := bson.M{}, bson.M{}
+	for field, version := range map[string]*T{"current": current, "next": next} {
+		if version == nil {
+			unset[field] = ""
+		} else {
+			set[field] = version
+		}
+	}
+
+	update := bson.M{}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	return update
+}
+
+// DeleteTopic removes a topic document and its content document
+func (m *Mongo) DeleteTopic(ctx context.Context, id string) error {
+	ctx, end := startOperation(ctx, "DeleteTopic")
+	defer end()
+
+	for _, collection := range []string{config.TopicsCollection, config.ContentCollection} {
+		if _, err := m.Connection.Collection(m.ActualCollectionName(collection)).DeleteOne(ctx, bson.M{"id": id}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteContent removes the content document of a topic, leaving the topic itself
+func (m *Mongo) DeleteContent(ctx context.Context, id string) error {
+	ctx, end := startOperation(ctx, "DeleteContent")
+	defer end()
+
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.ContentCollection)).DeleteOne(ctx, bson.M{"id": id}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAllTopics retrieves every topic document, ordered by id
+func (m *Mongo) GetAllTopics(ctx context.Context) ([]models.TopicResponse, error) {
+	ctx, end := startOperation(ctx, "GetAllTopics")
+	defer end()
+
+	var topics []models.TopicResponse
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.TopicsCollection)).Find(ctx, bson.M{}, &topics, mongodriver.Sort(bson.M{"id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	return topics, nil
+}
+
+// GetAllContent retrieves every content document, ordered by id
+func (m *Mongo) GetAllContent(ctx context.Context) ([]models.ContentResponse, error) {
+	ctx, end := startOperation(ctx, "GetAllContent")
+	defer end()
+
+	var content []models.ContentResponse
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.ContentCollection)).Find(ctx, bson.M{}, &content, mongodriver.Sort(bson.M{"id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// DeleteAllTopicsAndContent removes every topic and content document
+func (m *Mongo) DeleteAllTopicsAndContent(ctx context.Context) error {
+	ctx, end := startOperation(ctx, "DeleteAllTopicsAndContent")
+	defer end()
+
+	for _, collection := range []string{config.TopicsCollection, config.ContentCollection} {
+		if _, err := m.Connection.Collection(m.ActualCollectionName(collection)).DeleteMany(ctx, bson.M{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CreateCollection inserts a new collection
+func (m *Mongo) CreateCollection(ctx context.Context, collection *models.Collection) error {
+	ctx, end := startOperation(ctx, "CreateCollection")
+	defer end()
+
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.CollectionsCollection)).InsertOne(ctx, collection); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetCollection retrieves a collection by its ID
+func (m *Mongo) GetCollection(ctx context.Context, id string) (*models.Collection, error) {
+	ctx, end := startOperation(ctx, "GetCollection")
+	defer end()
+
+	var collection models.Collection
+
+	err := m.Connection.Collection(m.ActualCollectionName(config.CollectionsCollection)).FindOne(ctx, bson.M{"id": id}, &collection)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrCollectionNotFound
+		}
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+// GetCollections retrieves all collections, ordered by their release date
+func (m *Mongo) GetCollections(ctx context.Context) ([]models.Collection, error) {
+	ctx, end := startOperation(ctx, "GetCollections")
+	defer end()
+
+	var collections []models.Collection
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.CollectionsCollection)).Find(ctx, bson.M{}, &collections,
+		mongodriver.Sort(bson.D{{Key: "release_date", Value: 1}, {Key: "id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// UpsertCollection creates or overwrites a collection (based on id)
+func (m *Mongo) UpsertCollection(ctx context.Context, collection *models.Collection) error {
+	ctx, end := startOperation(ctx, "UpsertCollection")
+	defer end()
+
+	selector := bson.M{"id": collection.ID}
+
+	currentTime := time.Now()
+	collection.LastUpdated = &currentTime
+	update := bson.M{
+		"$set": collection,
+	}
+
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.CollectionsCollection)).Upsert(ctx, selector, update); err != nil {
+		return err
+	}
+
+	return nil
+}
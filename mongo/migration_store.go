@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	"github.com/ONSdigital/dp-mongodb/v3/dplock"
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// migrationsLockResource is the resource locked while migrations run, the locks are held in the "<resource>_locks" collection
+const migrationsLockResource = "schema_migrations"
+
+// Collection returns the collection configured for the given name, e.g. config.TopicsCollection
+func (m *Mongo) Collection(name string) *mongodriver.Collection {
+	return m.Connection.Collection(m.ActualCollectionName(name))
+}
+
+// GetSchemaMigrations retrieves the record of every migration that has been applied, in the order they were applied
+func (m *Mongo) GetSchemaMigrations(ctx context.Context) ([]models.SchemaMigration, error) {
+	ctx, end := startOperation(ctx, "GetSchemaMigrations")
+	defer end()
+
+	var migrations []models.SchemaMigration
+
+	_, err := m.Collection(config.SchemaMigrationsCollection).Find(ctx, bson.M{}, &migrations, mongodriver.Sort(bson.M{"applied_at": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	return migrations, nil
+}
+
+// InsertSchemaMigration records that a migration has been applied
+func (m *Mongo) InsertSchemaMigration(ctx context.Context, migration *models.SchemaMigration) error {
+	ctx, end := startOperation(ctx, "InsertSchemaMigration")
+	defer end()
+
+	if _, err := m.Collection(config.SchemaMigrationsCollection).InsertOne(ctx, migration); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// NewMigrationsLock creates the lock that ensures only one instance runs migrations at a time. It must be closed once finished with.
+func (m *Mongo) NewMigrationsLock(ctx context.Context) *dplock.Lock {
+	return dplock.New(ctx, m.Connection, migrationsLockResource)
+}
@@ -0,0 +1,29 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/metrics"
+	"github.com/ONSdigital/dp-topic-api/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startOperation starts the span of an operation of the store, as a child of the span of the context, and returns the
+// context of the span with the function that ends it and observes the duration of the operation. Every method of the
+// store that queries mongo starts with:
+//
+//	ctx, end := startOperation(ctx, "GetTopic")
+//	defer end()
+func startOperation(ctx context.Context, method string) (context.Context, func()) {
+	start := time.Now()
+	ctx, span := tracing.Tracer().Start(ctx, "mongo."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "mongodb"), attribute.String("db.operation", method)))
+
+	return ctx, func() {
+		span.End()
+		metrics.ObserveMongo(method, start)
+	}
+}
@@ -0,0 +1,39 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStartOperation(t *testing.T) {
+	Convey("Given a tracer provider that records spans, and the span of a request", t, func() {
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(provider)
+		defer otel.SetTracerProvider(previous)
+
+		ctx, request := provider.Tracer("test").Start(context.Background(), "GET /topics/{id}")
+
+		Convey("When an operation of the store is started and ended", func() {
+			opCtx, end := startOperation(ctx, "GetTopic")
+			end()
+
+			Convey("Then its span is a child of the span of the request", func() {
+				spans := recorder.Ended()
+				So(spans, ShouldHaveLength, 1)
+				So(spans[0].Name(), ShouldEqual, "mongo.GetTopic")
+				So(spans[0].SpanKind(), ShouldEqual, trace.SpanKindClient)
+				So(spans[0].Parent().SpanID(), ShouldEqual, request.SpanContext().SpanID())
+				So(trace.SpanContextFromContext(opCtx).SpanID(), ShouldEqual, spans[0].SpanContext().SpanID())
+			})
+		})
+	})
+}
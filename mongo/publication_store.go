@@ -0,0 +1,45 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// InsertPublication records that a topic was published
+func (m *Mongo) InsertPublication(ctx context.Context, publication *models.Publication) error {
+	ctx, end := startOperation(ctx, "InsertPublication")
+	defer end()
+
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.PublicationsCollection)).InsertOne(ctx, publication); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetPublications retrieves the most recent publications of a topic, or of every topic if topicID is empty, newest first
+func (m *Mongo) GetPublications(ctx context.Context, topicID string, limit int) ([]models.Publication, error) {
+	ctx, end := startOperation(ctx, "GetPublications")
+	defer end()
+
+	var publications []models.Publication
+
+	filter := bson.M{}
+	if topicID != "" {
+		filter["topic_id"] = topicID
+	}
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.PublicationsCollection)).Find(ctx, filter, &publications,
+		mongodriver.Sort(bson.M{"published_at": -1}), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return publications, nil
+}
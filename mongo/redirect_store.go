@@ -0,0 +1,77 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	errs "github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// GetRedirect retrieves the redirect of a path
+func (m *Mongo) GetRedirect(ctx context.Context, from string) (*models.Redirect, error) {
+	ctx, end := startOperation(ctx, "GetRedirect")
+	defer end()
+
+	var redirect models.Redirect
+
+	err := m.Connection.Collection(m.ActualCollectionName(config.RedirectsCollection)).FindOne(ctx, bson.M{"from": from}, &redirect)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrRedirectNotFound
+		}
+		return nil, err
+	}
+
+	return &redirect, nil
+}
+
+// GetRedirects retrieves all redirects, ordered by the path they redirect
+func (m *Mongo) GetRedirects(ctx context.Context) ([]models.Redirect, error) {
+	ctx, end := startOperation(ctx, "GetRedirects")
+	defer end()
+
+	var redirects []models.Redirect
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.RedirectsCollection)).Find(ctx, bson.M{}, &redirects,
+		mongodriver.Sort(bson.D{{Key: "from", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	return redirects, nil
+}
+
+// UpsertRedirect creates or overwrites the redirect of a path (based on from)
+func (m *Mongo) UpsertRedirect(ctx context.Context, redirect *models.Redirect) error {
+	ctx, end := startOperation(ctx, "UpsertRedirect")
+	defer end()
+
+	selector := bson.M{"from": redirect.From}
+	update := bson.M{
+		"$set": redirect,
+	}
+
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.RedirectsCollection)).Upsert(ctx, selector, update); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteRedirect removes the redirect of a path, if there is one
+func (m *Mongo) DeleteRedirect(ctx context.Context, from string) error {
+	ctx, end := startOperation(ctx, "DeleteRedirect")
+	defer end()
+
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.RedirectsCollection)).DeleteOne(ctx, bson.M{"from": from}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	driver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// Mongo server error codes
+const (
+	errCodeNamespaceExists = 48
+)
+
+// Index is an index that a collection must have
+type Index struct {
+	// Collection is the name of the collection in the config, e.g. config.TopicsCollection
+	Collection string
+	// Name identifies the index, so that it can be found when checking for drift
+	Name   string
+	Keys   bson.D
+	Unique bool
+}
+
+// Indexes are the indexes required by the queries made by the API
+var Indexes = []Index{
+	{Collection: config.TopicsCollection, Name: "id_unique", Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+	{Collection: config.TopicsCollection, Name: "current_slug", Keys: bson.D{{Key: "current.slug", Value: 1}}},
+	{Collection: config.TopicsCollection, Name: "next_slug", Keys: bson.D{{Key: "next.slug", Value: 1}}},
+	{Collection: config.TopicsCollection, Name: "current_state", Keys: bson.D{{Key: "current.state", Value: 1}}},
+	{Collection: config.TopicsCollection, Name: "next_release_date", Keys: bson.D{{Key: "next.release_date", Value: 1}}},
+	{Collection: config.TopicsCollection, Name: "current_text", Keys: bson.D{
+		{Key: "current.title", Value: "text"},
+		{Key: "current.description", Value: "text"},
+		{Key: "current.keywords", Value: "text"},
+	}},
+	{Collection: config.ContentCollection, Name: "id_unique", Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+	{Collection: config.CollectionsCollection, Name: "id_unique", Keys: bson.D{{Key: "id", Value: 1}}, Unique: true},
+	{Collection: config.RedirectsCollection, Name: "from_unique", Keys: bson.D{{Key: "from", Value: 1}}, Unique: true},
+	{Collection: config.PublicationsCollection, Name: "published_at", Keys: bson.D{{Key: "published_at", Value: -1}}},
+	{Collection: config.PublicationsCollection, Name: "topic_published_at", Keys: bson.D{{Key: "topic_id", Value: 1}, {Key: "published_at", Value: -1}}},
+}
+
+// Validators are the $jsonSchema validators of the collections, by the name of the collection in the config
+var Validators = map[string]bson.M{
+	config.TopicsCollection: {
+		"bsonType": "object",
+		"required": bson.A{"id"},
+		"properties": bson.M{
+			"id":      bson.M{"bsonType": "string"},
+			"current": jsonSchema(reflect.TypeFor[*models.Topic]()),
+			"next":    jsonSchema(reflect.TypeFor[*models.Topic]()),
+			"owners":  bson.M{"bsonType": "array", "items": bson.M{"bsonType": "string"}},
+		},
+	},
+}
+
+// schemaState records the problems found when the indexes and validators were last ensured, so they can be reported
+// by the health check
+type schemaState struct {
+	mutex    sync.RWMutex
+	problems []string
+}
+
+// EnsureSchema creates the required indexes and applies the collection validators. It is idempotent, so it is called
+// on every startup, and returns every problem found rather than stopping at the first. Validators are applied at the
+// moderate level, so that documents which were already invalid can still be updated, e.g. by migrations.
+func (m *Mongo) EnsureSchema(ctx context.Context) error {
+	var errs []error
+
+	for name, validator := range Validators {
+		if err := m.ensureValidator(ctx, name, validator); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply the validator of the %s collection: %w", m.ActualCollectionName(name), err))
+		}
+	}
+
+	for _, index := range Indexes {
+		if err := m.ensureIndex(ctx, index); err != nil {
+			errs = append(errs, fmt.Errorf("failed to create index %s of the %s collection: %w", index.Name, m.ActualCollectionName(index.Collection), err))
+		}
+	}
+
+	problems := make([]string, 0, len(errs))
+	for _, err := range errs {
+		problems = append(problems, err.Error())
+	}
+	m.schema.mutex.Lock()
+	m.schema.problems = problems
+	m.schema.mutex.Unlock()
+
+	return errors.Join(errs...)
+}
+
+func (m *Mongo) ensureValidator(ctx context.Context, name string, validator bson.M) error {
+	collection := m.ActualCollectionName(name)
+	validation := bson.D{
+		{Key: "validator", Value: bson.M{"$jsonSchema": validator}},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: "error"},
+	}
+
+	err := m.Connection.RunCommand(ctx, append(bson.D{{Key: "create", Value: collection}}, validation...))
+	var serverErr driver.ServerError
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(errCodeNamespaceExists) {
+		return m.Connection.RunCommand(ctx, append(bson.D{{Key: "collMod", Value: collection}}, validation...))
+	}
+
+	return err
+}
+
+func (m *Mongo) ensureIndex(ctx context.Context, index Index) error {
+	spec := bson.M{"key": index.Keys, "name": index.Name}
+	if index.Unique {
+		spec["unique"] = true
+	}
+
+	// creating an index that already exists with the same spec does nothing
+	return m.Connection.RunCommand(ctx, bson.D{
+		{Key: "createIndexes", Value: m.ActualCollectionName(index.Collection)},
+		{Key: "indexes", Value: bson.A{spec}},
+	})
+}
+
+// SchemaChecker is called by the healthcheck library to report drift from the required indexes and validators.
+// It reports a warning, rather than failing, as queries still work without the indexes.
+func (m *Mongo) SchemaChecker(ctx context.Context, state *healthcheck.CheckState) error {
+	m.schema.mutex.RLock()
+	problems := append([]string{}, m.schema.problems...)
+	m.schema.mutex.RUnlock()
+
+	missing, err := m.missingIndexes(ctx)
+	if err != nil {
+		log.Error(ctx, "failed to list the indexes of the collections", err)
+		problems = append(problems, "failed to list indexes: "+err.Error())
+	}
+	for _, index := range missing {
+		problems = append(problems, fmt.Sprintf("index %s of the %s collection is missing", index.Name, m.ActualCollectionName(index.Collection)))
+	}
+
+	if len(problems) > 0 {
+		return state.Update(healthcheck.StatusWarning, strings.Join(problems, "; "), 0)
+	}
+	return state.Update(healthcheck.StatusOK, "indexes and validators are up to date", 0)
+}
+
+// missingIndexes returns the required indexes that do not exist. The indexes are listed using $collStats, which
+// only needs the read role.
+func (m *Mongo) missingIndexes(ctx context.Context) ([]Index, error) {
+	existing := map[string]map[string]bool{}
+	for _, index := range Indexes {
+		if existing[index.Collection] != nil {
+			continue
+		}
+
+		var stats []struct {
+			StorageStats struct {
+				IndexSizes map[string]int64 `bson:"indexSizes"`
+			} `bson:"storageStats"`
+		}
+		pipeline := bson.A{bson.M{"$collStats": bson.M{"storageStats": bson.M{}}}}
+		if err := m.Collection(index.Collection).Aggregate(ctx, pipeline, &stats); err != nil {
+			return nil, err
+		}
+
+		existing[index.Collection] = map[string]bool{}
+		for _, shard := range stats {
+			for name := range shard.StorageStats.IndexSizes {
+				existing[index.Collection][name] = true
+			}
+		}
+	}
+
+	var missing []Index
+	for _, index := range Indexes {
+		if !existing[index.Collection][index.Name] {
+			missing = append(missing, index)
+		}
+	}
+	return missing, nil
+}
+
+// jsonSchema derives the $jsonSchema of a field of the given type from its bson encoding, following the bson tags of
+// structs. Pointers, and fields without omitempty, may be null.
+func jsonSchema(t reflect.Type) bson.M {
+	nullable := false
+	if t.Kind() == reflect.Pointer {
+		nullable = true
+		t = t.Elem()
+	}
+
+	schema := bson.M{}
+	switch {
+	case t == reflect.TypeFor[time.Time]():
+		schema["bsonType"] = "date"
+	case t.Kind() == reflect.String:
+		schema["bsonType"] = "string"
+	case t.Kind() == reflect.Bool:
+		schema["bsonType"] = "bool"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		schema["bsonType"] = bson.A{"int", "long"}
+	case t.Kind() == reflect.Slice:
+		schema["bsonType"] = "array"
+		schema["items"] = jsonSchema(t.Elem())
+	case t.Kind() == reflect.Map:
+		schema["bsonType"] = "object"
+		schema["additionalProperties"] = jsonSchema(t.Elem())
+	case t.Kind() == reflect.Struct:
+		schema["bsonType"] = "object"
+		properties := bson.M{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, options, _ := strings.Cut(field.Tag.Get("bson"), ",")
+			if name == "-" || !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+
+			property := jsonSchema(field.Type)
+			if !strings.Contains(options, "omitempty") {
+				property = allowNull(property)
+			}
+			properties[name] = property
+		}
+		schema["properties"] = properties
+	}
+
+	if nullable {
+		return allowNull(schema)
+	}
+	return schema
+}
+
+// allowNull adds null to the types of a schema
+func allowNull(schema bson.M) bson.M {
+	switch bsonType := schema["bsonType"].(type) {
+	case string:
+		schema["bsonType"] = bson.A{bsonType, "null"}
+	case bson.A:
+		if bsonType[len(bsonType)-1] != "null" {
+			schema["bsonType"] = append(bsonType, "null")
+		}
+	}
+	return schema
+}
@@ -0,0 +1,66 @@
+package mongo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestJSONSchema(t *testing.T) {
+	Convey("Given the schema derived from a topic", t, func() {
+		schema := jsonSchema(reflect.TypeFor[*models.Topic]())
+		properties := schema["properties"].(bson.M)
+
+		Convey("Then the topic may be null, as it is a pointer", func() {
+			So(schema["bsonType"], ShouldResemble, bson.A{"object", "null"})
+		})
+
+		Convey("Then its properties are named by their bson tags", func() {
+			So(properties, ShouldContainKey, "subtopics_ids")
+			So(properties, ShouldContainKey, "release_date")
+			So(properties, ShouldNotContainKey, "SubtopicIds")
+		})
+
+		Convey("Then strings, dates and arrays have their bson types", func() {
+			So(properties["title"], ShouldResemble, bson.M{"bsonType": "string"})
+			So(properties["release_date"], ShouldResemble, bson.M{"bsonType": bson.A{"date", "null"}})
+			So(properties["keywords"], ShouldResemble, bson.M{"bsonType": bson.A{"array", "null"}, "items": bson.M{"bsonType": "string"}})
+		})
+
+		Convey("Then maps are objects whose properties have the bson type of their values", func() {
+			So(properties["related_topic_types"], ShouldResemble, bson.M{"bsonType": "object", "additionalProperties": bson.M{"bsonType": "string"}})
+		})
+
+		Convey("Then fields without omitempty may be null, but only once", func() {
+			So(properties["last_updated"], ShouldResemble, bson.M{"bsonType": bson.A{"date", "null"}})
+		})
+
+		Convey("Then nested structs are described by their properties", func() {
+			links := properties["links"].(bson.M)["properties"].(bson.M)
+			So(links["self"].(bson.M)["properties"], ShouldContainKey, "href")
+		})
+	})
+}
+
+func TestIndexes(t *testing.T) {
+	Convey("The required indexes are uniquely named within each collection", t, func() {
+		names := map[string]bool{}
+		for _, index := range Indexes {
+			key := index.Collection + "." + index.Name
+			So(names[key], ShouldBeFalse)
+			names[key] = true
+		}
+
+		Convey("And the topics, content and collections have a unique id index", func() {
+			So(names[config.TopicsCollection+".id_unique"], ShouldBeTrue)
+			So(names[config.ContentCollection+".id_unique"], ShouldBeTrue)
+			So(names[config.CollectionsCollection+".id_unique"], ShouldBeTrue)
+			So(names[config.RedirectsCollection+".from_unique"], ShouldBeTrue)
+		})
+	})
+}
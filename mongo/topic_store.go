@@ -24,6 +24,7 @@ type Mongo struct {
 
 	Connection   *mongodriver.MongoConnection
 	healthClient *mongohealth.CheckMongoClient
+	schema       schemaState
 }
 
 // NewDBConnection creates a new Mongo object encapsulating a connection to the mongo server/cluster with the given configuration,
@@ -59,6 +60,9 @@ func (m *Mongo) Checker(ctx context.Context, state *healthcheck.CheckState) erro
 
 // GetTopic retrieves a topic document by its ID
 func (m *Mongo) GetTopic(ctx context.Context, id string) (*models.TopicResponse, error) {
+	ctx, end := startOperation(ctx, "GetTopic")
+	defer end()
+
 	var topic models.TopicResponse
 
 	err := m.Connection.Collection(m.ActualCollectionName(config.TopicsCollection)).FindOne(ctx, bson.M{"id": id}, &topic)
@@ -74,6 +78,9 @@ func (m *Mongo) GetTopic(ctx context.Context, id string) (*models.TopicResponse,
 
 // CheckTopicExists checks that the topic exists
 func (m *Mongo) CheckTopicExists(ctx context.Context, id string) error {
+	ctx, end := startOperation(ctx, "CheckTopicExists")
+	defer end()
+
 	count, err := m.Connection.Collection(m.ActualCollectionName(config.TopicsCollection)).Count(ctx, bson.M{"id": id})
 	if err != nil {
 		return err
@@ -86,8 +93,49 @@ func (m *Mongo) CheckTopicExists(ctx context.Context, id string) error {
 	return nil
 }
 
+// GetArchivedTopicIDs retrieves the ids of the topics whose current document has been archived, ordered by id
+func (m *Mongo) GetArchivedTopicIDs(ctx context.Context) ([]string, error) {
+	ctx, end := startOperation(ctx, "GetArchivedTopicIDs")
+	defer end()
+
+	var topics []models.TopicResponse
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.TopicsCollection)).Find(ctx,
+		bson.M{"current.state": models.StateArchived.String()}, &topics,
+		mongodriver.Projection(bson.M{"id": 1}), mongodriver.Sort(bson.M{"id": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(topics))
+	for i := range topics {
+		ids = append(ids, topics[i].ID)
+	}
+
+	return ids, nil
+}
+
+// IsSlugInUse checks whether a topic other than the one with the given id has the slug, in either its current or next document
+func (m *Mongo) IsSlugInUse(ctx context.Context, id, slug string) (bool, error) {
+	ctx, end := startOperation(ctx, "IsSlugInUse")
+	defer end()
+
+	count, err := m.Connection.Collection(m.ActualCollectionName(config.TopicsCollection)).Count(ctx, bson.M{
+		"id":  bson.M{"$ne": id},
+		"$or": bson.A{bson.M{"current.slug": slug}, bson.M{"next.slug": slug}},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
 // GetContent retrieves a content document by its ID
 func (m *Mongo) GetContent(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+	ctx, end := startOperation(ctx, "GetContent")
+	defer end()
+
 	var content models.ContentResponse
 	// init default, used to minimise the mongo response to minimise go HEAP usage
 	contentSelect := bson.M{
@@ -149,6 +197,9 @@ func (m *Mongo) GetContent(ctx context.Context, id string, queryTypeFlags int) (
 
 // UpdateReleaseDate update releaseDate of document by its topic ID
 func (m *Mongo) UpdateReleaseDate(ctx context.Context, id string, releaseDate time.Time) error {
+	ctx, end := startOperation(ctx, "UpdateReleaseDate")
+	defer end()
+
 	selector := bson.M{"id": id}
 	update := bson.M{
 		"$set": bson.M{"next.release_date": releaseDate, "next.last_updated": time.Now()},
@@ -166,8 +217,33 @@ func (m *Mongo) UpdateReleaseDate(ctx context.Context, id string, releaseDate ti
 	return nil
 }
 
+// UpdateOwners replaces the groups that own a topic
+func (m *Mongo) UpdateOwners(ctx context.Context, id string, owners []string) error {
+	ctx, end := startOperation(ctx, "UpdateOwners")
+	defer end()
+
+	selector := bson.M{"id": id}
+	update := bson.M{
+		"$set": bson.M{"owners": owners},
+	}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.TopicsCollection)).Update(ctx, selector, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errs.ErrTopicNotFound
+	}
+
+	return nil
+}
+
 // UpdateState updates state field against next object
 func (m *Mongo) UpdateState(ctx context.Context, id, state string) error {
+	ctx, end := startOperation(ctx, "UpdateState")
+	defer end()
+
 	selector := bson.M{"id": id}
 	update := bson.M{
 		"$set": bson.M{"next.state": state, "next.last_updated": time.Now()},
@@ -185,15 +261,44 @@ func (m *Mongo) UpdateState(ctx context.Context, id, state string) error {
 	return nil
 }
 
+// UpdateReview updates the state of the next version of a topic along with the record of its review
+func (m *Mongo) UpdateReview(ctx context.Context, id, state string, review *models.Review) error {
+	ctx, end := startOperation(ctx, "UpdateReview")
+	defer end()
+
+	selector := bson.M{"id": id}
+	update := bson.M{
+		"$set": bson.M{"next.state": state, "next.review": review, "next.last_updated": time.Now()},
+	}
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.TopicsCollection)).Update(ctx, selector, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errs.ErrTopicNotFound
+	}
+
+	return nil
+}
+
 // UpsertTopic creates or overwrites an existing topic (based on id) in mongodb with a new document
 func (m *Mongo) UpsertTopic(ctx context.Context, id string, topic *models.TopicResponse) error {
+	ctx, end := startOperation(ctx, "UpsertTopic")
+	defer end()
+
 	// Topic to store in mongo
 	selector := bson.M{"id": id}
 
 	// Update the last updated timestamp
 	currentTime := time.Now()
-	topic.Current.LastUpdated = &currentTime
-	topic.Next.LastUpdated = &currentTime
+	if topic.Current != nil {
+		topic.Current.LastUpdated = &currentTime
+	}
+	if topic.Next != nil {
+		topic.Next.LastUpdated = &currentTime
+	}
 	update := bson.M{
 		"$set": topic,
 	}
@@ -210,8 +315,30 @@ func (m *Mongo) UpsertTopic(ctx context.Context, id string, topic *models.TopicR
 	return nil
 }
 
+// ReplaceTopic replaces the current and next documents of a topic with those given, removing any it does not have,
+// and keeping their last updated timestamps, e.g. to restore a topic as it was before it was changed
+func (m *Mongo) ReplaceTopic(ctx context.Context, topic *models.TopicResponse) error {
+	ctx, end := startOperation(ctx, "ReplaceTopic")
+	defer end()
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.TopicsCollection)).Update(ctx, bson.M{"id": topic.ID},
+		replaceVersions(topic.Current, topic.Next))
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errs.ErrTopicNotFound
+	}
+
+	return nil
+}
+
 // UpdateTopic updates the next instance with new values.
 func (m *Mongo) UpdateTopic(ctx context.Context, host, id string, topic *models.TopicUpdate) error {
+	ctx, end := startOperation(ctx, "UpdateTopic")
+	defer end()
+
 	selector := bson.M{"id": id}
 	update := createTopicUpdateQuery(ctx, host, id, topic)
 
@@ -238,7 +365,6 @@ func createTopicUpdateQuery(ctx context.Context, host, id string, topic *models.
 		"next.links.content.href": fmt.Sprintf("%s/topics/%s/content", host, id),
 		"next.links.self.href":    fmt.Sprintf("%s/topics/%s", host, id),
 		"next.links.self.id":      id,
-		"next.release_date":       topic.ReleaseDate,
 		"next.state":              topic.State,
 		"next.title":              topic.Title,
 	}
@@ -246,6 +372,35 @@ func createTopicUpdateQuery(ctx context.Context, host, id string, topic *models.
 	// ability to remove optional fields from existing resource using the unset query parameter
 	unsetFields := bson.M{}
 
+	// a topic that leaves the review workflow needs to be reviewed afresh, so any previous review is removed
+	if !topic.KeepsReview() {
+		unsetFields["next.review"] = ""
+	}
+
+	if topic.LastEditedBy != "" {
+		setFields["next.last_edited_by"] = topic.LastEditedBy
+	} else {
+		unsetFields["next.last_edited_by"] = ""
+	}
+
+	// the release date has been validated, but is stored as a string if it cannot be parsed rather than being lost
+	if releaseDate, err := time.Parse(time.RFC3339, topic.ReleaseDate); err == nil {
+		setFields["next.release_date"] = releaseDate
+	} else {
+		setFields["next.release_date"] = topic.ReleaseDate
+	}
+
+	// the slug is left unchanged when it is not provided
+	if topic.Slug != "" {
+		setFields["next.slug"] = topic.Slug
+	}
+
+	if topic.WelshTitle != "" {
+		setFields["next.welsh_title"] = topic.WelshTitle
+	} else {
+		unsetFields["next.welsh_title"] = ""
+	}
+
 	if topic.Keywords != nil && len(*topic.Keywords) > 0 {
 		setFields["next.keywords"] = topic.Keywords
 	} else {
@@ -260,6 +415,20 @@ func createTopicUpdateQuery(ctx context.Context, host, id string, topic *models.
 		unsetFields["next.links.subtopics"] = nil // remove subtopics link object due to no subtopics available for this topic
 	}
 
+	if topic.RelatedTopicIDs != nil && len(*topic.RelatedTopicIDs) > 0 {
+		setFields["next.related_topic_ids"] = topic.RelatedTopicIDs
+		setFields["next.links.related.href"] = fmt.Sprintf("%s/topics/%s/related", host, id)
+	} else {
+		unsetFields["next.related_topic_ids"] = ""
+		unsetFields["next.links.related"] = nil
+	}
+
+	if len(topic.RelatedTopicTypes) > 0 {
+		setFields["next.related_topic_types"] = topic.RelatedTopicTypes
+	} else {
+		unsetFields["next.related_topic_types"] = ""
+	}
+
 	update := bson.M{"$set": setFields}
 
 	if len(unsetFields) > 0 {
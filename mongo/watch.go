@@ -0,0 +1,65 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	gomongo "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Watch follows the change stream of the topics and content collections until the context is done or the stream fails.
+// onOpen is called once the stream is open, then onChange is called with the id of each changed document,
+// or with an empty id when the document no longer exists to look it up (e.g. it has been deleted).
+// Change streams are only available when mongo is running as a replica set.
+func (m *Mongo) Watch(ctx context.Context, onOpen func(), onChange func(id string)) error {
+	// dp-mongodb does not expose change streams, so a separate client is used for the stream
+	uri, err := m.GetConnectionURI()
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := m.GetTLSConfig()
+	if err != nil {
+		return err
+	}
+
+	client, err := gomongo.Connect(ctx, options.Client().ApplyURI(uri).SetTLSConfig(tlsConfig))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Disconnect(context.WithoutCancel(ctx)) }()
+
+	pipeline := gomongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"ns.coll": bson.M{"$in": []string{
+			m.ActualCollectionName(config.TopicsCollection),
+			m.ActualCollectionName(config.ContentCollection),
+		}}}}},
+	}
+
+	stream, err := client.Database(m.Database).Watch(ctx, pipeline, options.ChangeStream().SetFullDocument(options.UpdateLookup))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stream.Close(context.WithoutCancel(ctx)) }()
+
+	onOpen()
+
+	for stream.Next(ctx) {
+		var change struct {
+			FullDocument struct {
+				ID string `bson:"id"`
+			} `bson:"fullDocument"`
+		}
+		if err := stream.Decode(&change); err != nil {
+			onChange("")
+			continue
+		}
+
+		onChange(change.FullDocument.ID)
+	}
+
+	return stream.Err()
+}
@@ -0,0 +1,113 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	errs "github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/models"
+
+	mongodriver "github.com/ONSdigital/dp-mongodb/v3/mongodb"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CreateWebhook inserts a new webhook subscription
+func (m *Mongo) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	ctx, end := startOperation(ctx, "CreateWebhook")
+	defer end()
+
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.WebhooksCollection)).InsertOne(ctx, webhook); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetWebhook retrieves a webhook subscription by its ID
+func (m *Mongo) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	ctx, end := startOperation(ctx, "GetWebhook")
+	defer end()
+
+	var webhook models.Webhook
+
+	err := m.Connection.Collection(m.ActualCollectionName(config.WebhooksCollection)).FindOne(ctx, bson.M{"id": id}, &webhook)
+	if err != nil {
+		if errors.Is(err, mongodriver.ErrNoDocumentFound) {
+			return nil, errs.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// GetWebhooks retrieves all webhook subscriptions, oldest first
+func (m *Mongo) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	ctx, end := startOperation(ctx, "GetWebhooks")
+	defer end()
+
+	var webhooks []models.Webhook
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.WebhooksCollection)).Find(ctx, bson.M{}, &webhooks, mongodriver.Sort(bson.M{"created_at": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook removes a webhook subscription by its ID
+func (m *Mongo) DeleteWebhook(ctx context.Context, id string) error {
+	ctx, end := startOperation(ctx, "DeleteWebhook")
+	defer end()
+
+	result, err := m.Connection.Collection(m.ActualCollectionName(config.WebhooksCollection)).DeleteOne(ctx, bson.M{"id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errs.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// UpsertWebhookDelivery creates or overwrites a delivery log entry (based on id)
+func (m *Mongo) UpsertWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	ctx, end := startOperation(ctx, "UpsertWebhookDelivery")
+	defer end()
+
+	selector := bson.M{"id": delivery.ID}
+
+	currentTime := time.Now()
+	delivery.LastUpdated = &currentTime
+	update := bson.M{
+		"$set": delivery,
+	}
+
+	if _, err := m.Connection.Collection(m.ActualCollectionName(config.WebhookDeliveriesCollection)).Upsert(ctx, selector, update); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries retrieves the most recent deliveries for a webhook subscription, newest first
+func (m *Mongo) GetWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	ctx, end := startOperation(ctx, "GetWebhookDeliveries")
+	defer end()
+
+	var deliveries []models.WebhookDelivery
+
+	_, err := m.Connection.Collection(m.ActualCollectionName(config.WebhookDeliveriesCollection)).Find(ctx, bson.M{"webhook_id": webhookID}, &deliveries,
+		mongodriver.Sort(bson.M{"created_at": -1}), mongodriver.Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,95 @@
+// Package preview signs and verifies the time-limited tokens that give access to the next, unpublished, view of a topic
+// on the public API. Tokens are signed with HMAC-SHA256, so the publishing and web instances of the service must share
+// the secret they are signed with.
+package preview
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+	"github.com/ONSdigital/dp-topic-api/models"
+)
+
+// MinSecretLength is the minimum length of the secret tokens are signed with
+const MinSecretLength = 32
+
+// Signer issues and verifies the preview tokens of topics
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewSigner creates a Signer issuing tokens with the secret, which expire once the ttl has passed
+func NewSigner(secret string, ttl time.Duration) (*Signer, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("preview token secret must be at least %d characters long", MinSecretLength)
+	}
+	if ttl <= 0 {
+		return nil, errors.New("preview token ttl must be positive")
+	}
+
+	return &Signer{secret: []byte(secret), ttl: ttl}, nil
+}
+
+// Issue returns a token giving access to the next view of the topic with the id until the ttl has passed from now
+func (s *Signer) Issue(topicID string, now time.Time) *models.PreviewToken {
+	expiresAt := now.Add(s.ttl).UTC().Truncate(time.Second)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(topicID + "\n" + strconv.FormatInt(expiresAt.Unix(), 10)))
+
+	return &models.PreviewToken{
+		Token:     payload + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload)),
+		TopicID:   topicID,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// Verify returns an error wrapping apierrors.ErrInvalidPreviewToken unless the token was issued by a Signer with the
+// same secret for the topic with the id, and has not expired by now
+func (s *Signer) Verify(token, topicID string, now time.Time) error {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return apierrors.ErrInvalidPreviewToken
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.sign(payload)) {
+		return apierrors.ErrInvalidPreviewToken
+	}
+
+	claims, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return apierrors.ErrInvalidPreviewToken
+	}
+	id, expiry, ok := strings.Cut(string(claims), "\n")
+	if !ok {
+		return apierrors.ErrInvalidPreviewToken
+	}
+	if id != topicID {
+		return fmt.Errorf("%w: the token was issued for topic %s", apierrors.ErrInvalidPreviewToken, id)
+	}
+
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return apierrors.ErrInvalidPreviewToken
+	}
+	if !now.Before(time.Unix(expiresAt, 0)) {
+		return fmt.Errorf("%w: the token expired at %s", apierrors.ErrInvalidPreviewToken,
+			time.Unix(expiresAt, 0).UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// sign returns the HMAC-SHA256 of the payload
+func (s *Signer) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
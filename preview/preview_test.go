@@ -0,0 +1,79 @@
+package preview
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testSecret = "a-secret-that-is-at-least-32-characters"
+
+func TestSigner(t *testing.T) {
+	Convey("Given a signer issuing tokens that expire after an hour", t, func() {
+		signer, err := NewSigner(testSecret, time.Hour)
+		So(err, ShouldBeNil)
+		now := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+		Convey("When a token is issued for a topic", func() {
+			token := signer.Issue("economy", now)
+
+			Convey("Then it expires after the ttl", func() {
+				So(token.TopicID, ShouldEqual, "economy")
+				So(token.ExpiresAt, ShouldEqual, now.Add(time.Hour))
+			})
+
+			Convey("Then it is valid for the topic until it expires", func() {
+				So(signer.Verify(token.Token, "economy", now), ShouldBeNil)
+				So(signer.Verify(token.Token, "economy", now.Add(59*time.Minute)), ShouldBeNil)
+			})
+
+			Convey("Then it is invalid once it has expired", func() {
+				err := signer.Verify(token.Token, "economy", now.Add(time.Hour))
+				So(errors.Is(err, apierrors.ErrInvalidPreviewToken), ShouldBeTrue)
+				So(err.Error(), ShouldEqual, "invalid or expired preview token: the token expired at 2024-03-01T10:30:00Z")
+			})
+
+			Convey("Then it is invalid for another topic", func() {
+				err := signer.Verify(token.Token, "census", now)
+				So(errors.Is(err, apierrors.ErrInvalidPreviewToken), ShouldBeTrue)
+				So(err.Error(), ShouldEqual, "invalid or expired preview token: the token was issued for topic economy")
+			})
+
+			Convey("Then it is invalid for a signer with another secret", func() {
+				other, err := NewSigner(strings.ToUpper(testSecret), time.Hour)
+				So(err, ShouldBeNil)
+				So(other.Verify(token.Token, "economy", now), ShouldEqual, apierrors.ErrInvalidPreviewToken)
+			})
+
+			Convey("Then it is invalid once its payload is tampered with", func() {
+				payload, signature, _ := strings.Cut(token.Token, ".")
+				forged := signer.Issue("census", now.Add(24*time.Hour))
+				forgedPayload, _, _ := strings.Cut(forged.Token, ".")
+
+				So(signer.Verify(forgedPayload+"."+signature, "census", now), ShouldEqual, apierrors.ErrInvalidPreviewToken)
+				So(signer.Verify(payload+"x."+signature, "economy", now), ShouldEqual, apierrors.ErrInvalidPreviewToken)
+			})
+		})
+
+		Convey("Then malformed tokens are invalid", func() {
+			for _, token := range []string{"", "token", "a.b", "a.b.c", "..."} {
+				So(signer.Verify(token, "economy", now), ShouldEqual, apierrors.ErrInvalidPreviewToken)
+			}
+		})
+	})
+
+	Convey("Given a secret that is too short, creating a signer fails", t, func() {
+		_, err := NewSigner("secret", time.Hour)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Given a ttl that is not positive, creating a signer fails", t, func() {
+		_, err := NewSigner(testSecret, 0)
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -1,23 +0,0 @@
-const addTopicsData = [
-  {
-    title: "International trade",
-    description:
-      "Trade in goods and services across the UK's international borders, including total imports and exports, the types of goods and services traded and general trends in international trade.",
-    parentID: "8691"
-  },
-  {
-    title: "IT and internet industry",
-    description:
-      "Internet sales by businesses in the UK (total value and as a percentage of all retail sales) and the percentage of businesses that have a website and broadband connection. These figures indicate the importance of the internet to UK businesses.",
-    parentID: "8691"
-  },
-  {
-    title: "Changes to business",
-    desciption: "UK business growth, survival and change over time. These figures are an informal indicator of confidence in the UK economy.",
-    subtopics: [
-        { title: "Business births, deaths and survival rates", description: "Demography of UK businesses: active businesses, new registrations for VAT and PAYE (births), cessation of trading (deaths), and duration of trading (survival rates)."},
-        { title: "Mergers and acquisitions", description: "Business mergers and acquisitions involving UK companies, including de-mergers and disposals, where the transaction value is £1 million or more."}
-    ],
-    parentID: "8691"
-  },
-];
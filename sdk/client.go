@@ -8,11 +8,13 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/dp-topic-api/models"
 	apiError "github.com/ONSdigital/dp-topic-api/sdk/errors"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 const (
@@ -184,6 +186,9 @@ func (cli *Client) callTopicAPI(ctx context.Context, path, method string, header
 		}
 	}
 
+	// propagate the W3C trace context of the caller, so that the call is part of its trace
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
 	resp, err := cli.hcCli.Client.Do(ctx, req)
 	if err != nil {
 		return nil, apiError.StatusError{
@@ -201,10 +206,7 @@ func (cli *Client) callTopicAPI(ctx context.Context, path, method string, header
 	}
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= 400 {
-		return respInfo, apiError.StatusError{
-			Err:  fmt.Errorf("failed as unexpected code from topic api: %v", resp.StatusCode),
-			Code: resp.StatusCode,
-		}
+		return respInfo, problemError(resp)
 	}
 
 	if resp.Body == nil {
@@ -222,6 +224,34 @@ func (cli *Client) callTopicAPI(ctx context.Context, path, method string, header
 	return respInfo, nil
 }
 
+// problemError returns an apiError.ProblemError describing an unsuccessful response, if its body is a
+// problem document, otherwise an apiError.StatusError with the response status code
+func problemError(resp *http.Response) apiError.Error {
+	statusErr := apiError.StatusError{
+		Err:  fmt.Errorf("failed as unexpected code from topic api: %v", resp.StatusCode),
+		Code: resp.StatusCode,
+	}
+
+	if resp.Body == nil || !strings.HasPrefix(resp.Header.Get("Content-Type"), models.ProblemContentType) {
+		return statusErr
+	}
+
+	var problem models.Problem
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		return statusErr
+	}
+
+	return apiError.ProblemError{
+		StatusError: apiError.StatusError{
+			Err:  fmt.Errorf("failed as unexpected code from topic api: %v, %s: %s", resp.StatusCode, problem.Code, problem.Detail),
+			Code: resp.StatusCode,
+		},
+		ErrorCode: problem.Code,
+		RequestID: problem.RequestID,
+		Fields:    problem.Errors,
+	}
+}
+
 // closeResponseBody closes the response body and logs an error if unsuccessful
 func closeResponseBody(resp *http.Response) apiError.Error {
 	if resp.Body != nil {
@@ -14,7 +14,10 @@ import (
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v3/http"
+	"github.com/ONSdigital/dp-topic-api/apierrors"
 	"github.com/ONSdigital/dp-topic-api/models"
+	apiError "github.com/ONSdigital/dp-topic-api/sdk/errors"
+	"go.opentelemetry.io/otel/trace"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -300,6 +303,45 @@ func TestGetTopicPublic(t *testing.T) {
 		})
 	})
 
+	Convey("Given a 404 response from topic api describing the problem", t, func() {
+		body, err := json.Marshal(models.Problem{
+			Type:      "about:blank",
+			Title:     "Not Found",
+			Status:    http.StatusNotFound,
+			Detail:    "topic not found",
+			Code:      apierrors.CodeTopicNotFound,
+			RequestID: "request-1",
+		})
+		if err != nil {
+			t.Errorf("failed to setup test data, error: %v", err)
+		}
+
+		httpClient := newMockHTTPClient(
+			&http.Response{
+				StatusCode: http.StatusNotFound,
+				Header:     http.Header{"Content-Type": []string{models.ProblemContentType}},
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			},
+			nil)
+		topicAPIClient := newTopicAPIClient(t, httpClient)
+
+		Convey("When GetTopicPublic is called", func() {
+			respTopic, err := topicAPIClient.GetTopicPublic(ctx, Headers{}, "1234")
+
+			Convey("Then a problem error with the code of the error should be returned", func() {
+				So(respTopic, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Status(), ShouldEqual, http.StatusNotFound)
+				So(apiError.ErrorCode(err), ShouldEqual, apierrors.CodeTopicNotFound)
+
+				problemErr, ok := err.(apiError.ProblemError)
+				So(ok, ShouldBeTrue)
+				So(problemErr.RequestID, ShouldEqual, "request-1")
+				So(problemErr.Error(), ShouldContainSubstring, "topic not found")
+			})
+		})
+	})
+
 	Convey("Given the client returns an unexpected error", t, func() {
 		clientError := errors.New("unexpected error")
 		httpClient := newMockHTTPClient(&http.Response{}, clientError)
@@ -522,3 +564,31 @@ func TestGetNavigationPublic(t *testing.T) {
 		})
 	})
 }
+
+func TestTraceContextPropagation(t *testing.T) {
+	t.Parallel()
+
+	Convey("Given the context of a span of the caller", t, func() {
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		}))
+
+		body, err := json.Marshal(testPublicTopic1)
+		So(err, ShouldBeNil)
+		httpClient := newMockHTTPClient(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil)
+		topicAPIClient := newTopicAPIClient(t, httpClient)
+
+		Convey("When the topic api is called, the W3C trace context is propagated", func() {
+			_, err := topicAPIClient.GetTopicPublic(ctx, Headers{}, "1234")
+			So(err, ShouldBeNil)
+
+			doCalls := httpClient.DoCalls()
+			So(doCalls, ShouldHaveLength, 1)
+			So(doCalls[0].Req.Header.Get("traceparent"), ShouldEqual, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		})
+	})
+}
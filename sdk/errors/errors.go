@@ -1,6 +1,10 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/ONSdigital/dp-topic-api/apierrors"
+)
 
 // Error represents a handler error. It provides methods for a HTTP status
 // code and embeds the built-in error interface.
@@ -48,3 +52,23 @@ func ErrorMessage(err error) string {
 
 	return err.Error()
 }
+
+// ProblemError represents an error response from the topic API that described the problem in an
+// RFC 7807 problem document. ErrorCode is the stable code of the error, one of the apierrors codes,
+// which callers can switch on rather than parsing messages.
+type ProblemError struct {
+	StatusError
+	ErrorCode string
+	RequestID string
+	Fields    []apierrors.FieldError
+}
+
+// ErrorCode returns the code of the error from the problem document, if there was one
+func ErrorCode(err error) string {
+	var perr ProblemError
+	if errors.As(err, &perr) {
+		return perr.ErrorCode
+	}
+
+	return ""
+}
@@ -49,3 +49,30 @@ func Test(t *testing.T) {
 		})
 	})
 }
+
+func TestProblemError(t *testing.T) {
+	t.Parallel()
+
+	Convey("given a problem error", t, func() {
+		pErr := ProblemError{
+			StatusError: StatusError{
+				Code: 400,
+				Err:  errors.New("missing topic update mandatory fields"),
+			},
+			ErrorCode: "missing_fields",
+		}
+
+		Convey("when passing problem error into ErrorStatus and ErrorCode funcs", func() {
+			Convey("then the status and code are returned", func() {
+				So(ErrorStatus(pErr), ShouldEqual, 400)
+				So(ErrorCode(pErr), ShouldEqual, "missing_fields")
+			})
+		})
+
+		Convey("when passing a status error into ErrorCode func", func() {
+			Convey("then no code is returned", func() {
+				So(ErrorCode(pErr.StatusError), ShouldBeEmpty)
+			})
+		})
+	})
+}
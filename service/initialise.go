@@ -2,9 +2,11 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/memory"
 	"github.com/ONSdigital/dp-topic-api/mongo"
 	"github.com/ONSdigital/dp-topic-api/store"
 
@@ -36,8 +38,8 @@ func (e *ExternalServiceList) GetHTTPServer(bindAddr string, router http.Handler
 	return s
 }
 
-// GetMongoDB creates a mongoDB client and sets the Mongo flag to true
-func (e *ExternalServiceList) GetMongoDB(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) {
+// GetMongoDB creates a mongoDB client (or the configured alternative store) and sets the Mongo flag to true
+func (e *ExternalServiceList) GetMongoDB(ctx context.Context, cfg *config.Config) (store.MongoDB, error) {
 	mongoDB, err := e.Init.DoGetMongoDB(ctx, cfg)
 	if err != nil {
 		return nil, err
@@ -68,13 +70,23 @@ func (e *Init) DoGetHTTPServer(bindAddr string, router http.Handler) HTTPServer
 	return s
 }
 
-// DoGetMongoDB returns a MongoDB
-func (e *Init) DoGetMongoDB(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) {
-	mongodb, err := mongo.NewDBConnection(ctx, cfg)
-	if err != nil {
-		return nil, err
+// DoGetMongoDB returns a MongoDB, or an in-memory store (seeded from the fixture file, if provided) when that backend is configured
+func (e *Init) DoGetMongoDB(ctx context.Context, cfg *config.Config) (store.MongoDB, error) {
+	switch cfg.StoreBackend {
+	case config.StoreBackendMongo:
+		mongodb, err := mongo.NewDBConnection(ctx, cfg.MongoConfig)
+		if err != nil {
+			return nil, err
+		}
+		return mongodb, nil
+	case config.StoreBackendMemory:
+		if cfg.StoreFixturePath == "" {
+			return memory.New(), nil
+		}
+		return memory.NewFromFile(cfg.StoreFixturePath)
+	default:
+		return nil, fmt.Errorf("unrecognised store backend: %q", cfg.StoreBackend)
 	}
-	return mongodb, nil
 }
 
 // DoGetHealthClient creates a new Health Client for the provided name and url
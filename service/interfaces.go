@@ -6,7 +6,9 @@ import (
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/dp-mongodb/v3/dplock"
 	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/migrations"
 	"github.com/ONSdigital/dp-topic-api/store"
 )
 
@@ -17,7 +19,7 @@ import (
 // Initialiser defines the methods to initialise external services
 type Initialiser interface {
 	DoGetHTTPServer(bindAddr string, router http.Handler) HTTPServer
-	DoGetMongoDB(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error)
+	DoGetMongoDB(ctx context.Context, cfg *config.Config) (store.MongoDB, error)
 	DoGetHealthClient(name, url string) *health.Client
 	DoGetHealthCheck(cfg *config.Config, buildTime, gitCommit, version string) (HealthChecker, error)
 }
@@ -35,3 +37,15 @@ type HealthChecker interface {
 	Stop()
 	AddCheck(name string, checker healthcheck.Checker) (err error)
 }
+
+// migratableDB defines the methods required from a store backend to apply schema migrations
+type migratableDB interface {
+	migrations.Database
+	NewMigrationsLock(ctx context.Context) *dplock.Lock
+}
+
+// schemaEnsurer defines the methods required from a store backend to ensure its indexes and validators, and report drift from them
+type schemaEnsurer interface {
+	EnsureSchema(ctx context.Context) error
+	SchemaChecker(ctx context.Context, state *healthcheck.CheckState) error
+}
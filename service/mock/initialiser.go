@@ -6,7 +6,6 @@ package mock
 import (
 	"context"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
-	"github.com/ONSdigital/dp-mongodb/v3/mongodb"
 	"github.com/ONSdigital/dp-topic-api/config"
 	"github.com/ONSdigital/dp-topic-api/service"
 	"github.com/ONSdigital/dp-topic-api/store"
@@ -33,7 +32,7 @@ var _ service.Initialiser = &InitialiserMock{}
 //			DoGetHealthClientFunc: func(name string, url string) *health.Client {
 //				panic("mock out the DoGetHealthClient method")
 //			},
-//			DoGetMongoDBFunc: func(ctx context.Context, cfg mongodb.MongoDriverConfig) (store.MongoDB, error) {
+//			DoGetMongoDBFunc: func(ctx context.Context, cfg *config.Config) (store.MongoDB, error) {
 //				panic("mock out the DoGetMongoDB method")
 //			},
 //		}
@@ -53,7 +52,7 @@ type InitialiserMock struct {
 	DoGetHealthClientFunc func(name string, url string) *health.Client
 
 	// DoGetMongoDBFunc mocks the DoGetMongoDB method.
-	DoGetMongoDBFunc func(ctx context.Context, cfg mongodb.MongoDriverConfig) (store.MongoDB, error)
+	DoGetMongoDBFunc func(ctx context.Context, cfg *config.Config) (store.MongoDB, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -87,7 +86,7 @@ type InitialiserMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Cfg is the cfg argument value.
-			Cfg mongodb.MongoDriverConfig
+			Cfg *config.Config
 		}
 	}
 	lockDoGetHTTPServer   sync.RWMutex
@@ -213,13 +212,13 @@ func (mock *InitialiserMock) DoGetHealthClientCalls() []struct {
 }
 
 // DoGetMongoDB calls DoGetMongoDBFunc.
-func (mock *InitialiserMock) DoGetMongoDB(ctx context.Context, cfg mongodb.MongoDriverConfig) (store.MongoDB, error) {
+func (mock *InitialiserMock) DoGetMongoDB(ctx context.Context, cfg *config.Config) (store.MongoDB, error) {
 	if mock.DoGetMongoDBFunc == nil {
 		panic("InitialiserMock.DoGetMongoDBFunc: method is nil but Initialiser.DoGetMongoDB was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Cfg mongodb.MongoDriverConfig
+		Cfg *config.Config
 	}{
 		Ctx: ctx,
 		Cfg: cfg,
@@ -236,11 +235,11 @@ func (mock *InitialiserMock) DoGetMongoDB(ctx context.Context, cfg mongodb.Mongo
 //	len(mockedInitialiser.DoGetMongoDBCalls())
 func (mock *InitialiserMock) DoGetMongoDBCalls() []struct {
 	Ctx context.Context
-	Cfg mongodb.MongoDriverConfig
+	Cfg *config.Config
 } {
 	var calls []struct {
 		Ctx context.Context
-		Cfg mongodb.MongoDriverConfig
+		Cfg *config.Config
 	}
 	mock.lockDoGetMongoDB.RLock()
 	calls = mock.calls.DoGetMongoDB
@@ -2,17 +2,27 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	clientsidentity "github.com/ONSdigital/dp-api-clients-go/v2/identity"
 	"github.com/ONSdigital/dp-authorisation/auth"
+	"github.com/ONSdigital/dp-authorisation/v2/jwt"
 	"github.com/justinas/alice"
 
+	"github.com/ONSdigital/dp-mongodb/v3/dplock"
 	dphandlers "github.com/ONSdigital/dp-net/v3/handlers"
 	dphttp "github.com/ONSdigital/dp-net/v3/http"
 	"github.com/ONSdigital/dp-topic-api/api"
+	"github.com/ONSdigital/dp-topic-api/authz"
+	"github.com/ONSdigital/dp-topic-api/cache"
 	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/ONSdigital/dp-topic-api/metrics"
+	"github.com/ONSdigital/dp-topic-api/migrations"
+	"github.com/ONSdigital/dp-topic-api/preview"
 	"github.com/ONSdigital/dp-topic-api/store"
+	"github.com/ONSdigital/dp-topic-api/tracing"
+	"github.com/ONSdigital/dp-topic-api/webhook"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -37,6 +47,10 @@ type Service struct {
 	HealthCheck    HealthChecker
 	mongoDB        store.MongoDB
 	IdentityClient *clientsidentity.Client
+	webhooks       *webhook.Dispatcher
+	authz          *authz.Handler
+	stopCacheWatch context.CancelFunc
+	stopTracing    func(context.Context) error
 }
 
 // New creates a new service
@@ -50,19 +64,47 @@ func New(cfg *config.Config, serviceList *ExternalServiceList) *Service {
 
 // Run the service
 func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version string, svcErrors chan error) (err error) {
+	// Set up tracing first, so that the spans of everything else are exported
+	svc.stopTracing, err = tracing.Setup(ctx, svc.Config)
+	if err != nil {
+		log.Fatal(ctx, "failed to set up tracing", err)
+		return err
+	}
+
 	// Get MongoDB client
-	svc.mongoDB, err = svc.ServiceList.GetMongoDB(ctx, svc.Config.MongoConfig)
+	svc.mongoDB, err = svc.ServiceList.GetMongoDB(ctx, svc.Config)
 	if err != nil {
 		log.Fatal(ctx, "failed to initialise mongo DB", err)
 		return err
 	}
 
+	if svc.Config.MigrateOnStartup {
+		log.Info(ctx, "feature flag enabled", log.Data{"feature": "MIGRATE_ON_STARTUP"})
+		if err := svc.migrate(ctx); err != nil {
+			log.Fatal(ctx, "failed to migrate mongo DB", err)
+			return err
+		}
+	}
+
+	// The service can run without the indexes and validators, so failing to ensure them is reported by the health check
+	if db, ok := svc.mongoDB.(schemaEnsurer); ok {
+		if err := db.EnsureSchema(ctx); err != nil {
+			log.Error(ctx, "failed to ensure the mongo DB indexes and validators", err)
+		}
+	}
+
 	// Get Identity Client (only if private endpoints are enabled)
 	if svc.Config.EnablePrivateEndpoints {
 		// Only in Publishing ... create client(s):
 		svc.IdentityClient = clientsidentity.New(svc.Config.ZebedeeURL)
 	}
 
+	permissions, err := svc.getAuthorisationHandlers(ctx)
+	if err != nil {
+		log.Fatal(ctx, "could not instantiate authorisation", err)
+		return err
+	}
+
 	// Get HealthCheck
 	svc.HealthCheck, err = svc.ServiceList.GetHealthCheck(svc.Config, buildTime, gitCommit, version)
 	if err != nil {
@@ -76,13 +118,61 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 
 	// Get HTTP router and server with middleware
 	router := mux.NewRouter()
-	middle := svc.createMiddleware(svc.Config)
+	middle := svc.createMiddleware(svc.Config, router)
 	svc.Server = svc.ServiceList.GetHTTPServer(svc.Config.BindAddr, middle.Then(router))
 
 	// Set up the API
-	permissions := getAuthorisationHandlers(ctx, svc.Config)
-	s := store.DataStore{Backend: DatsetAPIStore{svc.mongoDB}}
-	svc.API = api.Setup(ctx, svc.Config, router, s, permissions, svc.Config.TopicAPIURL)
+	var backend store.Storer = DatsetAPIStore{svc.mongoDB}
+
+	// Public views are only cached in web, where topics change solely when publishing syncs mongoDB
+	if !svc.Config.EnablePrivateEndpoints && svc.Config.EnableCache {
+		log.Info(ctx, "feature flag enabled", log.Data{"feature": "ENABLE_CACHE"})
+		cachedStore := cache.NewStore(backend, svc.Config.CacheTTL, svc.Config.CacheMaxEntries)
+		if watcher, ok := svc.mongoDB.(cache.Watcher); ok {
+			var watchCtx context.Context
+			watchCtx, svc.stopCacheWatch = context.WithCancel(context.WithoutCancel(ctx))
+			go cachedStore.Watch(watchCtx, watcher, cache.WatchRetryInterval)
+		}
+		backend = cachedStore
+	}
+
+	s := store.DataStore{Backend: backend}
+
+	// Webhook notifications are only sent from publishing, where topics change
+	var notifier api.Notifier
+	if svc.Config.EnablePrivateEndpoints && svc.Config.EnableWebhooks {
+		log.Info(ctx, "feature flag enabled", log.Data{"feature": "ENABLE_WEBHOOKS"})
+		svc.webhooks = webhook.NewDispatcher(svc.mongoDB, &http.Client{Timeout: svc.Config.WebhookTimeout},
+			svc.Config.WebhookMaxRetries, svc.Config.WebhookRetryBackoff)
+		notifier = svc.webhooks
+	}
+
+	// The ownership of topics is only enforced in publishing, where they are edited
+	var entityParser api.EntityParser
+	if svc.Config.EnablePrivateEndpoints && svc.Config.EnableTopicOwnership {
+		log.Info(ctx, "feature flag enabled", log.Data{"feature": "ENABLE_TOPIC_OWNERSHIP"})
+		if len(svc.Config.JWTVerificationPublicKeys) == 0 {
+			err = errors.New("JWT_VERIFICATION_PUBLIC_KEYS must be set to enforce the ownership of topics")
+			log.Fatal(ctx, "failed to create the parser of JWTs", err)
+			return err
+		}
+		if entityParser, err = jwt.NewCognitoRSAParser(svc.Config.JWTVerificationPublicKeys); err != nil {
+			log.Fatal(ctx, "failed to create the parser of JWTs", err)
+			return err
+		}
+	}
+
+	// Previews are issued in publishing and served in web, which must share the secret they are signed with
+	var previews *preview.Signer
+	if svc.Config.PreviewTokenSecret != "" {
+		log.Info(ctx, "previews of topics are enabled", log.Data{"preview_token_ttl": svc.Config.PreviewTokenTTL.String()})
+		if previews, err = preview.NewSigner(svc.Config.PreviewTokenSecret, svc.Config.PreviewTokenTTL); err != nil {
+			log.Fatal(ctx, "failed to create the signer of preview tokens", err)
+			return err
+		}
+	}
+
+	svc.API = api.Setup(ctx, svc.Config, router, s, permissions, entityParser, notifier, previews, svc.Config.TopicAPIURL)
 
 	svc.HealthCheck.Start(ctx)
 
@@ -96,37 +186,89 @@ func (svc *Service) Run(ctx context.Context, buildTime, gitCommit, version strin
 	return nil
 }
 
-func getAuthorisationHandlers(ctx context.Context, cfg *config.Config) api.AuthHandler {
+// getAuthorisationHandlers returns the checks of the permissions of callers, by the backend of the config
+func (svc *Service) getAuthorisationHandlers(ctx context.Context) (api.AuthHandler, error) {
+	cfg := svc.Config
 	if !cfg.EnablePermissionsAuth {
 		log.Info(ctx, "feature flag not enabled defaulting to nop authZ impl", log.Data{"feature": "ENABLE_PERMISSIONS_AUTHZ"})
-		return &auth.NopHandler{}
+		return &auth.NopHandler{}, nil
 	}
 
-	log.Info(ctx, "feature flag enabled", log.Data{"feature": "ENABLE_PERMISSIONS_AUTHZ"})
+	log.Info(ctx, "feature flag enabled", log.Data{"feature": "ENABLE_PERMISSIONS_AUTHZ", "backend": cfg.AuthBackend})
+
+	switch cfg.AuthBackend {
+	case config.AuthBackendZebedee:
+		authClient := auth.NewPermissionsClient(dphttp.NewClient())
+		authVerifier := auth.DefaultPermissionsVerifier()
+
+		// for checking caller permissions when we only have a user/service token
+		permissions := auth.NewHandler(
+			auth.NewPermissionsRequestBuilder(cfg.ZebedeeURL),
+			authClient,
+			authVerifier,
+		)
+
+		return permissions, nil
+	case config.AuthBackendJWT:
+		var err error
+		if svc.authz, err = authz.NewHandler(ctx, cfg); err != nil {
+			return nil, err
+		}
+		return svc.authz, nil
+	default:
+		return nil, fmt.Errorf("unrecognised auth backend: %q", cfg.AuthBackend)
+	}
+}
 
-	authClient := auth.NewPermissionsClient(dphttp.NewClient())
-	authVerifier := auth.DefaultPermissionsVerifier()
+// migrate applies any pending schema migrations. If another instance holds the migrations lock, it is assumed to be
+// applying them, and startup continues without waiting for it.
+func (svc *Service) migrate(ctx context.Context) error {
+	db, ok := svc.mongoDB.(migratableDB)
+	if !ok {
+		log.Info(ctx, "schema migrations are not supported by the store backend", log.Data{"backend": svc.Config.StoreBackend})
+		return nil
+	}
 
-	// for checking caller permissions when we only have a user/service token
-	permissions := auth.NewHandler(
-		auth.NewPermissionsRequestBuilder(cfg.ZebedeeURL),
-		authClient,
-		authVerifier,
-	)
+	lock := db.NewMigrationsLock(ctx)
+	defer lock.Close(ctx)
 
-	return permissions
+	results, err := migrations.New(db, lock, migrations.All).Migrate(ctx, false)
+	if errors.Is(err, dplock.ErrAcquireMaxRetries) {
+		log.Warn(ctx, "schema migrations are being applied by another instance", log.Data{"err": err.Error()})
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "schema migrations applied", log.Data{"migrations": results})
+	return nil
 }
 
 // CreateMiddleware creates an Alice middleware chain of handlers
 // to forward collectionID from cookie from header
-func (svc *Service) createMiddleware(cfg *config.Config) alice.Chain {
+func (svc *Service) createMiddleware(cfg *config.Config, router *mux.Router) alice.Chain {
 	// healthcheck
 	healthcheckHandler := healthcheckMiddleware(svc.HealthCheck.Handler, "/health")
 	middleware := alice.New(healthcheckHandler)
 
+	// tracing, which starts a server span for each request once the route it matches is known, and continues the trace
+	// of its caller even when spans are not exported
+	router.Use(tracing.Middleware)
+
+	// metrics, which are observed by the route the requests match
+	if cfg.EnableMetrics {
+		middleware = middleware.Append(metrics.Middleware(router))
+	}
+
 	// Only add the identity middleware when running in publishing.
+	// With the JWT auth backend, only the callers with service tokens are identified by Zebedee.
 	if cfg.EnablePrivateEndpoints {
-		middleware = middleware.Append(dphandlers.IdentityWithHTTPClient(svc.IdentityClient))
+		identity := dphandlers.IdentityWithHTTPClient(svc.IdentityClient)
+		if svc.authz != nil {
+			identity = svc.authz.Identity(identity)
+		}
+		middleware = middleware.Append(identity)
 	}
 
 	return middleware
@@ -171,6 +313,27 @@ func (svc *Service) Close(ctx context.Context) error {
 
 		// ADD CODE HERE: Close other dependencies, in the expected order
 
+		// stop following the change stream used to invalidate the cache
+		if svc.stopCacheWatch != nil {
+			svc.stopCacheWatch()
+		}
+
+		// stop updating the permissions bundle
+		if svc.authz != nil {
+			if err := svc.authz.Close(ctx); err != nil {
+				log.Error(ctx, "failed to close authorisation", err)
+				hasShutdownError = true
+			}
+		}
+
+		// wait for in-flight webhook deliveries, which record their outcome in mongoDB
+		if svc.webhooks != nil {
+			if err := svc.webhooks.Close(ctx); err != nil {
+				log.Error(ctx, "failed to complete webhook deliveries", err)
+				hasShutdownError = true
+			}
+		}
+
 		// close mongoDB
 		if svc.ServiceList.MongoDB {
 			if err := svc.mongoDB.Close(ctx); err != nil {
@@ -178,6 +341,14 @@ func (svc *Service) Close(ctx context.Context) error {
 				hasShutdownError = true
 			}
 		}
+
+		// export the remaining spans, including those of closing everything else
+		if svc.stopTracing != nil {
+			if err := svc.stopTracing(ctx); err != nil {
+				log.Error(ctx, "failed to shutdown tracing", err)
+				hasShutdownError = true
+			}
+		}
 	}()
 
 	// wait for shutdown success (via cancel) or failure (timeout)
@@ -214,11 +385,25 @@ func (svc *Service) registerCheckers(ctx context.Context) (err error) {
 		}
 	}
 
+	if svc.authz != nil {
+		if err = svc.HealthCheck.AddCheck("Permissions", svc.authz.Checker); err != nil {
+			hasErrors = true
+			log.Error(ctx, "error adding check for permissions", err)
+		}
+	}
+
 	if err = svc.HealthCheck.AddCheck("Mongo DB", svc.mongoDB.Checker); err != nil {
 		hasErrors = true
 		log.Error(ctx, "error adding check for mongo db", err)
 	}
 
+	if db, ok := svc.mongoDB.(schemaEnsurer); ok {
+		if err = svc.HealthCheck.AddCheck("Mongo DB schema", db.SchemaChecker); err != nil {
+			hasErrors = true
+			log.Error(ctx, "error adding check for mongo db schema", err)
+		}
+	}
+
 	if hasErrors {
 		return errors.New("Error(s) registering checkers for healthcheck")
 	}
@@ -32,7 +32,7 @@ var (
 	errHealthcheck = errors.New("healthCheck error")
 )
 
-var funcDoGetMongoDBErr = func(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) {
+var funcDoGetMongoDBErr = func(ctx context.Context, cfg *config.Config) (store.MongoDB, error) {
 	return nil, errMongoDB
 }
 
@@ -76,7 +76,7 @@ func TestRun(t *testing.T) {
 			},
 		}
 
-		funcDoGetMongoDBOk := func(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) {
+		funcDoGetMongoDBOk := func(ctx context.Context, cfg *config.Config) (store.MongoDB, error) {
 			return mongoDBMock, nil
 		}
 
@@ -283,7 +283,7 @@ func TestClose(t *testing.T) {
 		Convey("Closing the service results in all the dependencies being closed in the expected order", func() {
 			initMock := &serviceMock.InitialiserMock{
 				DoGetHTTPServerFunc: func(bindAddr string, router http.Handler) service.HTTPServer { return serverMock },
-				DoGetMongoDBFunc:    func(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) { return mongoDBMock, nil },
+				DoGetMongoDBFunc:    func(ctx context.Context, cfg *config.Config) (store.MongoDB, error) { return mongoDBMock, nil },
 				DoGetHealthCheckFunc: func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
 					return hcMock, nil
 				},
@@ -313,7 +313,7 @@ func TestClose(t *testing.T) {
 
 			initMock := &serviceMock.InitialiserMock{
 				DoGetHTTPServerFunc: func(bindAddr string, router http.Handler) service.HTTPServer { return failingServerMock },
-				DoGetMongoDBFunc:    func(ctx context.Context, cfg config.MongoConfig) (store.MongoDB, error) { return mongoDBMock, nil },
+				DoGetMongoDBFunc:    func(ctx context.Context, cfg *config.Config) (store.MongoDB, error) { return mongoDBMock, nil },
 				DoGetHealthCheckFunc: func(cfg *config.Config, buildTime string, gitCommit string, version string) (service.HealthChecker, error) {
 					return hcMock, nil
 				},
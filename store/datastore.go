@@ -20,11 +20,40 @@ type DataStore struct {
 type dataMongoDB interface {
 	GetTopic(ctx context.Context, id string) (*models.TopicResponse, error)
 	CheckTopicExists(ctx context.Context, id string) error
+	IsSlugInUse(ctx context.Context, id, slug string) (bool, error)
 	GetContent(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error)
 	UpdateReleaseDate(ctx context.Context, id string, releaseDate time.Time) error
 	UpdateState(ctx context.Context, id, state string) error
+	UpdateReview(ctx context.Context, id, state string, review *models.Review) error
 	UpsertTopic(ctx context.Context, id string, topic *models.TopicResponse) error
 	UpdateTopic(ctx context.Context, host, id string, topic *models.TopicUpdate) error
+	UpdateOwners(ctx context.Context, id string, owners []string) error
+	GetAllTopics(ctx context.Context) ([]models.TopicResponse, error)
+	GetArchivedTopicIDs(ctx context.Context) ([]string, error)
+	GetAllContent(ctx context.Context) ([]models.ContentResponse, error)
+	InsertTopic(ctx context.Context, topic *models.TopicResponse) error
+	InsertContent(ctx context.Context, content *models.ContentResponse) error
+	UpsertContent(ctx context.Context, id string, content *models.ContentResponse) error
+	ReplaceTopic(ctx context.Context, topic *models.TopicResponse) error
+	ReplaceContent(ctx context.Context, content *models.ContentResponse) error
+	DeleteTopic(ctx context.Context, id string) error
+	DeleteContent(ctx context.Context, id string) error
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	GetWebhook(ctx context.Context, id string) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	UpsertWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error)
+	InsertPublication(ctx context.Context, publication *models.Publication) error
+	GetPublications(ctx context.Context, topicID string, limit int) ([]models.Publication, error)
+	CreateCollection(ctx context.Context, collection *models.Collection) error
+	GetCollection(ctx context.Context, id string) (*models.Collection, error)
+	GetCollections(ctx context.Context) ([]models.Collection, error)
+	UpsertCollection(ctx context.Context, collection *models.Collection) error
+	GetRedirect(ctx context.Context, from string) (*models.Redirect, error)
+	GetRedirects(ctx context.Context) ([]models.Redirect, error)
+	UpsertRedirect(ctx context.Context, redirect *models.Redirect) error
+	DeleteRedirect(ctx context.Context, from string) error
 }
 
 // MongoDB represents all the required methods from mongo DB
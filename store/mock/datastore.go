@@ -11,75 +11,239 @@ import (
 	"time"
 )
 
-var (
-	lockStorerMockCheckTopicExists  sync.RWMutex
-	lockStorerMockGetContent        sync.RWMutex
-	lockStorerMockGetTopic          sync.RWMutex
-	lockStorerMockUpdateReleaseDate sync.RWMutex
-	lockStorerMockUpdateState       sync.RWMutex
-	lockStorerMockUpdateTopic       sync.RWMutex
-	lockStorerMockUpsertTopic       sync.RWMutex
-)
-
 // Ensure, that StorerMock does implement store.Storer.
 // If this is not the case, regenerate this file with moq.
 var _ store.Storer = &StorerMock{}
 
 // StorerMock is a mock implementation of store.Storer.
 //
-//     func TestSomethingThatUsesStorer(t *testing.T) {
-//
-//         // make and configure a mocked store.Storer
-//         mockedStorer := &StorerMock{
-//             CheckTopicExistsFunc: func(ctx context.Context, id string) error {
-// 	               panic("mock out the CheckTopicExists method")
-//             },
-//             GetContentFunc: func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
-// 	               panic("mock out the GetContent method")
-//             },
-//             GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
-// 	               panic("mock out the GetTopic method")
-//             },
-//             UpdateReleaseDateFunc: func(ctx context.Context, id string, releaseDate time.Time) error {
-// 	               panic("mock out the UpdateReleaseDate method")
-//             },
-//             UpdateStateFunc: func(ctx context.Context, id string, state string) error {
-// 	               panic("mock out the UpdateState method")
-//             },
-//             UpdateTopicFunc: func(ctx context.Context, host string, id string, topic *models.TopicUpdate) error {
-// 	               panic("mock out the UpdateTopic method")
-//             },
-//             UpsertTopicFunc: func(ctx context.Context, id string, topic *models.TopicResponse) error {
-// 	               panic("mock out the UpsertTopic method")
-//             },
-//         }
-//
-//         // use mockedStorer in code that requires store.Storer
-//         // and then make assertions.
-//
-//     }
+//	func TestSomethingThatUsesStorer(t *testing.T) {
+//
+//		// make and configure a mocked store.Storer
+//		mockedStorer := &StorerMock{
+//			CheckTopicExistsFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the CheckTopicExists method")
+//			},
+//			CreateCollectionFunc: func(ctx context.Context, collection *models.Collection) error {
+//				panic("mock out the CreateCollection method")
+//			},
+//			CreateWebhookFunc: func(ctx context.Context, webhook *models.Webhook) error {
+//				panic("mock out the CreateWebhook method")
+//			},
+//			DeleteContentFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the DeleteContent method")
+//			},
+//			DeleteRedirectFunc: func(ctx context.Context, from string) error {
+//				panic("mock out the DeleteRedirect method")
+//			},
+//			DeleteTopicFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the DeleteTopic method")
+//			},
+//			DeleteWebhookFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the DeleteWebhook method")
+//			},
+//			GetAllContentFunc: func(ctx context.Context) ([]models.ContentResponse, error) {
+//				panic("mock out the GetAllContent method")
+//			},
+//			GetAllTopicsFunc: func(ctx context.Context) ([]models.TopicResponse, error) {
+//				panic("mock out the GetAllTopics method")
+//			},
+//			GetArchivedTopicIDsFunc: func(ctx context.Context) ([]string, error) {
+//				panic("mock out the GetArchivedTopicIDs method")
+//			},
+//			GetCollectionFunc: func(ctx context.Context, id string) (*models.Collection, error) {
+//				panic("mock out the GetCollection method")
+//			},
+//			GetCollectionsFunc: func(ctx context.Context) ([]models.Collection, error) {
+//				panic("mock out the GetCollections method")
+//			},
+//			GetContentFunc: func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+//				panic("mock out the GetContent method")
+//			},
+//			GetPublicationsFunc: func(ctx context.Context, topicID string, limit int) ([]models.Publication, error) {
+//				panic("mock out the GetPublications method")
+//			},
+//			GetRedirectFunc: func(ctx context.Context, from string) (*models.Redirect, error) {
+//				panic("mock out the GetRedirect method")
+//			},
+//			GetRedirectsFunc: func(ctx context.Context) ([]models.Redirect, error) {
+//				panic("mock out the GetRedirects method")
+//			},
+//			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+//				panic("mock out the GetTopic method")
+//			},
+//			GetWebhookFunc: func(ctx context.Context, id string) (*models.Webhook, error) {
+//				panic("mock out the GetWebhook method")
+//			},
+//			GetWebhookDeliveriesFunc: func(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+//				panic("mock out the GetWebhookDeliveries method")
+//			},
+//			GetWebhooksFunc: func(ctx context.Context) ([]models.Webhook, error) {
+//				panic("mock out the GetWebhooks method")
+//			},
+//			InsertContentFunc: func(ctx context.Context, content *models.ContentResponse) error {
+//				panic("mock out the InsertContent method")
+//			},
+//			InsertPublicationFunc: func(ctx context.Context, publication *models.Publication) error {
+//				panic("mock out the InsertPublication method")
+//			},
+//			InsertTopicFunc: func(ctx context.Context, topic *models.TopicResponse) error {
+//				panic("mock out the InsertTopic method")
+//			},
+//			IsSlugInUseFunc: func(ctx context.Context, id string, slug string) (bool, error) {
+//				panic("mock out the IsSlugInUse method")
+//			},
+//			ReplaceContentFunc: func(ctx context.Context, content *models.ContentResponse) error {
+//				panic("mock out the ReplaceContent method")
+//			},
+//			ReplaceTopicFunc: func(ctx context.Context, topic *models.TopicResponse) error {
+//				panic("mock out the ReplaceTopic method")
+//			},
+//			UpdateOwnersFunc: func(ctx context.Context, id string, owners []string) error {
+//				panic("mock out the UpdateOwners method")
+//			},
+//			UpdateReleaseDateFunc: func(ctx context.Context, id string, releaseDate time.Time) error {
+//				panic("mock out the UpdateReleaseDate method")
+//			},
+//			UpdateReviewFunc: func(ctx context.Context, id string, state string, review *models.Review) error {
+//				panic("mock out the UpdateReview method")
+//			},
+//			UpdateStateFunc: func(ctx context.Context, id string, state string) error {
+//				panic("mock out the UpdateState method")
+//			},
+//			UpdateTopicFunc: func(ctx context.Context, host string, id string, topic *models.TopicUpdate) error {
+//				panic("mock out the UpdateTopic method")
+//			},
+//			UpsertCollectionFunc: func(ctx context.Context, collection *models.Collection) error {
+//				panic("mock out the UpsertCollection method")
+//			},
+//			UpsertContentFunc: func(ctx context.Context, id string, content *models.ContentResponse) error {
+//				panic("mock out the UpsertContent method")
+//			},
+//			UpsertRedirectFunc: func(ctx context.Context, redirect *models.Redirect) error {
+//				panic("mock out the UpsertRedirect method")
+//			},
+//			UpsertTopicFunc: func(ctx context.Context, id string, topic *models.TopicResponse) error {
+//				panic("mock out the UpsertTopic method")
+//			},
+//			UpsertWebhookDeliveryFunc: func(ctx context.Context, delivery *models.WebhookDelivery) error {
+//				panic("mock out the UpsertWebhookDelivery method")
+//			},
+//		}
+//
+//		// use mockedStorer in code that requires store.Storer
+//		// and then make assertions.
+//
+//	}
 type StorerMock struct {
 	// CheckTopicExistsFunc mocks the CheckTopicExists method.
 	CheckTopicExistsFunc func(ctx context.Context, id string) error
 
+	// CreateCollectionFunc mocks the CreateCollection method.
+	CreateCollectionFunc func(ctx context.Context, collection *models.Collection) error
+
+	// CreateWebhookFunc mocks the CreateWebhook method.
+	CreateWebhookFunc func(ctx context.Context, webhook *models.Webhook) error
+
+	// DeleteContentFunc mocks the DeleteContent method.
+	DeleteContentFunc func(ctx context.Context, id string) error
+
+	// DeleteRedirectFunc mocks the DeleteRedirect method.
+	DeleteRedirectFunc func(ctx context.Context, from string) error
+
+	// DeleteTopicFunc mocks the DeleteTopic method.
+	DeleteTopicFunc func(ctx context.Context, id string) error
+
+	// DeleteWebhookFunc mocks the DeleteWebhook method.
+	DeleteWebhookFunc func(ctx context.Context, id string) error
+
+	// GetAllContentFunc mocks the GetAllContent method.
+	GetAllContentFunc func(ctx context.Context) ([]models.ContentResponse, error)
+
+	// GetAllTopicsFunc mocks the GetAllTopics method.
+	GetAllTopicsFunc func(ctx context.Context) ([]models.TopicResponse, error)
+
+	// GetArchivedTopicIDsFunc mocks the GetArchivedTopicIDs method.
+	GetArchivedTopicIDsFunc func(ctx context.Context) ([]string, error)
+
+	// GetCollectionFunc mocks the GetCollection method.
+	GetCollectionFunc func(ctx context.Context, id string) (*models.Collection, error)
+
+	// GetCollectionsFunc mocks the GetCollections method.
+	GetCollectionsFunc func(ctx context.Context) ([]models.Collection, error)
+
 	// GetContentFunc mocks the GetContent method.
 	GetContentFunc func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error)
 
+	// GetPublicationsFunc mocks the GetPublications method.
+	GetPublicationsFunc func(ctx context.Context, topicID string, limit int) ([]models.Publication, error)
+
+	// GetRedirectFunc mocks the GetRedirect method.
+	GetRedirectFunc func(ctx context.Context, from string) (*models.Redirect, error)
+
+	// GetRedirectsFunc mocks the GetRedirects method.
+	GetRedirectsFunc func(ctx context.Context) ([]models.Redirect, error)
+
 	// GetTopicFunc mocks the GetTopic method.
 	GetTopicFunc func(ctx context.Context, id string) (*models.TopicResponse, error)
 
+	// GetWebhookFunc mocks the GetWebhook method.
+	GetWebhookFunc func(ctx context.Context, id string) (*models.Webhook, error)
+
+	// GetWebhookDeliveriesFunc mocks the GetWebhookDeliveries method.
+	GetWebhookDeliveriesFunc func(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error)
+
+	// GetWebhooksFunc mocks the GetWebhooks method.
+	GetWebhooksFunc func(ctx context.Context) ([]models.Webhook, error)
+
+	// InsertContentFunc mocks the InsertContent method.
+	InsertContentFunc func(ctx context.Context, content *models.ContentResponse) error
+
+	// InsertPublicationFunc mocks the InsertPublication method.
+	InsertPublicationFunc func(ctx context.Context, publication *models.Publication) error
+
+	// InsertTopicFunc mocks the InsertTopic method.
+	InsertTopicFunc func(ctx context.Context, topic *models.TopicResponse) error
+
+	// IsSlugInUseFunc mocks the IsSlugInUse method.
+	IsSlugInUseFunc func(ctx context.Context, id string, slug string) (bool, error)
+
+	// ReplaceContentFunc mocks the ReplaceContent method.
+	ReplaceContentFunc func(ctx context.Context, content *models.ContentResponse) error
+
+	// ReplaceTopicFunc mocks the ReplaceTopic method.
+	ReplaceTopicFunc func(ctx context.Context, topic *models.TopicResponse) error
+
+	// UpdateOwnersFunc mocks the UpdateOwners method.
+	UpdateOwnersFunc func(ctx context.Context, id string, owners []string) error
+
 	// UpdateReleaseDateFunc mocks the UpdateReleaseDate method.
 	UpdateReleaseDateFunc func(ctx context.Context, id string, releaseDate time.Time) error
 
+	// UpdateReviewFunc mocks the UpdateReview method.
+	UpdateReviewFunc func(ctx context.Context, id string, state string, review *models.Review) error
+
 	// UpdateStateFunc mocks the UpdateState method.
 	UpdateStateFunc func(ctx context.Context, id string, state string) error
 
 	// UpdateTopicFunc mocks the UpdateTopic method.
 	UpdateTopicFunc func(ctx context.Context, host string, id string, topic *models.TopicUpdate) error
 
+	// UpsertCollectionFunc mocks the UpsertCollection method.
+	UpsertCollectionFunc func(ctx context.Context, collection *models.Collection) error
+
+	// UpsertContentFunc mocks the UpsertContent method.
+	UpsertContentFunc func(ctx context.Context, id string, content *models.ContentResponse) error
+
+	// UpsertRedirectFunc mocks the UpsertRedirect method.
+	UpsertRedirectFunc func(ctx context.Context, redirect *models.Redirect) error
+
 	// UpsertTopicFunc mocks the UpsertTopic method.
 	UpsertTopicFunc func(ctx context.Context, id string, topic *models.TopicResponse) error
 
+	// UpsertWebhookDeliveryFunc mocks the UpsertWebhookDelivery method.
+	UpsertWebhookDeliveryFunc func(ctx context.Context, delivery *models.WebhookDelivery) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CheckTopicExists holds details about calls to the CheckTopicExists method.
@@ -89,6 +253,75 @@ type StorerMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// CreateCollection holds details about calls to the CreateCollection method.
+		CreateCollection []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Collection is the collection argument value.
+			Collection *models.Collection
+		}
+		// CreateWebhook holds details about calls to the CreateWebhook method.
+		CreateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Webhook is the webhook argument value.
+			Webhook *models.Webhook
+		}
+		// DeleteContent holds details about calls to the DeleteContent method.
+		DeleteContent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// DeleteRedirect holds details about calls to the DeleteRedirect method.
+		DeleteRedirect []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// From is the from argument value.
+			From string
+		}
+		// DeleteTopic holds details about calls to the DeleteTopic method.
+		DeleteTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// DeleteWebhook holds details about calls to the DeleteWebhook method.
+		DeleteWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// GetAllContent holds details about calls to the GetAllContent method.
+		GetAllContent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetAllTopics holds details about calls to the GetAllTopics method.
+		GetAllTopics []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetArchivedTopicIDs holds details about calls to the GetArchivedTopicIDs method.
+		GetArchivedTopicIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetCollection holds details about calls to the GetCollection method.
+		GetCollection []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// GetCollections holds details about calls to the GetCollections method.
+		GetCollections []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetContent holds details about calls to the GetContent method.
 		GetContent []struct {
 			// Ctx is the ctx argument value.
@@ -98,6 +331,27 @@ type StorerMock struct {
 			// QueryTypeFlags is the queryTypeFlags argument value.
 			QueryTypeFlags int
 		}
+		// GetPublications holds details about calls to the GetPublications method.
+		GetPublications []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TopicID is the topicID argument value.
+			TopicID string
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetRedirect holds details about calls to the GetRedirect method.
+		GetRedirect []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// From is the from argument value.
+			From string
+		}
+		// GetRedirects holds details about calls to the GetRedirects method.
+		GetRedirects []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetTopic holds details about calls to the GetTopic method.
 		GetTopic []struct {
 			// Ctx is the ctx argument value.
@@ -105,6 +359,80 @@ type StorerMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// GetWebhook holds details about calls to the GetWebhook method.
+		GetWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// GetWebhookDeliveries holds details about calls to the GetWebhookDeliveries method.
+		GetWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WebhookID is the webhookID argument value.
+			WebhookID string
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetWebhooks holds details about calls to the GetWebhooks method.
+		GetWebhooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// InsertContent holds details about calls to the InsertContent method.
+		InsertContent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Content is the content argument value.
+			Content *models.ContentResponse
+		}
+		// InsertPublication holds details about calls to the InsertPublication method.
+		InsertPublication []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Publication is the publication argument value.
+			Publication *models.Publication
+		}
+		// InsertTopic holds details about calls to the InsertTopic method.
+		InsertTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Topic is the topic argument value.
+			Topic *models.TopicResponse
+		}
+		// IsSlugInUse holds details about calls to the IsSlugInUse method.
+		IsSlugInUse []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Slug is the slug argument value.
+			Slug string
+		}
+		// ReplaceContent holds details about calls to the ReplaceContent method.
+		ReplaceContent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Content is the content argument value.
+			Content *models.ContentResponse
+		}
+		// ReplaceTopic holds details about calls to the ReplaceTopic method.
+		ReplaceTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Topic is the topic argument value.
+			Topic *models.TopicResponse
+		}
+		// UpdateOwners holds details about calls to the UpdateOwners method.
+		UpdateOwners []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Owners is the owners argument value.
+			Owners []string
+		}
 		// UpdateReleaseDate holds details about calls to the UpdateReleaseDate method.
 		UpdateReleaseDate []struct {
 			// Ctx is the ctx argument value.
@@ -114,6 +442,17 @@ type StorerMock struct {
 			// ReleaseDate is the releaseDate argument value.
 			ReleaseDate time.Time
 		}
+		// UpdateReview holds details about calls to the UpdateReview method.
+		UpdateReview []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// State is the state argument value.
+			State string
+			// Review is the review argument value.
+			Review *models.Review
+		}
 		// UpdateState holds details about calls to the UpdateState method.
 		UpdateState []struct {
 			// Ctx is the ctx argument value.
@@ -134,6 +473,29 @@ type StorerMock struct {
 			// Topic is the topic argument value.
 			Topic *models.TopicUpdate
 		}
+		// UpsertCollection holds details about calls to the UpsertCollection method.
+		UpsertCollection []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Collection is the collection argument value.
+			Collection *models.Collection
+		}
+		// UpsertContent holds details about calls to the UpsertContent method.
+		UpsertContent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Content is the content argument value.
+			Content *models.ContentResponse
+		}
+		// UpsertRedirect holds details about calls to the UpsertRedirect method.
+		UpsertRedirect []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Redirect is the redirect argument value.
+			Redirect *models.Redirect
+		}
 		// UpsertTopic holds details about calls to the UpsertTopic method.
 		UpsertTopic []struct {
 			// Ctx is the ctx argument value.
@@ -143,13 +505,656 @@ type StorerMock struct {
 			// Topic is the topic argument value.
 			Topic *models.TopicResponse
 		}
+		// UpsertWebhookDelivery holds details about calls to the UpsertWebhookDelivery method.
+		UpsertWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Delivery is the delivery argument value.
+			Delivery *models.WebhookDelivery
+		}
+	}
+	lockCheckTopicExists      sync.RWMutex
+	lockCreateCollection      sync.RWMutex
+	lockCreateWebhook         sync.RWMutex
+	lockDeleteContent         sync.RWMutex
+	lockDeleteRedirect        sync.RWMutex
+	lockDeleteTopic           sync.RWMutex
+	lockDeleteWebhook         sync.RWMutex
+	lockGetAllContent         sync.RWMutex
+	lockGetAllTopics          sync.RWMutex
+	lockGetArchivedTopicIDs   sync.RWMutex
+	lockGetCollection         sync.RWMutex
+	lockGetCollections        sync.RWMutex
+	lockGetContent            sync.RWMutex
+	lockGetPublications       sync.RWMutex
+	lockGetRedirect           sync.RWMutex
+	lockGetRedirects          sync.RWMutex
+	lockGetTopic              sync.RWMutex
+	lockGetWebhook            sync.RWMutex
+	lockGetWebhookDeliveries  sync.RWMutex
+	lockGetWebhooks           sync.RWMutex
+	lockInsertContent         sync.RWMutex
+	lockInsertPublication     sync.RWMutex
+	lockInsertTopic           sync.RWMutex
+	lockIsSlugInUse           sync.RWMutex
+	lockReplaceContent        sync.RWMutex
+	lockReplaceTopic          sync.RWMutex
+	lockUpdateOwners          sync.RWMutex
+	lockUpdateReleaseDate     sync.RWMutex
+	lockUpdateReview          sync.RWMutex
+	lockUpdateState           sync.RWMutex
+	lockUpdateTopic           sync.RWMutex
+	lockUpsertCollection      sync.RWMutex
+	lockUpsertContent         sync.RWMutex
+	lockUpsertRedirect        sync.RWMutex
+	lockUpsertTopic           sync.RWMutex
+	lockUpsertWebhookDelivery sync.RWMutex
+}
+
+// CheckTopicExists calls CheckTopicExistsFunc.
+func (mock *StorerMock) CheckTopicExists(ctx context.Context, id string) error {
+	if mock.CheckTopicExistsFunc == nil {
+		panic("StorerMock.CheckTopicExistsFunc: method is nil but Storer.CheckTopicExists was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockCheckTopicExists.Lock()
+	mock.calls.CheckTopicExists = append(mock.calls.CheckTopicExists, callInfo)
+	mock.lockCheckTopicExists.Unlock()
+	return mock.CheckTopicExistsFunc(ctx, id)
+}
+
+// CheckTopicExistsCalls gets all the calls that were made to CheckTopicExists.
+// Check the length with:
+//
+//	len(mockedStorer.CheckTopicExistsCalls())
+func (mock *StorerMock) CheckTopicExistsCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockCheckTopicExists.RLock()
+	calls = mock.calls.CheckTopicExists
+	mock.lockCheckTopicExists.RUnlock()
+	return calls
+}
+
+// CreateCollection calls CreateCollectionFunc.
+func (mock *StorerMock) CreateCollection(ctx context.Context, collection *models.Collection) error {
+	if mock.CreateCollectionFunc == nil {
+		panic("StorerMock.CreateCollectionFunc: method is nil but Storer.CreateCollection was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Collection *models.Collection
+	}{
+		Ctx:        ctx,
+		Collection: collection,
+	}
+	mock.lockCreateCollection.Lock()
+	mock.calls.CreateCollection = append(mock.calls.CreateCollection, callInfo)
+	mock.lockCreateCollection.Unlock()
+	return mock.CreateCollectionFunc(ctx, collection)
+}
+
+// CreateCollectionCalls gets all the calls that were made to CreateCollection.
+// Check the length with:
+//
+//	len(mockedStorer.CreateCollectionCalls())
+func (mock *StorerMock) CreateCollectionCalls() []struct {
+	Ctx        context.Context
+	Collection *models.Collection
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Collection *models.Collection
+	}
+	mock.lockCreateCollection.RLock()
+	calls = mock.calls.CreateCollection
+	mock.lockCreateCollection.RUnlock()
+	return calls
+}
+
+// CreateWebhook calls CreateWebhookFunc.
+func (mock *StorerMock) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if mock.CreateWebhookFunc == nil {
+		panic("StorerMock.CreateWebhookFunc: method is nil but Storer.CreateWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Webhook *models.Webhook
+	}{
+		Ctx:     ctx,
+		Webhook: webhook,
+	}
+	mock.lockCreateWebhook.Lock()
+	mock.calls.CreateWebhook = append(mock.calls.CreateWebhook, callInfo)
+	mock.lockCreateWebhook.Unlock()
+	return mock.CreateWebhookFunc(ctx, webhook)
+}
+
+// CreateWebhookCalls gets all the calls that were made to CreateWebhook.
+// Check the length with:
+//
+//	len(mockedStorer.CreateWebhookCalls())
+func (mock *StorerMock) CreateWebhookCalls() []struct {
+	Ctx     context.Context
+	Webhook *models.Webhook
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Webhook *models.Webhook
+	}
+	mock.lockCreateWebhook.RLock()
+	calls = mock.calls.CreateWebhook
+	mock.lockCreateWebhook.RUnlock()
+	return calls
+}
+
+// DeleteContent calls DeleteContentFunc.
+func (mock *StorerMock) DeleteContent(ctx context.Context, id string) error {
+	if mock.DeleteContentFunc == nil {
+		panic("StorerMock.DeleteContentFunc: method is nil but Storer.DeleteContent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteContent.Lock()
+	mock.calls.DeleteContent = append(mock.calls.DeleteContent, callInfo)
+	mock.lockDeleteContent.Unlock()
+	return mock.DeleteContentFunc(ctx, id)
+}
+
+// DeleteContentCalls gets all the calls that were made to DeleteContent.
+// Check the length with:
+//
+//	len(mockedStorer.DeleteContentCalls())
+func (mock *StorerMock) DeleteContentCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockDeleteContent.RLock()
+	calls = mock.calls.DeleteContent
+	mock.lockDeleteContent.RUnlock()
+	return calls
+}
+
+// DeleteRedirect calls DeleteRedirectFunc.
+func (mock *StorerMock) DeleteRedirect(ctx context.Context, from string) error {
+	if mock.DeleteRedirectFunc == nil {
+		panic("StorerMock.DeleteRedirectFunc: method is nil but Storer.DeleteRedirect was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		From string
+	}{
+		Ctx:  ctx,
+		From: from,
+	}
+	mock.lockDeleteRedirect.Lock()
+	mock.calls.DeleteRedirect = append(mock.calls.DeleteRedirect, callInfo)
+	mock.lockDeleteRedirect.Unlock()
+	return mock.DeleteRedirectFunc(ctx, from)
+}
+
+// DeleteRedirectCalls gets all the calls that were made to DeleteRedirect.
+// Check the length with:
+//
+//	len(mockedStorer.DeleteRedirectCalls())
+func (mock *StorerMock) DeleteRedirectCalls() []struct {
+	Ctx  context.Context
+	From string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		From string
+	}
+	mock.lockDeleteRedirect.RLock()
+	calls = mock.calls.DeleteRedirect
+	mock.lockDeleteRedirect.RUnlock()
+	return calls
+}
+
+// DeleteTopic calls DeleteTopicFunc.
+func (mock *StorerMock) DeleteTopic(ctx context.Context, id string) error {
+	if mock.DeleteTopicFunc == nil {
+		panic("StorerMock.DeleteTopicFunc: method is nil but Storer.DeleteTopic was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteTopic.Lock()
+	mock.calls.DeleteTopic = append(mock.calls.DeleteTopic, callInfo)
+	mock.lockDeleteTopic.Unlock()
+	return mock.DeleteTopicFunc(ctx, id)
+}
+
+// DeleteTopicCalls gets all the calls that were made to DeleteTopic.
+// Check the length with:
+//
+//	len(mockedStorer.DeleteTopicCalls())
+func (mock *StorerMock) DeleteTopicCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockDeleteTopic.RLock()
+	calls = mock.calls.DeleteTopic
+	mock.lockDeleteTopic.RUnlock()
+	return calls
+}
+
+// DeleteWebhook calls DeleteWebhookFunc.
+func (mock *StorerMock) DeleteWebhook(ctx context.Context, id string) error {
+	if mock.DeleteWebhookFunc == nil {
+		panic("StorerMock.DeleteWebhookFunc: method is nil but Storer.DeleteWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteWebhook.Lock()
+	mock.calls.DeleteWebhook = append(mock.calls.DeleteWebhook, callInfo)
+	mock.lockDeleteWebhook.Unlock()
+	return mock.DeleteWebhookFunc(ctx, id)
+}
+
+// DeleteWebhookCalls gets all the calls that were made to DeleteWebhook.
+// Check the length with:
+//
+//	len(mockedStorer.DeleteWebhookCalls())
+func (mock *StorerMock) DeleteWebhookCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockDeleteWebhook.RLock()
+	calls = mock.calls.DeleteWebhook
+	mock.lockDeleteWebhook.RUnlock()
+	return calls
+}
+
+// GetAllContent calls GetAllContentFunc.
+func (mock *StorerMock) GetAllContent(ctx context.Context) ([]models.ContentResponse, error) {
+	if mock.GetAllContentFunc == nil {
+		panic("StorerMock.GetAllContentFunc: method is nil but Storer.GetAllContent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAllContent.Lock()
+	mock.calls.GetAllContent = append(mock.calls.GetAllContent, callInfo)
+	mock.lockGetAllContent.Unlock()
+	return mock.GetAllContentFunc(ctx)
+}
+
+// GetAllContentCalls gets all the calls that were made to GetAllContent.
+// Check the length with:
+//
+//	len(mockedStorer.GetAllContentCalls())
+func (mock *StorerMock) GetAllContentCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAllContent.RLock()
+	calls = mock.calls.GetAllContent
+	mock.lockGetAllContent.RUnlock()
+	return calls
+}
+
+// GetAllTopics calls GetAllTopicsFunc.
+func (mock *StorerMock) GetAllTopics(ctx context.Context) ([]models.TopicResponse, error) {
+	if mock.GetAllTopicsFunc == nil {
+		panic("StorerMock.GetAllTopicsFunc: method is nil but Storer.GetAllTopics was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAllTopics.Lock()
+	mock.calls.GetAllTopics = append(mock.calls.GetAllTopics, callInfo)
+	mock.lockGetAllTopics.Unlock()
+	return mock.GetAllTopicsFunc(ctx)
+}
+
+// GetAllTopicsCalls gets all the calls that were made to GetAllTopics.
+// Check the length with:
+//
+//	len(mockedStorer.GetAllTopicsCalls())
+func (mock *StorerMock) GetAllTopicsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAllTopics.RLock()
+	calls = mock.calls.GetAllTopics
+	mock.lockGetAllTopics.RUnlock()
+	return calls
+}
+
+// GetArchivedTopicIDs calls GetArchivedTopicIDsFunc.
+func (mock *StorerMock) GetArchivedTopicIDs(ctx context.Context) ([]string, error) {
+	if mock.GetArchivedTopicIDsFunc == nil {
+		panic("StorerMock.GetArchivedTopicIDsFunc: method is nil but Storer.GetArchivedTopicIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetArchivedTopicIDs.Lock()
+	mock.calls.GetArchivedTopicIDs = append(mock.calls.GetArchivedTopicIDs, callInfo)
+	mock.lockGetArchivedTopicIDs.Unlock()
+	return mock.GetArchivedTopicIDsFunc(ctx)
+}
+
+// GetArchivedTopicIDsCalls gets all the calls that were made to GetArchivedTopicIDs.
+// Check the length with:
+//
+//	len(mockedStorer.GetArchivedTopicIDsCalls())
+func (mock *StorerMock) GetArchivedTopicIDsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetArchivedTopicIDs.RLock()
+	calls = mock.calls.GetArchivedTopicIDs
+	mock.lockGetArchivedTopicIDs.RUnlock()
+	return calls
+}
+
+// GetCollection calls GetCollectionFunc.
+func (mock *StorerMock) GetCollection(ctx context.Context, id string) (*models.Collection, error) {
+	if mock.GetCollectionFunc == nil {
+		panic("StorerMock.GetCollectionFunc: method is nil but Storer.GetCollection was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetCollection.Lock()
+	mock.calls.GetCollection = append(mock.calls.GetCollection, callInfo)
+	mock.lockGetCollection.Unlock()
+	return mock.GetCollectionFunc(ctx, id)
+}
+
+// GetCollectionCalls gets all the calls that were made to GetCollection.
+// Check the length with:
+//
+//	len(mockedStorer.GetCollectionCalls())
+func (mock *StorerMock) GetCollectionCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetCollection.RLock()
+	calls = mock.calls.GetCollection
+	mock.lockGetCollection.RUnlock()
+	return calls
+}
+
+// GetCollections calls GetCollectionsFunc.
+func (mock *StorerMock) GetCollections(ctx context.Context) ([]models.Collection, error) {
+	if mock.GetCollectionsFunc == nil {
+		panic("StorerMock.GetCollectionsFunc: method is nil but Storer.GetCollections was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetCollections.Lock()
+	mock.calls.GetCollections = append(mock.calls.GetCollections, callInfo)
+	mock.lockGetCollections.Unlock()
+	return mock.GetCollectionsFunc(ctx)
+}
+
+// GetCollectionsCalls gets all the calls that were made to GetCollections.
+// Check the length with:
+//
+//	len(mockedStorer.GetCollectionsCalls())
+func (mock *StorerMock) GetCollectionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetCollections.RLock()
+	calls = mock.calls.GetCollections
+	mock.lockGetCollections.RUnlock()
+	return calls
+}
+
+// GetContent calls GetContentFunc.
+func (mock *StorerMock) GetContent(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+	if mock.GetContentFunc == nil {
+		panic("StorerMock.GetContentFunc: method is nil but Storer.GetContent was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		ID             string
+		QueryTypeFlags int
+	}{
+		Ctx:            ctx,
+		ID:             id,
+		QueryTypeFlags: queryTypeFlags,
+	}
+	mock.lockGetContent.Lock()
+	mock.calls.GetContent = append(mock.calls.GetContent, callInfo)
+	mock.lockGetContent.Unlock()
+	return mock.GetContentFunc(ctx, id, queryTypeFlags)
+}
+
+// GetContentCalls gets all the calls that were made to GetContent.
+// Check the length with:
+//
+//	len(mockedStorer.GetContentCalls())
+func (mock *StorerMock) GetContentCalls() []struct {
+	Ctx            context.Context
+	ID             string
+	QueryTypeFlags int
+} {
+	var calls []struct {
+		Ctx            context.Context
+		ID             string
+		QueryTypeFlags int
+	}
+	mock.lockGetContent.RLock()
+	calls = mock.calls.GetContent
+	mock.lockGetContent.RUnlock()
+	return calls
+}
+
+// GetPublications calls GetPublicationsFunc.
+func (mock *StorerMock) GetPublications(ctx context.Context, topicID string, limit int) ([]models.Publication, error) {
+	if mock.GetPublicationsFunc == nil {
+		panic("StorerMock.GetPublicationsFunc: method is nil but Storer.GetPublications was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		TopicID string
+		Limit   int
+	}{
+		Ctx:     ctx,
+		TopicID: topicID,
+		Limit:   limit,
+	}
+	mock.lockGetPublications.Lock()
+	mock.calls.GetPublications = append(mock.calls.GetPublications, callInfo)
+	mock.lockGetPublications.Unlock()
+	return mock.GetPublicationsFunc(ctx, topicID, limit)
+}
+
+// GetPublicationsCalls gets all the calls that were made to GetPublications.
+// Check the length with:
+//
+//	len(mockedStorer.GetPublicationsCalls())
+func (mock *StorerMock) GetPublicationsCalls() []struct {
+	Ctx     context.Context
+	TopicID string
+	Limit   int
+} {
+	var calls []struct {
+		Ctx     context.Context
+		TopicID string
+		Limit   int
+	}
+	mock.lockGetPublications.RLock()
+	calls = mock.calls.GetPublications
+	mock.lockGetPublications.RUnlock()
+	return calls
+}
+
+// GetRedirect calls GetRedirectFunc.
+func (mock *StorerMock) GetRedirect(ctx context.Context, from string) (*models.Redirect, error) {
+	if mock.GetRedirectFunc == nil {
+		panic("StorerMock.GetRedirectFunc: method is nil but Storer.GetRedirect was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		From string
+	}{
+		Ctx:  ctx,
+		From: from,
+	}
+	mock.lockGetRedirect.Lock()
+	mock.calls.GetRedirect = append(mock.calls.GetRedirect, callInfo)
+	mock.lockGetRedirect.Unlock()
+	return mock.GetRedirectFunc(ctx, from)
+}
+
+// GetRedirectCalls gets all the calls that were made to GetRedirect.
+// Check the length with:
+//
+//	len(mockedStorer.GetRedirectCalls())
+func (mock *StorerMock) GetRedirectCalls() []struct {
+	Ctx  context.Context
+	From string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		From string
+	}
+	mock.lockGetRedirect.RLock()
+	calls = mock.calls.GetRedirect
+	mock.lockGetRedirect.RUnlock()
+	return calls
+}
+
+// GetRedirects calls GetRedirectsFunc.
+func (mock *StorerMock) GetRedirects(ctx context.Context) ([]models.Redirect, error) {
+	if mock.GetRedirectsFunc == nil {
+		panic("StorerMock.GetRedirectsFunc: method is nil but Storer.GetRedirects was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetRedirects.Lock()
+	mock.calls.GetRedirects = append(mock.calls.GetRedirects, callInfo)
+	mock.lockGetRedirects.Unlock()
+	return mock.GetRedirectsFunc(ctx)
+}
+
+// GetRedirectsCalls gets all the calls that were made to GetRedirects.
+// Check the length with:
+//
+//	len(mockedStorer.GetRedirectsCalls())
+func (mock *StorerMock) GetRedirectsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetRedirects.RLock()
+	calls = mock.calls.GetRedirects
+	mock.lockGetRedirects.RUnlock()
+	return calls
+}
+
+// GetTopic calls GetTopicFunc.
+func (mock *StorerMock) GetTopic(ctx context.Context, id string) (*models.TopicResponse, error) {
+	if mock.GetTopicFunc == nil {
+		panic("StorerMock.GetTopicFunc: method is nil but Storer.GetTopic was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
 	}
+	mock.lockGetTopic.Lock()
+	mock.calls.GetTopic = append(mock.calls.GetTopic, callInfo)
+	mock.lockGetTopic.Unlock()
+	return mock.GetTopicFunc(ctx, id)
 }
 
-// CheckTopicExists calls CheckTopicExistsFunc.
-func (mock *StorerMock) CheckTopicExists(ctx context.Context, id string) error {
-	if mock.CheckTopicExistsFunc == nil {
-		panic("StorerMock.CheckTopicExistsFunc: method is nil but Storer.CheckTopicExists was just called")
+// GetTopicCalls gets all the calls that were made to GetTopic.
+// Check the length with:
+//
+//	len(mockedStorer.GetTopicCalls())
+func (mock *StorerMock) GetTopicCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetTopic.RLock()
+	calls = mock.calls.GetTopic
+	mock.lockGetTopic.RUnlock()
+	return calls
+}
+
+// GetWebhook calls GetWebhookFunc.
+func (mock *StorerMock) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	if mock.GetWebhookFunc == nil {
+		panic("StorerMock.GetWebhookFunc: method is nil but Storer.GetWebhook was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
@@ -158,16 +1163,17 @@ func (mock *StorerMock) CheckTopicExists(ctx context.Context, id string) error {
 		Ctx: ctx,
 		ID:  id,
 	}
-	lockStorerMockCheckTopicExists.Lock()
-	mock.calls.CheckTopicExists = append(mock.calls.CheckTopicExists, callInfo)
-	lockStorerMockCheckTopicExists.Unlock()
-	return mock.CheckTopicExistsFunc(ctx, id)
+	mock.lockGetWebhook.Lock()
+	mock.calls.GetWebhook = append(mock.calls.GetWebhook, callInfo)
+	mock.lockGetWebhook.Unlock()
+	return mock.GetWebhookFunc(ctx, id)
 }
 
-// CheckTopicExistsCalls gets all the calls that were made to CheckTopicExists.
+// GetWebhookCalls gets all the calls that were made to GetWebhook.
 // Check the length with:
-//     len(mockedStorer.CheckTopicExistsCalls())
-func (mock *StorerMock) CheckTopicExistsCalls() []struct {
+//
+//	len(mockedStorer.GetWebhookCalls())
+func (mock *StorerMock) GetWebhookCalls() []struct {
 	Ctx context.Context
 	ID  string
 } {
@@ -175,83 +1181,341 @@ func (mock *StorerMock) CheckTopicExistsCalls() []struct {
 		Ctx context.Context
 		ID  string
 	}
-	lockStorerMockCheckTopicExists.RLock()
-	calls = mock.calls.CheckTopicExists
-	lockStorerMockCheckTopicExists.RUnlock()
+	mock.lockGetWebhook.RLock()
+	calls = mock.calls.GetWebhook
+	mock.lockGetWebhook.RUnlock()
 	return calls
 }
 
-// GetContent calls GetContentFunc.
-func (mock *StorerMock) GetContent(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
-	if mock.GetContentFunc == nil {
-		panic("StorerMock.GetContentFunc: method is nil but Storer.GetContent was just called")
+// GetWebhookDeliveries calls GetWebhookDeliveriesFunc.
+func (mock *StorerMock) GetWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	if mock.GetWebhookDeliveriesFunc == nil {
+		panic("StorerMock.GetWebhookDeliveriesFunc: method is nil but Storer.GetWebhookDeliveries was just called")
 	}
 	callInfo := struct {
-		Ctx            context.Context
-		ID             string
-		QueryTypeFlags int
+		Ctx       context.Context
+		WebhookID string
+		Limit     int
 	}{
-		Ctx:            ctx,
-		ID:             id,
-		QueryTypeFlags: queryTypeFlags,
+		Ctx:       ctx,
+		WebhookID: webhookID,
+		Limit:     limit,
 	}
-	lockStorerMockGetContent.Lock()
-	mock.calls.GetContent = append(mock.calls.GetContent, callInfo)
-	lockStorerMockGetContent.Unlock()
-	return mock.GetContentFunc(ctx, id, queryTypeFlags)
+	mock.lockGetWebhookDeliveries.Lock()
+	mock.calls.GetWebhookDeliveries = append(mock.calls.GetWebhookDeliveries, callInfo)
+	mock.lockGetWebhookDeliveries.Unlock()
+	return mock.GetWebhookDeliveriesFunc(ctx, webhookID, limit)
 }
 
-// GetContentCalls gets all the calls that were made to GetContent.
+// GetWebhookDeliveriesCalls gets all the calls that were made to GetWebhookDeliveries.
 // Check the length with:
-//     len(mockedStorer.GetContentCalls())
-func (mock *StorerMock) GetContentCalls() []struct {
-	Ctx            context.Context
-	ID             string
-	QueryTypeFlags int
+//
+//	len(mockedStorer.GetWebhookDeliveriesCalls())
+func (mock *StorerMock) GetWebhookDeliveriesCalls() []struct {
+	Ctx       context.Context
+	WebhookID string
+	Limit     int
 } {
 	var calls []struct {
-		Ctx            context.Context
-		ID             string
-		QueryTypeFlags int
+		Ctx       context.Context
+		WebhookID string
+		Limit     int
 	}
-	lockStorerMockGetContent.RLock()
-	calls = mock.calls.GetContent
-	lockStorerMockGetContent.RUnlock()
+	mock.lockGetWebhookDeliveries.RLock()
+	calls = mock.calls.GetWebhookDeliveries
+	mock.lockGetWebhookDeliveries.RUnlock()
 	return calls
 }
 
-// GetTopic calls GetTopicFunc.
-func (mock *StorerMock) GetTopic(ctx context.Context, id string) (*models.TopicResponse, error) {
-	if mock.GetTopicFunc == nil {
-		panic("StorerMock.GetTopicFunc: method is nil but Storer.GetTopic was just called")
+// GetWebhooks calls GetWebhooksFunc.
+func (mock *StorerMock) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	if mock.GetWebhooksFunc == nil {
+		panic("StorerMock.GetWebhooksFunc: method is nil but Storer.GetWebhooks was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		ID  string
 	}{
 		Ctx: ctx,
-		ID:  id,
 	}
-	lockStorerMockGetTopic.Lock()
-	mock.calls.GetTopic = append(mock.calls.GetTopic, callInfo)
-	lockStorerMockGetTopic.Unlock()
-	return mock.GetTopicFunc(ctx, id)
+	mock.lockGetWebhooks.Lock()
+	mock.calls.GetWebhooks = append(mock.calls.GetWebhooks, callInfo)
+	mock.lockGetWebhooks.Unlock()
+	return mock.GetWebhooksFunc(ctx)
 }
 
-// GetTopicCalls gets all the calls that were made to GetTopic.
+// GetWebhooksCalls gets all the calls that were made to GetWebhooks.
 // Check the length with:
-//     len(mockedStorer.GetTopicCalls())
-func (mock *StorerMock) GetTopicCalls() []struct {
+//
+//	len(mockedStorer.GetWebhooksCalls())
+func (mock *StorerMock) GetWebhooksCalls() []struct {
 	Ctx context.Context
-	ID  string
 } {
 	var calls []struct {
 		Ctx context.Context
-		ID  string
 	}
-	lockStorerMockGetTopic.RLock()
-	calls = mock.calls.GetTopic
-	lockStorerMockGetTopic.RUnlock()
+	mock.lockGetWebhooks.RLock()
+	calls = mock.calls.GetWebhooks
+	mock.lockGetWebhooks.RUnlock()
+	return calls
+}
+
+// InsertContent calls InsertContentFunc.
+func (mock *StorerMock) InsertContent(ctx context.Context, content *models.ContentResponse) error {
+	if mock.InsertContentFunc == nil {
+		panic("StorerMock.InsertContentFunc: method is nil but Storer.InsertContent was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Content *models.ContentResponse
+	}{
+		Ctx:     ctx,
+		Content: content,
+	}
+	mock.lockInsertContent.Lock()
+	mock.calls.InsertContent = append(mock.calls.InsertContent, callInfo)
+	mock.lockInsertContent.Unlock()
+	return mock.InsertContentFunc(ctx, content)
+}
+
+// InsertContentCalls gets all the calls that were made to InsertContent.
+// Check the length with:
+//
+//	len(mockedStorer.InsertContentCalls())
+func (mock *StorerMock) InsertContentCalls() []struct {
+	Ctx     context.Context
+	Content *models.ContentResponse
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Content *models.ContentResponse
+	}
+	mock.lockInsertContent.RLock()
+	calls = mock.calls.InsertContent
+	mock.lockInsertContent.RUnlock()
+	return calls
+}
+
+// InsertPublication calls InsertPublicationFunc.
+func (mock *StorerMock) InsertPublication(ctx context.Context, publication *models.Publication) error {
+	if mock.InsertPublicationFunc == nil {
+		panic("StorerMock.InsertPublicationFunc: method is nil but Storer.InsertPublication was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Publication *models.Publication
+	}{
+		Ctx:         ctx,
+		Publication: publication,
+	}
+	mock.lockInsertPublication.Lock()
+	mock.calls.InsertPublication = append(mock.calls.InsertPublication, callInfo)
+	mock.lockInsertPublication.Unlock()
+	return mock.InsertPublicationFunc(ctx, publication)
+}
+
+// InsertPublicationCalls gets all the calls that were made to InsertPublication.
+// Check the length with:
+//
+//	len(mockedStorer.InsertPublicationCalls())
+func (mock *StorerMock) InsertPublicationCalls() []struct {
+	Ctx         context.Context
+	Publication *models.Publication
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Publication *models.Publication
+	}
+	mock.lockInsertPublication.RLock()
+	calls = mock.calls.InsertPublication
+	mock.lockInsertPublication.RUnlock()
+	return calls
+}
+
+// InsertTopic calls InsertTopicFunc.
+func (mock *StorerMock) InsertTopic(ctx context.Context, topic *models.TopicResponse) error {
+	if mock.InsertTopicFunc == nil {
+		panic("StorerMock.InsertTopicFunc: method is nil but Storer.InsertTopic was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Topic *models.TopicResponse
+	}{
+		Ctx:   ctx,
+		Topic: topic,
+	}
+	mock.lockInsertTopic.Lock()
+	mock.calls.InsertTopic = append(mock.calls.InsertTopic, callInfo)
+	mock.lockInsertTopic.Unlock()
+	return mock.InsertTopicFunc(ctx, topic)
+}
+
+// InsertTopicCalls gets all the calls that were made to InsertTopic.
+// Check the length with:
+//
+//	len(mockedStorer.InsertTopicCalls())
+func (mock *StorerMock) InsertTopicCalls() []struct {
+	Ctx   context.Context
+	Topic *models.TopicResponse
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Topic *models.TopicResponse
+	}
+	mock.lockInsertTopic.RLock()
+	calls = mock.calls.InsertTopic
+	mock.lockInsertTopic.RUnlock()
+	return calls
+}
+
+// IsSlugInUse calls IsSlugInUseFunc.
+func (mock *StorerMock) IsSlugInUse(ctx context.Context, id string, slug string) (bool, error) {
+	if mock.IsSlugInUseFunc == nil {
+		panic("StorerMock.IsSlugInUseFunc: method is nil but Storer.IsSlugInUse was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		ID   string
+		Slug string
+	}{
+		Ctx:  ctx,
+		ID:   id,
+		Slug: slug,
+	}
+	mock.lockIsSlugInUse.Lock()
+	mock.calls.IsSlugInUse = append(mock.calls.IsSlugInUse, callInfo)
+	mock.lockIsSlugInUse.Unlock()
+	return mock.IsSlugInUseFunc(ctx, id, slug)
+}
+
+// IsSlugInUseCalls gets all the calls that were made to IsSlugInUse.
+// Check the length with:
+//
+//	len(mockedStorer.IsSlugInUseCalls())
+func (mock *StorerMock) IsSlugInUseCalls() []struct {
+	Ctx  context.Context
+	ID   string
+	Slug string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		ID   string
+		Slug string
+	}
+	mock.lockIsSlugInUse.RLock()
+	calls = mock.calls.IsSlugInUse
+	mock.lockIsSlugInUse.RUnlock()
+	return calls
+}
+
+// ReplaceContent calls ReplaceContentFunc.
+func (mock *StorerMock) ReplaceContent(ctx context.Context, content *models.ContentResponse) error {
+	if mock.ReplaceContentFunc == nil {
+		panic("StorerMock.ReplaceContentFunc: method is nil but Storer.ReplaceContent was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Content *models.ContentResponse
+	}{
+		Ctx:     ctx,
+		Content: content,
+	}
+	mock.lockReplaceContent.Lock()
+	mock.calls.ReplaceContent = append(mock.calls.ReplaceContent, callInfo)
+	mock.lockReplaceContent.Unlock()
+	return mock.ReplaceContentFunc(ctx, content)
+}
+
+// ReplaceContentCalls gets all the calls that were made to ReplaceContent.
+// Check the length with:
+//
+//	len(mockedStorer.ReplaceContentCalls())
+func (mock *StorerMock) ReplaceContentCalls() []struct {
+	Ctx     context.Context
+	Content *models.ContentResponse
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Content *models.ContentResponse
+	}
+	mock.lockReplaceContent.RLock()
+	calls = mock.calls.ReplaceContent
+	mock.lockReplaceContent.RUnlock()
+	return calls
+}
+
+// ReplaceTopic calls ReplaceTopicFunc.
+func (mock *StorerMock) ReplaceTopic(ctx context.Context, topic *models.TopicResponse) error {
+	if mock.ReplaceTopicFunc == nil {
+		panic("StorerMock.ReplaceTopicFunc: method is nil but Storer.ReplaceTopic was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Topic *models.TopicResponse
+	}{
+		Ctx:   ctx,
+		Topic: topic,
+	}
+	mock.lockReplaceTopic.Lock()
+	mock.calls.ReplaceTopic = append(mock.calls.ReplaceTopic, callInfo)
+	mock.lockReplaceTopic.Unlock()
+	return mock.ReplaceTopicFunc(ctx, topic)
+}
+
+// ReplaceTopicCalls gets all the calls that were made to ReplaceTopic.
+// Check the length with:
+//
+//	len(mockedStorer.ReplaceTopicCalls())
+func (mock *StorerMock) ReplaceTopicCalls() []struct {
+	Ctx   context.Context
+	Topic *models.TopicResponse
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Topic *models.TopicResponse
+	}
+	mock.lockReplaceTopic.RLock()
+	calls = mock.calls.ReplaceTopic
+	mock.lockReplaceTopic.RUnlock()
+	return calls
+}
+
+// UpdateOwners calls UpdateOwnersFunc.
+func (mock *StorerMock) UpdateOwners(ctx context.Context, id string, owners []string) error {
+	if mock.UpdateOwnersFunc == nil {
+		panic("StorerMock.UpdateOwnersFunc: method is nil but Storer.UpdateOwners was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     string
+		Owners []string
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		Owners: owners,
+	}
+	mock.lockUpdateOwners.Lock()
+	mock.calls.UpdateOwners = append(mock.calls.UpdateOwners, callInfo)
+	mock.lockUpdateOwners.Unlock()
+	return mock.UpdateOwnersFunc(ctx, id, owners)
+}
+
+// UpdateOwnersCalls gets all the calls that were made to UpdateOwners.
+// Check the length with:
+//
+//	len(mockedStorer.UpdateOwnersCalls())
+func (mock *StorerMock) UpdateOwnersCalls() []struct {
+	Ctx    context.Context
+	ID     string
+	Owners []string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     string
+		Owners []string
+	}
+	mock.lockUpdateOwners.RLock()
+	calls = mock.calls.UpdateOwners
+	mock.lockUpdateOwners.RUnlock()
 	return calls
 }
 
@@ -269,15 +1533,16 @@ func (mock *StorerMock) UpdateReleaseDate(ctx context.Context, id string, releas
 		ID:          id,
 		ReleaseDate: releaseDate,
 	}
-	lockStorerMockUpdateReleaseDate.Lock()
+	mock.lockUpdateReleaseDate.Lock()
 	mock.calls.UpdateReleaseDate = append(mock.calls.UpdateReleaseDate, callInfo)
-	lockStorerMockUpdateReleaseDate.Unlock()
+	mock.lockUpdateReleaseDate.Unlock()
 	return mock.UpdateReleaseDateFunc(ctx, id, releaseDate)
 }
 
 // UpdateReleaseDateCalls gets all the calls that were made to UpdateReleaseDate.
 // Check the length with:
-//     len(mockedStorer.UpdateReleaseDateCalls())
+//
+//	len(mockedStorer.UpdateReleaseDateCalls())
 func (mock *StorerMock) UpdateReleaseDateCalls() []struct {
 	Ctx         context.Context
 	ID          string
@@ -288,9 +1553,53 @@ func (mock *StorerMock) UpdateReleaseDateCalls() []struct {
 		ID          string
 		ReleaseDate time.Time
 	}
-	lockStorerMockUpdateReleaseDate.RLock()
+	mock.lockUpdateReleaseDate.RLock()
 	calls = mock.calls.UpdateReleaseDate
-	lockStorerMockUpdateReleaseDate.RUnlock()
+	mock.lockUpdateReleaseDate.RUnlock()
+	return calls
+}
+
+// UpdateReview calls UpdateReviewFunc.
+func (mock *StorerMock) UpdateReview(ctx context.Context, id string, state string, review *models.Review) error {
+	if mock.UpdateReviewFunc == nil {
+		panic("StorerMock.UpdateReviewFunc: method is nil but Storer.UpdateReview was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     string
+		State  string
+		Review *models.Review
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		State:  state,
+		Review: review,
+	}
+	mock.lockUpdateReview.Lock()
+	mock.calls.UpdateReview = append(mock.calls.UpdateReview, callInfo)
+	mock.lockUpdateReview.Unlock()
+	return mock.UpdateReviewFunc(ctx, id, state, review)
+}
+
+// UpdateReviewCalls gets all the calls that were made to UpdateReview.
+// Check the length with:
+//
+//	len(mockedStorer.UpdateReviewCalls())
+func (mock *StorerMock) UpdateReviewCalls() []struct {
+	Ctx    context.Context
+	ID     string
+	State  string
+	Review *models.Review
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     string
+		State  string
+		Review *models.Review
+	}
+	mock.lockUpdateReview.RLock()
+	calls = mock.calls.UpdateReview
+	mock.lockUpdateReview.RUnlock()
 	return calls
 }
 
@@ -308,15 +1617,16 @@ func (mock *StorerMock) UpdateState(ctx context.Context, id string, state string
 		ID:    id,
 		State: state,
 	}
-	lockStorerMockUpdateState.Lock()
+	mock.lockUpdateState.Lock()
 	mock.calls.UpdateState = append(mock.calls.UpdateState, callInfo)
-	lockStorerMockUpdateState.Unlock()
+	mock.lockUpdateState.Unlock()
 	return mock.UpdateStateFunc(ctx, id, state)
 }
 
 // UpdateStateCalls gets all the calls that were made to UpdateState.
 // Check the length with:
-//     len(mockedStorer.UpdateStateCalls())
+//
+//	len(mockedStorer.UpdateStateCalls())
 func (mock *StorerMock) UpdateStateCalls() []struct {
 	Ctx   context.Context
 	ID    string
@@ -327,9 +1637,9 @@ func (mock *StorerMock) UpdateStateCalls() []struct {
 		ID    string
 		State string
 	}
-	lockStorerMockUpdateState.RLock()
+	mock.lockUpdateState.RLock()
 	calls = mock.calls.UpdateState
-	lockStorerMockUpdateState.RUnlock()
+	mock.lockUpdateState.RUnlock()
 	return calls
 }
 
@@ -349,15 +1659,16 @@ func (mock *StorerMock) UpdateTopic(ctx context.Context, host string, id string,
 		ID:    id,
 		Topic: topic,
 	}
-	lockStorerMockUpdateTopic.Lock()
+	mock.lockUpdateTopic.Lock()
 	mock.calls.UpdateTopic = append(mock.calls.UpdateTopic, callInfo)
-	lockStorerMockUpdateTopic.Unlock()
+	mock.lockUpdateTopic.Unlock()
 	return mock.UpdateTopicFunc(ctx, host, id, topic)
 }
 
 // UpdateTopicCalls gets all the calls that were made to UpdateTopic.
 // Check the length with:
-//     len(mockedStorer.UpdateTopicCalls())
+//
+//	len(mockedStorer.UpdateTopicCalls())
 func (mock *StorerMock) UpdateTopicCalls() []struct {
 	Ctx   context.Context
 	Host  string
@@ -370,9 +1681,121 @@ func (mock *StorerMock) UpdateTopicCalls() []struct {
 		ID    string
 		Topic *models.TopicUpdate
 	}
-	lockStorerMockUpdateTopic.RLock()
+	mock.lockUpdateTopic.RLock()
 	calls = mock.calls.UpdateTopic
-	lockStorerMockUpdateTopic.RUnlock()
+	mock.lockUpdateTopic.RUnlock()
+	return calls
+}
+
+// UpsertCollection calls UpsertCollectionFunc.
+func (mock *StorerMock) UpsertCollection(ctx context.Context, collection *models.Collection) error {
+	if mock.UpsertCollectionFunc == nil {
+		panic("StorerMock.UpsertCollectionFunc: method is nil but Storer.UpsertCollection was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Collection *models.Collection
+	}{
+		Ctx:        ctx,
+		Collection: collection,
+	}
+	mock.lockUpsertCollection.Lock()
+	mock.calls.UpsertCollection = append(mock.calls.UpsertCollection, callInfo)
+	mock.lockUpsertCollection.Unlock()
+	return mock.UpsertCollectionFunc(ctx, collection)
+}
+
+// UpsertCollectionCalls gets all the calls that were made to UpsertCollection.
+// Check the length with:
+//
+//	len(mockedStorer.UpsertCollectionCalls())
+func (mock *StorerMock) UpsertCollectionCalls() []struct {
+	Ctx        context.Context
+	Collection *models.Collection
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Collection *models.Collection
+	}
+	mock.lockUpsertCollection.RLock()
+	calls = mock.calls.UpsertCollection
+	mock.lockUpsertCollection.RUnlock()
+	return calls
+}
+
+// UpsertContent calls UpsertContentFunc.
+func (mock *StorerMock) UpsertContent(ctx context.Context, id string, content *models.ContentResponse) error {
+	if mock.UpsertContentFunc == nil {
+		panic("StorerMock.UpsertContentFunc: method is nil but Storer.UpsertContent was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      string
+		Content *models.ContentResponse
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Content: content,
+	}
+	mock.lockUpsertContent.Lock()
+	mock.calls.UpsertContent = append(mock.calls.UpsertContent, callInfo)
+	mock.lockUpsertContent.Unlock()
+	return mock.UpsertContentFunc(ctx, id, content)
+}
+
+// UpsertContentCalls gets all the calls that were made to UpsertContent.
+// Check the length with:
+//
+//	len(mockedStorer.UpsertContentCalls())
+func (mock *StorerMock) UpsertContentCalls() []struct {
+	Ctx     context.Context
+	ID      string
+	Content *models.ContentResponse
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      string
+		Content *models.ContentResponse
+	}
+	mock.lockUpsertContent.RLock()
+	calls = mock.calls.UpsertContent
+	mock.lockUpsertContent.RUnlock()
+	return calls
+}
+
+// UpsertRedirect calls UpsertRedirectFunc.
+func (mock *StorerMock) UpsertRedirect(ctx context.Context, redirect *models.Redirect) error {
+	if mock.UpsertRedirectFunc == nil {
+		panic("StorerMock.UpsertRedirectFunc: method is nil but Storer.UpsertRedirect was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Redirect *models.Redirect
+	}{
+		Ctx:      ctx,
+		Redirect: redirect,
+	}
+	mock.lockUpsertRedirect.Lock()
+	mock.calls.UpsertRedirect = append(mock.calls.UpsertRedirect, callInfo)
+	mock.lockUpsertRedirect.Unlock()
+	return mock.UpsertRedirectFunc(ctx, redirect)
+}
+
+// UpsertRedirectCalls gets all the calls that were made to UpsertRedirect.
+// Check the length with:
+//
+//	len(mockedStorer.UpsertRedirectCalls())
+func (mock *StorerMock) UpsertRedirectCalls() []struct {
+	Ctx      context.Context
+	Redirect *models.Redirect
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Redirect *models.Redirect
+	}
+	mock.lockUpsertRedirect.RLock()
+	calls = mock.calls.UpsertRedirect
+	mock.lockUpsertRedirect.RUnlock()
 	return calls
 }
 
@@ -390,15 +1813,16 @@ func (mock *StorerMock) UpsertTopic(ctx context.Context, id string, topic *model
 		ID:    id,
 		Topic: topic,
 	}
-	lockStorerMockUpsertTopic.Lock()
+	mock.lockUpsertTopic.Lock()
 	mock.calls.UpsertTopic = append(mock.calls.UpsertTopic, callInfo)
-	lockStorerMockUpsertTopic.Unlock()
+	mock.lockUpsertTopic.Unlock()
 	return mock.UpsertTopicFunc(ctx, id, topic)
 }
 
 // UpsertTopicCalls gets all the calls that were made to UpsertTopic.
 // Check the length with:
-//     len(mockedStorer.UpsertTopicCalls())
+//
+//	len(mockedStorer.UpsertTopicCalls())
 func (mock *StorerMock) UpsertTopicCalls() []struct {
 	Ctx   context.Context
 	ID    string
@@ -409,8 +1833,44 @@ func (mock *StorerMock) UpsertTopicCalls() []struct {
 		ID    string
 		Topic *models.TopicResponse
 	}
-	lockStorerMockUpsertTopic.RLock()
+	mock.lockUpsertTopic.RLock()
 	calls = mock.calls.UpsertTopic
-	lockStorerMockUpsertTopic.RUnlock()
+	mock.lockUpsertTopic.RUnlock()
+	return calls
+}
+
+// UpsertWebhookDelivery calls UpsertWebhookDeliveryFunc.
+func (mock *StorerMock) UpsertWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if mock.UpsertWebhookDeliveryFunc == nil {
+		panic("StorerMock.UpsertWebhookDeliveryFunc: method is nil but Storer.UpsertWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Delivery *models.WebhookDelivery
+	}{
+		Ctx:      ctx,
+		Delivery: delivery,
+	}
+	mock.lockUpsertWebhookDelivery.Lock()
+	mock.calls.UpsertWebhookDelivery = append(mock.calls.UpsertWebhookDelivery, callInfo)
+	mock.lockUpsertWebhookDelivery.Unlock()
+	return mock.UpsertWebhookDeliveryFunc(ctx, delivery)
+}
+
+// UpsertWebhookDeliveryCalls gets all the calls that were made to UpsertWebhookDelivery.
+// Check the length with:
+//
+//	len(mockedStorer.UpsertWebhookDeliveryCalls())
+func (mock *StorerMock) UpsertWebhookDeliveryCalls() []struct {
+	Ctx      context.Context
+	Delivery *models.WebhookDelivery
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Delivery *models.WebhookDelivery
+	}
+	mock.lockUpsertWebhookDelivery.RLock()
+	calls = mock.calls.UpsertWebhookDelivery
+	mock.lockUpsertWebhookDelivery.RUnlock()
 	return calls
 }
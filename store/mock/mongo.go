@@ -12,89 +12,251 @@ import (
 	"time"
 )
 
-var (
-	lockMongoDBMockCheckTopicExists  sync.RWMutex
-	lockMongoDBMockChecker           sync.RWMutex
-	lockMongoDBMockClose             sync.RWMutex
-	lockMongoDBMockGetContent        sync.RWMutex
-	lockMongoDBMockGetTopic          sync.RWMutex
-	lockMongoDBMockUpdateReleaseDate sync.RWMutex
-	lockMongoDBMockUpdateState       sync.RWMutex
-	lockMongoDBMockUpdateTopic       sync.RWMutex
-	lockMongoDBMockUpsertTopic       sync.RWMutex
-)
-
 // Ensure, that MongoDBMock does implement store.MongoDB.
 // If this is not the case, regenerate this file with moq.
 var _ store.MongoDB = &MongoDBMock{}
 
 // MongoDBMock is a mock implementation of store.MongoDB.
 //
-//     func TestSomethingThatUsesMongoDB(t *testing.T) {
-//
-//         // make and configure a mocked store.MongoDB
-//         mockedMongoDB := &MongoDBMock{
-//             CheckTopicExistsFunc: func(ctx context.Context, id string) error {
-// 	               panic("mock out the CheckTopicExists method")
-//             },
-//             CheckerFunc: func(in1 context.Context, in2 *healthcheck.CheckState) error {
-// 	               panic("mock out the Checker method")
-//             },
-//             CloseFunc: func(in1 context.Context) error {
-// 	               panic("mock out the Close method")
-//             },
-//             GetContentFunc: func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
-// 	               panic("mock out the GetContent method")
-//             },
-//             GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
-// 	               panic("mock out the GetTopic method")
-//             },
-//             UpdateReleaseDateFunc: func(ctx context.Context, id string, releaseDate time.Time) error {
-// 	               panic("mock out the UpdateReleaseDate method")
-//             },
-//             UpdateStateFunc: func(ctx context.Context, id string, state string) error {
-// 	               panic("mock out the UpdateState method")
-//             },
-//             UpdateTopicFunc: func(ctx context.Context, host string, id string, topic *models.TopicUpdate) error {
-// 	               panic("mock out the UpdateTopic method")
-//             },
-//             UpsertTopicFunc: func(ctx context.Context, id string, topic *models.TopicResponse) error {
-// 	               panic("mock out the UpsertTopic method")
-//             },
-//         }
-//
-//         // use mockedMongoDB in code that requires store.MongoDB
-//         // and then make assertions.
-//
-//     }
+//	func TestSomethingThatUsesMongoDB(t *testing.T) {
+//
+//		// make and configure a mocked store.MongoDB
+//		mockedMongoDB := &MongoDBMock{
+//			CheckTopicExistsFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the CheckTopicExists method")
+//			},
+//			CheckerFunc: func(contextMoqParam context.Context, checkState *healthcheck.CheckState) error {
+//				panic("mock out the Checker method")
+//			},
+//			CloseFunc: func(contextMoqParam context.Context) error {
+//				panic("mock out the Close method")
+//			},
+//			CreateCollectionFunc: func(ctx context.Context, collection *models.Collection) error {
+//				panic("mock out the CreateCollection method")
+//			},
+//			CreateWebhookFunc: func(ctx context.Context, webhook *models.Webhook) error {
+//				panic("mock out the CreateWebhook method")
+//			},
+//			DeleteContentFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the DeleteContent method")
+//			},
+//			DeleteRedirectFunc: func(ctx context.Context, from string) error {
+//				panic("mock out the DeleteRedirect method")
+//			},
+//			DeleteTopicFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the DeleteTopic method")
+//			},
+//			DeleteWebhookFunc: func(ctx context.Context, id string) error {
+//				panic("mock out the DeleteWebhook method")
+//			},
+//			GetAllContentFunc: func(ctx context.Context) ([]models.ContentResponse, error) {
+//				panic("mock out the GetAllContent method")
+//			},
+//			GetAllTopicsFunc: func(ctx context.Context) ([]models.TopicResponse, error) {
+//				panic("mock out the GetAllTopics method")
+//			},
+//			GetArchivedTopicIDsFunc: func(ctx context.Context) ([]string, error) {
+//				panic("mock out the GetArchivedTopicIDs method")
+//			},
+//			GetCollectionFunc: func(ctx context.Context, id string) (*models.Collection, error) {
+//				panic("mock out the GetCollection method")
+//			},
+//			GetCollectionsFunc: func(ctx context.Context) ([]models.Collection, error) {
+//				panic("mock out the GetCollections method")
+//			},
+//			GetContentFunc: func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+//				panic("mock out the GetContent method")
+//			},
+//			GetPublicationsFunc: func(ctx context.Context, topicID string, limit int) ([]models.Publication, error) {
+//				panic("mock out the GetPublications method")
+//			},
+//			GetRedirectFunc: func(ctx context.Context, from string) (*models.Redirect, error) {
+//				panic("mock out the GetRedirect method")
+//			},
+//			GetRedirectsFunc: func(ctx context.Context) ([]models.Redirect, error) {
+//				panic("mock out the GetRedirects method")
+//			},
+//			GetTopicFunc: func(ctx context.Context, id string) (*models.TopicResponse, error) {
+//				panic("mock out the GetTopic method")
+//			},
+//			GetWebhookFunc: func(ctx context.Context, id string) (*models.Webhook, error) {
+//				panic("mock out the GetWebhook method")
+//			},
+//			GetWebhookDeliveriesFunc: func(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+//				panic("mock out the GetWebhookDeliveries method")
+//			},
+//			GetWebhooksFunc: func(ctx context.Context) ([]models.Webhook, error) {
+//				panic("mock out the GetWebhooks method")
+//			},
+//			InsertContentFunc: func(ctx context.Context, content *models.ContentResponse) error {
+//				panic("mock out the InsertContent method")
+//			},
+//			InsertPublicationFunc: func(ctx context.Context, publication *models.Publication) error {
+//				panic("mock out the InsertPublication method")
+//			},
+//			InsertTopicFunc: func(ctx context.Context, topic *models.TopicResponse) error {
+//				panic("mock out the InsertTopic method")
+//			},
+//			IsSlugInUseFunc: func(ctx context.Context, id string, slug string) (bool, error) {
+//				panic("mock out the IsSlugInUse method")
+//			},
+//			ReplaceContentFunc: func(ctx context.Context, content *models.ContentResponse) error {
+//				panic("mock out the ReplaceContent method")
+//			},
+//			ReplaceTopicFunc: func(ctx context.Context, topic *models.TopicResponse) error {
+//				panic("mock out the ReplaceTopic method")
+//			},
+//			UpdateOwnersFunc: func(ctx context.Context, id string, owners []string) error {
+//				panic("mock out the UpdateOwners method")
+//			},
+//			UpdateReleaseDateFunc: func(ctx context.Context, id string, releaseDate time.Time) error {
+//				panic("mock out the UpdateReleaseDate method")
+//			},
+//			UpdateReviewFunc: func(ctx context.Context, id string, state string, review *models.Review) error {
+//				panic("mock out the UpdateReview method")
+//			},
+//			UpdateStateFunc: func(ctx context.Context, id string, state string) error {
+//				panic("mock out the UpdateState method")
+//			},
+//			UpdateTopicFunc: func(ctx context.Context, host string, id string, topic *models.TopicUpdate) error {
+//				panic("mock out the UpdateTopic method")
+//			},
+//			UpsertCollectionFunc: func(ctx context.Context, collection *models.Collection) error {
+//				panic("mock out the UpsertCollection method")
+//			},
+//			UpsertContentFunc: func(ctx context.Context, id string, content *models.ContentResponse) error {
+//				panic("mock out the UpsertContent method")
+//			},
+//			UpsertRedirectFunc: func(ctx context.Context, redirect *models.Redirect) error {
+//				panic("mock out the UpsertRedirect method")
+//			},
+//			UpsertTopicFunc: func(ctx context.Context, id string, topic *models.TopicResponse) error {
+//				panic("mock out the UpsertTopic method")
+//			},
+//			UpsertWebhookDeliveryFunc: func(ctx context.Context, delivery *models.WebhookDelivery) error {
+//				panic("mock out the UpsertWebhookDelivery method")
+//			},
+//		}
+//
+//		// use mockedMongoDB in code that requires store.MongoDB
+//		// and then make assertions.
+//
+//	}
 type MongoDBMock struct {
 	// CheckTopicExistsFunc mocks the CheckTopicExists method.
 	CheckTopicExistsFunc func(ctx context.Context, id string) error
 
 	// CheckerFunc mocks the Checker method.
-	CheckerFunc func(in1 context.Context, in2 *healthcheck.CheckState) error
+	CheckerFunc func(contextMoqParam context.Context, checkState *healthcheck.CheckState) error
 
 	// CloseFunc mocks the Close method.
-	CloseFunc func(in1 context.Context) error
+	CloseFunc func(contextMoqParam context.Context) error
+
+	// CreateCollectionFunc mocks the CreateCollection method.
+	CreateCollectionFunc func(ctx context.Context, collection *models.Collection) error
+
+	// CreateWebhookFunc mocks the CreateWebhook method.
+	CreateWebhookFunc func(ctx context.Context, webhook *models.Webhook) error
+
+	// DeleteContentFunc mocks the DeleteContent method.
+	DeleteContentFunc func(ctx context.Context, id string) error
+
+	// DeleteRedirectFunc mocks the DeleteRedirect method.
+	DeleteRedirectFunc func(ctx context.Context, from string) error
+
+	// DeleteTopicFunc mocks the DeleteTopic method.
+	DeleteTopicFunc func(ctx context.Context, id string) error
+
+	// DeleteWebhookFunc mocks the DeleteWebhook method.
+	DeleteWebhookFunc func(ctx context.Context, id string) error
+
+	// GetAllContentFunc mocks the GetAllContent method.
+	GetAllContentFunc func(ctx context.Context) ([]models.ContentResponse, error)
+
+	// GetAllTopicsFunc mocks the GetAllTopics method.
+	GetAllTopicsFunc func(ctx context.Context) ([]models.TopicResponse, error)
+
+	// GetArchivedTopicIDsFunc mocks the GetArchivedTopicIDs method.
+	GetArchivedTopicIDsFunc func(ctx context.Context) ([]string, error)
+
+	// GetCollectionFunc mocks the GetCollection method.
+	GetCollectionFunc func(ctx context.Context, id string) (*models.Collection, error)
+
+	// GetCollectionsFunc mocks the GetCollections method.
+	GetCollectionsFunc func(ctx context.Context) ([]models.Collection, error)
 
 	// GetContentFunc mocks the GetContent method.
 	GetContentFunc func(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error)
 
+	// GetPublicationsFunc mocks the GetPublications method.
+	GetPublicationsFunc func(ctx context.Context, topicID string, limit int) ([]models.Publication, error)
+
+	// GetRedirectFunc mocks the GetRedirect method.
+	GetRedirectFunc func(ctx context.Context, from string) (*models.Redirect, error)
+
+	// GetRedirectsFunc mocks the GetRedirects method.
+	GetRedirectsFunc func(ctx context.Context) ([]models.Redirect, error)
+
 	// GetTopicFunc mocks the GetTopic method.
 	GetTopicFunc func(ctx context.Context, id string) (*models.TopicResponse, error)
 
+	// GetWebhookFunc mocks the GetWebhook method.
+	GetWebhookFunc func(ctx context.Context, id string) (*models.Webhook, error)
+
+	// GetWebhookDeliveriesFunc mocks the GetWebhookDeliveries method.
+	GetWebhookDeliveriesFunc func(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error)
+
+	// GetWebhooksFunc mocks the GetWebhooks method.
+	GetWebhooksFunc func(ctx context.Context) ([]models.Webhook, error)
+
+	// InsertContentFunc mocks the InsertContent method.
+	InsertContentFunc func(ctx context.Context, content *models.ContentResponse) error
+
+	// InsertPublicationFunc mocks the InsertPublication method.
+	InsertPublicationFunc func(ctx context.Context, publication *models.Publication) error
+
+	// InsertTopicFunc mocks the InsertTopic method.
+	InsertTopicFunc func(ctx context.Context, topic *models.TopicResponse) error
+
+	// IsSlugInUseFunc mocks the IsSlugInUse method.
+	IsSlugInUseFunc func(ctx context.Context, id string, slug string) (bool, error)
+
+	// ReplaceContentFunc mocks the ReplaceContent method.
+	ReplaceContentFunc func(ctx context.Context, content *models.ContentResponse) error
+
+	// ReplaceTopicFunc mocks the ReplaceTopic method.
+	ReplaceTopicFunc func(ctx context.Context, topic *models.TopicResponse) error
+
+	// UpdateOwnersFunc mocks the UpdateOwners method.
+	UpdateOwnersFunc func(ctx context.Context, id string, owners []string) error
+
 	// UpdateReleaseDateFunc mocks the UpdateReleaseDate method.
 	UpdateReleaseDateFunc func(ctx context.Context, id string, releaseDate time.Time) error
 
+	// UpdateReviewFunc mocks the UpdateReview method.
+	UpdateReviewFunc func(ctx context.Context, id string, state string, review *models.Review) error
+
 	// UpdateStateFunc mocks the UpdateState method.
 	UpdateStateFunc func(ctx context.Context, id string, state string) error
 
 	// UpdateTopicFunc mocks the UpdateTopic method.
 	UpdateTopicFunc func(ctx context.Context, host string, id string, topic *models.TopicUpdate) error
 
+	// UpsertCollectionFunc mocks the UpsertCollection method.
+	UpsertCollectionFunc func(ctx context.Context, collection *models.Collection) error
+
+	// UpsertContentFunc mocks the UpsertContent method.
+	UpsertContentFunc func(ctx context.Context, id string, content *models.ContentResponse) error
+
+	// UpsertRedirectFunc mocks the UpsertRedirect method.
+	UpsertRedirectFunc func(ctx context.Context, redirect *models.Redirect) error
+
 	// UpsertTopicFunc mocks the UpsertTopic method.
 	UpsertTopicFunc func(ctx context.Context, id string, topic *models.TopicResponse) error
 
+	// UpsertWebhookDeliveryFunc mocks the UpsertWebhookDelivery method.
+	UpsertWebhookDeliveryFunc func(ctx context.Context, delivery *models.WebhookDelivery) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CheckTopicExists holds details about calls to the CheckTopicExists method.
@@ -106,15 +268,84 @@ type MongoDBMock struct {
 		}
 		// Checker holds details about calls to the Checker method.
 		Checker []struct {
-			// In1 is the in1 argument value.
-			In1 context.Context
-			// In2 is the in2 argument value.
-			In2 *healthcheck.CheckState
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+			// CheckState is the checkState argument value.
+			CheckState *healthcheck.CheckState
 		}
 		// Close holds details about calls to the Close method.
 		Close []struct {
-			// In1 is the in1 argument value.
-			In1 context.Context
+			// ContextMoqParam is the contextMoqParam argument value.
+			ContextMoqParam context.Context
+		}
+		// CreateCollection holds details about calls to the CreateCollection method.
+		CreateCollection []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Collection is the collection argument value.
+			Collection *models.Collection
+		}
+		// CreateWebhook holds details about calls to the CreateWebhook method.
+		CreateWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Webhook is the webhook argument value.
+			Webhook *models.Webhook
+		}
+		// DeleteContent holds details about calls to the DeleteContent method.
+		DeleteContent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// DeleteRedirect holds details about calls to the DeleteRedirect method.
+		DeleteRedirect []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// From is the from argument value.
+			From string
+		}
+		// DeleteTopic holds details about calls to the DeleteTopic method.
+		DeleteTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// DeleteWebhook holds details about calls to the DeleteWebhook method.
+		DeleteWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// GetAllContent holds details about calls to the GetAllContent method.
+		GetAllContent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetAllTopics holds details about calls to the GetAllTopics method.
+		GetAllTopics []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetArchivedTopicIDs holds details about calls to the GetArchivedTopicIDs method.
+		GetArchivedTopicIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetCollection holds details about calls to the GetCollection method.
+		GetCollection []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// GetCollections holds details about calls to the GetCollections method.
+		GetCollections []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
 		}
 		// GetContent holds details about calls to the GetContent method.
 		GetContent []struct {
@@ -125,6 +356,27 @@ type MongoDBMock struct {
 			// QueryTypeFlags is the queryTypeFlags argument value.
 			QueryTypeFlags int
 		}
+		// GetPublications holds details about calls to the GetPublications method.
+		GetPublications []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// TopicID is the topicID argument value.
+			TopicID string
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetRedirect holds details about calls to the GetRedirect method.
+		GetRedirect []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// From is the from argument value.
+			From string
+		}
+		// GetRedirects holds details about calls to the GetRedirects method.
+		GetRedirects []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// GetTopic holds details about calls to the GetTopic method.
 		GetTopic []struct {
 			// Ctx is the ctx argument value.
@@ -132,6 +384,80 @@ type MongoDBMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// GetWebhook holds details about calls to the GetWebhook method.
+		GetWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// GetWebhookDeliveries holds details about calls to the GetWebhookDeliveries method.
+		GetWebhookDeliveries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// WebhookID is the webhookID argument value.
+			WebhookID string
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetWebhooks holds details about calls to the GetWebhooks method.
+		GetWebhooks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// InsertContent holds details about calls to the InsertContent method.
+		InsertContent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Content is the content argument value.
+			Content *models.ContentResponse
+		}
+		// InsertPublication holds details about calls to the InsertPublication method.
+		InsertPublication []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Publication is the publication argument value.
+			Publication *models.Publication
+		}
+		// InsertTopic holds details about calls to the InsertTopic method.
+		InsertTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Topic is the topic argument value.
+			Topic *models.TopicResponse
+		}
+		// IsSlugInUse holds details about calls to the IsSlugInUse method.
+		IsSlugInUse []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Slug is the slug argument value.
+			Slug string
+		}
+		// ReplaceContent holds details about calls to the ReplaceContent method.
+		ReplaceContent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Content is the content argument value.
+			Content *models.ContentResponse
+		}
+		// ReplaceTopic holds details about calls to the ReplaceTopic method.
+		ReplaceTopic []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Topic is the topic argument value.
+			Topic *models.TopicResponse
+		}
+		// UpdateOwners holds details about calls to the UpdateOwners method.
+		UpdateOwners []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Owners is the owners argument value.
+			Owners []string
+		}
 		// UpdateReleaseDate holds details about calls to the UpdateReleaseDate method.
 		UpdateReleaseDate []struct {
 			// Ctx is the ctx argument value.
@@ -141,6 +467,17 @@ type MongoDBMock struct {
 			// ReleaseDate is the releaseDate argument value.
 			ReleaseDate time.Time
 		}
+		// UpdateReview holds details about calls to the UpdateReview method.
+		UpdateReview []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// State is the state argument value.
+			State string
+			// Review is the review argument value.
+			Review *models.Review
+		}
 		// UpdateState holds details about calls to the UpdateState method.
 		UpdateState []struct {
 			// Ctx is the ctx argument value.
@@ -161,6 +498,29 @@ type MongoDBMock struct {
 			// Topic is the topic argument value.
 			Topic *models.TopicUpdate
 		}
+		// UpsertCollection holds details about calls to the UpsertCollection method.
+		UpsertCollection []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Collection is the collection argument value.
+			Collection *models.Collection
+		}
+		// UpsertContent holds details about calls to the UpsertContent method.
+		UpsertContent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Content is the content argument value.
+			Content *models.ContentResponse
+		}
+		// UpsertRedirect holds details about calls to the UpsertRedirect method.
+		UpsertRedirect []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Redirect is the redirect argument value.
+			Redirect *models.Redirect
+		}
 		// UpsertTopic holds details about calls to the UpsertTopic method.
 		UpsertTopic []struct {
 			// Ctx is the ctx argument value.
@@ -170,7 +530,52 @@ type MongoDBMock struct {
 			// Topic is the topic argument value.
 			Topic *models.TopicResponse
 		}
+		// UpsertWebhookDelivery holds details about calls to the UpsertWebhookDelivery method.
+		UpsertWebhookDelivery []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Delivery is the delivery argument value.
+			Delivery *models.WebhookDelivery
+		}
 	}
+	lockCheckTopicExists      sync.RWMutex
+	lockChecker               sync.RWMutex
+	lockClose                 sync.RWMutex
+	lockCreateCollection      sync.RWMutex
+	lockCreateWebhook         sync.RWMutex
+	lockDeleteContent         sync.RWMutex
+	lockDeleteRedirect        sync.RWMutex
+	lockDeleteTopic           sync.RWMutex
+	lockDeleteWebhook         sync.RWMutex
+	lockGetAllContent         sync.RWMutex
+	lockGetAllTopics          sync.RWMutex
+	lockGetArchivedTopicIDs   sync.RWMutex
+	lockGetCollection         sync.RWMutex
+	lockGetCollections        sync.RWMutex
+	lockGetContent            sync.RWMutex
+	lockGetPublications       sync.RWMutex
+	lockGetRedirect           sync.RWMutex
+	lockGetRedirects          sync.RWMutex
+	lockGetTopic              sync.RWMutex
+	lockGetWebhook            sync.RWMutex
+	lockGetWebhookDeliveries  sync.RWMutex
+	lockGetWebhooks           sync.RWMutex
+	lockInsertContent         sync.RWMutex
+	lockInsertPublication     sync.RWMutex
+	lockInsertTopic           sync.RWMutex
+	lockIsSlugInUse           sync.RWMutex
+	lockReplaceContent        sync.RWMutex
+	lockReplaceTopic          sync.RWMutex
+	lockUpdateOwners          sync.RWMutex
+	lockUpdateReleaseDate     sync.RWMutex
+	lockUpdateReview          sync.RWMutex
+	lockUpdateState           sync.RWMutex
+	lockUpdateTopic           sync.RWMutex
+	lockUpsertCollection      sync.RWMutex
+	lockUpsertContent         sync.RWMutex
+	lockUpsertRedirect        sync.RWMutex
+	lockUpsertTopic           sync.RWMutex
+	lockUpsertWebhookDelivery sync.RWMutex
 }
 
 // CheckTopicExists calls CheckTopicExistsFunc.
@@ -185,15 +590,16 @@ func (mock *MongoDBMock) CheckTopicExists(ctx context.Context, id string) error
 		Ctx: ctx,
 		ID:  id,
 	}
-	lockMongoDBMockCheckTopicExists.Lock()
+	mock.lockCheckTopicExists.Lock()
 	mock.calls.CheckTopicExists = append(mock.calls.CheckTopicExists, callInfo)
-	lockMongoDBMockCheckTopicExists.Unlock()
+	mock.lockCheckTopicExists.Unlock()
 	return mock.CheckTopicExistsFunc(ctx, id)
 }
 
 // CheckTopicExistsCalls gets all the calls that were made to CheckTopicExists.
 // Check the length with:
-//     len(mockedMongoDB.CheckTopicExistsCalls())
+//
+//	len(mockedMongoDB.CheckTopicExistsCalls())
 func (mock *MongoDBMock) CheckTopicExistsCalls() []struct {
 	Ctx context.Context
 	ID  string
@@ -202,121 +608,156 @@ func (mock *MongoDBMock) CheckTopicExistsCalls() []struct {
 		Ctx context.Context
 		ID  string
 	}
-	lockMongoDBMockCheckTopicExists.RLock()
+	mock.lockCheckTopicExists.RLock()
 	calls = mock.calls.CheckTopicExists
-	lockMongoDBMockCheckTopicExists.RUnlock()
+	mock.lockCheckTopicExists.RUnlock()
 	return calls
 }
 
 // Checker calls CheckerFunc.
-func (mock *MongoDBMock) Checker(in1 context.Context, in2 *healthcheck.CheckState) error {
+func (mock *MongoDBMock) Checker(contextMoqParam context.Context, checkState *healthcheck.CheckState) error {
 	if mock.CheckerFunc == nil {
 		panic("MongoDBMock.CheckerFunc: method is nil but MongoDB.Checker was just called")
 	}
 	callInfo := struct {
-		In1 context.Context
-		In2 *healthcheck.CheckState
+		ContextMoqParam context.Context
+		CheckState      *healthcheck.CheckState
 	}{
-		In1: in1,
-		In2: in2,
+		ContextMoqParam: contextMoqParam,
+		CheckState:      checkState,
 	}
-	lockMongoDBMockChecker.Lock()
+	mock.lockChecker.Lock()
 	mock.calls.Checker = append(mock.calls.Checker, callInfo)
-	lockMongoDBMockChecker.Unlock()
-	return mock.CheckerFunc(in1, in2)
+	mock.lockChecker.Unlock()
+	return mock.CheckerFunc(contextMoqParam, checkState)
 }
 
 // CheckerCalls gets all the calls that were made to Checker.
 // Check the length with:
-//     len(mockedMongoDB.CheckerCalls())
+//
+//	len(mockedMongoDB.CheckerCalls())
 func (mock *MongoDBMock) CheckerCalls() []struct {
-	In1 context.Context
-	In2 *healthcheck.CheckState
+	ContextMoqParam context.Context
+	CheckState      *healthcheck.CheckState
 } {
 	var calls []struct {
-		In1 context.Context
-		In2 *healthcheck.CheckState
+		ContextMoqParam context.Context
+		CheckState      *healthcheck.CheckState
 	}
-	lockMongoDBMockChecker.RLock()
+	mock.lockChecker.RLock()
 	calls = mock.calls.Checker
-	lockMongoDBMockChecker.RUnlock()
+	mock.lockChecker.RUnlock()
 	return calls
 }
 
 // Close calls CloseFunc.
-func (mock *MongoDBMock) Close(in1 context.Context) error {
+func (mock *MongoDBMock) Close(contextMoqParam context.Context) error {
 	if mock.CloseFunc == nil {
 		panic("MongoDBMock.CloseFunc: method is nil but MongoDB.Close was just called")
 	}
 	callInfo := struct {
-		In1 context.Context
+		ContextMoqParam context.Context
 	}{
-		In1: in1,
+		ContextMoqParam: contextMoqParam,
 	}
-	lockMongoDBMockClose.Lock()
+	mock.lockClose.Lock()
 	mock.calls.Close = append(mock.calls.Close, callInfo)
-	lockMongoDBMockClose.Unlock()
-	return mock.CloseFunc(in1)
+	mock.lockClose.Unlock()
+	return mock.CloseFunc(contextMoqParam)
 }
 
 // CloseCalls gets all the calls that were made to Close.
 // Check the length with:
-//     len(mockedMongoDB.CloseCalls())
+//
+//	len(mockedMongoDB.CloseCalls())
 func (mock *MongoDBMock) CloseCalls() []struct {
-	In1 context.Context
+	ContextMoqParam context.Context
 } {
 	var calls []struct {
-		In1 context.Context
+		ContextMoqParam context.Context
 	}
-	lockMongoDBMockClose.RLock()
+	mock.lockClose.RLock()
 	calls = mock.calls.Close
-	lockMongoDBMockClose.RUnlock()
+	mock.lockClose.RUnlock()
 	return calls
 }
 
-// GetContent calls GetContentFunc.
-func (mock *MongoDBMock) GetContent(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
-	if mock.GetContentFunc == nil {
-		panic("MongoDBMock.GetContentFunc: method is nil but MongoDB.GetContent was just called")
+// CreateCollection calls CreateCollectionFunc.
+func (mock *MongoDBMock) CreateCollection(ctx context.Context, collection *models.Collection) error {
+	if mock.CreateCollectionFunc == nil {
+		panic("MongoDBMock.CreateCollectionFunc: method is nil but MongoDB.CreateCollection was just called")
 	}
 	callInfo := struct {
-		Ctx            context.Context
-		ID             string
-		QueryTypeFlags int
+		Ctx        context.Context
+		Collection *models.Collection
 	}{
-		Ctx:            ctx,
-		ID:             id,
-		QueryTypeFlags: queryTypeFlags,
+		Ctx:        ctx,
+		Collection: collection,
 	}
-	lockMongoDBMockGetContent.Lock()
-	mock.calls.GetContent = append(mock.calls.GetContent, callInfo)
-	lockMongoDBMockGetContent.Unlock()
-	return mock.GetContentFunc(ctx, id, queryTypeFlags)
+	mock.lockCreateCollection.Lock()
+	mock.calls.CreateCollection = append(mock.calls.CreateCollection, callInfo)
+	mock.lockCreateCollection.Unlock()
+	return mock.CreateCollectionFunc(ctx, collection)
 }
 
-// GetContentCalls gets all the calls that were made to GetContent.
+// CreateCollectionCalls gets all the calls that were made to CreateCollection.
 // Check the length with:
-//     len(mockedMongoDB.GetContentCalls())
-func (mock *MongoDBMock) GetContentCalls() []struct {
-	Ctx            context.Context
-	ID             string
-	QueryTypeFlags int
+//
+//	len(mockedMongoDB.CreateCollectionCalls())
+func (mock *MongoDBMock) CreateCollectionCalls() []struct {
+	Ctx        context.Context
+	Collection *models.Collection
 } {
 	var calls []struct {
-		Ctx            context.Context
-		ID             string
-		QueryTypeFlags int
+		Ctx        context.Context
+		Collection *models.Collection
 	}
-	lockMongoDBMockGetContent.RLock()
-	calls = mock.calls.GetContent
-	lockMongoDBMockGetContent.RUnlock()
+	mock.lockCreateCollection.RLock()
+	calls = mock.calls.CreateCollection
+	mock.lockCreateCollection.RUnlock()
 	return calls
 }
 
-// GetTopic calls GetTopicFunc.
-func (mock *MongoDBMock) GetTopic(ctx context.Context, id string) (*models.TopicResponse, error) {
-	if mock.GetTopicFunc == nil {
-		panic("MongoDBMock.GetTopicFunc: method is nil but MongoDB.GetTopic was just called")
+// CreateWebhook calls CreateWebhookFunc.
+func (mock *MongoDBMock) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if mock.CreateWebhookFunc == nil {
+		panic("MongoDBMock.CreateWebhookFunc: method is nil but MongoDB.CreateWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Webhook *models.Webhook
+	}{
+		Ctx:     ctx,
+		Webhook: webhook,
+	}
+	mock.lockCreateWebhook.Lock()
+	mock.calls.CreateWebhook = append(mock.calls.CreateWebhook, callInfo)
+	mock.lockCreateWebhook.Unlock()
+	return mock.CreateWebhookFunc(ctx, webhook)
+}
+
+// CreateWebhookCalls gets all the calls that were made to CreateWebhook.
+// Check the length with:
+//
+//	len(mockedMongoDB.CreateWebhookCalls())
+func (mock *MongoDBMock) CreateWebhookCalls() []struct {
+	Ctx     context.Context
+	Webhook *models.Webhook
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Webhook *models.Webhook
+	}
+	mock.lockCreateWebhook.RLock()
+	calls = mock.calls.CreateWebhook
+	mock.lockCreateWebhook.RUnlock()
+	return calls
+}
+
+// DeleteContent calls DeleteContentFunc.
+func (mock *MongoDBMock) DeleteContent(ctx context.Context, id string) error {
+	if mock.DeleteContentFunc == nil {
+		panic("MongoDBMock.DeleteContentFunc: method is nil but MongoDB.DeleteContent was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
@@ -325,16 +766,17 @@ func (mock *MongoDBMock) GetTopic(ctx context.Context, id string) (*models.Topic
 		Ctx: ctx,
 		ID:  id,
 	}
-	lockMongoDBMockGetTopic.Lock()
-	mock.calls.GetTopic = append(mock.calls.GetTopic, callInfo)
-	lockMongoDBMockGetTopic.Unlock()
-	return mock.GetTopicFunc(ctx, id)
+	mock.lockDeleteContent.Lock()
+	mock.calls.DeleteContent = append(mock.calls.DeleteContent, callInfo)
+	mock.lockDeleteContent.Unlock()
+	return mock.DeleteContentFunc(ctx, id)
 }
 
-// GetTopicCalls gets all the calls that were made to GetTopic.
+// DeleteContentCalls gets all the calls that were made to DeleteContent.
 // Check the length with:
-//     len(mockedMongoDB.GetTopicCalls())
-func (mock *MongoDBMock) GetTopicCalls() []struct {
+//
+//	len(mockedMongoDB.DeleteContentCalls())
+func (mock *MongoDBMock) DeleteContentCalls() []struct {
 	Ctx context.Context
 	ID  string
 } {
@@ -342,115 +784,986 @@ func (mock *MongoDBMock) GetTopicCalls() []struct {
 		Ctx context.Context
 		ID  string
 	}
-	lockMongoDBMockGetTopic.RLock()
-	calls = mock.calls.GetTopic
-	lockMongoDBMockGetTopic.RUnlock()
+	mock.lockDeleteContent.RLock()
+	calls = mock.calls.DeleteContent
+	mock.lockDeleteContent.RUnlock()
 	return calls
 }
 
-// UpdateReleaseDate calls UpdateReleaseDateFunc.
-func (mock *MongoDBMock) UpdateReleaseDate(ctx context.Context, id string, releaseDate time.Time) error {
-	if mock.UpdateReleaseDateFunc == nil {
-		panic("MongoDBMock.UpdateReleaseDateFunc: method is nil but MongoDB.UpdateReleaseDate was just called")
+// DeleteRedirect calls DeleteRedirectFunc.
+func (mock *MongoDBMock) DeleteRedirect(ctx context.Context, from string) error {
+	if mock.DeleteRedirectFunc == nil {
+		panic("MongoDBMock.DeleteRedirectFunc: method is nil but MongoDB.DeleteRedirect was just called")
 	}
 	callInfo := struct {
-		Ctx         context.Context
-		ID          string
-		ReleaseDate time.Time
+		Ctx  context.Context
+		From string
 	}{
-		Ctx:         ctx,
-		ID:          id,
-		ReleaseDate: releaseDate,
+		Ctx:  ctx,
+		From: from,
 	}
-	lockMongoDBMockUpdateReleaseDate.Lock()
-	mock.calls.UpdateReleaseDate = append(mock.calls.UpdateReleaseDate, callInfo)
-	lockMongoDBMockUpdateReleaseDate.Unlock()
-	return mock.UpdateReleaseDateFunc(ctx, id, releaseDate)
+	mock.lockDeleteRedirect.Lock()
+	mock.calls.DeleteRedirect = append(mock.calls.DeleteRedirect, callInfo)
+	mock.lockDeleteRedirect.Unlock()
+	return mock.DeleteRedirectFunc(ctx, from)
 }
 
-// UpdateReleaseDateCalls gets all the calls that were made to UpdateReleaseDate.
+// DeleteRedirectCalls gets all the calls that were made to DeleteRedirect.
 // Check the length with:
-//     len(mockedMongoDB.UpdateReleaseDateCalls())
-func (mock *MongoDBMock) UpdateReleaseDateCalls() []struct {
-	Ctx         context.Context
-	ID          string
-	ReleaseDate time.Time
+//
+//	len(mockedMongoDB.DeleteRedirectCalls())
+func (mock *MongoDBMock) DeleteRedirectCalls() []struct {
+	Ctx  context.Context
+	From string
 } {
 	var calls []struct {
-		Ctx         context.Context
-		ID          string
-		ReleaseDate time.Time
+		Ctx  context.Context
+		From string
 	}
-	lockMongoDBMockUpdateReleaseDate.RLock()
-	calls = mock.calls.UpdateReleaseDate
-	lockMongoDBMockUpdateReleaseDate.RUnlock()
+	mock.lockDeleteRedirect.RLock()
+	calls = mock.calls.DeleteRedirect
+	mock.lockDeleteRedirect.RUnlock()
 	return calls
 }
 
-// UpdateState calls UpdateStateFunc.
-func (mock *MongoDBMock) UpdateState(ctx context.Context, id string, state string) error {
-	if mock.UpdateStateFunc == nil {
-		panic("MongoDBMock.UpdateStateFunc: method is nil but MongoDB.UpdateState was just called")
+// DeleteTopic calls DeleteTopicFunc.
+func (mock *MongoDBMock) DeleteTopic(ctx context.Context, id string) error {
+	if mock.DeleteTopicFunc == nil {
+		panic("MongoDBMock.DeleteTopicFunc: method is nil but MongoDB.DeleteTopic was just called")
 	}
 	callInfo := struct {
-		Ctx   context.Context
-		ID    string
-		State string
+		Ctx context.Context
+		ID  string
 	}{
-		Ctx:   ctx,
-		ID:    id,
-		State: state,
+		Ctx: ctx,
+		ID:  id,
 	}
-	lockMongoDBMockUpdateState.Lock()
-	mock.calls.UpdateState = append(mock.calls.UpdateState, callInfo)
-	lockMongoDBMockUpdateState.Unlock()
-	return mock.UpdateStateFunc(ctx, id, state)
+	mock.lockDeleteTopic.Lock()
+	mock.calls.DeleteTopic = append(mock.calls.DeleteTopic, callInfo)
+	mock.lockDeleteTopic.Unlock()
+	return mock.DeleteTopicFunc(ctx, id)
 }
 
-// UpdateStateCalls gets all the calls that were made to UpdateState.
+// DeleteTopicCalls gets all the calls that were made to DeleteTopic.
 // Check the length with:
-//     len(mockedMongoDB.UpdateStateCalls())
-func (mock *MongoDBMock) UpdateStateCalls() []struct {
-	Ctx   context.Context
-	ID    string
-	State string
+//
+//	len(mockedMongoDB.DeleteTopicCalls())
+func (mock *MongoDBMock) DeleteTopicCalls() []struct {
+	Ctx context.Context
+	ID  string
 } {
 	var calls []struct {
-		Ctx   context.Context
-		ID    string
-		State string
+		Ctx context.Context
+		ID  string
 	}
-	lockMongoDBMockUpdateState.RLock()
-	calls = mock.calls.UpdateState
-	lockMongoDBMockUpdateState.RUnlock()
+	mock.lockDeleteTopic.RLock()
+	calls = mock.calls.DeleteTopic
+	mock.lockDeleteTopic.RUnlock()
 	return calls
 }
 
-// UpdateTopic calls UpdateTopicFunc.
-func (mock *MongoDBMock) UpdateTopic(ctx context.Context, host string, id string, topic *models.TopicUpdate) error {
-	if mock.UpdateTopicFunc == nil {
-		panic("MongoDBMock.UpdateTopicFunc: method is nil but MongoDB.UpdateTopic was just called")
+// DeleteWebhook calls DeleteWebhookFunc.
+func (mock *MongoDBMock) DeleteWebhook(ctx context.Context, id string) error {
+	if mock.DeleteWebhookFunc == nil {
+		panic("MongoDBMock.DeleteWebhookFunc: method is nil but MongoDB.DeleteWebhook was just called")
 	}
 	callInfo := struct {
-		Ctx   context.Context
-		Host  string
-		ID    string
-		Topic *models.TopicUpdate
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteWebhook.Lock()
+	mock.calls.DeleteWebhook = append(mock.calls.DeleteWebhook, callInfo)
+	mock.lockDeleteWebhook.Unlock()
+	return mock.DeleteWebhookFunc(ctx, id)
+}
+
+// DeleteWebhookCalls gets all the calls that were made to DeleteWebhook.
+// Check the length with:
+//
+//	len(mockedMongoDB.DeleteWebhookCalls())
+func (mock *MongoDBMock) DeleteWebhookCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockDeleteWebhook.RLock()
+	calls = mock.calls.DeleteWebhook
+	mock.lockDeleteWebhook.RUnlock()
+	return calls
+}
+
+// GetAllContent calls GetAllContentFunc.
+func (mock *MongoDBMock) GetAllContent(ctx context.Context) ([]models.ContentResponse, error) {
+	if mock.GetAllContentFunc == nil {
+		panic("MongoDBMock.GetAllContentFunc: method is nil but MongoDB.GetAllContent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAllContent.Lock()
+	mock.calls.GetAllContent = append(mock.calls.GetAllContent, callInfo)
+	mock.lockGetAllContent.Unlock()
+	return mock.GetAllContentFunc(ctx)
+}
+
+// GetAllContentCalls gets all the calls that were made to GetAllContent.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetAllContentCalls())
+func (mock *MongoDBMock) GetAllContentCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAllContent.RLock()
+	calls = mock.calls.GetAllContent
+	mock.lockGetAllContent.RUnlock()
+	return calls
+}
+
+// GetAllTopics calls GetAllTopicsFunc.
+func (mock *MongoDBMock) GetAllTopics(ctx context.Context) ([]models.TopicResponse, error) {
+	if mock.GetAllTopicsFunc == nil {
+		panic("MongoDBMock.GetAllTopicsFunc: method is nil but MongoDB.GetAllTopics was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetAllTopics.Lock()
+	mock.calls.GetAllTopics = append(mock.calls.GetAllTopics, callInfo)
+	mock.lockGetAllTopics.Unlock()
+	return mock.GetAllTopicsFunc(ctx)
+}
+
+// GetAllTopicsCalls gets all the calls that were made to GetAllTopics.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetAllTopicsCalls())
+func (mock *MongoDBMock) GetAllTopicsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetAllTopics.RLock()
+	calls = mock.calls.GetAllTopics
+	mock.lockGetAllTopics.RUnlock()
+	return calls
+}
+
+// GetArchivedTopicIDs calls GetArchivedTopicIDsFunc.
+func (mock *MongoDBMock) GetArchivedTopicIDs(ctx context.Context) ([]string, error) {
+	if mock.GetArchivedTopicIDsFunc == nil {
+		panic("MongoDBMock.GetArchivedTopicIDsFunc: method is nil but MongoDB.GetArchivedTopicIDs was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetArchivedTopicIDs.Lock()
+	mock.calls.GetArchivedTopicIDs = append(mock.calls.GetArchivedTopicIDs, callInfo)
+	mock.lockGetArchivedTopicIDs.Unlock()
+	return mock.GetArchivedTopicIDsFunc(ctx)
+}
+
+// GetArchivedTopicIDsCalls gets all the calls that were made to GetArchivedTopicIDs.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetArchivedTopicIDsCalls())
+func (mock *MongoDBMock) GetArchivedTopicIDsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetArchivedTopicIDs.RLock()
+	calls = mock.calls.GetArchivedTopicIDs
+	mock.lockGetArchivedTopicIDs.RUnlock()
+	return calls
+}
+
+// GetCollection calls GetCollectionFunc.
+func (mock *MongoDBMock) GetCollection(ctx context.Context, id string) (*models.Collection, error) {
+	if mock.GetCollectionFunc == nil {
+		panic("MongoDBMock.GetCollectionFunc: method is nil but MongoDB.GetCollection was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetCollection.Lock()
+	mock.calls.GetCollection = append(mock.calls.GetCollection, callInfo)
+	mock.lockGetCollection.Unlock()
+	return mock.GetCollectionFunc(ctx, id)
+}
+
+// GetCollectionCalls gets all the calls that were made to GetCollection.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetCollectionCalls())
+func (mock *MongoDBMock) GetCollectionCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetCollection.RLock()
+	calls = mock.calls.GetCollection
+	mock.lockGetCollection.RUnlock()
+	return calls
+}
+
+// GetCollections calls GetCollectionsFunc.
+func (mock *MongoDBMock) GetCollections(ctx context.Context) ([]models.Collection, error) {
+	if mock.GetCollectionsFunc == nil {
+		panic("MongoDBMock.GetCollectionsFunc: method is nil but MongoDB.GetCollections was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetCollections.Lock()
+	mock.calls.GetCollections = append(mock.calls.GetCollections, callInfo)
+	mock.lockGetCollections.Unlock()
+	return mock.GetCollectionsFunc(ctx)
+}
+
+// GetCollectionsCalls gets all the calls that were made to GetCollections.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetCollectionsCalls())
+func (mock *MongoDBMock) GetCollectionsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetCollections.RLock()
+	calls = mock.calls.GetCollections
+	mock.lockGetCollections.RUnlock()
+	return calls
+}
+
+// GetContent calls GetContentFunc.
+func (mock *MongoDBMock) GetContent(ctx context.Context, id string, queryTypeFlags int) (*models.ContentResponse, error) {
+	if mock.GetContentFunc == nil {
+		panic("MongoDBMock.GetContentFunc: method is nil but MongoDB.GetContent was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		ID             string
+		QueryTypeFlags int
+	}{
+		Ctx:            ctx,
+		ID:             id,
+		QueryTypeFlags: queryTypeFlags,
+	}
+	mock.lockGetContent.Lock()
+	mock.calls.GetContent = append(mock.calls.GetContent, callInfo)
+	mock.lockGetContent.Unlock()
+	return mock.GetContentFunc(ctx, id, queryTypeFlags)
+}
+
+// GetContentCalls gets all the calls that were made to GetContent.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetContentCalls())
+func (mock *MongoDBMock) GetContentCalls() []struct {
+	Ctx            context.Context
+	ID             string
+	QueryTypeFlags int
+} {
+	var calls []struct {
+		Ctx            context.Context
+		ID             string
+		QueryTypeFlags int
+	}
+	mock.lockGetContent.RLock()
+	calls = mock.calls.GetContent
+	mock.lockGetContent.RUnlock()
+	return calls
+}
+
+// GetPublications calls GetPublicationsFunc.
+func (mock *MongoDBMock) GetPublications(ctx context.Context, topicID string, limit int) ([]models.Publication, error) {
+	if mock.GetPublicationsFunc == nil {
+		panic("MongoDBMock.GetPublicationsFunc: method is nil but MongoDB.GetPublications was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		TopicID string
+		Limit   int
+	}{
+		Ctx:     ctx,
+		TopicID: topicID,
+		Limit:   limit,
+	}
+	mock.lockGetPublications.Lock()
+	mock.calls.GetPublications = append(mock.calls.GetPublications, callInfo)
+	mock.lockGetPublications.Unlock()
+	return mock.GetPublicationsFunc(ctx, topicID, limit)
+}
+
+// GetPublicationsCalls gets all the calls that were made to GetPublications.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetPublicationsCalls())
+func (mock *MongoDBMock) GetPublicationsCalls() []struct {
+	Ctx     context.Context
+	TopicID string
+	Limit   int
+} {
+	var calls []struct {
+		Ctx     context.Context
+		TopicID string
+		Limit   int
+	}
+	mock.lockGetPublications.RLock()
+	calls = mock.calls.GetPublications
+	mock.lockGetPublications.RUnlock()
+	return calls
+}
+
+// GetRedirect calls GetRedirectFunc.
+func (mock *MongoDBMock) GetRedirect(ctx context.Context, from string) (*models.Redirect, error) {
+	if mock.GetRedirectFunc == nil {
+		panic("MongoDBMock.GetRedirectFunc: method is nil but MongoDB.GetRedirect was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		From string
+	}{
+		Ctx:  ctx,
+		From: from,
+	}
+	mock.lockGetRedirect.Lock()
+	mock.calls.GetRedirect = append(mock.calls.GetRedirect, callInfo)
+	mock.lockGetRedirect.Unlock()
+	return mock.GetRedirectFunc(ctx, from)
+}
+
+// GetRedirectCalls gets all the calls that were made to GetRedirect.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetRedirectCalls())
+func (mock *MongoDBMock) GetRedirectCalls() []struct {
+	Ctx  context.Context
+	From string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		From string
+	}
+	mock.lockGetRedirect.RLock()
+	calls = mock.calls.GetRedirect
+	mock.lockGetRedirect.RUnlock()
+	return calls
+}
+
+// GetRedirects calls GetRedirectsFunc.
+func (mock *MongoDBMock) GetRedirects(ctx context.Context) ([]models.Redirect, error) {
+	if mock.GetRedirectsFunc == nil {
+		panic("MongoDBMock.GetRedirectsFunc: method is nil but MongoDB.GetRedirects was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetRedirects.Lock()
+	mock.calls.GetRedirects = append(mock.calls.GetRedirects, callInfo)
+	mock.lockGetRedirects.Unlock()
+	return mock.GetRedirectsFunc(ctx)
+}
+
+// GetRedirectsCalls gets all the calls that were made to GetRedirects.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetRedirectsCalls())
+func (mock *MongoDBMock) GetRedirectsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetRedirects.RLock()
+	calls = mock.calls.GetRedirects
+	mock.lockGetRedirects.RUnlock()
+	return calls
+}
+
+// GetTopic calls GetTopicFunc.
+func (mock *MongoDBMock) GetTopic(ctx context.Context, id string) (*models.TopicResponse, error) {
+	if mock.GetTopicFunc == nil {
+		panic("MongoDBMock.GetTopicFunc: method is nil but MongoDB.GetTopic was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetTopic.Lock()
+	mock.calls.GetTopic = append(mock.calls.GetTopic, callInfo)
+	mock.lockGetTopic.Unlock()
+	return mock.GetTopicFunc(ctx, id)
+}
+
+// GetTopicCalls gets all the calls that were made to GetTopic.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetTopicCalls())
+func (mock *MongoDBMock) GetTopicCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetTopic.RLock()
+	calls = mock.calls.GetTopic
+	mock.lockGetTopic.RUnlock()
+	return calls
+}
+
+// GetWebhook calls GetWebhookFunc.
+func (mock *MongoDBMock) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	if mock.GetWebhookFunc == nil {
+		panic("MongoDBMock.GetWebhookFunc: method is nil but MongoDB.GetWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetWebhook.Lock()
+	mock.calls.GetWebhook = append(mock.calls.GetWebhook, callInfo)
+	mock.lockGetWebhook.Unlock()
+	return mock.GetWebhookFunc(ctx, id)
+}
+
+// GetWebhookCalls gets all the calls that were made to GetWebhook.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetWebhookCalls())
+func (mock *MongoDBMock) GetWebhookCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetWebhook.RLock()
+	calls = mock.calls.GetWebhook
+	mock.lockGetWebhook.RUnlock()
+	return calls
+}
+
+// GetWebhookDeliveries calls GetWebhookDeliveriesFunc.
+func (mock *MongoDBMock) GetWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]models.WebhookDelivery, error) {
+	if mock.GetWebhookDeliveriesFunc == nil {
+		panic("MongoDBMock.GetWebhookDeliveriesFunc: method is nil but MongoDB.GetWebhookDeliveries was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		WebhookID string
+		Limit     int
+	}{
+		Ctx:       ctx,
+		WebhookID: webhookID,
+		Limit:     limit,
+	}
+	mock.lockGetWebhookDeliveries.Lock()
+	mock.calls.GetWebhookDeliveries = append(mock.calls.GetWebhookDeliveries, callInfo)
+	mock.lockGetWebhookDeliveries.Unlock()
+	return mock.GetWebhookDeliveriesFunc(ctx, webhookID, limit)
+}
+
+// GetWebhookDeliveriesCalls gets all the calls that were made to GetWebhookDeliveries.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetWebhookDeliveriesCalls())
+func (mock *MongoDBMock) GetWebhookDeliveriesCalls() []struct {
+	Ctx       context.Context
+	WebhookID string
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		WebhookID string
+		Limit     int
+	}
+	mock.lockGetWebhookDeliveries.RLock()
+	calls = mock.calls.GetWebhookDeliveries
+	mock.lockGetWebhookDeliveries.RUnlock()
+	return calls
+}
+
+// GetWebhooks calls GetWebhooksFunc.
+func (mock *MongoDBMock) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	if mock.GetWebhooksFunc == nil {
+		panic("MongoDBMock.GetWebhooksFunc: method is nil but MongoDB.GetWebhooks was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockGetWebhooks.Lock()
+	mock.calls.GetWebhooks = append(mock.calls.GetWebhooks, callInfo)
+	mock.lockGetWebhooks.Unlock()
+	return mock.GetWebhooksFunc(ctx)
+}
+
+// GetWebhooksCalls gets all the calls that were made to GetWebhooks.
+// Check the length with:
+//
+//	len(mockedMongoDB.GetWebhooksCalls())
+func (mock *MongoDBMock) GetWebhooksCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockGetWebhooks.RLock()
+	calls = mock.calls.GetWebhooks
+	mock.lockGetWebhooks.RUnlock()
+	return calls
+}
+
+// InsertContent calls InsertContentFunc.
+func (mock *MongoDBMock) InsertContent(ctx context.Context, content *models.ContentResponse) error {
+	if mock.InsertContentFunc == nil {
+		panic("MongoDBMock.InsertContentFunc: method is nil but MongoDB.InsertContent was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Content *models.ContentResponse
+	}{
+		Ctx:     ctx,
+		Content: content,
+	}
+	mock.lockInsertContent.Lock()
+	mock.calls.InsertContent = append(mock.calls.InsertContent, callInfo)
+	mock.lockInsertContent.Unlock()
+	return mock.InsertContentFunc(ctx, content)
+}
+
+// InsertContentCalls gets all the calls that were made to InsertContent.
+// Check the length with:
+//
+//	len(mockedMongoDB.InsertContentCalls())
+func (mock *MongoDBMock) InsertContentCalls() []struct {
+	Ctx     context.Context
+	Content *models.ContentResponse
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Content *models.ContentResponse
+	}
+	mock.lockInsertContent.RLock()
+	calls = mock.calls.InsertContent
+	mock.lockInsertContent.RUnlock()
+	return calls
+}
+
+// InsertPublication calls InsertPublicationFunc.
+func (mock *MongoDBMock) InsertPublication(ctx context.Context, publication *models.Publication) error {
+	if mock.InsertPublicationFunc == nil {
+		panic("MongoDBMock.InsertPublicationFunc: method is nil but MongoDB.InsertPublication was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Publication *models.Publication
+	}{
+		Ctx:         ctx,
+		Publication: publication,
+	}
+	mock.lockInsertPublication.Lock()
+	mock.calls.InsertPublication = append(mock.calls.InsertPublication, callInfo)
+	mock.lockInsertPublication.Unlock()
+	return mock.InsertPublicationFunc(ctx, publication)
+}
+
+// InsertPublicationCalls gets all the calls that were made to InsertPublication.
+// Check the length with:
+//
+//	len(mockedMongoDB.InsertPublicationCalls())
+func (mock *MongoDBMock) InsertPublicationCalls() []struct {
+	Ctx         context.Context
+	Publication *models.Publication
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Publication *models.Publication
+	}
+	mock.lockInsertPublication.RLock()
+	calls = mock.calls.InsertPublication
+	mock.lockInsertPublication.RUnlock()
+	return calls
+}
+
+// InsertTopic calls InsertTopicFunc.
+func (mock *MongoDBMock) InsertTopic(ctx context.Context, topic *models.TopicResponse) error {
+	if mock.InsertTopicFunc == nil {
+		panic("MongoDBMock.InsertTopicFunc: method is nil but MongoDB.InsertTopic was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Topic *models.TopicResponse
+	}{
+		Ctx:   ctx,
+		Topic: topic,
+	}
+	mock.lockInsertTopic.Lock()
+	mock.calls.InsertTopic = append(mock.calls.InsertTopic, callInfo)
+	mock.lockInsertTopic.Unlock()
+	return mock.InsertTopicFunc(ctx, topic)
+}
+
+// InsertTopicCalls gets all the calls that were made to InsertTopic.
+// Check the length with:
+//
+//	len(mockedMongoDB.InsertTopicCalls())
+func (mock *MongoDBMock) InsertTopicCalls() []struct {
+	Ctx   context.Context
+	Topic *models.TopicResponse
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Topic *models.TopicResponse
+	}
+	mock.lockInsertTopic.RLock()
+	calls = mock.calls.InsertTopic
+	mock.lockInsertTopic.RUnlock()
+	return calls
+}
+
+// IsSlugInUse calls IsSlugInUseFunc.
+func (mock *MongoDBMock) IsSlugInUse(ctx context.Context, id string, slug string) (bool, error) {
+	if mock.IsSlugInUseFunc == nil {
+		panic("MongoDBMock.IsSlugInUseFunc: method is nil but MongoDB.IsSlugInUse was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		ID   string
+		Slug string
+	}{
+		Ctx:  ctx,
+		ID:   id,
+		Slug: slug,
+	}
+	mock.lockIsSlugInUse.Lock()
+	mock.calls.IsSlugInUse = append(mock.calls.IsSlugInUse, callInfo)
+	mock.lockIsSlugInUse.Unlock()
+	return mock.IsSlugInUseFunc(ctx, id, slug)
+}
+
+// IsSlugInUseCalls gets all the calls that were made to IsSlugInUse.
+// Check the length with:
+//
+//	len(mockedMongoDB.IsSlugInUseCalls())
+func (mock *MongoDBMock) IsSlugInUseCalls() []struct {
+	Ctx  context.Context
+	ID   string
+	Slug string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		ID   string
+		Slug string
+	}
+	mock.lockIsSlugInUse.RLock()
+	calls = mock.calls.IsSlugInUse
+	mock.lockIsSlugInUse.RUnlock()
+	return calls
+}
+
+// ReplaceContent calls ReplaceContentFunc.
+func (mock *MongoDBMock) ReplaceContent(ctx context.Context, content *models.ContentResponse) error {
+	if mock.ReplaceContentFunc == nil {
+		panic("MongoDBMock.ReplaceContentFunc: method is nil but MongoDB.ReplaceContent was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Content *models.ContentResponse
+	}{
+		Ctx:     ctx,
+		Content: content,
+	}
+	mock.lockReplaceContent.Lock()
+	mock.calls.ReplaceContent = append(mock.calls.ReplaceContent, callInfo)
+	mock.lockReplaceContent.Unlock()
+	return mock.ReplaceContentFunc(ctx, content)
+}
+
+// ReplaceContentCalls gets all the calls that were made to ReplaceContent.
+// Check the length with:
+//
+//	len(mockedMongoDB.ReplaceContentCalls())
+func (mock *MongoDBMock) ReplaceContentCalls() []struct {
+	Ctx     context.Context
+	Content *models.ContentResponse
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Content *models.ContentResponse
+	}
+	mock.lockReplaceContent.RLock()
+	calls = mock.calls.ReplaceContent
+	mock.lockReplaceContent.RUnlock()
+	return calls
+}
+
+// ReplaceTopic calls ReplaceTopicFunc.
+func (mock *MongoDBMock) ReplaceTopic(ctx context.Context, topic *models.TopicResponse) error {
+	if mock.ReplaceTopicFunc == nil {
+		panic("MongoDBMock.ReplaceTopicFunc: method is nil but MongoDB.ReplaceTopic was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Topic *models.TopicResponse
+	}{
+		Ctx:   ctx,
+		Topic: topic,
+	}
+	mock.lockReplaceTopic.Lock()
+	mock.calls.ReplaceTopic = append(mock.calls.ReplaceTopic, callInfo)
+	mock.lockReplaceTopic.Unlock()
+	return mock.ReplaceTopicFunc(ctx, topic)
+}
+
+// ReplaceTopicCalls gets all the calls that were made to ReplaceTopic.
+// Check the length with:
+//
+//	len(mockedMongoDB.ReplaceTopicCalls())
+func (mock *MongoDBMock) ReplaceTopicCalls() []struct {
+	Ctx   context.Context
+	Topic *models.TopicResponse
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Topic *models.TopicResponse
+	}
+	mock.lockReplaceTopic.RLock()
+	calls = mock.calls.ReplaceTopic
+	mock.lockReplaceTopic.RUnlock()
+	return calls
+}
+
+// UpdateOwners calls UpdateOwnersFunc.
+func (mock *MongoDBMock) UpdateOwners(ctx context.Context, id string, owners []string) error {
+	if mock.UpdateOwnersFunc == nil {
+		panic("MongoDBMock.UpdateOwnersFunc: method is nil but MongoDB.UpdateOwners was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     string
+		Owners []string
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		Owners: owners,
+	}
+	mock.lockUpdateOwners.Lock()
+	mock.calls.UpdateOwners = append(mock.calls.UpdateOwners, callInfo)
+	mock.lockUpdateOwners.Unlock()
+	return mock.UpdateOwnersFunc(ctx, id, owners)
+}
+
+// UpdateOwnersCalls gets all the calls that were made to UpdateOwners.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateOwnersCalls())
+func (mock *MongoDBMock) UpdateOwnersCalls() []struct {
+	Ctx    context.Context
+	ID     string
+	Owners []string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     string
+		Owners []string
+	}
+	mock.lockUpdateOwners.RLock()
+	calls = mock.calls.UpdateOwners
+	mock.lockUpdateOwners.RUnlock()
+	return calls
+}
+
+// UpdateReleaseDate calls UpdateReleaseDateFunc.
+func (mock *MongoDBMock) UpdateReleaseDate(ctx context.Context, id string, releaseDate time.Time) error {
+	if mock.UpdateReleaseDateFunc == nil {
+		panic("MongoDBMock.UpdateReleaseDateFunc: method is nil but MongoDB.UpdateReleaseDate was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		ID          string
+		ReleaseDate time.Time
+	}{
+		Ctx:         ctx,
+		ID:          id,
+		ReleaseDate: releaseDate,
+	}
+	mock.lockUpdateReleaseDate.Lock()
+	mock.calls.UpdateReleaseDate = append(mock.calls.UpdateReleaseDate, callInfo)
+	mock.lockUpdateReleaseDate.Unlock()
+	return mock.UpdateReleaseDateFunc(ctx, id, releaseDate)
+}
+
+// UpdateReleaseDateCalls gets all the calls that were made to UpdateReleaseDate.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateReleaseDateCalls())
+func (mock *MongoDBMock) UpdateReleaseDateCalls() []struct {
+	Ctx         context.Context
+	ID          string
+	ReleaseDate time.Time
+} {
+	var calls []struct {
+		Ctx         context.Context
+		ID          string
+		ReleaseDate time.Time
+	}
+	mock.lockUpdateReleaseDate.RLock()
+	calls = mock.calls.UpdateReleaseDate
+	mock.lockUpdateReleaseDate.RUnlock()
+	return calls
+}
+
+// UpdateReview calls UpdateReviewFunc.
+func (mock *MongoDBMock) UpdateReview(ctx context.Context, id string, state string, review *models.Review) error {
+	if mock.UpdateReviewFunc == nil {
+		panic("MongoDBMock.UpdateReviewFunc: method is nil but MongoDB.UpdateReview was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     string
+		State  string
+		Review *models.Review
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		State:  state,
+		Review: review,
+	}
+	mock.lockUpdateReview.Lock()
+	mock.calls.UpdateReview = append(mock.calls.UpdateReview, callInfo)
+	mock.lockUpdateReview.Unlock()
+	return mock.UpdateReviewFunc(ctx, id, state, review)
+}
+
+// UpdateReviewCalls gets all the calls that were made to UpdateReview.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateReviewCalls())
+func (mock *MongoDBMock) UpdateReviewCalls() []struct {
+	Ctx    context.Context
+	ID     string
+	State  string
+	Review *models.Review
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     string
+		State  string
+		Review *models.Review
+	}
+	mock.lockUpdateReview.RLock()
+	calls = mock.calls.UpdateReview
+	mock.lockUpdateReview.RUnlock()
+	return calls
+}
+
+// UpdateState calls UpdateStateFunc.
+func (mock *MongoDBMock) UpdateState(ctx context.Context, id string, state string) error {
+	if mock.UpdateStateFunc == nil {
+		panic("MongoDBMock.UpdateStateFunc: method is nil but MongoDB.UpdateState was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		ID    string
+		State string
+	}{
+		Ctx:   ctx,
+		ID:    id,
+		State: state,
+	}
+	mock.lockUpdateState.Lock()
+	mock.calls.UpdateState = append(mock.calls.UpdateState, callInfo)
+	mock.lockUpdateState.Unlock()
+	return mock.UpdateStateFunc(ctx, id, state)
+}
+
+// UpdateStateCalls gets all the calls that were made to UpdateState.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpdateStateCalls())
+func (mock *MongoDBMock) UpdateStateCalls() []struct {
+	Ctx   context.Context
+	ID    string
+	State string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		ID    string
+		State string
+	}
+	mock.lockUpdateState.RLock()
+	calls = mock.calls.UpdateState
+	mock.lockUpdateState.RUnlock()
+	return calls
+}
+
+// UpdateTopic calls UpdateTopicFunc.
+func (mock *MongoDBMock) UpdateTopic(ctx context.Context, host string, id string, topic *models.TopicUpdate) error {
+	if mock.UpdateTopicFunc == nil {
+		panic("MongoDBMock.UpdateTopicFunc: method is nil but MongoDB.UpdateTopic was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Host  string
+		ID    string
+		Topic *models.TopicUpdate
 	}{
 		Ctx:   ctx,
 		Host:  host,
 		ID:    id,
 		Topic: topic,
 	}
-	lockMongoDBMockUpdateTopic.Lock()
+	mock.lockUpdateTopic.Lock()
 	mock.calls.UpdateTopic = append(mock.calls.UpdateTopic, callInfo)
-	lockMongoDBMockUpdateTopic.Unlock()
+	mock.lockUpdateTopic.Unlock()
 	return mock.UpdateTopicFunc(ctx, host, id, topic)
 }
 
 // UpdateTopicCalls gets all the calls that were made to UpdateTopic.
 // Check the length with:
-//     len(mockedMongoDB.UpdateTopicCalls())
+//
+//	len(mockedMongoDB.UpdateTopicCalls())
 func (mock *MongoDBMock) UpdateTopicCalls() []struct {
 	Ctx   context.Context
 	Host  string
@@ -463,9 +1776,121 @@ func (mock *MongoDBMock) UpdateTopicCalls() []struct {
 		ID    string
 		Topic *models.TopicUpdate
 	}
-	lockMongoDBMockUpdateTopic.RLock()
+	mock.lockUpdateTopic.RLock()
 	calls = mock.calls.UpdateTopic
-	lockMongoDBMockUpdateTopic.RUnlock()
+	mock.lockUpdateTopic.RUnlock()
+	return calls
+}
+
+// UpsertCollection calls UpsertCollectionFunc.
+func (mock *MongoDBMock) UpsertCollection(ctx context.Context, collection *models.Collection) error {
+	if mock.UpsertCollectionFunc == nil {
+		panic("MongoDBMock.UpsertCollectionFunc: method is nil but MongoDB.UpsertCollection was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Collection *models.Collection
+	}{
+		Ctx:        ctx,
+		Collection: collection,
+	}
+	mock.lockUpsertCollection.Lock()
+	mock.calls.UpsertCollection = append(mock.calls.UpsertCollection, callInfo)
+	mock.lockUpsertCollection.Unlock()
+	return mock.UpsertCollectionFunc(ctx, collection)
+}
+
+// UpsertCollectionCalls gets all the calls that were made to UpsertCollection.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpsertCollectionCalls())
+func (mock *MongoDBMock) UpsertCollectionCalls() []struct {
+	Ctx        context.Context
+	Collection *models.Collection
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Collection *models.Collection
+	}
+	mock.lockUpsertCollection.RLock()
+	calls = mock.calls.UpsertCollection
+	mock.lockUpsertCollection.RUnlock()
+	return calls
+}
+
+// UpsertContent calls UpsertContentFunc.
+func (mock *MongoDBMock) UpsertContent(ctx context.Context, id string, content *models.ContentResponse) error {
+	if mock.UpsertContentFunc == nil {
+		panic("MongoDBMock.UpsertContentFunc: method is nil but MongoDB.UpsertContent was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ID      string
+		Content *models.ContentResponse
+	}{
+		Ctx:     ctx,
+		ID:      id,
+		Content: content,
+	}
+	mock.lockUpsertContent.Lock()
+	mock.calls.UpsertContent = append(mock.calls.UpsertContent, callInfo)
+	mock.lockUpsertContent.Unlock()
+	return mock.UpsertContentFunc(ctx, id, content)
+}
+
+// UpsertContentCalls gets all the calls that were made to UpsertContent.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpsertContentCalls())
+func (mock *MongoDBMock) UpsertContentCalls() []struct {
+	Ctx     context.Context
+	ID      string
+	Content *models.ContentResponse
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ID      string
+		Content *models.ContentResponse
+	}
+	mock.lockUpsertContent.RLock()
+	calls = mock.calls.UpsertContent
+	mock.lockUpsertContent.RUnlock()
+	return calls
+}
+
+// UpsertRedirect calls UpsertRedirectFunc.
+func (mock *MongoDBMock) UpsertRedirect(ctx context.Context, redirect *models.Redirect) error {
+	if mock.UpsertRedirectFunc == nil {
+		panic("MongoDBMock.UpsertRedirectFunc: method is nil but MongoDB.UpsertRedirect was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Redirect *models.Redirect
+	}{
+		Ctx:      ctx,
+		Redirect: redirect,
+	}
+	mock.lockUpsertRedirect.Lock()
+	mock.calls.UpsertRedirect = append(mock.calls.UpsertRedirect, callInfo)
+	mock.lockUpsertRedirect.Unlock()
+	return mock.UpsertRedirectFunc(ctx, redirect)
+}
+
+// UpsertRedirectCalls gets all the calls that were made to UpsertRedirect.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpsertRedirectCalls())
+func (mock *MongoDBMock) UpsertRedirectCalls() []struct {
+	Ctx      context.Context
+	Redirect *models.Redirect
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Redirect *models.Redirect
+	}
+	mock.lockUpsertRedirect.RLock()
+	calls = mock.calls.UpsertRedirect
+	mock.lockUpsertRedirect.RUnlock()
 	return calls
 }
 
@@ -483,15 +1908,16 @@ func (mock *MongoDBMock) UpsertTopic(ctx context.Context, id string, topic *mode
 		ID:    id,
 		Topic: topic,
 	}
-	lockMongoDBMockUpsertTopic.Lock()
+	mock.lockUpsertTopic.Lock()
 	mock.calls.UpsertTopic = append(mock.calls.UpsertTopic, callInfo)
-	lockMongoDBMockUpsertTopic.Unlock()
+	mock.lockUpsertTopic.Unlock()
 	return mock.UpsertTopicFunc(ctx, id, topic)
 }
 
 // UpsertTopicCalls gets all the calls that were made to UpsertTopic.
 // Check the length with:
-//     len(mockedMongoDB.UpsertTopicCalls())
+//
+//	len(mockedMongoDB.UpsertTopicCalls())
 func (mock *MongoDBMock) UpsertTopicCalls() []struct {
 	Ctx   context.Context
 	ID    string
@@ -502,8 +1928,44 @@ func (mock *MongoDBMock) UpsertTopicCalls() []struct {
 		ID    string
 		Topic *models.TopicResponse
 	}
-	lockMongoDBMockUpsertTopic.RLock()
+	mock.lockUpsertTopic.RLock()
 	calls = mock.calls.UpsertTopic
-	lockMongoDBMockUpsertTopic.RUnlock()
+	mock.lockUpsertTopic.RUnlock()
+	return calls
+}
+
+// UpsertWebhookDelivery calls UpsertWebhookDeliveryFunc.
+func (mock *MongoDBMock) UpsertWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if mock.UpsertWebhookDeliveryFunc == nil {
+		panic("MongoDBMock.UpsertWebhookDeliveryFunc: method is nil but MongoDB.UpsertWebhookDelivery was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Delivery *models.WebhookDelivery
+	}{
+		Ctx:      ctx,
+		Delivery: delivery,
+	}
+	mock.lockUpsertWebhookDelivery.Lock()
+	mock.calls.UpsertWebhookDelivery = append(mock.calls.UpsertWebhookDelivery, callInfo)
+	mock.lockUpsertWebhookDelivery.Unlock()
+	return mock.UpsertWebhookDeliveryFunc(ctx, delivery)
+}
+
+// UpsertWebhookDeliveryCalls gets all the calls that were made to UpsertWebhookDelivery.
+// Check the length with:
+//
+//	len(mockedMongoDB.UpsertWebhookDeliveryCalls())
+func (mock *MongoDBMock) UpsertWebhookDeliveryCalls() []struct {
+	Ctx      context.Context
+	Delivery *models.WebhookDelivery
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Delivery *models.WebhookDelivery
+	}
+	mock.lockUpsertWebhookDelivery.RLock()
+	calls = mock.calls.UpsertWebhookDelivery
+	mock.lockUpsertWebhookDelivery.RUnlock()
 	return calls
 }
@@ -0,0 +1,109 @@
+// Package tracing sets up OpenTelemetry tracing, so that a request can be followed from its caller, through the
+// handlers of the API, into mongo.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerName is the name of the tracer of the service's own spans
+const TracerName = "github.com/ONSdigital/dp-topic-api"
+
+// The exporters the spans can be sent to. With none, spans are not recorded, but the trace context of requests is
+// still extracted by the middleware and propagated.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Setup sets the global tracer provider, exporting spans with the exporter of the config, and the global propagator of
+// W3C trace context and baggage. It returns the function that flushes the spans that have not been exported and shuts
+// the provider down.
+func Setup(ctx context.Context, cfg *config.Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var closeFile func() error
+	switch cfg.OTelExporter {
+	case ExporterNone:
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		var w io.Writer = os.Stdout
+		if cfg.OTelExporterFile != "" {
+			file, err := os.OpenFile(cfg.OTelExporterFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open trace file: %w", err)
+			}
+			w, closeFile = file, file.Close
+		}
+		var err error
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(w)); err != nil {
+			return nil, err
+		}
+	case ExporterOTLP:
+		var err error
+		if exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(cfg.OTelOTLPEndpoint), otlptracehttp.WithInsecure()); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unrecognised trace exporter: %q", cfg.OTelExporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", cfg.OTelServiceName)))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closeFile != nil {
+			if closeErr := closeFile(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+// Tracer returns the tracer of the service's own spans, from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Middleware is a router middleware that starts a server span for each request, continuing the trace of its caller,
+// named by the method and the template of the route it matched, e.g. GET /topics/{id}. With the none exporter, the span
+// is not recorded, but it carries the trace context of the caller.
+func Middleware(h http.Handler) http.Handler {
+	return otelhttp.NewHandler(h, "topic-api", otelhttp.WithSpanNameFormatter(spanName))
+}
+
+// spanName returns the name of the server span of a request
+func spanName(_ string, req *http.Request) string {
+	if route := mux.CurrentRoute(req); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return req.Method + " " + template
+		}
+	}
+	return req.Method
+}
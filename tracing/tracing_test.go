@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ONSdigital/dp-topic-api/config"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSetup(t *testing.T) {
+	Convey("Given tracing is set up to export spans to a file", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		traceCfg := *cfg
+		traceCfg.OTelExporter = ExporterStdout
+		traceCfg.OTelExporterFile = filepath.Join(t.TempDir(), "traces.json")
+
+		stop, err := Setup(context.Background(), &traceCfg)
+		So(err, ShouldBeNil)
+
+		router := mux.NewRouter()
+		router.Use(Middleware)
+		router.HandleFunc("/topics/{id}", func(w http.ResponseWriter, req *http.Request) {}).Methods(http.MethodGet)
+
+		Convey("When a request continuing the trace of its caller is served, and tracing is stopped", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:25300/topics/1", http.NoBody)
+			req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+			So(stop(context.Background()), ShouldBeNil)
+
+			Convey("Then its server span is exported, named by its route, as part of the caller's trace", func() {
+				traces, err := os.ReadFile(traceCfg.OTelExporterFile)
+				So(err, ShouldBeNil)
+				So(string(traces), ShouldContainSubstring, `"Name":"GET /topics/{id}"`)
+				So(string(traces), ShouldContainSubstring, `"TraceID":"4bf92f3577b34da6a3ce929d0e0e4736"`)
+				So(string(traces), ShouldContainSubstring, `"service.name"`)
+			})
+		})
+	})
+
+	Convey("Given tracing is set up not to export spans", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		traceCfg := *cfg
+		traceCfg.OTelExporter = ExporterNone
+
+		stop, err := Setup(context.Background(), &traceCfg)
+		So(err, ShouldBeNil)
+		defer func() { So(stop(context.Background()), ShouldBeNil) }()
+
+		var spanContext trace.SpanContext
+		router := mux.NewRouter()
+		router.Use(Middleware)
+		router.HandleFunc("/topics/{id}", func(w http.ResponseWriter, req *http.Request) {
+			spanContext = trace.SpanContextFromContext(req.Context())
+		}).Methods(http.MethodGet)
+
+		Convey("When a request continuing the trace of its caller is served", func() {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:25300/topics/1", http.NoBody)
+			req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			Convey("Then the handler sees the caller's trace context, so that it is propagated", func() {
+				So(spanContext.TraceID().String(), ShouldEqual, "4bf92f3577b34da6a3ce929d0e0e4736")
+				So(spanContext.SpanID().String(), ShouldEqual, "00f067aa0ba902b7")
+			})
+		})
+	})
+
+	Convey("Given an unrecognised exporter, setting up tracing fails", t, func() {
+		cfg, err := config.Get()
+		So(err, ShouldBeNil)
+		traceCfg := *cfg
+		traceCfg.OTelExporter = "jaeger"
+
+		_, err = Setup(context.Background(), &traceCfg)
+		So(err, ShouldNotBeNil)
+	})
+}
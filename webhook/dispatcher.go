@@ -0,0 +1,227 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gofrs/uuid"
+)
+
+// Headers sent with every webhook delivery
+const (
+	EventHeader     = "X-Topic-Api-Event"
+	DeliveryHeader  = "X-Topic-Api-Delivery"
+	SignatureHeader = "X-Topic-Api-Signature"
+)
+
+// Store represents the methods required to look up subscriptions and record their deliveries
+type Store interface {
+	GetWebhooks(ctx context.Context) ([]models.Webhook, error)
+	UpsertWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+// HTTPClient represents the method required to send a delivery
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Dispatcher delivers topic lifecycle events to the webhook subscriptions that have registered for them
+type Dispatcher struct {
+	store      Store
+	client     HTTPClient
+	maxRetries int
+	backoff    time.Duration
+	wg         sync.WaitGroup
+	closing    chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewDispatcher creates a Dispatcher that will attempt each delivery up to maxRetries+1 times,
+// doubling the wait between attempts starting from backoff
+func NewDispatcher(store Store, client HTTPClient, maxRetries int, backoff time.Duration) *Dispatcher {
+	return &Dispatcher{
+		store:      store,
+		client:     client,
+		maxRetries: maxRetries,
+		backoff:    backoff,
+		closing:    make(chan struct{}),
+	}
+}
+
+// Notify asynchronously delivers the event to every subscription that filters on its type.
+// The request context is detached so deliveries outlive the request that triggered them.
+func (d *Dispatcher) Notify(ctx context.Context, eventType, topicID, state string) {
+	ctx = context.WithoutCancel(ctx)
+
+	event := models.WebhookEvent{
+		ID:         newID(),
+		Type:       eventType,
+		TopicID:    topicID,
+		State:      state,
+		OccurredAt: time.Now().UTC(),
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.dispatch(ctx, &event)
+	}()
+}
+
+// Close stops any further retries and waits for in-flight deliveries to finish, or for the context to be done
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.closeOnce.Do(func() { close(d.closing) })
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, event *models.WebhookEvent) {
+	logdata := log.Data{"event": event}
+
+	webhooks, err := d.store.GetWebhooks(ctx)
+	if err != nil {
+		log.Error(ctx, "failed to get webhooks to notify", err, logdata)
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Error(ctx, "failed to marshal webhook event", err, logdata)
+		return
+	}
+
+	for i := range webhooks {
+		if !webhooks[i].Subscribes(event.Type) {
+			continue
+		}
+
+		webhook := webhooks[i]
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.deliver(ctx, &webhook, event, payload)
+		}()
+	}
+}
+
+// deliver sends the payload to a single subscription, retrying with exponential backoff,
+// and records the outcome of every attempt in the delivery log
+func (d *Dispatcher) deliver(ctx context.Context, webhook *models.Webhook, event *models.WebhookEvent, payload []byte) {
+	createdAt := time.Now()
+	delivery := &models.WebhookDelivery{
+		ID:        newID(),
+		WebhookID: webhook.ID,
+		Event:     *event,
+		Status:    models.WebhookDeliveryPending,
+		CreatedAt: &createdAt,
+	}
+	logdata := log.Data{"webhook_id": webhook.ID, "delivery_id": delivery.ID, "event_type": event.Type}
+
+	wait := d.backoff
+retries:
+	for {
+		delivery.Attempts++
+		status, err := d.send(ctx, webhook, delivery.ID, event.Type, payload)
+		delivery.ResponseStatus = status
+		if err == nil {
+			delivery.Status = models.WebhookDeliverySucceeded
+			delivery.LastError = ""
+			d.record(ctx, delivery, logdata)
+			log.Info(ctx, "webhook delivered", logdata)
+			return
+		}
+
+		delivery.LastError = err.Error()
+		if delivery.Attempts > d.maxRetries {
+			break
+		}
+		d.record(ctx, delivery, logdata)
+
+		select {
+		case <-time.After(wait):
+			wait *= 2
+		case <-d.closing:
+			delivery.LastError = "retries abandoned on shutdown, last error: " + delivery.LastError
+			break retries
+		}
+	}
+
+	delivery.Status = models.WebhookDeliveryFailed
+	d.record(ctx, delivery, logdata)
+	log.Warn(ctx, "webhook delivery failed after all attempts", logdata)
+}
+
+func (d *Dispatcher) send(ctx context.Context, webhook *models.Webhook, deliveryID, eventType string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected status code from webhook: %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (d *Dispatcher) record(ctx context.Context, delivery *models.WebhookDelivery, logdata log.Data) {
+	if err := d.store.UpsertWebhookDelivery(ctx, delivery); err != nil {
+		log.Error(ctx, "failed to record webhook delivery", err, logdata)
+	}
+}
+
+// Sign returns the value of the signature header for a payload, being the hex encoded
+// HMAC-SHA256 of the payload keyed with the subscription secret
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret generates a random secret for a subscription that did not provide one
+func NewSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func newID() string {
+	return uuid.Must(uuid.NewV4()).String()
+}
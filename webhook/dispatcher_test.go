@@ -0,0 +1,135 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-topic-api/models"
+	"github.com/ONSdigital/dp-topic-api/webhook"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type storeStub struct {
+	mu         sync.Mutex
+	webhooks   []models.Webhook
+	deliveries map[string]models.WebhookDelivery
+}
+
+func (s *storeStub) GetWebhooks(_ context.Context) ([]models.Webhook, error) {
+	return s.webhooks, nil
+}
+
+func (s *storeStub) UpsertWebhookDelivery(_ context.Context, delivery *models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (s *storeStub) delivered() []models.WebhookDelivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]models.WebhookDelivery, 0, len(s.deliveries))
+	for id := range s.deliveries {
+		result = append(result, s.deliveries[id])
+	}
+	return result
+}
+
+func TestDispatcherNotify(t *testing.T) {
+	Convey("Given a subscriber that accepts deliveries", t, func() {
+		var mu sync.Mutex
+		var received []*http.Request
+		var bodies [][]byte
+		subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			received = append(received, r)
+			bodies = append(bodies, body)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer subscriber.Close()
+
+		store := &storeStub{
+			webhooks: []models.Webhook{
+				{ID: "published", URL: subscriber.URL, Events: []string{models.WebhookEventTopicPublished}, Secret: "s3cret"},
+				{ID: "updated", URL: subscriber.URL, Events: []string{models.WebhookEventTopicUpdated}, Secret: "other"},
+			},
+			deliveries: map[string]models.WebhookDelivery{},
+		}
+		dispatcher := webhook.NewDispatcher(store, subscriber.Client(), 2, time.Millisecond)
+
+		Convey("When a published event is notified", func() {
+			dispatcher.Notify(context.Background(), models.WebhookEventTopicPublished, "economy", "published")
+			So(dispatcher.Close(context.Background()), ShouldBeNil)
+
+			Convey("Then only the matching subscription receives a signed delivery", func() {
+				So(received, ShouldHaveLength, 1)
+				So(received[0].Header.Get(webhook.EventHeader), ShouldEqual, models.WebhookEventTopicPublished)
+				So(received[0].Header.Get(webhook.SignatureHeader), ShouldEqual, webhook.Sign("s3cret", bodies[0]))
+
+				var event models.WebhookEvent
+				So(json.Unmarshal(bodies[0], &event), ShouldBeNil)
+				So(event.TopicID, ShouldEqual, "economy")
+				So(event.State, ShouldEqual, "published")
+			})
+
+			Convey("And the successful delivery is recorded", func() {
+				deliveries := store.delivered()
+				So(deliveries, ShouldHaveLength, 1)
+				So(deliveries[0].WebhookID, ShouldEqual, "published")
+				So(deliveries[0].Status, ShouldEqual, models.WebhookDeliverySucceeded)
+				So(deliveries[0].Attempts, ShouldEqual, 1)
+				So(deliveries[0].ResponseStatus, ShouldEqual, http.StatusNoContent)
+			})
+		})
+	})
+
+	Convey("Given a subscriber that always fails", t, func() {
+		var mu sync.Mutex
+		calls := 0
+		subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			calls++
+			mu.Unlock()
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer subscriber.Close()
+
+		store := &storeStub{
+			webhooks:   []models.Webhook{{ID: "failing", URL: subscriber.URL, Events: []string{models.WebhookEventTopicUpdated}}},
+			deliveries: map[string]models.WebhookDelivery{},
+		}
+		dispatcher := webhook.NewDispatcher(store, subscriber.Client(), 2, time.Millisecond)
+
+		Convey("When an event is notified", func() {
+			dispatcher.Notify(context.Background(), models.WebhookEventTopicUpdated, "economy", "")
+			time.Sleep(50 * time.Millisecond)
+			So(dispatcher.Close(context.Background()), ShouldBeNil)
+
+			Convey("Then the delivery is retried and recorded as failed", func() {
+				So(calls, ShouldEqual, 3)
+				deliveries := store.delivered()
+				So(deliveries, ShouldHaveLength, 1)
+				So(deliveries[0].Status, ShouldEqual, models.WebhookDeliveryFailed)
+				So(deliveries[0].Attempts, ShouldEqual, 3)
+				So(deliveries[0].ResponseStatus, ShouldEqual, http.StatusBadGateway)
+				So(deliveries[0].LastError, ShouldContainSubstring, "502")
+			})
+		})
+	})
+}
+
+func TestSign(t *testing.T) {
+	Convey("Given a secret and payload, the signature is the hex encoded HMAC-SHA256", t, func() {
+		So(webhook.Sign("key", []byte("The quick brown fox jumps over the lazy dog")),
+			ShouldEqual, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8")
+	})
+}